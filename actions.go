@@ -0,0 +1,227 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
+)
+
+// actionResponse is the envelope Reddit uses for write endpoints. Failures are reported
+// with a 200 status and a list of [code, message, field] triples.
+type actionResponse struct {
+	JSON struct {
+		Errors [][]string      `json:"errors"`
+		Data   json.RawMessage `json:"data"`
+	} `json:"json"`
+}
+
+// postForm sends a form-encoded POST to a write endpoint and reports any errors Reddit
+// returns in the response body. If v is non-nil, the response's json.data object is decoded
+// into it. Every call is reported to the configured WriteAuditor.
+func (r *Reddit) postForm(ctx context.Context, operation, path, target string, form url.Values, v any) (err error) {
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("api_type", "json")
+
+	record := WriteAuditRecord{
+		Time:      time.Now(),
+		Operation: operation,
+		Method:    http.MethodPost,
+		Endpoint:  path,
+		Target:    target,
+		Payload:   summarizePayload(form),
+	}
+	defer func() {
+		record.Err = err
+		r.auditWrite(ctx, record)
+	}()
+
+	req, err := r.httpClient.NewRequest(ctx, http.MethodPost, path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var resp actionResponse
+	if err := r.httpClient.DoJSON(req, &resp); err != nil {
+		return wrapDoError(err, operation, path)
+	}
+	if len(resp.JSON.Errors) > 0 {
+		return actionError(resp.JSON.Errors, operation, path)
+	}
+	if v != nil {
+		if len(resp.JSON.Data) == 0 {
+			return &pkgerrs.ParseError{Operation: operation, Err: fmt.Errorf("response has no data")}
+		}
+		if err := json.Unmarshal(resp.JSON.Data, v); err != nil {
+			return &pkgerrs.ParseError{Operation: operation, Err: err}
+		}
+	}
+	return nil
+}
+
+// actionError converts Reddit's [code, message, field] error triples into an APIError,
+// classified so that READ_ONLY_MODE is reported as a ServiceDegradedError.
+func actionError(errs [][]string, operation, path string) error {
+	apiErr := pkgerrs.NewAPIErrorFromList(http.StatusOK, errs)
+	return pkgerrs.ClassifyAPIError(apiErr, resourceContext(operation, path))
+}
+
+// Save saves a post or comment to the authenticated user's saved items.
+//
+// Reddit Premium users can file the item under a category; pass an empty category to save
+// without one. Use GetSavedCategories to list existing categories.
+//
+// Returns an error if:
+//   - The fullname is not a valid post or comment fullname
+//   - The category contains newline characters
+//   - The API request fails, e.g. when saving with a category without Reddit Premium
+func (r *Reddit) Save(ctx context.Context, fullname, category string) error {
+	if err := r.validator.ValidatePaginationToken(fullname); err != nil {
+		return err
+	}
+	if strings.ContainsAny(category, "\r\n") {
+		return &pkgerrs.ConfigError{Field: "category", Message: "category cannot contain newline characters"}
+	}
+
+	form := url.Values{}
+	form.Set("id", fullname)
+	if category != "" {
+		form.Set("category", category)
+	}
+	return r.postForm(ctx, "save", SaveURL, fullname, form, nil)
+}
+
+// Unsave removes a post or comment from the authenticated user's saved items. Unsaving an
+// item that is not saved succeeds.
+//
+// Returns an error if the fullname is not a valid post or comment fullname or the API request
+// fails.
+func (r *Reddit) Unsave(ctx context.Context, fullname string) error {
+	if err := r.validator.ValidatePaginationToken(fullname); err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("id", fullname)
+	return r.postForm(ctx, "unsave", UnsaveURL, fullname, form, nil)
+}
+
+// Hide hides posts from the authenticated user's listings, in one request. Hidden posts
+// can still be fetched directly.
+//
+// Returns an error if:
+//   - No fullnames are provided, or any is not a post (t3_) fullname
+//   - The API request fails
+func (r *Reddit) Hide(ctx context.Context, fullnames ...string) error {
+	return r.setHidden(ctx, "hide", HideURL, fullnames)
+}
+
+// Unhide returns hidden posts to the authenticated user's listings, in one request. See
+// Hide for the accepted fullnames.
+func (r *Reddit) Unhide(ctx context.Context, fullnames ...string) error {
+	return r.setHidden(ctx, "unhide", UnhideURL, fullnames)
+}
+
+// setHidden validates post fullnames and posts them to the hide or unhide endpoint.
+func (r *Reddit) setHidden(ctx context.Context, operation, path string, fullnames []string) error {
+	if len(fullnames) == 0 {
+		return &pkgerrs.ConfigError{Field: "fullnames", Message: "at least one fullname is required"}
+	}
+	for _, name := range fullnames {
+		if !strings.HasPrefix(name, string(types.KIND_POST)) || !validation.IsValidFullname(name) {
+			return &pkgerrs.ConfigError{Field: "fullnames", Message: fmt.Sprintf("not a post fullname: %q", name)}
+		}
+	}
+
+	ids := strings.Join(fullnames, ",")
+	form := url.Values{}
+	form.Set("id", ids)
+	return r.postForm(ctx, operation, path, ids, form, nil)
+}
+
+// SubmitComment posts a comment replying to a post or comment and returns the new comment,
+// whose Name is its fullname. The text is markdown.
+//
+// Returns an error if:
+//   - parentFullname is not a post (t3_) or comment (t1_) fullname, or text is empty or too long
+//   - The API request fails or Reddit rejects the comment, e.g. because the thread is locked
+//   - The response does not contain the new comment
+func (r *Reddit) SubmitComment(ctx context.Context, parentFullname, text string) (*types.Comment, error) {
+	if err := validation.ValidateCommentDraft(parentFullname, text); err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("thing_id", parentFullname)
+	form.Set("text", text)
+
+	var data struct {
+		Things []*types.Thing `json:"things"`
+	}
+	if err := r.postForm(ctx, "submit comment", CommentURL, parentFullname, form, &data); err != nil {
+		return nil, err
+	}
+	for _, thing := range data.Things {
+		if thing == nil || thing.Kind != "t1" {
+			continue
+		}
+		parsed, err := r.parser.ParseThing(ctx, thing)
+		if err != nil {
+			return nil, &pkgerrs.ParseError{Operation: "parse comment", Err: err}
+		}
+		if comment, ok := parsed.(*types.Comment); ok {
+			r.annotateComments(ctx, "submit comment", []*types.Comment{comment})
+			return comment, nil
+		}
+	}
+	return nil, &pkgerrs.ParseError{Operation: "submit comment", Err: fmt.Errorf("response has no comment")}
+}
+
+// GetSavedCategories returns the names of the authenticated user's saved-item categories.
+// Saved categories are a Reddit Premium feature; other accounts receive a *errors.ForbiddenError.
+//
+// Returns an error if the API request fails or the response cannot be decoded.
+func (r *Reddit) GetSavedCategories(ctx context.Context) ([]string, error) {
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, SavedCategoriesURL, nil)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: SavedCategoriesURL, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var resp struct {
+		Categories []struct {
+			Category string `json:"category"`
+		} `json:"categories"`
+	}
+	if err := r.httpClient.DoJSON(req, &resp); err != nil {
+		return nil, wrapDoError(err, "get saved categories", SavedCategoriesURL)
+	}
+
+	categories := make([]string, 0, len(resp.Categories))
+	for _, c := range resp.Categories {
+		if c.Category == "" {
+			continue
+		}
+		categories = append(categories, c.Category)
+	}
+	return categories, nil
+}
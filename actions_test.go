@@ -0,0 +1,210 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestClient_Save(t *testing.T) {
+	tests := []struct {
+		name      string
+		fullname  string
+		category  string
+		response  string
+		wantForm  url.Values
+		wantError bool
+	}{
+		{
+			name:     "without category",
+			fullname: "t3_abc123",
+			response: `{}`,
+			wantForm: url.Values{"id": {"t3_abc123"}, "api_type": {"json"}},
+		},
+		{
+			name:     "with category",
+			fullname: "t1_def456",
+			category: "recipes",
+			response: `{}`,
+			wantForm: url.Values{"id": {"t1_def456"}, "category": {"recipes"}, "api_type": {"json"}},
+		},
+		{
+			name:      "reddit rejects",
+			fullname:  "t3_abc123",
+			category:  "recipes",
+			response:  `{"json":{"errors":[["NOT_PREMIUM","you need premium","category"]]}}`,
+			wantError: true,
+		},
+		{name: "invalid fullname", fullname: "abc123", wantError: true},
+		{name: "newline in category", fullname: "t3_abc123", category: "a\nb", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockHTTPClient{
+				doJSONFunc: func(req *http.Request, v any) error {
+					if req.Method != http.MethodPost || req.URL.Path != "/"+SaveURL {
+						t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+					}
+					body, _ := io.ReadAll(req.Body)
+					form, _ := url.ParseQuery(string(body))
+					if tt.wantForm != nil && form.Encode() != tt.wantForm.Encode() {
+						t.Errorf("form = %v, want %v", form, tt.wantForm)
+					}
+					return json.Unmarshal([]byte(tt.response), v)
+				},
+			}
+			err := newTestClient(mock, nil).Save(context.Background(), tt.fullname, tt.category)
+			if (err != nil) != tt.wantError {
+				t.Errorf("Save() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+
+	t.Run("reddit error code is surfaced", func(t *testing.T) {
+		mock := &mockHTTPClient{
+			doJSONFunc: func(req *http.Request, v any) error {
+				return json.Unmarshal([]byte(`{"json":{"errors":[["NOT_PREMIUM","you need premium","category"]]}}`), v)
+			},
+		}
+		err := newTestClient(mock, nil).Save(context.Background(), "t3_abc123", "recipes")
+		var apiErr *pkgerrs.APIError
+		if !errors.As(err, &apiErr) || apiErr.ErrorCode != "NOT_PREMIUM" {
+			t.Errorf("expected APIError with code NOT_PREMIUM, got %v", err)
+		}
+	})
+}
+
+func TestClient_SubmitComment(t *testing.T) {
+	tests := []struct {
+		name      string
+		parent    string
+		text      string
+		response  string
+		wantCode  string
+		wantError bool
+	}{
+		{
+			name:     "reply to post",
+			parent:   "t3_abc123",
+			text:     "Nice *write-up*",
+			response: `{"json":{"errors":[],"data":{"things":[{"kind":"t1","data":{"id":"c9","name":"t1_c9","parent_id":"t3_abc123","link_id":"t3_abc123","body":"Nice *write-up*","author":"gopher","subreddit":"golang","created":1700000000,"created_utc":1700000000,"replies":""}}]}}}`,
+		},
+		{name: "thread locked", parent: "t1_def456", text: "hi", response: `{"json":{"errors":[["THREAD_LOCKED","Comments are locked.","parent"]]}}`, wantCode: "THREAD_LOCKED", wantError: true},
+		{name: "no comment in response", parent: "t3_abc123", text: "hi", response: `{"json":{"errors":[],"data":{"things":[]}}}`, wantError: true},
+		{name: "subreddit parent", parent: "t5_2qh1i", text: "hi", wantError: true},
+		{name: "empty text", parent: "t3_abc123", text: "  ", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockHTTPClient{
+				doJSONFunc: func(req *http.Request, v any) error {
+					if req.Method != http.MethodPost || req.URL.Path != "/"+CommentURL {
+						t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+					}
+					body, _ := io.ReadAll(req.Body)
+					form, _ := url.ParseQuery(string(body))
+					if form.Get("thing_id") != tt.parent || form.Get("text") != tt.text || form.Get("api_type") != "json" {
+						t.Errorf("form = %v", form)
+					}
+					return json.Unmarshal([]byte(tt.response), v)
+				},
+			}
+			comment, err := newTestClient(mock, nil).SubmitComment(context.Background(), tt.parent, tt.text)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("SubmitComment() error = %v, wantError %v", err, tt.wantError)
+			}
+			if tt.wantCode != "" {
+				var apiErr *pkgerrs.APIError
+				if !errors.As(err, &apiErr) || apiErr.ErrorCode != tt.wantCode {
+					t.Errorf("expected APIError with code %s, got %v", tt.wantCode, err)
+				}
+			}
+			if err == nil && (comment.Name != "t1_c9" || comment.ParentID != tt.parent || comment.Body != tt.text) {
+				t.Errorf("comment = %+v", comment)
+			}
+		})
+	}
+}
+
+func TestClient_GetSavedCategories(t *testing.T) {
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.URL.Path != "/"+SavedCategoriesURL {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			return json.Unmarshal([]byte(`{"categories":[{"category":"recipes"},{"category":""},{"category":"travel"}]}`), v)
+		},
+	}
+	categories, err := newTestClient(mock, nil).GetSavedCategories(context.Background())
+	if err != nil {
+		t.Fatalf("GetSavedCategories returned error: %v", err)
+	}
+	if len(categories) != 2 || categories[0] != "recipes" || categories[1] != "travel" {
+		t.Errorf("GetSavedCategories() = %v, want [recipes travel]", categories)
+	}
+
+	forbidden := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			return &pkgerrs.APIError{StatusCode: http.StatusForbidden, Message: "request failed"}
+		},
+	}
+	_, err = newTestClient(forbidden, nil).GetSavedCategories(context.Background())
+	var forbiddenErr *pkgerrs.ForbiddenError
+	if !errors.As(err, &forbiddenErr) {
+		t.Errorf("expected ForbiddenError for non-premium account, got %T: %v", err, err)
+	}
+}
+
+func TestClient_UnsaveHideUnhide(t *testing.T) {
+	var paths, ids []string
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			paths = append(paths, req.URL.Path)
+			ids = append(ids, form.Get("id"))
+			return json.Unmarshal([]byte(`{}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	if err := client.Unsave(ctx, "t1_def456"); err != nil {
+		t.Fatalf("Unsave returned error: %v", err)
+	}
+	if err := client.Hide(ctx, "t3_abc123", "t3_xyz789"); err != nil {
+		t.Fatalf("Hide returned error: %v", err)
+	}
+	if err := client.Unhide(ctx, "t3_abc123"); err != nil {
+		t.Fatalf("Unhide returned error: %v", err)
+	}
+	wantPaths := []string{"/" + UnsaveURL, "/" + HideURL, "/" + UnhideURL}
+	wantIDs := []string{"t1_def456", "t3_abc123,t3_xyz789", "t3_abc123"}
+	for i := range wantPaths {
+		if i >= len(paths) || paths[i] != wantPaths[i] || ids[i] != wantIDs[i] {
+			t.Fatalf("requests = %v %v, want %v %v", paths, ids, wantPaths, wantIDs)
+		}
+	}
+
+	var configErr *pkgerrs.ConfigError
+	if err := client.Unsave(ctx, "abc123"); err == nil {
+		t.Error("Unsave accepted an invalid fullname")
+	}
+	if err := client.Hide(ctx); !errors.As(err, &configErr) {
+		t.Errorf("Hide() error = %v, want ConfigError", err)
+	}
+	if err := client.Unhide(ctx, "t1_def456"); !errors.As(err, &configErr) {
+		t.Errorf("Unhide(comment) error = %v, want ConfigError", err)
+	}
+	if len(paths) != 3 {
+		t.Errorf("invalid calls sent %d requests", len(paths)-3)
+	}
+}
@@ -0,0 +1,117 @@
+package graw
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
+)
+
+const (
+	// userHistoryLimit is roughly how many of a user's newest items Reddit will list.
+	userHistoryLimit = 1000
+	// activityPageSize is the number of items requested per overview page (Reddit's maximum).
+	activityPageSize = 100
+)
+
+// AggregateUserActivity pages through a user's overview, newest first, and summarizes the
+// posts and comments created at or after since: counts and karma per subreddit, karma
+// totals, and histograms by hour of day and day of week. A zero since summarizes all the
+// history Reddit will list, which is about the newest 1000 items; Truncated is set if
+// that limit was reached before since.
+//
+// The username may be given with or without a "u/" prefix.
+//
+// Returns an error if the username is invalid or any page fails to load.
+func (r *Reddit) AggregateUserActivity(ctx context.Context, username string, since time.Time) (*types.UserActivity, error) {
+	activity := &types.UserActivity{Username: validation.NormalizeUsername(username), Since: since.UTC()}
+	bySubreddit := make(map[string]*types.SubredditActivity)
+
+	fetched := 0
+	after := ""
+	reachedSince := false
+	for !reachedSince {
+		opts := []ListingOption{WithLimit(activityPageSize)}
+		if after != "" {
+			opts = append(opts, WithAfter(after))
+		}
+		page, err := r.GetUserOverview(ctx, username, opts...)
+		if err != nil {
+			return nil, err
+		}
+		fetched += page.Len()
+
+		for _, item := range page.Items {
+			created := item.CreatedAt()
+			if created.Before(since) {
+				reachedSince = true
+				break
+			}
+			addActivity(activity, bySubreddit, item, created)
+		}
+		if page.After == "" || page.Len() == 0 {
+			activity.Truncated = !reachedSince && !since.IsZero() && fetched >= userHistoryLimit
+			break
+		}
+		after = page.After
+	}
+
+	activity.Subreddits = make([]types.SubredditActivity, 0, len(bySubreddit))
+	for _, s := range bySubreddit {
+		activity.Subreddits = append(activity.Subreddits, *s)
+	}
+	slices.SortFunc(activity.Subreddits, func(a, b types.SubredditActivity) int {
+		if c := cmp.Compare(b.Total(), a.Total()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Subreddit, b.Subreddit)
+	})
+	return activity, nil
+}
+
+// addActivity counts item, created at the given time, in activity and its subreddit's totals.
+func addActivity(activity *types.UserActivity, bySubreddit map[string]*types.SubredditActivity, item types.OverviewItem, created time.Time) {
+	var subreddit string
+	switch item.Kind {
+	case types.OverviewPost:
+		subreddit = item.Post.Subreddit
+	case types.OverviewComment:
+		subreddit = item.Comment.Subreddit
+	default:
+		return
+	}
+	s, ok := bySubreddit[subreddit]
+	if !ok {
+		s = &types.SubredditActivity{Subreddit: subreddit}
+		bySubreddit[subreddit] = s
+	}
+
+	switch item.Kind {
+	case types.OverviewPost:
+		activity.Posts++
+		activity.PostKarma += item.Post.Score
+		s.Posts++
+		s.PostKarma += item.Post.Score
+	case types.OverviewComment:
+		score := 0
+		if item.Comment.ScoreKnown() {
+			score = item.Comment.Score
+		}
+		activity.Comments++
+		activity.CommentKarma += score
+		s.Comments++
+		s.CommentKarma += score
+	}
+
+	activity.ByHour[created.Hour()]++
+	activity.ByWeekday[created.Weekday()]++
+	if activity.Newest.IsZero() || created.After(activity.Newest) {
+		activity.Newest = created
+	}
+	if activity.Oldest.IsZero() || created.Before(activity.Oldest) {
+		activity.Oldest = created
+	}
+}
@@ -0,0 +1,108 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// activityComment returns a comment Thing in subreddit with the given score and creation time.
+func activityComment(t *testing.T, id, subreddit string, score int, created time.Time) *types.Thing {
+	t.Helper()
+	thing := commentThing(t, id, "body", false)
+	var data map[string]any
+	if err := json.Unmarshal(thing.Data, &data); err != nil {
+		t.Fatalf("unmarshal comment: %v", err)
+	}
+	data["subreddit"] = subreddit
+	data["score"], data["ups"] = score, score
+	data["created"], data["created_utc"] = float64(created.Unix()), float64(created.Unix())
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("marshal comment: %v", err)
+	}
+	thing.Data = raw
+	return thing
+}
+
+func TestAggregateUserActivity(t *testing.T) {
+	monday := time.Date(2024, 3, 4, 15, 30, 0, 0, time.UTC)
+	pages := map[string][]*types.Thing{
+		"": {
+			activityComment(t, "c1", "golang", 5, monday),
+			submitPostThing(t, "p1", "title", "gopher", monday.Add(-time.Hour)),
+		},
+		"t3_p1": {
+			activityComment(t, "c2", "rust", 2, monday.Add(-24*time.Hour)),
+			activityComment(t, "c3", "golang", 7, monday.Add(-10*24*time.Hour)), // before since
+		},
+	}
+	var afters []string
+	client := newTestClient(&mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			if req.URL.Path != "/user/gopher/overview" || req.URL.Query().Get("limit") != "100" {
+				t.Errorf("unexpected request %s", req.URL)
+			}
+			after := req.URL.Query().Get("after")
+			afters = append(afters, after)
+			listing := listingThing(t, pages[after]...)
+			var data types.ListingData
+			if err := json.Unmarshal(listing.Data, &data); err != nil {
+				t.Fatal(err)
+			}
+			if after == "" {
+				data.AfterFullname = "t3_p1"
+			} else {
+				data.AfterFullname = "t1_c3"
+			}
+			raw, _ := json.Marshal(data)
+			*v = types.Thing{Kind: "Listing", Data: raw}
+			return nil
+		},
+	}, nil)
+
+	since := monday.Add(-7 * 24 * time.Hour)
+	activity, err := client.AggregateUserActivity(context.Background(), "u/gopher", since)
+	if err != nil {
+		t.Fatalf("AggregateUserActivity returned error: %v", err)
+	}
+	if len(afters) != 2 {
+		t.Errorf("fetched pages %v, want 2 pages stopping at since", afters)
+	}
+	if activity.Username != "gopher" || activity.Truncated {
+		t.Errorf("Username = %q, Truncated = %v", activity.Username, activity.Truncated)
+	}
+	if activity.Posts != 1 || activity.Comments != 2 || activity.PostKarma != 1 || activity.CommentKarma != 7 {
+		t.Errorf("totals = %d posts (%d karma), %d comments (%d karma)",
+			activity.Posts, activity.PostKarma, activity.Comments, activity.CommentKarma)
+	}
+	want := []types.SubredditActivity{
+		{Subreddit: "golang", Posts: 1, Comments: 1, PostKarma: 1, CommentKarma: 5},
+		{Subreddit: "rust", Comments: 1, CommentKarma: 2},
+	}
+	if len(activity.Subreddits) != len(want) {
+		t.Fatalf("Subreddits = %+v, want %+v", activity.Subreddits, want)
+	}
+	for i := range want {
+		if activity.Subreddits[i] != want[i] {
+			t.Errorf("Subreddits[%d] = %+v, want %+v", i, activity.Subreddits[i], want[i])
+		}
+	}
+	if activity.ByHour[15] != 2 || activity.ByHour[14] != 1 || activity.ByWeekday[time.Monday] != 2 || activity.ByWeekday[time.Sunday] != 1 {
+		t.Errorf("ByHour = %v, ByWeekday = %v", activity.ByHour, activity.ByWeekday)
+	}
+	if !activity.Newest.Equal(monday) || !activity.Oldest.Equal(monday.Add(-24*time.Hour)) {
+		t.Errorf("Newest = %v, Oldest = %v", activity.Newest, activity.Oldest)
+	}
+}
+
+func TestAggregateUserActivity_InvalidUsername(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	if _, err := client.AggregateUserActivity(context.Background(), "not a user!", time.Time{}); err == nil {
+		t.Error("expected an error for an invalid username")
+	}
+}
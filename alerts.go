@@ -0,0 +1,359 @@
+package graw
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const (
+	// DefaultAlertRetryInterval is how long an AlertManager waits before retrying a subreddit
+	// stream that failed to start.
+	DefaultAlertRetryInterval = time.Minute
+	// alertFiredCapacity bounds how many (rule, post) pairs an AlertManager remembers for
+	// de-duplication.
+	alertFiredCapacity = 10000
+)
+
+// Alert is a post that matched an AlertRule.
+type Alert struct {
+	// Rule is the name of the rule that matched.
+	Rule string
+	Post *types.Post
+	// Keyword is the rule keyword found in the post, or "" for rules without keywords.
+	Keyword string
+}
+
+// AlertFunc handles an Alert. A returned error is logged; it does not stop the AlertManager.
+type AlertFunc func(ctx context.Context, alert *Alert) error
+
+// AlertRule describes posts to be alerted about in one subreddit.
+type AlertRule struct {
+	// Name identifies the rule. Adding a rule with the name of an existing rule replaces it.
+	Name      string
+	Subreddit string
+
+	// Keywords are matched case-insensitively against each post's title and self text. A
+	// post matches if it contains any of them. With no keywords every post matches.
+	Keywords []string
+	// Filter, if set, must also accept a post for it to match.
+	Filter func(*types.Post) bool
+	// Callback is called for each matching post.
+	Callback AlertFunc
+}
+
+// AlertConfig configures an AlertManager.
+type AlertConfig struct {
+	// Stream is the template for each subreddit's stream: Interval, Mode, Limit, and the
+	// adaptive interval bounds apply to every subreddit. Subreddit is ignored. Optional.
+	Stream types.StreamRequest
+
+	// RetryInterval is how long to wait before retrying a stream that failed to start.
+	// Defaults to DefaultAlertRetryInterval when zero. Optional.
+	RetryInterval time.Duration
+}
+
+// AlertManager runs keyword alert rules against new posts. It keeps one StreamNewPosts stream
+// per subreddit that has rules, and calls each matching rule's Callback.
+//
+// Rules can be added, replaced, and removed while Run is active. Changes apply to the next
+// post delivered: streams are started for newly watched subreddits and stopped for
+// subreddits that no longer have rules. A post triggers each rule at most once, even if its
+// stream is restarted.
+//
+// Callbacks run on the subreddit's stream goroutine, so a slow callback delays that
+// subreddit's later alerts. Callback errors and panics are logged and do not stop the
+// manager.
+//
+// An AlertManager is safe for concurrent use.
+type AlertManager struct {
+	r      *Reddit
+	config AlertConfig
+
+	mu    sync.Mutex
+	rules map[string]*alertRule
+	fired *recentIDs
+	// runCtx is Run's context while Run is active, and nil otherwise.
+	runCtx  context.Context
+	streams map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// alertRule is a validated AlertRule.
+type alertRule struct {
+	AlertRule
+	// subreddit is the normalized subreddit name, in lowercase.
+	subreddit string
+	// keywords are the rule's keywords, in lowercase.
+	keywords []string
+}
+
+// NewAlertManager creates an AlertManager that streams posts with r. A nil config uses the
+// defaults.
+//
+// Returns a *errors.ConfigError if r is nil or config is invalid.
+func NewAlertManager(r *Reddit, config *AlertConfig) (*AlertManager, error) {
+	if r == nil {
+		return nil, &pkgerrs.ConfigError{Message: "reddit client cannot be nil"}
+	}
+	var cfg AlertConfig
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.RetryInterval < 0 {
+		return nil, &pkgerrs.ConfigError{Field: "RetryInterval", Message: "retry interval cannot be negative"}
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = DefaultAlertRetryInterval
+	}
+	return &AlertManager{
+		r:       r,
+		config:  cfg,
+		rules:   make(map[string]*alertRule),
+		fired:   newRecentIDs(alertFiredCapacity),
+		streams: make(map[string]context.CancelFunc),
+	}, nil
+}
+
+// AddRule adds rule, replacing any rule with the same name.
+//
+// Returns a *errors.ConfigError if the rule has no name or callback, and a validation error
+// if the subreddit name is invalid.
+func (m *AlertManager) AddRule(rule AlertRule) error {
+	compiled, err := m.compileRule(rule)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules[compiled.Name] = compiled
+	m.reconcile()
+	return nil
+}
+
+// RemoveRule removes the rule with the given name and reports whether it existed.
+func (m *AlertManager) RemoveRule(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.rules[name]; !ok {
+		return false
+	}
+	delete(m.rules, name)
+	m.reconcile()
+	return true
+}
+
+// SetRules replaces all rules with rules, e.g. after reloading them from a file. If any rule
+// is invalid, or two rules share a name, the existing rules are kept.
+//
+// Returns the same errors as AddRule, or a *errors.ConfigError for a duplicate name.
+func (m *AlertManager) SetRules(rules []AlertRule) error {
+	compiled := make(map[string]*alertRule, len(rules))
+	for _, rule := range rules {
+		c, err := m.compileRule(rule)
+		if err != nil {
+			return err
+		}
+		if _, dup := compiled[c.Name]; dup {
+			return &pkgerrs.ConfigError{Field: "Name", Message: "duplicate alert rule name " + c.Name}
+		}
+		compiled[c.Name] = c
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules = compiled
+	m.reconcile()
+	return nil
+}
+
+// Rules returns the current rules, sorted by name.
+func (m *AlertManager) Rules() []AlertRule {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rules := make([]AlertRule, 0, len(m.rules))
+	for _, rule := range m.rules {
+		rules = append(rules, rule.AlertRule)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules
+}
+
+// Run streams the subreddits that have rules and delivers alerts until ctx is cancelled,
+// then stops every stream and returns ctx.Err(). Only one Run may be active at a time.
+//
+// Returns a *errors.ConfigError immediately if Run is already active.
+func (m *AlertManager) Run(ctx context.Context) error {
+	m.mu.Lock()
+	if m.runCtx != nil {
+		m.mu.Unlock()
+		return &pkgerrs.ConfigError{Message: "alert manager is already running"}
+	}
+	m.runCtx = ctx
+	m.reconcile()
+	m.mu.Unlock()
+
+	<-ctx.Done()
+
+	m.mu.Lock()
+	m.runCtx = nil
+	for subreddit, cancel := range m.streams {
+		cancel()
+		delete(m.streams, subreddit)
+	}
+	m.mu.Unlock()
+	m.wg.Wait()
+	return ctx.Err()
+}
+
+// compileRule validates rule and prepares it for matching.
+func (m *AlertManager) compileRule(rule AlertRule) (*alertRule, error) {
+	if rule.Name == "" {
+		return nil, &pkgerrs.ConfigError{Field: "Name", Message: "alert rule name cannot be empty"}
+	}
+	if rule.Callback == nil {
+		return nil, &pkgerrs.ConfigError{Field: "Callback", Message: "alert rule " + rule.Name + " has no callback"}
+	}
+	subreddit, err := m.r.validator.NormalizeSubredditName(rule.Subreddit)
+	if err != nil {
+		return nil, err
+	}
+	rule.Subreddit = subreddit
+	rule.Keywords = append([]string(nil), rule.Keywords...)
+
+	compiled := &alertRule{AlertRule: rule, subreddit: strings.ToLower(subreddit)}
+	for _, keyword := range rule.Keywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+			compiled.keywords = append(compiled.keywords, keyword)
+		}
+	}
+	return compiled, nil
+}
+
+// reconcile starts and stops streams to match the current rules. m.mu must be held.
+func (m *AlertManager) reconcile() {
+	if m.runCtx == nil {
+		return
+	}
+	wanted := make(map[string]string)
+	for _, rule := range m.rules {
+		wanted[rule.subreddit] = rule.Subreddit
+	}
+	for subreddit, cancel := range m.streams {
+		if _, ok := wanted[subreddit]; !ok {
+			cancel()
+			delete(m.streams, subreddit)
+		}
+	}
+	for key, subreddit := range wanted {
+		if _, ok := m.streams[key]; ok {
+			continue
+		}
+		ctx, cancel := context.WithCancel(m.runCtx)
+		m.streams[key] = cancel
+		m.wg.Add(1)
+		go m.watch(ctx, subreddit)
+	}
+}
+
+// watch streams one subreddit and dispatches its posts until ctx is cancelled, retrying
+// if the stream cannot be started.
+func (m *AlertManager) watch(ctx context.Context, subreddit string) {
+	defer m.wg.Done()
+	for {
+		request := m.config.Stream
+		request.Subreddit = subreddit
+		posts, err := m.r.StreamNewPosts(ctx, &request)
+		if err == nil {
+			key := strings.ToLower(subreddit)
+			for post := range posts {
+				m.dispatch(ctx, key, post)
+			}
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if m.r.config != nil && m.r.config.Logger != nil {
+			m.r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "alert stream failed to start",
+				slog.String("subreddit", subreddit),
+				slog.String("error", err.Error()))
+		}
+		timer := time.NewTimer(m.config.RetryInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// dispatch calls the callback of every current rule for subreddit that post matches and has
+// not yet triggered. Rules are read when the post arrives, so rule changes apply at once.
+func (m *AlertManager) dispatch(ctx context.Context, subreddit string, post *types.Post) {
+	var rules []*alertRule
+	m.mu.Lock()
+	for _, rule := range m.rules {
+		if rule.subreddit == subreddit {
+			rules = append(rules, rule)
+		}
+	}
+	m.mu.Unlock()
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+
+	for _, rule := range rules {
+		err := m.r.safeCall(ctx, "alert rule", func() error {
+			keyword, ok := rule.match(post)
+			if !ok || !m.markFired(rule.Name, post.Name) {
+				return nil
+			}
+			return rule.Callback(ctx, &Alert{Rule: rule.Name, Post: post, Keyword: keyword})
+		})
+		if err != nil && m.r.config != nil && m.r.config.Logger != nil {
+			m.r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "alert rule failed",
+				slog.String("rule", rule.Name),
+				slog.String("post", post.Name),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// markFired records that a rule triggered for a post, and reports whether it had not
+// triggered for it before.
+func (m *AlertManager) markFired(rule, post string) bool {
+	key := rule + "\x00" + post
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.fired.contains(key) {
+		return false
+	}
+	m.fired.add(key)
+	return true
+}
+
+// match reports whether post matches the rule, and which keyword it contains.
+func (rule *alertRule) match(post *types.Post) (string, bool) {
+	keyword := ""
+	if len(rule.keywords) > 0 {
+		text := strings.ToLower(post.Title + "\n" + post.SelfText)
+		for _, k := range rule.keywords {
+			if strings.Contains(text, k) {
+				keyword = k
+				break
+			}
+		}
+		if keyword == "" {
+			return "", false
+		}
+	}
+	if rule.Filter != nil && !rule.Filter(post) {
+		return "", false
+	}
+	return keyword, true
+}
@@ -0,0 +1,179 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// alertRecorder collects the alerts delivered to it as "rule:post" strings.
+type alertRecorder struct {
+	mu     sync.Mutex
+	alerts []string
+}
+
+func (a *alertRecorder) callback(ctx context.Context, alert *Alert) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.alerts = append(a.alerts, alert.Rule+":"+alert.Post.ID)
+	return nil
+}
+
+func (a *alertRecorder) snapshot() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.alerts)
+}
+
+// waitUntil polls cond until it holds, failing the test after two seconds.
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// polled returns a condition that holds once the listing has served n more requests.
+func (f *fakeNewListing) polled(n int) func() bool {
+	f.mu.Lock()
+	want := len(f.befores) + n
+	f.mu.Unlock()
+	return func() bool {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return len(f.befores) >= want
+	}
+}
+
+func TestAlertManager(t *testing.T) {
+	listing := &fakeNewListing{}
+	listing.publish("old1")
+	client := newTestClient(listing.client(t), nil)
+	manager, err := NewAlertManager(client, &AlertConfig{Stream: types.StreamRequest{Interval: 10 * time.Millisecond}})
+	if err != nil {
+		t.Fatalf("NewAlertManager returned error: %v", err)
+	}
+
+	rec := &alertRecorder{}
+	if err := manager.AddRule(AlertRule{Name: "go", Subreddit: "r/golang", Keywords: []string{" GENERICS "}, Callback: rec.callback}); err != nil {
+		t.Fatalf("AddRule returned error: %v", err)
+	}
+	panicky := func(ctx context.Context, alert *Alert) error { panic("callback exploded") }
+	if err := manager.AddRule(AlertRule{Name: "broken", Subreddit: "golang", Callback: panicky}); err != nil {
+		t.Fatalf("AddRule returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- manager.Run(ctx) }()
+	waitUntil(t, "the stream to start", listing.polled(2))
+	if err := manager.Run(ctx); err == nil {
+		t.Error("second Run returned nil error, want already running")
+	}
+
+	listing.publish("generics1", "other1", "generics2")
+	waitUntil(t, "alerts", func() bool { return len(rec.snapshot()) == 2 })
+
+	// Hot reload: swap in a rule with a filter and the stream keeps running.
+	err = manager.SetRules([]AlertRule{{
+		Name:      "all",
+		Subreddit: "golang",
+		Filter:    func(p *types.Post) bool { return p.ID != "skip" },
+		Callback:  rec.callback,
+	}})
+	if err != nil {
+		t.Fatalf("SetRules returned error: %v", err)
+	}
+	listing.publish("generics3", "noskip", "skip")
+	waitUntil(t, "reloaded alerts", func() bool { return len(rec.snapshot()) == 4 })
+
+	// Removing the last rule stops the subreddit's stream.
+	if !manager.RemoveRule("all") || manager.RemoveRule("all") {
+		t.Error("RemoveRule did not report the rule's existence")
+	}
+	waitUntil(t, "the stream to stop", func() bool {
+		manager.mu.Lock()
+		defer manager.mu.Unlock()
+		return len(manager.streams) == 0
+	})
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run returned %v, want context.Canceled", err)
+	}
+	want := []string{"go:generics1", "go:generics2", "all:generics3", "all:noskip"}
+	if got := rec.snapshot(); !slices.Equal(got, want) {
+		t.Errorf("alerts = %v, want %v", got, want)
+	}
+}
+
+func TestAlertManager_Dedup(t *testing.T) {
+	client := newTestClient((&fakeNewListing{}).client(t), nil)
+	manager, err := NewAlertManager(client, nil)
+	if err != nil {
+		t.Fatalf("NewAlertManager returned error: %v", err)
+	}
+	rec := &alertRecorder{}
+	if err := manager.AddRule(AlertRule{Name: "all", Subreddit: "golang", Callback: rec.callback}); err != nil {
+		t.Fatalf("AddRule returned error: %v", err)
+	}
+
+	post := &types.Post{ThingData: types.ThingData{ID: "a1", Name: "t3_a1"}, Subreddit: "golang"}
+	for range 2 {
+		manager.dispatch(context.Background(), "golang", post)
+	}
+	if want := []string{"all:a1"}; !slices.Equal(rec.snapshot(), want) {
+		t.Errorf("alerts = %v, want %v", rec.snapshot(), want)
+	}
+}
+
+func TestAlertManager_InvalidRules(t *testing.T) {
+	manager, err := NewAlertManager(newTestClient(&mockHTTPClient{}, nil), nil)
+	if err != nil {
+		t.Fatalf("NewAlertManager returned error: %v", err)
+	}
+	noop := func(context.Context, *Alert) error { return nil }
+	if err := manager.AddRule(AlertRule{Name: "keep", Subreddit: "golang", Callback: noop}); err != nil {
+		t.Fatalf("AddRule returned error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		rules     []AlertRule
+		wantField string
+	}{
+		{name: "no name", rules: []AlertRule{{Subreddit: "golang", Callback: noop}}, wantField: "Name"},
+		{name: "no callback", rules: []AlertRule{{Name: "a", Subreddit: "golang"}}, wantField: "Callback"},
+		{name: "duplicate", rules: []AlertRule{{Name: "a", Subreddit: "golang", Callback: noop}, {Name: "a", Subreddit: "rust", Callback: noop}}, wantField: "Name"},
+		{name: "bad subreddit", rules: []AlertRule{{Name: "a", Subreddit: "not a subreddit!", Callback: noop}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := manager.SetRules(tt.rules)
+			if err == nil {
+				t.Fatal("SetRules returned nil error")
+			}
+			var cfgErr *pkgerrs.ConfigError
+			if tt.wantField != "" && (!errors.As(err, &cfgErr) || cfgErr.Field != tt.wantField) {
+				t.Errorf("error = %v, want ConfigError for %s", err, tt.wantField)
+			}
+			if rules := manager.Rules(); len(rules) != 1 || rules[0].Name != "keep" {
+				t.Errorf("Rules() = %+v, want the previous rules kept", rules)
+			}
+		})
+	}
+
+	if _, err := NewAlertManager(nil, nil); err == nil {
+		t.Error("NewAlertManager(nil) returned nil error")
+	}
+}
@@ -0,0 +1,167 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// DefaultAnnotatorConcurrency is how many comments an AnnotatorChain annotates at once
+// when its Concurrency is zero.
+const DefaultAnnotatorConcurrency = 8
+
+// Annotator enriches a comment, typically by attaching values with Comment.SetAnnotation.
+// Annotate is called for many comments concurrently, but never for the same comment from
+// two goroutines at once.
+type Annotator interface {
+	Annotate(ctx context.Context, comment *types.Comment) error
+}
+
+// AnnotatorFunc adapts an ordinary function to the Annotator interface.
+type AnnotatorFunc func(ctx context.Context, comment *types.Comment) error
+
+// Annotate calls f(ctx, comment).
+func (f AnnotatorFunc) Annotate(ctx context.Context, comment *types.Comment) error {
+	return f(ctx, comment)
+}
+
+// NopAnnotator leaves comments unchanged. It is useful as a placeholder in configuration.
+type NopAnnotator struct{}
+
+// Annotate does nothing.
+func (NopAnnotator) Annotate(context.Context, *types.Comment) error { return nil }
+
+// KeywordAnnotator labels comments by keyword. For each label in Keywords whose words occur
+// in a comment's body (as whole words, ignoring case), the label is added to a sorted
+// []string stored under Key. Comments without a match get no annotation.
+//
+//	topics := graw.NewKeywordAnnotator("topics", map[string][]string{
+//	    "generics": {"generic", "generics", "type parameter"},
+//	    "errors":   {"error handling", "errors.Is"},
+//	})
+type KeywordAnnotator struct {
+	key      string
+	patterns map[string]*regexp.Regexp
+}
+
+// NewKeywordAnnotator returns a KeywordAnnotator that stores matched labels under key.
+func NewKeywordAnnotator(key string, keywords map[string][]string) *KeywordAnnotator {
+	a := &KeywordAnnotator{key: key, patterns: make(map[string]*regexp.Regexp, len(keywords))}
+	for label, words := range keywords {
+		quoted := make([]string, 0, len(words))
+		for _, w := range words {
+			if w = strings.TrimSpace(w); w != "" {
+				quoted = append(quoted, regexp.QuoteMeta(w))
+			}
+		}
+		if len(quoted) > 0 {
+			a.patterns[label] = regexp.MustCompile(`(?i)(^|\W)(` + strings.Join(quoted, "|") + `)($|\W)`)
+		}
+	}
+	return a
+}
+
+// Annotate records the labels whose keywords appear in the comment body.
+func (a *KeywordAnnotator) Annotate(_ context.Context, comment *types.Comment) error {
+	var labels []string
+	for label, pattern := range a.patterns {
+		if pattern.MatchString(comment.Body) {
+			labels = append(labels, label)
+		}
+	}
+	if len(labels) > 0 {
+		sort.Strings(labels)
+		comment.SetAnnotation(a.key, labels)
+	}
+	return nil
+}
+
+// AnnotatorChain runs annotators in order on each comment and annotates many comments
+// concurrently. A chain is itself an Annotator, so chains can be nested. Set it as
+// Config.Annotator to annotate comments returned by GetComments, GetMoreComments, and
+// StreamPostComments.
+type AnnotatorChain struct {
+	Annotators []Annotator
+	// Concurrency limits how many comments are annotated at once.
+	// Defaults to DefaultAnnotatorConcurrency.
+	Concurrency int
+}
+
+// NewAnnotatorChain returns a chain running annotators in the given order.
+func NewAnnotatorChain(annotators ...Annotator) *AnnotatorChain {
+	return &AnnotatorChain{Annotators: annotators}
+}
+
+// Annotate runs every annotator on comment. An annotator's failure does not stop the later
+// ones; the failures are joined into the returned error.
+func (c *AnnotatorChain) Annotate(ctx context.Context, comment *types.Comment) error {
+	var errs []error
+	for _, a := range c.Annotators {
+		if a == nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := a.Annotate(ctx, comment); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// AnnotateAll runs the chain on every comment in the given trees, including replies.
+// Failures are joined into the returned error; comments that failed keep whatever
+// annotations succeeded. Returns ctx.Err() if ctx is cancelled first.
+func (c *AnnotatorChain) AnnotateAll(ctx context.Context, comments []*types.Comment) error {
+	return annotateAll(ctx, c, c.Concurrency, flattenComments(comments))
+}
+
+// annotateAll runs a on each of the comments, not their replies, with at most concurrency
+// calls in flight.
+func annotateAll(ctx context.Context, a Annotator, concurrency int, flat []*types.Comment) error {
+	if concurrency <= 0 {
+		concurrency = DefaultAnnotatorConcurrency
+	}
+
+	var mu sync.Mutex
+	var errs []error
+	err := runBatch(ctx, len(flat), concurrency, func(ctx context.Context, i int) error {
+		if err := a.Annotate(ctx, flat[i]); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// annotateComments runs the configured Annotator, if any, on each of the comments (not their
+// replies). Annotation failures are logged; the comments are returned regardless.
+func (r *Reddit) annotateComments(ctx context.Context, operation string, comments []*types.Comment) {
+	if r.config == nil || r.config.Annotator == nil || len(comments) == 0 {
+		return
+	}
+	concurrency := 0
+	if chain, ok := r.config.Annotator.(*AnnotatorChain); ok {
+		concurrency = chain.Concurrency
+	}
+	err := r.safeCall(ctx, "annotate comments", func() error {
+		return annotateAll(ctx, r.config.Annotator, concurrency, comments)
+	})
+	if err != nil && r.config.Logger != nil {
+		r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "comment annotation failed",
+			slog.String("operation", operation),
+			slog.String("error", err.Error()))
+	}
+}
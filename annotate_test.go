@@ -0,0 +1,127 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestKeywordAnnotator(t *testing.T) {
+	a := NewKeywordAnnotator("topics", map[string][]string{
+		"generics": {"generics", "type parameter"},
+		"errors":   {"errors.Is"},
+		"empty":    {" "},
+	})
+	tests := []struct {
+		body string
+		want []string
+	}{
+		{"Are Generics worth it?", []string{"generics"}},
+		{"use errors.Is with a type parameter", []string{"errors", "generics"}},
+		{"nongenerics here", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		c := &types.Comment{Body: tt.body}
+		if err := a.Annotate(context.Background(), c); err != nil {
+			t.Fatalf("Annotate: %v", err)
+		}
+		got, ok := c.Annotation("topics")
+		if tt.want == nil {
+			if ok {
+				t.Errorf("%q: unexpected annotation %v", tt.body, got)
+			}
+			continue
+		}
+		if labels, _ := got.([]string); !slices.Equal(labels, tt.want) {
+			t.Errorf("%q: labels = %v, want %v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestAnnotatorChain(t *testing.T) {
+	boom := errors.New("boom")
+	var calls atomic.Int32
+	chain := NewAnnotatorChain(
+		AnnotatorFunc(func(ctx context.Context, c *types.Comment) error {
+			calls.Add(1)
+			if c.ID == "bad" {
+				return boom
+			}
+			return nil
+		}),
+		NopAnnotator{},
+		AnnotatorFunc(func(ctx context.Context, c *types.Comment) error {
+			c.SetAnnotation("length", len(c.Body))
+			return nil
+		}),
+	)
+	chain.Concurrency = 2
+
+	reply := &types.Comment{ThingData: types.ThingData{ID: "bad"}, Body: "reply"}
+	root := &types.Comment{ThingData: types.ThingData{ID: "root"}, Body: "root", Replies: []*types.Comment{reply}}
+	other := &types.Comment{ThingData: types.ThingData{ID: "other"}, Body: "other!"}
+
+	err := chain.AnnotateAll(context.Background(), []*types.Comment{root, other})
+	if !errors.Is(err, boom) {
+		t.Errorf("AnnotateAll error = %v, want %v", err, boom)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("annotated %d comments, want 3 including the reply", calls.Load())
+	}
+	for _, c := range []*types.Comment{root, reply, other} {
+		if v, _ := c.Annotation("length"); v != len(c.Body) {
+			t.Errorf("%s: length annotation = %v; later annotators should run after a failure", c.ID, v)
+		}
+	}
+}
+
+func TestClient_AnnotatesFetchedComments(t *testing.T) {
+	now := time.Now()
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			return []*types.Thing{
+				listingThing(t, submitPostThing(t, "post1", "Thread", "gopher", now)),
+				listingThing(t, replyThing(t, "c1", "t3_post1", now, replyThing(t, "c2", "t1_c1", now))),
+			}, nil
+		},
+		doMoreChildrenFunc: func(req *http.Request) ([]*types.Thing, error) {
+			return []*types.Thing{replyThing(t, "c3", "t1_c1", now)}, nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	var annotated atomic.Int32
+	client.config.Annotator = AnnotatorFunc(func(ctx context.Context, c *types.Comment) error {
+		annotated.Add(1)
+		c.SetAnnotation("seen", true)
+		return nil
+	})
+	ctx := context.Background()
+
+	resp, err := client.GetComments(ctx, &types.CommentsRequest{Subreddit: "golang", PostID: "post1"})
+	if err != nil {
+		t.Fatalf("GetComments: %v", err)
+	}
+	for _, c := range flattenComments(resp.Comments) {
+		if _, ok := c.Annotation("seen"); !ok {
+			t.Errorf("comment %s was not annotated", c.ID)
+		}
+	}
+
+	more, err := client.GetMoreComments(ctx, &types.MoreCommentsRequest{LinkID: "post1", CommentIDs: []string{"c3"}})
+	if err != nil {
+		t.Fatalf("GetMoreComments: %v", err)
+	}
+	if len(more) != 1 || more[0].Annotations["seen"] != true {
+		t.Errorf("more comments not annotated: %+v", more)
+	}
+	if annotated.Load() != 3 {
+		t.Errorf("annotator called %d times, want 3", annotated.Load())
+	}
+}
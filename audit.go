@@ -0,0 +1,110 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// maxAuditValueLength is the number of characters of each payload value kept in audit records.
+const maxAuditValueLength = 200
+
+// auditRedactedFields are form fields whose values are never copied into audit records.
+var auditRedactedFields = map[string]bool{
+	"password": true,
+	"passwd":   true,
+	"token":    true,
+	"uh":       true, // modhash
+}
+
+// WriteAuditRecord describes one mutating API call made by the client.
+type WriteAuditRecord struct {
+	// Time is when the call started.
+	Time time.Time
+	// Operation names the client action, e.g. "save".
+	Operation string
+	// Method is the HTTP method used.
+	Method string
+	// Endpoint is the API path that was called.
+	Endpoint string
+	// Target is the fullname or name of the thing acted on, if any.
+	Target string
+	// Payload summarizes the request parameters. Long values are truncated and
+	// sensitive fields are redacted.
+	Payload map[string]string
+	// Duration is how long the call took.
+	Duration time.Duration
+	// Err is the error the call returned, or nil if it succeeded.
+	Err error
+}
+
+// Succeeded reports whether the audited call completed without error.
+func (r WriteAuditRecord) Succeeded() bool {
+	return r.Err == nil
+}
+
+// String formats the record as a single log line.
+func (r WriteAuditRecord) String() string {
+	result := "ok"
+	if r.Err != nil {
+		result = "error: " + r.Err.Error()
+	}
+	keys := make([]string, 0, len(r.Payload))
+	for k := range r.Payload {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	payload := ""
+	for _, k := range keys {
+		payload += fmt.Sprintf(" %s=%q", k, r.Payload[k])
+	}
+	return fmt.Sprintf("%s %s %s %s target=%s%s (%s) %s",
+		r.Time.UTC().Format(time.RFC3339), r.Operation, r.Method, r.Endpoint, r.Target, payload, r.Duration, result)
+}
+
+// WriteAuditor receives a record for every mutating API call.
+// Implementations are called synchronously after the call completes, so they should be fast
+// and must be safe for concurrent use.
+type WriteAuditor interface {
+	AuditWrite(ctx context.Context, record WriteAuditRecord)
+}
+
+// WriteAuditorFunc adapts an ordinary function to the WriteAuditor interface.
+type WriteAuditorFunc func(ctx context.Context, record WriteAuditRecord)
+
+// AuditWrite calls f(ctx, record).
+func (f WriteAuditorFunc) AuditWrite(ctx context.Context, record WriteAuditRecord) {
+	f(ctx, record)
+}
+
+// auditWrite reports a completed write to the configured WriteAuditor, if any.
+func (r *Reddit) auditWrite(ctx context.Context, record WriteAuditRecord) {
+	if r.config == nil || r.config.WriteAuditor == nil {
+		return
+	}
+	record.Duration = time.Since(record.Time)
+	r.config.WriteAuditor.AuditWrite(ctx, record)
+}
+
+// summarizePayload copies form values into an audit payload, truncating long values
+// and redacting sensitive fields.
+func summarizePayload(form url.Values) map[string]string {
+	payload := make(map[string]string, len(form))
+	for key, values := range form {
+		if key == "api_type" || len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch {
+		case auditRedactedFields[key]:
+			value = "[redacted]"
+		case len([]rune(value)) > maxAuditValueLength:
+			runes := []rune(value)
+			value = fmt.Sprintf("%s… (%d chars)", string(runes[:maxAuditValueLength]), len(runes))
+		}
+		payload[key] = value
+	}
+	return payload
+}
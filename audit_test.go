@@ -0,0 +1,78 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWriteAuditor(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		records []WriteAuditRecord
+	)
+	auditor := WriteAuditorFunc(func(ctx context.Context, record WriteAuditRecord) {
+		mu.Lock()
+		defer mu.Unlock()
+		records = append(records, record)
+	})
+
+	responses := []string{`{}`, `{"json":{"errors":[["RATELIMIT","slow down","ratelimit"]]}}`}
+	calls := 0
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			resp := responses[calls]
+			calls++
+			return json.Unmarshal([]byte(resp), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	client.config.WriteAuditor = auditor
+
+	if err := client.Save(context.Background(), "t3_abc123", "recipes"); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if err := client.Save(context.Background(), "t1_def456", ""); err == nil {
+		t.Fatal("expected Save to fail")
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("got %d audit records, want 2", len(records))
+	}
+	first := records[0]
+	if first.Operation != "save" || first.Method != http.MethodPost || first.Endpoint != SaveURL || first.Target != "t3_abc123" {
+		t.Errorf("unexpected record: %+v", first)
+	}
+	if !first.Succeeded() || first.Payload["category"] != "recipes" {
+		t.Errorf("first record = %+v, want success with category payload", first)
+	}
+	if _, ok := first.Payload["api_type"]; ok {
+		t.Error("api_type should not be included in the payload summary")
+	}
+	if records[1].Succeeded() || !strings.Contains(records[1].String(), "slow down") {
+		t.Errorf("second record should report the failure, got %s", records[1])
+	}
+}
+
+func TestSummarizePayload(t *testing.T) {
+	long := strings.Repeat("x", maxAuditValueLength+50)
+	payload := summarizePayload(url.Values{
+		"text":     {long},
+		"password": {"hunter2"},
+		"title":    {"hello"},
+	})
+
+	if payload["title"] != "hello" {
+		t.Errorf("title = %q, want hello", payload["title"])
+	}
+	if payload["password"] != "[redacted]" {
+		t.Errorf("password = %q, want [redacted]", payload["password"])
+	}
+	if !strings.HasSuffix(payload["text"], "(250 chars)") || len(payload["text"]) >= len(long) {
+		t.Errorf("text was not truncated: %q", payload["text"])
+	}
+}
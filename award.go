@@ -0,0 +1,92 @@
+package graw
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// awardIDPattern matches legacy gilding IDs ("gid_1" to "gid_3") and award IDs such as
+// "award_5f123e3d-4f48-42f4-9c11-e98b566d5897".
+var awardIDPattern = regexp.MustCompile(`^(gid_[1-3]|award_[0-9a-zA-Z-]+)$`)
+
+// GiveAward gives the award awardID to a post or comment, paying for it with the
+// authenticated account's coins, and returns the thing's updated awards. Set anonymous to
+// hide the giver's name from the recipient.
+//
+// Reddit has retired most awards; where the endpoint is no longer available the call fails
+// with the API's error, typically a *errors.NotFoundError.
+//
+// Returns an error if:
+//   - The fullname is not a post or comment fullname
+//   - The award ID is not a "gid_N" or "award_..." ID
+//   - The account cannot afford the award (*errors.InsufficientCoinsError)
+//   - The API request fails or the response cannot be decoded
+func (r *Reddit) GiveAward(ctx context.Context, fullname, awardID string, anonymous bool) (_ *types.AwardResponse, err error) {
+	if err := r.validator.ValidatePaginationToken(fullname); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(fullname, string(types.KIND_POST)) && !strings.HasPrefix(fullname, string(types.KIND_COMMENT)) {
+		return nil, &pkgerrs.ConfigError{Field: "fullname", Message: "only posts and comments can be awarded"}
+	}
+	if !awardIDPattern.MatchString(awardID) {
+		return nil, &pkgerrs.ConfigError{Field: "awardID", Message: "award ID must be gid_1 to gid_3 or award_<id>"}
+	}
+
+	record := WriteAuditRecord{
+		Time:      time.Now(),
+		Operation: "give award",
+		Method:    http.MethodPost,
+		Endpoint:  GildURL,
+		Target:    fullname,
+		Payload:   map[string]string{"gild_type": awardID, "is_anonymous": strconv.FormatBool(anonymous)},
+	}
+	defer func() {
+		record.Err = err
+		r.auditWrite(ctx, record)
+	}()
+
+	body, err := json.Marshal(struct {
+		ThingID     string `json:"thing_id"`
+		GildType    string `json:"gild_type"`
+		IsAnonymous bool   `json:"is_anonymous"`
+	}{fullname, awardID, anonymous})
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "encode award", URL: GildURL, Err: err}
+	}
+
+	req, err := r.httpClient.NewRequest(ctx, http.MethodPost, GildURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: GildURL, Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var resp struct {
+		types.AwardResponse
+		// Some rejections arrive with a 200 status and the error in the body.
+		Reason      string `json:"reason"`
+		Explanation string `json:"explanation"`
+	}
+	if err := r.httpClient.DoJSON(req, &resp); err != nil {
+		return nil, wrapDoError(err, "give award", GildURL)
+	}
+	if resp.Reason != "" {
+		apiErr := &pkgerrs.APIError{StatusCode: http.StatusOK, ErrorCode: resp.Reason, Message: cmp.Or(resp.Explanation, "request rejected")}
+		return nil, pkgerrs.ClassifyAPIError(apiErr, resourceContext("give award", GildURL))
+	}
+	return &resp.AwardResponse, nil
+}
@@ -0,0 +1,132 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestClient_GiveAward(t *testing.T) {
+	var gotBody map[string]any
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.Method != http.MethodPost || req.URL.Path != "/"+GildURL {
+				t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			}
+			if ct := req.Header.Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+			body, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &gotBody); err != nil {
+				t.Fatalf("request body is not JSON: %v", err)
+			}
+			return json.Unmarshal([]byte(`{
+				"coins": 150,
+				"gildings": {"gid_2": 1},
+				"treatment_tags": [],
+				"all_awardings": [{"id": "gid_2", "name": "Gold", "award_type": "global", "coin_price": 500, "count": 1, "icon_url": "https://www.redditstatic.com/gold/awards/icon/gold_512.png"}]
+			}`), v)
+		},
+	}
+
+	resp, err := newTestClient(mock, nil).GiveAward(context.Background(), "t3_abc123", "gid_2", true)
+	if err != nil {
+		t.Fatalf("GiveAward returned error: %v", err)
+	}
+	if gotBody["thing_id"] != "t3_abc123" || gotBody["gild_type"] != "gid_2" || gotBody["is_anonymous"] != true {
+		t.Errorf("request body = %v", gotBody)
+	}
+	if resp.Coins != 150 || resp.Gildings["gid_2"] != 1 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	if len(resp.AllAwardings) != 1 || resp.AllAwardings[0].Name != "Gold" || resp.AllAwardings[0].CoinPrice != 500 {
+		t.Errorf("AllAwardings = %+v", resp.AllAwardings)
+	}
+}
+
+func TestClient_GiveAward_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullname string
+		awardID  string
+		respond  func(v any) error
+		check    func(error) bool
+	}{
+		{
+			name:     "subreddit fullname",
+			fullname: "t5_2qh1i",
+			awardID:  "gid_1",
+			check: func(err error) bool {
+				var e *pkgerrs.ConfigError
+				return errors.As(err, &e) && e.Field == "fullname"
+			},
+		},
+		{
+			name:     "invalid award ID",
+			fullname: "t1_abc123",
+			awardID:  "gold",
+			check: func(err error) bool {
+				var e *pkgerrs.ConfigError
+				return errors.As(err, &e) && e.Field == "awardID"
+			},
+		},
+		{
+			name:     "insufficient coins status",
+			fullname: "t1_abc123",
+			awardID:  "award_5f123e3d-4f48-42f4-9c11-e98b566d5897",
+			respond: func(any) error {
+				return &pkgerrs.APIError{StatusCode: http.StatusBadRequest, Message: "request failed", Reason: pkgerrs.ReasonInsufficientCoinsWithAward}
+			},
+			check: func(err error) bool {
+				var e *pkgerrs.InsufficientCoinsError
+				return errors.As(err, &e) && e.Operation == "give award"
+			},
+		},
+		{
+			name:     "insufficient coins in body",
+			fullname: "t1_abc123",
+			awardID:  "gid_1",
+			respond: func(v any) error {
+				return json.Unmarshal([]byte(`{"explanation": "Not enough coins", "reason": "INSUFFICIENT_COINS"}`), v)
+			},
+			check: func(err error) bool {
+				var e *pkgerrs.InsufficientCoinsError
+				return errors.As(err, &e) && e.Err.Message == "Not enough coins"
+			},
+		},
+		{
+			name:     "endpoint retired",
+			fullname: "t3_abc123",
+			awardID:  "gid_1",
+			respond: func(any) error {
+				return &pkgerrs.APIError{StatusCode: http.StatusNotFound, Message: "request failed"}
+			},
+			check: func(err error) bool {
+				var e *pkgerrs.NotFoundError
+				return errors.As(err, &e)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockHTTPClient{
+				doJSONFunc: func(req *http.Request, v any) error {
+					if tt.respond == nil {
+						t.Fatal("unexpected request")
+					}
+					return tt.respond(v)
+				},
+			}
+			_, err := newTestClient(mock, nil).GiveAward(context.Background(), tt.fullname, tt.awardID, false)
+			if !tt.check(err) {
+				t.Errorf("GiveAward() error = %T: %v", err, err)
+			}
+		})
+	}
+}
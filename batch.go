@@ -0,0 +1,126 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// runBatch calls fn for each index in [0, n) with at most limit calls in flight. fn should
+// store its result at index i of a slice preallocated by the caller, so memory is bounded by
+// n regardless of how results complete.
+//
+// The first error cancels the context passed to the remaining calls, and calls not yet
+// started are skipped. If ctx itself is cancelled, ctx.Err() is returned in preference to
+// errors caused by the cancellation. Otherwise the first error from fn is returned.
+func runBatch(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(limit)
+	for i := 0; i < n; i++ {
+		if gctx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			return fn(gctx, i)
+		})
+	}
+	err := g.Wait()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// retryBatch calls fn for each index in [0, n) with at most limit calls in flight, as
+// runBatch does, running each round through runWorkers so the calls show in the worker pool
+// stats. Unlike runBatch, an error from fn neither cancels the context of the other calls
+// nor skips calls not yet started: it is recorded for its index, and only the end of ctx
+// stops the batch. Calls that fail transiently are run again, in rounds of only the failed
+// indices, up to cfg.MaxRetries times with exponentially growing delays between rounds. It
+// returns the final error of each index, nil where fn succeeded, or an error if ctx ends
+// first.
+func (r *Reddit) retryBatch(ctx context.Context, n, limit int, cfg RetryConfig, fn func(ctx context.Context, i int) error) ([]error, error) {
+	errs := make([]error, n)
+	pending := make([]int, n)
+	for i := range pending {
+		pending[i] = i
+	}
+	for attempt := 1; ; attempt++ {
+		err := r.runWorkers(ctx, len(pending), limit, func(ctx context.Context, j int) error {
+			errs[pending[j]] = fn(ctx, pending[j])
+			return nil
+		})
+		if err != nil {
+			return errs, err
+		}
+
+		var failed []int
+		for _, i := range pending {
+			if transientError(errs[i]) {
+				failed = append(failed, i)
+			}
+		}
+		if len(failed) == 0 || attempt > cfg.MaxRetries {
+			return errs, nil
+		}
+		pending = failed
+
+		timer := time.NewTimer(retryBackoff(cfg, attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errs, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryBackoff returns the delay before the given retry (starting at 1): InitialBackoff,
+// doubling each time up to MaxBackoff, with the defaults of Config.RetryConfig.
+func retryBackoff(cfg RetryConfig, attempt int) time.Duration {
+	initial, maxDelay := cfg.InitialBackoff, cfg.MaxBackoff
+	if initial <= 0 {
+		initial = internal.DefaultRetryInitialBackoff
+	}
+	if maxDelay <= 0 {
+		maxDelay = internal.DefaultRetryMaxBackoff
+	}
+	delay := initial
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return max(min(delay, maxDelay), initial)
+}
+
+// transientError reports whether err is a failure that may not repeat: a retryable
+// transport error, a 429, 500, 502, 503, or 504 response, or a half-parsed comments page.
+func transientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *pkgerrs.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	// A comments page of which only one listing parsed is usually a truncated response.
+	var partialErr *pkgerrs.PartialResultError
+	var parseErr *pkgerrs.ParseError
+	if errors.As(err, &partialErr) && errors.As(partialErr.Err, &parseErr) {
+		return true
+	}
+	var transportErr *pkgerrs.TransportError
+	return errors.As(err, &transportErr) && transportErr.Retryable()
+}
@@ -0,0 +1,177 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestRunBatch_LimitsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	err := runBatch(context.Background(), 20, 3, func(ctx context.Context, i int) error {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		inFlight.Add(-1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("runBatch: %v", err)
+	}
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", got)
+	}
+}
+
+func TestRunBatch_FirstErrorCancelsRest(t *testing.T) {
+	boom := errors.New("boom")
+	var started atomic.Int32
+	err := runBatch(context.Background(), 50, 1, func(ctx context.Context, i int) error {
+		started.Add(1)
+		if i == 0 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("err = %v, want %v", err, boom)
+	}
+	if got := started.Load(); got == 50 {
+		t.Error("expected remaining calls to be skipped after the first error")
+	}
+}
+
+func TestRunBatch_PrefersContextError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := runBatch(ctx, 5, 1, func(ctx context.Context, i int) error {
+		cancel()
+		<-ctx.Done()
+		return errors.New("request aborted")
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestGetCommentsMultiple_BatchRetry(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			id := path.Base(req.URL.Path)
+			mu.Lock()
+			calls[id]++
+			n := calls[id]
+			mu.Unlock()
+			switch {
+			case id == "post2" && n <= 2:
+				return nil, &pkgerrs.APIError{StatusCode: http.StatusBadGateway, Message: "bad gateway"}
+			case id == "post3":
+				return nil, &pkgerrs.APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+			case id == "post4":
+				return nil, &pkgerrs.APIError{StatusCode: http.StatusServiceUnavailable, Message: "unavailable"}
+			}
+			return []*types.Thing{
+				listingThing(t, submitPostThing(t, id, "Title", "gopher", time.Unix(1700000000, 0))),
+				listingThing(t, commentThing(t, "c1", "hello", false)),
+			}, nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	client.config.BatchRetry = &RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond}
+
+	requests := []*types.CommentsRequest{
+		{Subreddit: "golang", PostID: "post1"},
+		{Subreddit: "golang", PostID: "post2"},
+		{Subreddit: "golang", PostID: "post3"},
+		{Subreddit: "golang", PostID: "post4"},
+	}
+	results, err := client.GetCommentsMultiple(context.Background(), requests)
+
+	var partial *pkgerrs.PartialResultError
+	if !errors.As(err, &partial) {
+		t.Fatalf("GetCommentsMultiple() error = %v, want PartialResultError", err)
+	}
+	if partial.Completed != 2 || partial.Total != 4 {
+		t.Errorf("completed %d of %d, want 2 of 4", partial.Completed, partial.Total)
+	}
+	var notFound *pkgerrs.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("error = %v, want the first failure, post3's NotFoundError", err)
+	}
+	for i, want := range []bool{true, true, false, false} {
+		if got := results[i] != nil; got != want {
+			t.Errorf("results[%d] present = %v, want %v", i, got, want)
+		}
+	}
+
+	// Only transient failures are retried, and at most MaxRetries times.
+	want := map[string]int{"post1": 1, "post2": 3, "post3": 1, "post4": 3}
+	for id, n := range want {
+		if calls[id] != n {
+			t.Errorf("%s fetched %d times, want %d", id, calls[id], n)
+		}
+	}
+}
+
+func TestGetCommentsMultiple_PartialPageSlotIsNil(t *testing.T) {
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			id := path.Base(req.URL.Path)
+			comments := listingThing(t, commentThing(t, "c1", "hello", false))
+			if id == "post2" {
+				comments = &types.Thing{Kind: "t3", Data: []byte(`{}`)} // not a Listing
+			}
+			return []*types.Thing{
+				listingThing(t, submitPostThing(t, id, "Title", "gopher", time.Unix(1700000000, 0))),
+				comments,
+			}, nil
+		},
+	}
+	requests := []*types.CommentsRequest{
+		{Subreddit: "golang", PostID: "post1"},
+		{Subreddit: "golang", PostID: "post2"},
+	}
+
+	for _, retry := range []*RetryConfig{nil, {MaxRetries: 1, InitialBackoff: time.Millisecond}} {
+		client := newTestClient(mock, nil)
+		client.config.BatchRetry = retry
+		results, err := client.GetCommentsMultiple(context.Background(), requests)
+
+		var partial *pkgerrs.PartialResultError
+		if !errors.As(err, &partial) {
+			t.Fatalf("BatchRetry %v: error = %v, want PartialResultError", retry, err)
+		}
+		if results[1] != nil {
+			t.Errorf("BatchRetry %v: failed slot holds %+v, want nil", retry, results[1])
+		}
+		if retry != nil && results[0] == nil {
+			t.Errorf("BatchRetry %v: completed slot is nil", retry)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	cfg := RetryConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 10: 300 * time.Millisecond} {
+		if got := retryBackoff(cfg, attempt); got != want {
+			t.Errorf("retryBackoff(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+	if got := retryBackoff(RetryConfig{}, 1); got != 500*time.Millisecond {
+		t.Errorf("default first backoff = %v, want 500ms", got)
+	}
+}
@@ -0,0 +1,97 @@
+package graw
+
+import (
+	"sync"
+	"time"
+)
+
+// boundedCache is a string-keyed cache with a size bound, for the client's in-memory caches.
+// Adding a key to a full cache first drops the expired entries and, failing that, the least
+// recently used one. The zero value is an empty cache ready to use.
+type boundedCache[V comparable] struct {
+	mu      sync.Mutex
+	entries map[string]*boundedEntry[V]
+}
+
+// boundedEntry is an entry of a boundedCache.
+type boundedEntry[V comparable] struct {
+	value   V
+	expires time.Time // after this the entry is dropped first when the cache is full
+	usedAt  time.Time
+}
+
+// get returns the value stored under key and marks it used. Callers check freshness
+// themselves; expiry only guides eviction.
+func (c *boundedCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	entry.usedAt = time.Now()
+	return entry.value, true
+}
+
+// put stores value under key until expires, evicting entries if the cache holds maxEntries.
+func (c *boundedCache[V]) put(key string, value V, expires time.Time, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]*boundedEntry[V])
+	}
+	now := time.Now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxEntries {
+		c.evict(now, maxEntries)
+	}
+	c.entries[key] = &boundedEntry[V]{value: value, expires: expires, usedAt: now}
+}
+
+// replace stores value under key until expires if key still holds old, and reports whether
+// it did.
+func (c *boundedCache[V]) replace(key string, old, value V, expires time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || entry.value != old {
+		return false
+	}
+	entry.value, entry.expires, entry.usedAt = value, expires, time.Now()
+	return true
+}
+
+// remove deletes key if it still holds value.
+func (c *boundedCache[V]) remove(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok && entry.value == value {
+		delete(c.entries, key)
+	}
+}
+
+// len returns the number of entries, expired or not.
+func (c *boundedCache[V]) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// evict makes room for one entry, dropping expired entries, or failing that the least
+// recently used one. The caller must hold c.mu.
+func (c *boundedCache[V]) evict(now time.Time, maxEntries int) {
+	var lruKey string
+	var lru time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if lruKey == "" || entry.usedAt.Before(lru) {
+			lruKey, lru = key, entry.usedAt
+		}
+	}
+	if len(c.entries) >= maxEntries {
+		delete(c.entries, lruKey)
+	}
+}
@@ -0,0 +1,168 @@
+package graw
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// dedupKeyLength is the number of hex digits kept from the hash in a dedup key.
+const dedupKeyLength = 32
+
+// trackingParams are query parameters that identify the sharer or campaign rather than the
+// content, and are removed by CanonicalURL. Parameters starting with "utm_" are removed too.
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true, "yclid": true,
+	"igshid": true, "igsh": true, "mc_cid": true, "mc_eid": true, "_ga": true,
+	"ref": true, "ref_src": true, "ref_url": true, "share_id": true, "si": true,
+	"feature": true, "rdt": true, "$deep_link": true, "$3p": true,
+	"correlation_id": true, "post_fullname": true, "_branch_match_id": true,
+}
+
+// hostAliases maps hosts to the host CanonicalURL reports for them.
+var hostAliases = map[string]string{
+	"old.reddit.com":       "reddit.com",
+	"new.reddit.com":       "reddit.com",
+	"np.reddit.com":        "reddit.com",
+	"amp.reddit.com":       "reddit.com",
+	"mobile.twitter.com":   "twitter.com",
+	"x.com":                "twitter.com",
+	"music.youtube.com":    "youtube.com",
+	"youtube-nocookie.com": "youtube.com",
+}
+
+// redditPostPath matches the path of a Reddit post or comment permalink, with or without
+// the subreddit and title slug.
+var redditPostPath = regexp.MustCompile(`^(?:/r/[A-Za-z0-9_]+)?/comments/([a-z0-9]+)(?:/[^/]*(?:/([a-z0-9]+))?)?/?$`)
+
+// CanonicalURL returns a normalized form of a link, so that different ways of writing the
+// same target compare equal. It is meant for deduplication and repost detection, not for
+// fetching: the result may not be a URL the site redirects to.
+//
+// CanonicalURL:
+//   - uses https, lowercases the host, and drops "www." and "m." prefixes, default ports,
+//     fragments, and a trailing slash
+//   - removes tracking parameters such as utm_*, fbclid, and si, and sorts the rest
+//   - resolves youtu.be, YouTube Shorts and embed links, and redd.it to their long forms, and
+//     Reddit permalinks to reddit.com/comments/{post}[/_/{comment}] without subreddit or slug
+//
+// Shorteners that need a network request to resolve, such as bit.ly, are left as they are.
+//
+// Returns a *errors.ConfigError if raw is not an absolute http or https URL.
+func CanonicalURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", &pkgerrs.ConfigError{Field: "url", Message: fmt.Sprintf("invalid URL: %v", err)}
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if (scheme != "http" && scheme != "https") || u.Hostname() == "" {
+		return "", &pkgerrs.ConfigError{Field: "url", Message: fmt.Sprintf("not an http or https URL: %q", raw)}
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	for _, prefix := range []string{"www.", "m."} {
+		host = strings.TrimPrefix(host, prefix)
+	}
+	if alias, ok := hostAliases[host]; ok {
+		host = alias
+	}
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host = net.JoinHostPort(host, port)
+	}
+
+	path := u.EscapedPath()
+	query := u.Query()
+	switch host {
+	case "youtu.be":
+		if id := strings.Trim(path, "/"); id != "" {
+			host, path = "youtube.com", "/watch"
+			query.Set("v", id)
+		}
+	case "youtube.com":
+		for _, prefix := range []string{"/shorts/", "/embed/", "/live/", "/v/"} {
+			if id, ok := strings.CutPrefix(path, prefix); ok && id != "" {
+				path = "/watch"
+				query.Set("v", strings.Trim(id, "/"))
+				break
+			}
+		}
+	case "redd.it":
+		if id := strings.Trim(path, "/"); id != "" {
+			host, path = "reddit.com", "/comments/"+strings.ToLower(id)
+		}
+	case "reddit.com":
+		if m := redditPostPath.FindStringSubmatch(path); m != nil {
+			path = "/comments/" + m[1]
+			if m[2] != "" {
+				path += "/_/" + m[2]
+			}
+			query = url.Values{}
+		}
+	}
+
+	for key := range query {
+		if trackingParams[strings.ToLower(key)] || strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	if path == "/" {
+		path = ""
+	}
+
+	canonical := "https://" + host + path
+	if len(query) > 0 {
+		// Encode sorts by key.
+		canonical += "?" + query.Encode()
+	}
+	return canonical, nil
+}
+
+// DedupKey returns a stable key identifying a post's content, so that reposts and
+// crossposts of the same link share a key across subreddits, authors, and time. Link posts
+// are keyed by CanonicalURL of their target; self posts, and links that cannot be
+// canonicalized, by their title and text with case, punctuation, and spacing normalized.
+// Keys of the two kinds never collide.
+//
+// The key is a short hex digest prefixed by "link:" or "text:", suitable for use as a map key
+// or database column. It returns an empty string for a nil post.
+func DedupKey(post *types.Post) string {
+	if post == nil {
+		return ""
+	}
+	if !post.IsSelf && post.URL != "" {
+		if canonical, err := CanonicalURL(post.URL); err == nil && !isOwnPermalink(canonical, post) {
+			return dedupDigest("link:", canonical)
+		}
+	}
+	return dedupDigest("text:", normalizeDedupText(post.Title)+"\x00"+normalizeDedupText(post.SelfText))
+}
+
+// isOwnPermalink reports whether canonical is the post's own comments page, which Reddit
+// sets as the URL of self posts and is unique to each post.
+func isOwnPermalink(canonical string, post *types.Post) bool {
+	return post.ID != "" && canonical == "https://reddit.com/comments/"+strings.ToLower(post.ID)
+}
+
+// normalizeDedupText lowercases s and reduces it to letters and digits separated by single
+// spaces.
+func normalizeDedupText(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func dedupDigest(prefix, s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return prefix + hex.EncodeToString(sum[:])[:dedupKeyLength]
+}
@@ -0,0 +1,80 @@
+package graw
+
+import (
+	"errors"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		raw, want string
+	}{
+		{"HTTP://WWW.Example.com:80/a/b/?utm_source=x&b=2&a=1#frag", "https://example.com/a/b?a=1&b=2"},
+		{"https://example.com/?fbclid=abc", "https://example.com"},
+		{"https://example.com:8443/x?UTM_Medium=y&q=go", "https://example.com:8443/x?q=go"},
+		{"https://youtu.be/dQw4w9WgXcQ?si=share", "https://youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"https://m.youtube.com/watch?v=dQw4w9WgXcQ&feature=share&t=42", "https://youtube.com/watch?t=42&v=dQw4w9WgXcQ"},
+		{"https://www.youtube.com/shorts/dQw4w9WgXcQ", "https://youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"https://redd.it/ABC123", "https://reddit.com/comments/abc123"},
+		{"https://old.reddit.com/r/golang/comments/abc123/some_title/?share_id=x", "https://reddit.com/comments/abc123"},
+		{"https://np.reddit.com/r/golang/comments/abc123/some_title/def456/?context=3", "https://reddit.com/comments/abc123/_/def456"},
+		{"https://x.com/golang/status/1?s=20", "https://twitter.com/golang/status/1?s=20"},
+	}
+	for _, tt := range tests {
+		got, err := CanonicalURL(tt.raw)
+		if err != nil {
+			t.Errorf("CanonicalURL(%q) returned error: %v", tt.raw, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CanonicalURL(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+
+	for _, raw := range []string{"", "/r/golang", "ftp://example.com/file", "https://", "http://[::1"} {
+		var configErr *pkgerrs.ConfigError
+		if _, err := CanonicalURL(raw); !errors.As(err, &configErr) {
+			t.Errorf("CanonicalURL(%q) error = %v, want ConfigError", raw, err)
+		}
+	}
+}
+
+func TestDedupKey(t *testing.T) {
+	link := func(id, url string) *types.Post {
+		return &types.Post{ThingData: types.ThingData{ID: id}, Title: "Title " + id, URL: url}
+	}
+	self := func(id, title, text string) *types.Post {
+		return &types.Post{ThingData: types.ThingData{ID: id}, IsSelf: true, Title: title, SelfText: text,
+			URL: "https://www.reddit.com/r/golang/comments/" + id + "/slug/"}
+	}
+
+	a := DedupKey(link("a1", "https://youtu.be/dQw4w9WgXcQ?si=1"))
+	if b := DedupKey(link("b2", "https://www.youtube.com/watch?v=dQw4w9WgXcQ&utm_source=reddit")); a != b {
+		t.Errorf("reposted link keys differ: %q, %q", a, b)
+	}
+	if c := DedupKey(link("c3", "https://youtube.com/watch?v=other")); a == c {
+		t.Error("different links share a key")
+	}
+
+	s1 := DedupKey(self("s1", "Is Go fast?", "Asking  for a FRIEND!"))
+	if s2 := DedupKey(self("s2", "is go fast", "asking for a friend")); s1 != s2 {
+		t.Errorf("reposted text keys differ: %q, %q", s1, s2)
+	}
+	if s3 := DedupKey(self("s3", "Is Go fast?", "Something else")); s1 == s3 {
+		t.Error("different texts share a key")
+	}
+	// A link post whose URL is its own permalink is keyed by its text.
+	if key := DedupKey(&types.Post{ThingData: types.ThingData{ID: "s4"}, Title: "Is Go fast?", SelfText: "asking for a friend", URL: "https://redd.it/s4"}); key != s1 {
+		t.Errorf("own-permalink key = %q, want %q", key, s1)
+	}
+
+	if a[:5] != "link:" || s1[:5] != "text:" || len(a) != 5+dedupKeyLength {
+		t.Errorf("keys %q, %q have the wrong form", a, s1)
+	}
+	if DedupKey(nil) != "" {
+		t.Error("DedupKey(nil) is not empty")
+	}
+}
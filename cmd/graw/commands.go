@@ -0,0 +1,349 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	graw "github.com/jamesprial/go-reddit-api-wrapper"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const (
+	// defaultStreamInterval is how often the stream command polls for new posts.
+	defaultStreamInterval = 30 * time.Second
+	// minStreamInterval keeps the stream command from hammering Reddit.
+	minStreamInterval = 2 * time.Second
+	// maxExportMoreBatch is the number of IDs requested per morechildren call during export.
+	maxExportMoreBatch = 100
+)
+
+// newFlagSet creates a flag set for a subcommand that reports errors instead of exiting.
+func newFlagSet(env *cliEnv, name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(env.errOut)
+	fs.Usage = func() {
+		fmt.Fprintf(env.errOut, "Usage: graw %s %s\n\nFlags:\n", name, usage)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses a subcommand's flags, translating -h and parse failures into errUsage.
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected arguments: %v\n", fs.Args())
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
+
+func runHot(ctx context.Context, env *cliEnv, args []string) error {
+	return runListing(ctx, env, "hot", args, (*graw.Reddit).GetHot)
+}
+
+func runNew(ctx context.Context, env *cliEnv, args []string) error {
+	return runListing(ctx, env, "new", args, (*graw.Reddit).GetNew)
+}
+
+// runListing implements the hot and new commands, which differ only in the client method.
+func runListing(ctx context.Context, env *cliEnv, name string, args []string,
+	fetch func(*graw.Reddit, context.Context, *types.PostsRequest, ...graw.ListingOption) (*types.PostsResponse, error)) error {
+	fs := newFlagSet(env, name, "[-sub name] [-limit n] [-after fullname | -before fullname]")
+	req := &types.PostsRequest{}
+	fs.StringVar(&req.Subreddit, "sub", "", "subreddit name without r/ (default: front page)")
+	fs.IntVar(&req.Limit, "limit", 25, "number of posts to fetch (max 100)")
+	fs.StringVar(&req.After, "after", "", "fetch posts after this fullname")
+	fs.StringVar(&req.Before, "before", "", "fetch posts before this fullname")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	client, err := env.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := fetch(client, ctx, req)
+	if err != nil {
+		return err
+	}
+	return env.printPosts(resp)
+}
+
+func runComments(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "comments", "-sub name -post id [-limit n] [-depth n]")
+	req := &types.CommentsRequest{}
+	var depth int
+	fs.StringVar(&req.Subreddit, "sub", "", "subreddit name without r/ (required)")
+	fs.StringVar(&req.PostID, "post", "", "post ID without the t3_ prefix (required)")
+	fs.IntVar(&req.Limit, "limit", 0, "number of top-level comments to fetch (max 100)")
+	fs.IntVar(&depth, "depth", 0, "maximum reply depth to print in table output (0 for all)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if req.Subreddit == "" || req.PostID == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := env.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetComments(ctx, req)
+	if err != nil {
+		return err
+	}
+	return env.printComments(resp, depth)
+}
+
+func runSearch(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "search", "-q query [-sub name] [-type posts|subreddits|users] [-sort s] [-t range] [-limit n] [-after fullname]")
+	req := &types.SearchRequest{}
+	var searchType string
+	fs.StringVar(&req.Query, "q", "", "search query in Reddit's search syntax (required)")
+	fs.StringVar(&req.Subreddit, "sub", "", "restrict a post search to this subreddit")
+	fs.StringVar(&searchType, "type", "posts", "what to search: posts, subreddits, or users")
+	fs.StringVar(&req.Sort, "sort", "", "result order, e.g. relevance, top, new (default: relevance)")
+	fs.StringVar(&req.TimeRange, "t", "", "time range of post results: hour, day, week, month, year, or all")
+	fs.IntVar(&req.Limit, "limit", 25, "number of results to fetch (max 100)")
+	fs.StringVar(&req.After, "after", "", "fetch results after this fullname")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	var ok bool
+	if req.Type, ok = searchTypes[searchType]; !ok || req.Query == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := env.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Search(ctx, req)
+	if err != nil {
+		return err
+	}
+	return env.printSearch(resp)
+}
+
+// searchTypes maps the search command's -type values to the library's search types.
+var searchTypes = map[string]types.SearchType{
+	"posts":      types.SearchTypePosts,
+	"subreddits": types.SearchTypeSubreddits,
+	"users":      types.SearchTypeUsers,
+}
+
+func runUser(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "user", "[-name username]")
+	var username string
+	fs.StringVar(&username, "name", "", "username to look up (default: the authenticated account)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	client, err := env.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	var account *types.AccountData
+	if username != "" {
+		account, err = client.GetUser(ctx, username)
+	} else {
+		account, err = client.Me(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	return env.printAccount(account)
+}
+
+func runScopes(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "scopes", "")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	client, err := env.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	scopes, err := client.GetAvailableScopes(ctx)
+	if err != nil {
+		return err
+	}
+	return env.printScopes(scopes)
+}
+
+func runStream(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "stream", "-sub name [-interval d]")
+	var subreddit string
+	var interval time.Duration
+	fs.StringVar(&subreddit, "sub", "", "subreddit name without r/ (required)")
+	fs.DurationVar(&interval, "interval", defaultStreamInterval, "polling interval")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if subreddit == "" {
+		fs.Usage()
+		return errUsage
+	}
+	if interval < minStreamInterval {
+		interval = minStreamInterval
+	}
+
+	client, err := env.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(env.errOut, "streaming new posts from r/%s every %s (Ctrl+C to stop)\n", subreddit, interval)
+
+	// The stream skips posts that already exist, so only posts created after startup are printed.
+	posts, err := client.StreamNewPosts(ctx, &types.StreamRequest{Subreddit: subreddit, Interval: interval})
+	if err != nil {
+		return err
+	}
+	for post := range posts {
+		if err := env.printStreamPost(post); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func runExport(ctx context.Context, env *cliEnv, args []string) error {
+	fs := newFlagSet(env, "export", "-sub name -post id [-o file] [-no-more]")
+	var subreddit, postID, output string
+	var skipMore bool
+	fs.StringVar(&subreddit, "sub", "", "subreddit name without r/ (required)")
+	fs.StringVar(&postID, "post", "", "post ID without the t3_ prefix (required)")
+	fs.StringVar(&output, "o", "", "output file (default: stdout)")
+	fs.BoolVar(&skipMore, "no-more", false, "do not load truncated comments via morechildren")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if subreddit == "" || postID == "" {
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := env.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetComments(ctx, &types.CommentsRequest{Subreddit: subreddit, PostID: postID})
+	if err != nil {
+		return err
+	}
+
+	export := exportDocument{
+		ExportedAt: time.Now().UTC(),
+		Post:       resp.Post,
+		Comments:   toExportComments(resp.Comments),
+	}
+
+	if !skipMore && len(resp.MoreIDs) > 0 {
+		more, remaining, err := loadAllMore(ctx, client, postID, resp.MoreIDs)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			fmt.Fprintf(env.errOut, "graw export: loading more comments stopped early: %v\n", err)
+		}
+		export.MoreComments = toExportComments(more)
+		export.UnloadedIDs = remaining
+	} else {
+		export.UnloadedIDs = resp.MoreIDs
+	}
+
+	w := env.out
+	if output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(export); err != nil {
+		return err
+	}
+	if output != "" {
+		fmt.Fprintf(env.errOut, "exported %d comments (%d loaded via morechildren) to %s\n",
+			countComments(export.Comments)+countComments(export.MoreComments), len(export.MoreComments), output)
+	}
+	return nil
+}
+
+// exportDocument is the JSON structure written by the export command.
+type exportDocument struct {
+	ExportedAt   time.Time        `json:"exported_at"`
+	Post         *types.Post      `json:"post"`
+	Comments     []*exportComment `json:"comments"`
+	MoreComments []*exportComment `json:"more_comments,omitempty"`
+	UnloadedIDs  []string         `json:"unloaded_ids,omitempty"`
+}
+
+// exportComment serializes a comment together with its replies, which types.Comment
+// deliberately omits from its own JSON encoding.
+type exportComment struct {
+	*types.Comment
+	Replies []*exportComment `json:"replies,omitempty"`
+}
+
+func toExportComments(comments []*types.Comment) []*exportComment {
+	if len(comments) == 0 {
+		return nil
+	}
+	out := make([]*exportComment, 0, len(comments))
+	for _, c := range comments {
+		if c == nil {
+			continue
+		}
+		out = append(out, &exportComment{Comment: c, Replies: toExportComments(c.Replies)})
+	}
+	return out
+}
+
+// loadAllMore fetches truncated comments in batches, returning the loaded comments and any IDs
+// that could not be loaded because an error stopped the process.
+func loadAllMore(ctx context.Context, client *graw.Reddit, postID string, ids []string) ([]*types.Comment, []string, error) {
+	var loaded []*types.Comment
+	for start := 0; start < len(ids); start += maxExportMoreBatch {
+		end := min(start+maxExportMoreBatch, len(ids))
+		comments, err := client.GetMoreComments(ctx, &types.MoreCommentsRequest{
+			LinkID:     postID,
+			CommentIDs: ids[start:end],
+		})
+		if err != nil {
+			return loaded, ids[start:], err
+		}
+		loaded = append(loaded, comments...)
+	}
+	return loaded, nil, nil
+}
+
+// countComments counts comments in an exported forest, including all nested replies.
+func countComments(comments []*exportComment) int {
+	n := 0
+	for _, c := range comments {
+		n += 1 + countComments(c.Replies)
+	}
+	return n
+}
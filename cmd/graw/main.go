@@ -0,0 +1,206 @@
+// Package main implements graw, a small command-line client for the Reddit API
+// built on top of the go-reddit-api-wrapper library.
+//
+// It is intended both as a quick query utility and as living documentation of
+// the library's API surface: every subcommand maps onto one or two client calls.
+//
+// Usage:
+//
+//	graw [global flags] <command> [command flags]
+//
+// Commands:
+//
+//	hot       List hot posts from a subreddit or the front page
+//	new       List new posts from a subreddit or the front page
+//	comments  Show a post and its comment tree
+//	search    Search posts, subreddits, or users
+//	user      Show a user's profile, or the authenticated account
+//	stream    Poll a subreddit and print new posts as they arrive
+//	export    Write a post and its full comment tree to a JSON file
+//	scopes    List the OAuth scopes an app can request
+//
+// Credentials are read from flags, falling back to the environment:
+//   - REDDIT_CLIENT_ID / -client-id (required)
+//   - REDDIT_CLIENT_SECRET / -client-secret (required)
+//   - REDDIT_USERNAME / -username (optional, for user authentication)
+//   - REDDIT_PASSWORD / -password (optional, for user authentication)
+//   - REDDIT_USER_AGENT / -user-agent (optional)
+//   - REDDIT_BASE_URL / -base-url and REDDIT_AUTH_URL / -auth-url (optional, for testing
+//     against a mock server)
+//
+// Example:
+//
+//	export REDDIT_CLIENT_ID="your_client_id"
+//	export REDDIT_CLIENT_SECRET="your_client_secret"
+//	go run ./cmd/graw hot -sub golang -limit 5
+//	go run ./cmd/graw -format json comments -sub golang -post abc123
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	graw "github.com/jamesprial/go-reddit-api-wrapper"
+)
+
+const defaultUserAgent = "graw-cli/1.0 (go-reddit-api-wrapper)"
+
+// errUsage is returned by commands when their arguments are invalid.
+// The usage text has already been printed when it is returned.
+var errUsage = errors.New("invalid usage")
+
+// globalOptions holds flags shared by every subcommand.
+type globalOptions struct {
+	clientID     string
+	clientSecret string
+	username     string
+	password     string
+	userAgent    string
+	baseURL      string
+	authURL      string
+	format       string
+	verbose      bool
+}
+
+// command describes a single graw subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, env *cliEnv, args []string) error
+}
+
+// cliEnv is the state shared by subcommands once global flags are parsed.
+type cliEnv struct {
+	opts   globalOptions
+	out    io.Writer
+	errOut io.Writer
+}
+
+var commands = []command{
+	{name: "hot", summary: "List hot posts from a subreddit or the front page", run: runHot},
+	{name: "new", summary: "List new posts from a subreddit or the front page", run: runNew},
+	{name: "comments", summary: "Show a post and its comment tree", run: runComments},
+	{name: "search", summary: "Search posts, subreddits, or users", run: runSearch},
+	{name: "user", summary: "Show a user's profile, or the authenticated account", run: runUser},
+	{name: "stream", summary: "Poll a subreddit and print new posts as they arrive", run: runStream},
+	{name: "export", summary: "Write a post and its full comment tree to a JSON file", run: runExport},
+	{name: "scopes", summary: "List the OAuth scopes an app can request", run: runScopes},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run parses global flags, dispatches to the selected subcommand and returns the exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	env := &cliEnv{out: stdout, errOut: stderr}
+
+	fs := flag.NewFlagSet("graw", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&env.opts.clientID, "client-id", os.Getenv(graw.EnvClientID), "Reddit app client ID")
+	fs.StringVar(&env.opts.clientSecret, "client-secret", os.Getenv(graw.EnvClientSecret), "Reddit app client secret")
+	fs.StringVar(&env.opts.username, "username", os.Getenv(graw.EnvUsername), "Reddit username (optional)")
+	fs.StringVar(&env.opts.password, "password", os.Getenv(graw.EnvPassword), "Reddit password (optional)")
+	fs.StringVar(&env.opts.userAgent, "user-agent", envOr(graw.EnvUserAgent, defaultUserAgent), "User-Agent sent to Reddit")
+	fs.StringVar(&env.opts.baseURL, "base-url", os.Getenv(graw.EnvBaseURL), "Reddit API base URL (default: oauth.reddit.com)")
+	fs.StringVar(&env.opts.authURL, "auth-url", os.Getenv(graw.EnvAuthURL), "Reddit OAuth base URL (default: www.reddit.com)")
+	fs.StringVar(&env.opts.format, "format", "table", "output format: table or json")
+	fs.BoolVar(&env.opts.verbose, "v", false, "enable debug logging to stderr")
+	fs.Usage = func() { printUsage(stderr, fs) }
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	if env.opts.format != formatTable && env.opts.format != formatJSON {
+		fmt.Fprintf(stderr, "graw: unknown format %q (want %q or %q)\n", env.opts.format, formatTable, formatJSON)
+		return 2
+	}
+
+	name := fs.Arg(0)
+	var cmd *command
+	for i := range commands {
+		if commands[i].name == name {
+			cmd = &commands[i]
+			break
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(stderr, "graw: unknown command %q\n\n", name)
+		fs.Usage()
+		return 2
+	}
+
+	if err := cmd.run(ctx, env, fs.Args()[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			return 2
+		}
+		if errors.Is(err, context.Canceled) {
+			return 0
+		}
+		fmt.Fprintf(stderr, "graw %s: %v\n", cmd.name, err)
+		return 1
+	}
+	return 0
+}
+
+// connect builds an authenticated client from the global options.
+// Commands call it after parsing their own flags so that -h works without credentials.
+func (env *cliEnv) connect(ctx context.Context) (*graw.Reddit, error) {
+	opts := env.opts
+	if opts.clientID == "" || opts.clientSecret == "" {
+		return nil, errors.New("client ID and secret are required (set REDDIT_CLIENT_ID and REDDIT_CLIENT_SECRET or pass -client-id and -client-secret)")
+	}
+
+	config := &graw.Config{
+		ClientID:     opts.clientID,
+		ClientSecret: opts.clientSecret,
+		Username:     opts.username,
+		Password:     opts.password,
+		UserAgent:    opts.userAgent,
+		BaseURL:      opts.baseURL,
+		AuthURL:      opts.authURL,
+	}
+	// Warnings such as failed stream polls are always shown; -v adds debug output.
+	level := slog.LevelWarn
+	if opts.verbose {
+		level = slog.LevelDebug
+	}
+	config.Logger = slog.New(slog.NewTextHandler(env.errOut, &slog.HandlerOptions{Level: level}))
+
+	return graw.NewClientWithContext(ctx, config)
+}
+
+func printUsage(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprintln(w, "Usage: graw [global flags] <command> [command flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Global flags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'graw <command> -h' for command flags.")
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
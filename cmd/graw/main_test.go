@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+)
+
+// newTestServer serves the endpoints the CLI commands call, recording each request's query.
+func newTestServer(t *testing.T, queries map[string]url.Values) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	grawtest.HandleToken(mux, grawtest.StaticTokenHandler("test-token"))
+	serve := func(path string, body func(r *http.Request) []byte) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			queries[r.URL.Path] = r.URL.Query()
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(body(r))
+		})
+	}
+	serve("/r/golang/hot", func(*http.Request) []byte { return grawtest.NewListing(2) })
+	serve("/r/golang/new", func(*http.Request) []byte { return grawtest.NewListing(1) })
+	serve("/r/golang/comments/"+grawtest.FixturePostID, func(*http.Request) []byte { return grawtest.NewCommentTree(2, 2) })
+	serve("/r/golang/search", func(*http.Request) []byte { return grawtest.NewListing(1) })
+	serve("/search", func(*http.Request) []byte {
+		return mustJSON(t, map[string]any{"kind": "Listing", "data": map[string]any{"children": []any{
+			map[string]any{"kind": "t5", "data": map[string]any{
+				"id": "2qh1i", "name": "t5_2qh1i", "display_name": "golang", "title": "The Go Programming Language", "subscribers": 250000,
+			}},
+		}}})
+	})
+	serve("/user/spez/about", func(*http.Request) []byte {
+		return mustJSON(t, map[string]any{"kind": "t2", "data": map[string]any{
+			"id": "1w72", "name": "spez", "link_karma": 1200, "comment_karma": 3400, "created": 1134028003.0, "created_utc": 1134028003.0,
+		}})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantCode  int
+		wantOut   []string
+		wantErr   string
+		wantPath  string
+		wantQuery url.Values
+	}{
+		{
+			name:     "hot",
+			args:     []string{"hot", "-sub", "golang", "-limit", "2"},
+			wantOut:  []string{"ID", "Generated post p1", "Generated post p2"},
+			wantPath: "/r/golang/hot", wantQuery: url.Values{"limit": {"2"}},
+		},
+		{
+			name:    "new as JSON",
+			args:    []string{"-format", "json", "new", "-sub", "golang"},
+			wantOut: []string{`"title": "Generated post p1"`},
+		},
+		{
+			name:    "comments",
+			args:    []string{"comments", "-sub", "golang", "-post", grawtest.FixturePostID},
+			wantOut: []string{"Generated post " + grawtest.FixturePostID, "  ["},
+		},
+		{
+			name:     "search posts in a subreddit",
+			args:     []string{"search", "-q", "generics", "-sub", "golang", "-sort", "top", "-t", "year"},
+			wantOut:  []string{"Generated post p1"},
+			wantPath: "/r/golang/search", wantQuery: url.Values{"q": {"generics"}, "restrict_sr": {"1"}, "sort": {"top"}, "t": {"year"}},
+		},
+		{
+			name:     "search subreddits",
+			args:     []string{"search", "-q", "go", "-type", "subreddits"},
+			wantOut:  []string{"SUBSCRIBERS", "golang", "250000"},
+			wantPath: "/search", wantQuery: url.Values{"q": {"go"}, "type": {"sr"}},
+		},
+		{
+			name:    "user by name",
+			args:    []string{"user", "-name", "u/spez"},
+			wantOut: []string{"spez", "1200", "3400"},
+		},
+		{name: "search without query", args: []string{"search"}, wantCode: 2, wantErr: "Usage: graw search"},
+		{name: "search with unknown type", args: []string{"search", "-q", "go", "-type", "comments"}, wantCode: 2},
+		{name: "unknown command", args: []string{"frobnicate"}, wantCode: 2, wantErr: `unknown command "frobnicate"`},
+		{name: "unknown format", args: []string{"-format", "xml", "hot"}, wantCode: 2},
+		{name: "invalid username", args: []string{"user", "-name", "no spaces"}, wantCode: 1, wantErr: "graw user:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := map[string]url.Values{}
+			server := newTestServer(t, queries)
+			args := append([]string{
+				"-client-id", "id", "-client-secret", "secret",
+				"-base-url", server.URL + "/", "-auth-url", server.URL + "/",
+			}, tt.args...)
+
+			var stdout, stderr bytes.Buffer
+			if code := run(context.Background(), args, &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d; stderr:\n%s", code, tt.wantCode, stderr.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("stdout does not contain %q:\n%s", want, stdout.String())
+				}
+			}
+			if tt.wantErr != "" && !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("stderr does not contain %q:\n%s", tt.wantErr, stderr.String())
+			}
+			if tt.wantPath != "" {
+				query, ok := queries[tt.wantPath]
+				if !ok {
+					t.Fatalf("%s was not requested; requested %v", tt.wantPath, queries)
+				}
+				for key, want := range tt.wantQuery {
+					if got := query.Get(key); got != want[0] {
+						t.Errorf("%s query %s = %q, want %q", tt.wantPath, key, got, want[0])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRun_MissingCredentials(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-client-id", "", "-client-secret", "", "hot"}, &stdout, &stderr)
+	if code != 1 || !strings.Contains(stderr.String(), "client ID and secret are required") {
+		t.Errorf("exit code = %d, stderr = %q; want 1 and a credentials error", code, stderr.String())
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+
+	// maxTitleWidth truncates titles in table output to keep rows on one line.
+	maxTitleWidth = 80
+	// maxBodyWidth truncates comment bodies in table output.
+	maxBodyWidth = 100
+)
+
+// printJSON writes v as indented JSON to stdout.
+func (env *cliEnv) printJSON(v any) error {
+	enc := json.NewEncoder(env.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (env *cliEnv) printPosts(resp *types.PostsResponse) error {
+	if env.opts.format == formatJSON {
+		return env.printJSON(resp)
+	}
+
+	tw := tabwriter.NewWriter(env.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSUBREDDIT\tSCORE\tCOMMENTS\tAUTHOR\tTITLE")
+	for _, post := range resp.Posts {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%s\n",
+			post.ID, post.Subreddit, post.Score, post.NumComments, post.Author, truncate(post.Title, maxTitleWidth))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if resp.AfterFullname != "" {
+		fmt.Fprintf(env.out, "\nnext page: -after %s\n", resp.AfterFullname)
+	}
+	return nil
+}
+
+func (env *cliEnv) printComments(resp *types.CommentsResponse, maxDepth int) error {
+	if env.opts.format == formatJSON {
+		return env.printJSON(struct {
+			Post     *types.Post      `json:"post"`
+			Comments []*exportComment `json:"comments"`
+			MoreIDs  []string         `json:"more_ids,omitempty"`
+		}{resp.Post, toExportComments(resp.Comments), resp.MoreIDs})
+	}
+
+	if resp.Post != nil {
+		fmt.Fprintf(env.out, "%s\n", resp.Post.Title)
+		fmt.Fprintf(env.out, "by u/%s in r/%s | score %d | %d comments\n",
+			resp.Post.Author, resp.Post.Subreddit, resp.Post.Score, resp.Post.NumComments)
+		if resp.Post.SelfText != "" {
+			fmt.Fprintf(env.out, "\n%s\n", resp.Post.SelfText)
+		}
+		fmt.Fprintln(env.out)
+	}
+
+	env.printCommentTree(resp.Comments, 0, maxDepth)
+	if len(resp.MoreIDs) > 0 {
+		fmt.Fprintf(env.out, "\n(%d more comments not loaded; use 'graw export' to fetch them)\n", len(resp.MoreIDs))
+	}
+	return nil
+}
+
+func (env *cliEnv) printCommentTree(comments []*types.Comment, depth, maxDepth int) {
+	if maxDepth > 0 && depth >= maxDepth {
+		return
+	}
+	indent := strings.Repeat("  ", depth)
+	for _, c := range comments {
+		if c == nil {
+			continue
+		}
+		score := "?"
+		if c.ScoreKnown() {
+			score = strconv.Itoa(c.Score)
+		}
+		fmt.Fprintf(env.out, "%s[%s] u/%s: %s\n", indent, score, c.Author, truncate(c.Body, maxBodyWidth))
+		env.printCommentTree(c.Replies, depth+1, maxDepth)
+	}
+}
+
+func (env *cliEnv) printAccount(account *types.AccountData) error {
+	if env.opts.format == formatJSON {
+		return env.printJSON(account)
+	}
+
+	tw := tabwriter.NewWriter(env.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "name\t%s\n", account.Name)
+	fmt.Fprintf(tw, "status\t%s\n", account.Status())
+	fmt.Fprintf(tw, "id\t%s\n", account.ID)
+	fmt.Fprintf(tw, "created\t%s\n", formatUnix(account.CreatedUTC))
+	fmt.Fprintf(tw, "link karma\t%d\n", account.LinkKarma)
+	fmt.Fprintf(tw, "comment karma\t%d\n", account.CommentKarma)
+	fmt.Fprintf(tw, "moderator\t%t\n", account.IsMod)
+	return tw.Flush()
+}
+
+func (env *cliEnv) printSearch(resp *types.SearchResponse) error {
+	if env.opts.format == formatJSON {
+		return env.printJSON(resp)
+	}
+
+	tw := tabwriter.NewWriter(env.out, 0, 0, 2, ' ', 0)
+	switch resp.Type {
+	case types.SearchTypeSubreddits:
+		fmt.Fprintln(tw, "NAME\tSUBSCRIBERS\tTITLE")
+		for _, sub := range resp.Subreddits {
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", sub.DisplayName, sub.Subscribers, truncate(sub.Title, maxTitleWidth))
+		}
+	case types.SearchTypeUsers:
+		fmt.Fprintln(tw, "NAME\tLINK KARMA\tCOMMENT KARMA\tCREATED")
+		for _, user := range resp.Users {
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", user.Name, user.LinkKarma, user.CommentKarma, formatUnix(user.CreatedUTC))
+		}
+	default:
+		fmt.Fprintln(tw, "ID\tSUBREDDIT\tSCORE\tCOMMENTS\tAUTHOR\tTITLE")
+		for _, post := range resp.Posts {
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%s\t%s\n",
+				post.ID, post.Subreddit, post.Score, post.NumComments, post.Author, truncate(post.Title, maxTitleWidth))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if resp.AfterFullname != "" {
+		fmt.Fprintf(env.out, "\nnext page: -after %s\n", resp.AfterFullname)
+	}
+	return nil
+}
+
+func (env *cliEnv) printScopes(scopes []types.Scope) error {
+	if env.opts.format == formatJSON {
+		return env.printJSON(scopes)
+	}
+
+	tw := tabwriter.NewWriter(env.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tDESCRIPTION")
+	for _, scope := range scopes {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", scope.ID, scope.Name, scope.Description)
+	}
+	return tw.Flush()
+}
+
+// printStreamPost writes a single streamed post; JSON output is one object per line.
+func (env *cliEnv) printStreamPost(post *types.Post) error {
+	if env.opts.format == formatJSON {
+		return json.NewEncoder(env.out).Encode(post)
+	}
+	_, err := fmt.Fprintf(env.out, "%s  %s  u/%s  %s\n",
+		formatUnix(post.CreatedUTC), post.ID, post.Author, truncate(post.Title, maxTitleWidth))
+	return err
+}
+
+// truncate shortens s to at most n runes, collapsing newlines so table rows stay intact.
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
+
+func formatUnix(seconds float64) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	return time.Unix(int64(seconds), 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,13 @@
+package graw
+
+// JSONCodec decodes Reddit's JSON responses. The default uses encoding/json; set
+// Config.JSONCodec to plug in a faster decoder such as sonic or jsoniter when processing
+// large volumes of listings. Implementations must honor json.Unmarshaler and encoding/json
+// struct tags, and be safe for concurrent use.
+//
+// For example, with github.com/bytedance/sonic:
+//
+//	config.JSONCodec = sonic.ConfigStd
+type JSONCodec interface {
+	Unmarshal(data []byte, v any) error
+}
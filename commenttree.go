@@ -0,0 +1,247 @@
+package graw
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// dotLabelBodyLength is how many characters of a comment body appear in a DOT node label.
+const dotLabelBodyLength = 60
+
+// CommentTree is a serializable view of a post's comment tree, as returned by
+// BuildCommentTree. Unlike types.CommentsResponse, it keeps "load more" placeholders at the
+// position where Reddit returned them.
+type CommentTree struct {
+	PostID    string             `json:"post_id,omitempty"`
+	PostTitle string             `json:"post_title,omitempty"`
+	Comments  []*CommentTreeNode `json:"comments"`
+	// More lists top-level comments not included in the response.
+	More *MorePlaceholder `json:"more,omitempty"`
+}
+
+// CommentTreeNode is one comment in a CommentTree. Depth is 0 for top-level comments.
+type CommentTreeNode struct {
+	ID       string             `json:"id"`
+	Name     string             `json:"name"`
+	ParentID string             `json:"parent_id"`
+	Author   string             `json:"author"`
+	Body     string             `json:"body"`
+	Score    int                `json:"score"`
+	Depth    int                `json:"depth"`
+	Replies  []*CommentTreeNode `json:"replies,omitempty"`
+	// More lists replies to this comment that were not included in the response.
+	More *MorePlaceholder `json:"more,omitempty"`
+}
+
+// MorePlaceholder stands for comments hidden behind a "load more" stub. Children are comment
+// IDs (without the "t1_" prefix) that can be loaded with GetMoreComments.
+type MorePlaceholder struct {
+	Count    int      `json:"count"`
+	Children []string `json:"children"`
+}
+
+// BuildCommentTree converts a GetComments response into a CommentTree.
+func BuildCommentTree(resp *types.CommentsResponse) *CommentTree {
+	tree := &CommentTree{Comments: []*CommentTreeNode{}}
+	if resp == nil {
+		return tree
+	}
+	if resp.Post != nil {
+		tree.PostID = resp.Post.ID
+		tree.PostTitle = resp.Post.Title
+	}
+
+	// resp.MoreIDs aggregates stubs from every level; keep only those not attached to a comment.
+	nested := make(map[string]bool)
+	for _, c := range flattenComments(resp.Comments) {
+		for _, id := range c.MoreChildrenIDs {
+			nested[id] = true
+		}
+	}
+	var topLevel []string
+	for _, id := range resp.MoreIDs {
+		if !nested[id] {
+			topLevel = append(topLevel, id)
+		}
+	}
+	tree.More = newMorePlaceholder(topLevel)
+
+	for _, c := range resp.Comments {
+		if c != nil {
+			tree.Comments = append(tree.Comments, newCommentTreeNode(c, 0))
+		}
+	}
+	return tree
+}
+
+// newCommentTreeNode converts c and its replies, placing c at the given depth.
+func newCommentTreeNode(c *types.Comment, depth int) *CommentTreeNode {
+	node := &CommentTreeNode{
+		ID:       c.ID,
+		Name:     c.Name,
+		ParentID: c.ParentID,
+		Author:   c.Author,
+		Body:     c.Body,
+		Score:    c.Score,
+		Depth:    depth,
+		More:     newMorePlaceholder(c.MoreChildrenIDs),
+	}
+	for _, reply := range c.Replies {
+		if reply != nil {
+			node.Replies = append(node.Replies, newCommentTreeNode(reply, depth+1))
+		}
+	}
+	return node
+}
+
+// newMorePlaceholder returns a placeholder for ids, or nil if there are none.
+func newMorePlaceholder(ids []string) *MorePlaceholder {
+	if len(ids) == 0 {
+		return nil
+	}
+	return &MorePlaceholder{Count: len(ids), Children: append([]string(nil), ids...)}
+}
+
+// WriteJSON writes the tree to w as indented JSON, with replies nested under their parents.
+func (t *CommentTree) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// WriteDOT writes the tree to w as a Graphviz digraph, for rendering with e.g.
+// "dot -Tsvg". Comments are labelled with their author, score, and the start of their body;
+// "load more" placeholders are drawn as dashed nodes.
+func (t *CommentTree) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	root := "post"
+	if t.PostID != "" {
+		root = string(types.KIND_POST) + t.PostID
+	}
+
+	fmt.Fprintln(bw, "digraph comments {")
+	fmt.Fprintln(bw, "\tnode [shape=box];")
+	title := t.PostTitle
+	if title == "" {
+		title = root
+	}
+	fmt.Fprintf(bw, "\t%s [label=%s, shape=doubleoctagon];\n", dotQuote(root), dotQuote(title))
+	writeDOTMore(bw, root, t.More)
+
+	var walk func(parent string, nodes []*CommentTreeNode)
+	walk = func(parent string, nodes []*CommentTreeNode) {
+		for _, n := range nodes {
+			id := n.Name
+			if id == "" {
+				id = string(types.KIND_COMMENT) + n.ID
+			}
+			label := fmt.Sprintf("%s (%d)\n%s", n.Author, n.Score, truncateRunes(n.Body, dotLabelBodyLength))
+			fmt.Fprintf(bw, "\t%s [label=%s];\n", dotQuote(id), dotQuote(label))
+			fmt.Fprintf(bw, "\t%s -> %s;\n", dotQuote(parent), dotQuote(id))
+			writeDOTMore(bw, id, n.More)
+			walk(id, n.Replies)
+		}
+	}
+	walk(root, t.Comments)
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// writeDOTMore writes a placeholder node for more under parent, if there is one.
+func writeDOTMore(w io.Writer, parent string, more *MorePlaceholder) {
+	if more == nil {
+		return
+	}
+	id := "more_" + parent
+	fmt.Fprintf(w, "\t%s [label=%s, shape=ellipse, style=dashed];\n", dotQuote(id), dotQuote(fmt.Sprintf("%d more", more.Count)))
+	fmt.Fprintf(w, "\t%s -> %s [style=dashed];\n", dotQuote(parent), dotQuote(id))
+}
+
+// dotQuote returns s as a DOT double-quoted string, with line breaks rendered as "\n".
+func dotQuote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+// truncateRunes shortens s to at most n characters, marking the cut with an ellipsis.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n]) + "…"
+}
+
+// DefaultCommentChunkSize is the chunk size CommentChunks uses when none is given.
+const DefaultCommentChunkSize = 500
+
+// DepthComment is a comment yielded by CommentChunks with its nesting depth, 0 for
+// top-level comments.
+type DepthComment struct {
+	Comment *types.Comment
+	Depth   int
+}
+
+// CommentChunks returns an iterator over a comment tree, such as CommentsResponse.Comments,
+// in listing order: each comment is followed by its replies before its next sibling. The
+// comments are yielded in chunks of size comments, the last possibly shorter, together with
+// the position of the chunk's first comment in that order. A size of zero or less means
+// DefaultCommentChunkSize.
+//
+// It suits megathreads with tens of thousands of comments, for rendering a virtualized list
+// or writing to a database in batches: the tree is walked without recursion and without
+// building a flat copy of it. The chunk slice is reused by the next iteration; copy it to
+// keep it. Nil comments are skipped.
+//
+//	for offset, chunk := range graw.CommentChunks(resp.Comments, 1000) {
+//		if err := store.InsertComments(ctx, offset, chunk); err != nil {
+//			return err
+//		}
+//	}
+func CommentChunks(comments []*types.Comment, size int) iter.Seq2[int, []DepthComment] {
+	if size <= 0 {
+		size = DefaultCommentChunkSize
+	}
+	return func(yield func(int, []DepthComment) bool) {
+		chunk := make([]DepthComment, 0, size)
+		offset := 0
+		// stack holds the unvisited remainder of each level of the current path.
+		stack := [][]*types.Comment{comments}
+		for len(stack) > 0 {
+			top := len(stack) - 1
+			level := stack[top]
+			if len(level) == 0 {
+				stack = stack[:top]
+				continue
+			}
+			c := level[0]
+			stack[top] = level[1:]
+			if c == nil {
+				continue
+			}
+
+			chunk = append(chunk, DepthComment{Comment: c, Depth: top})
+			if len(c.Replies) > 0 {
+				stack = append(stack, c.Replies)
+			}
+			if len(chunk) == size {
+				if !yield(offset, chunk) {
+					return
+				}
+				offset += len(chunk)
+				chunk = chunk[:0]
+			}
+		}
+		if len(chunk) > 0 {
+			yield(offset, chunk)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package graw
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// commentTreeResponse builds a response with one nested reply, a stub under the top-level
+// comment, and a top-level stub.
+func commentTreeResponse() *types.CommentsResponse {
+	reply := &types.Comment{
+		ThingData: types.ThingData{ID: "c2", Name: "t1_c2"},
+		Author:    "bob",
+		Body:      "line one\nsays \"hi\"",
+		ParentID:  "t1_c1",
+	}
+	top := &types.Comment{
+		ThingData:       types.ThingData{ID: "c1", Name: "t1_c1"},
+		Votable:         types.Votable{Score: 5},
+		Author:          "alice",
+		Body:            "top level",
+		ParentID:        "t3_post1",
+		Replies:         []*types.Comment{reply},
+		MoreChildrenIDs: []string{"c3", "c4"},
+	}
+	return &types.CommentsResponse{
+		Post:     &types.Post{ThingData: types.ThingData{ID: "post1"}, Title: "A post"},
+		Comments: []*types.Comment{top},
+		MoreIDs:  []string{"c3", "c4", "c9"},
+	}
+}
+
+func TestBuildCommentTree_JSON(t *testing.T) {
+	tree := BuildCommentTree(commentTreeResponse())
+
+	var buf bytes.Buffer
+	if err := tree.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON returned error: %v", err)
+	}
+	var got CommentTree
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+
+	if got.PostID != "post1" || len(got.Comments) != 1 {
+		t.Fatalf("got post %q with %d comments, want post1 with 1", got.PostID, len(got.Comments))
+	}
+	if got.More == nil || got.More.Count != 1 || got.More.Children[0] != "c9" {
+		t.Errorf("top-level more = %+v, want only c9", got.More)
+	}
+	top := got.Comments[0]
+	if top.Depth != 0 || top.Score != 5 || top.More == nil || top.More.Count != 2 {
+		t.Errorf("top comment = %+v, want depth 0, score 5, two more children", top)
+	}
+	if len(top.Replies) != 1 || top.Replies[0].Depth != 1 || top.Replies[0].Author != "bob" {
+		t.Errorf("replies = %+v, want bob at depth 1", top.Replies)
+	}
+	if top.Replies[0].More != nil {
+		t.Errorf("reply more = %+v, want none", top.Replies[0].More)
+	}
+}
+
+func TestBuildCommentTree_DOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := BuildCommentTree(commentTreeResponse()).WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT returned error: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"digraph comments {",
+		`"t3_post1" [label="A post", shape=doubleoctagon];`,
+		`"t3_post1" -> "t1_c1";`,
+		`"t1_c1" -> "t1_c2";`,
+		`"t1_c2" [label="bob (0)\nline one\nsays \"hi\""];`,
+		`"more_t1_c1" [label="2 more", shape=ellipse, style=dashed];`,
+		`"t3_post1" -> "more_t3_post1" [style=dashed];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "}\n") {
+		t.Errorf("DOT output not closed:\n%s", out)
+	}
+}
+
+func TestBuildCommentTree_Nil(t *testing.T) {
+	tree := BuildCommentTree(nil)
+	var buf bytes.Buffer
+	if err := tree.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON returned error: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "{\n  \"comments\": []\n}" {
+		t.Errorf("WriteJSON(nil tree) = %s", got)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("héllo wörld", 5); got != "héllo…" {
+		t.Errorf("truncateRunes() = %q, want %q", got, "héllo…")
+	}
+	if got := truncateRunes("short", 10); got != "short" {
+		t.Errorf("truncateRunes() = %q, want %q", got, "short")
+	}
+}
+
+func TestCommentChunks(t *testing.T) {
+	// Three top-level comments, each with two replies, the first reply with one of its own.
+	var comments []*types.Comment
+	wantDepth := map[string]int{}
+	for i := range 3 {
+		top := &types.Comment{ThingData: types.ThingData{ID: fmt.Sprintf("t%d", i)}}
+		wantDepth[top.ID] = 0
+		for j := range 2 {
+			reply := &types.Comment{ThingData: types.ThingData{ID: fmt.Sprintf("t%d-r%d", i, j)}}
+			wantDepth[reply.ID] = 1
+			if j == 0 {
+				nested := &types.Comment{ThingData: types.ThingData{ID: reply.ID + "-n"}}
+				wantDepth[nested.ID] = 2
+				reply.Replies = []*types.Comment{nested}
+			}
+			top.Replies = append(top.Replies, reply)
+		}
+		comments = append(comments, top)
+	}
+	comments = append(comments, nil)
+	want := flattenComments(comments)
+
+	var got []*types.Comment
+	var sizes []int
+	for offset, chunk := range CommentChunks(comments, 4) {
+		if offset != len(got) {
+			t.Errorf("chunk offset = %d, want %d", offset, len(got))
+		}
+		sizes = append(sizes, len(chunk))
+		for _, dc := range chunk {
+			if dc.Depth != wantDepth[dc.Comment.ID] {
+				t.Errorf("%s depth = %d, want %d", dc.Comment.ID, dc.Depth, wantDepth[dc.Comment.ID])
+			}
+			got = append(got, dc.Comment)
+		}
+	}
+	if fmt.Sprint(sizes) != "[4 4 4]" {
+		t.Errorf("chunk sizes = %v, want [4 4 4]", sizes)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d comments, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("comment %d = %s, want %s in listing order", i, got[i].ID, want[i].ID)
+		}
+	}
+
+	chunks := 0
+	for range CommentChunks(comments, 4) {
+		chunks++
+		break
+	}
+	if chunks != 1 {
+		t.Errorf("iteration continued after break")
+	}
+	for range CommentChunks(nil, 0) {
+		t.Error("empty tree yielded a chunk")
+	}
+}
+
+func TestCommentChunks_DeepThread(t *testing.T) {
+	// A reply chain far deeper than Reddit sends, walked without recursion.
+	const depth = 100000
+	root := &types.Comment{ThingData: types.ThingData{ID: "c0"}}
+	for c, i := root, 1; i < depth; i++ {
+		reply := &types.Comment{ThingData: types.ThingData{ID: fmt.Sprintf("c%d", i)}}
+		c.Replies = []*types.Comment{reply}
+		c = reply
+	}
+
+	count, maxDepth := 0, 0
+	for _, chunk := range CommentChunks([]*types.Comment{root}, 0) {
+		if len(chunk) > DefaultCommentChunkSize {
+			t.Fatalf("chunk of %d comments, want at most %d", len(chunk), DefaultCommentChunkSize)
+		}
+		count += len(chunk)
+		maxDepth = max(maxDepth, chunk[len(chunk)-1].Depth)
+	}
+	if count != depth || maxDepth != depth-1 {
+		t.Errorf("walked %d comments to depth %d, want %d to depth %d", count, maxDepth, depth, depth-1)
+	}
+}
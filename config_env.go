@@ -0,0 +1,116 @@
+package graw
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvClientID     = "REDDIT_CLIENT_ID"
+	EnvClientSecret = "REDDIT_CLIENT_SECRET"
+	EnvUsername     = "REDDIT_USERNAME"
+	EnvPassword     = "REDDIT_PASSWORD"
+	EnvUserAgent    = "REDDIT_USER_AGENT"
+	EnvBaseURL      = "REDDIT_BASE_URL"
+	EnvAuthURL      = "REDDIT_AUTH_URL"
+	EnvWebURL       = "REDDIT_WEB_URL"
+	EnvLocale       = "REDDIT_LOCALE"
+
+	// EnvRateLimitRPM sets RateLimitConfig.RequestsPerMinute
+	EnvRateLimitRPM = "REDDIT_RATE_LIMIT_RPM"
+	// EnvRateLimitBurst sets RateLimitConfig.Burst
+	EnvRateLimitBurst = "REDDIT_RATE_LIMIT_BURST"
+	// EnvRateLimitThreshold sets RateLimitConfig.ProactiveThreshold
+	EnvRateLimitThreshold = "REDDIT_RATE_LIMIT_THRESHOLD"
+
+	// EnvLogLevel enables a text logger on stderr at the given level: debug, info, warn, or error
+	EnvLogLevel = "REDDIT_LOG_LEVEL"
+)
+
+// ConfigFromEnv builds a Config from REDDIT_* environment variables, so applications do not
+// have to repeat the same os.Getenv boilerplate.
+//
+// REDDIT_CLIENT_ID and REDDIT_CLIENT_SECRET are required. REDDIT_USERNAME and REDDIT_PASSWORD
+// select user authentication when both are set. Unset optional variables leave the
+// corresponding fields empty so NewClient applies its usual defaults. A RateLimitConfig is
+// only created when at least one REDDIT_RATE_LIMIT_* variable is set.
+//
+// The returned Config can be adjusted before passing it to NewClient, e.g. to set a custom
+// HTTPClient or a UserAgent when REDDIT_USER_AGENT is unset.
+//
+// Returns a *errors.ConfigError if a required variable is missing or a value cannot be parsed.
+func ConfigFromEnv() (*Config, error) {
+	config := &Config{
+		ClientID:     strings.TrimSpace(os.Getenv(EnvClientID)),
+		ClientSecret: strings.TrimSpace(os.Getenv(EnvClientSecret)),
+		Username:     strings.TrimSpace(os.Getenv(EnvUsername)),
+		Password:     os.Getenv(EnvPassword),
+		UserAgent:    strings.TrimSpace(os.Getenv(EnvUserAgent)),
+		BaseURL:      strings.TrimSpace(os.Getenv(EnvBaseURL)),
+		AuthURL:      strings.TrimSpace(os.Getenv(EnvAuthURL)),
+		WebURL:       strings.TrimSpace(os.Getenv(EnvWebURL)),
+		Locale:       strings.TrimSpace(os.Getenv(EnvLocale)),
+	}
+	if config.ClientID == "" {
+		return nil, &pkgerrs.ConfigError{Field: EnvClientID, Message: "environment variable is required"}
+	}
+	if config.ClientSecret == "" {
+		return nil, &pkgerrs.ConfigError{Field: EnvClientSecret, Message: "environment variable is required"}
+	}
+
+	rateLimit, err := rateLimitFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	config.RateLimitConfig = rateLimit
+
+	if level := strings.TrimSpace(os.Getenv(EnvLogLevel)); level != "" {
+		var lvl slog.Level
+		if err := lvl.UnmarshalText([]byte(level)); err != nil {
+			return nil, &pkgerrs.ConfigError{Field: EnvLogLevel, Message: fmt.Sprintf("invalid log level %q (want debug, info, warn, or error)", level)}
+		}
+		config.Logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: lvl}))
+	}
+
+	return config, nil
+}
+
+// rateLimitFromEnv reads the REDDIT_RATE_LIMIT_* variables, returning nil if none are set.
+func rateLimitFromEnv() (*RateLimitConfig, error) {
+	rpm := strings.TrimSpace(os.Getenv(EnvRateLimitRPM))
+	burst := strings.TrimSpace(os.Getenv(EnvRateLimitBurst))
+	threshold := strings.TrimSpace(os.Getenv(EnvRateLimitThreshold))
+	if rpm == "" && burst == "" && threshold == "" {
+		return nil, nil
+	}
+
+	cfg := &RateLimitConfig{}
+	if rpm != "" {
+		v, err := strconv.ParseFloat(rpm, 64)
+		if err != nil || v <= 0 {
+			return nil, &pkgerrs.ConfigError{Field: EnvRateLimitRPM, Message: fmt.Sprintf("must be a positive number, got %q", rpm)}
+		}
+		cfg.RequestsPerMinute = v
+	}
+	if burst != "" {
+		v, err := strconv.Atoi(burst)
+		if err != nil || v <= 0 {
+			return nil, &pkgerrs.ConfigError{Field: EnvRateLimitBurst, Message: fmt.Sprintf("must be a positive integer, got %q", burst)}
+		}
+		cfg.Burst = v
+	}
+	if threshold != "" {
+		v, err := strconv.ParseFloat(threshold, 64)
+		if err != nil || v < 0 {
+			return nil, &pkgerrs.ConfigError{Field: EnvRateLimitThreshold, Message: fmt.Sprintf("must be a non-negative number, got %q", threshold)}
+		}
+		cfg.ProactiveThreshold = v
+	}
+	return cfg, nil
+}
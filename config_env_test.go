@@ -0,0 +1,83 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Run("full configuration", func(t *testing.T) {
+		t.Setenv(EnvClientID, "id")
+		t.Setenv(EnvClientSecret, "secret")
+		t.Setenv(EnvUsername, "gopher")
+		t.Setenv(EnvPassword, "hunter2")
+		t.Setenv(EnvUserAgent, "test:app:1.0 by /u/gopher")
+		t.Setenv(EnvRateLimitRPM, "60")
+		t.Setenv(EnvRateLimitBurst, "5")
+		t.Setenv(EnvLogLevel, "debug")
+
+		config, err := ConfigFromEnv()
+		if err != nil {
+			t.Fatalf("ConfigFromEnv returned error: %v", err)
+		}
+		if config.ClientID != "id" || config.ClientSecret != "secret" || config.Username != "gopher" || config.Password != "hunter2" {
+			t.Errorf("credentials not loaded: %+v", config)
+		}
+		if config.UserAgent != "test:app:1.0 by /u/gopher" {
+			t.Errorf("UserAgent = %q", config.UserAgent)
+		}
+		if config.RateLimitConfig == nil || config.RateLimitConfig.RequestsPerMinute != 60 || config.RateLimitConfig.Burst != 5 {
+			t.Errorf("RateLimitConfig = %+v, want 60 rpm burst 5", config.RateLimitConfig)
+		}
+		if config.Logger == nil || !config.Logger.Enabled(context.Background(), slog.LevelDebug) {
+			t.Error("expected debug logger")
+		}
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Setenv(EnvClientID, "id")
+		t.Setenv(EnvClientSecret, "secret")
+		for _, key := range []string{EnvUsername, EnvPassword, EnvUserAgent, EnvRateLimitRPM, EnvRateLimitBurst, EnvRateLimitThreshold, EnvLogLevel} {
+			t.Setenv(key, "")
+		}
+
+		config, err := ConfigFromEnv()
+		if err != nil {
+			t.Fatalf("ConfigFromEnv returned error: %v", err)
+		}
+		if config.RateLimitConfig != nil || config.Logger != nil || config.UserAgent != "" {
+			t.Errorf("expected optional fields to stay unset, got %+v", config)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		env       map[string]string
+		wantField string
+	}{
+		{name: "missing client id", env: map[string]string{EnvClientID: "", EnvClientSecret: "secret"}, wantField: EnvClientID},
+		{name: "missing secret", env: map[string]string{EnvClientID: "id", EnvClientSecret: ""}, wantField: EnvClientSecret},
+		{name: "bad rpm", env: map[string]string{EnvClientID: "id", EnvClientSecret: "secret", EnvRateLimitRPM: "fast"}, wantField: EnvRateLimitRPM},
+		{name: "bad burst", env: map[string]string{EnvClientID: "id", EnvClientSecret: "secret", EnvRateLimitBurst: "-1"}, wantField: EnvRateLimitBurst},
+		{name: "bad log level", env: map[string]string{EnvClientID: "id", EnvClientSecret: "secret", EnvLogLevel: "loud"}, wantField: EnvLogLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{EnvRateLimitRPM, EnvRateLimitBurst, EnvRateLimitThreshold, EnvLogLevel} {
+				t.Setenv(key, "")
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := ConfigFromEnv()
+			var cfgErr *pkgerrs.ConfigError
+			if !errors.As(err, &cfgErr) || cfgErr.Field != tt.wantField {
+				t.Errorf("ConfigFromEnv() error = %v, want ConfigError for %s", err, tt.wantField)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package graw
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig is the on-disk shape read by LoadConfigFile. Durations are Go duration strings
+// such as "30s" or "15m".
+type fileConfig struct {
+	ClientID     string `json:"client_id" yaml:"client_id"`
+	ClientSecret string `json:"client_secret" yaml:"client_secret"`
+	Username     string `json:"username" yaml:"username"`
+	Password     string `json:"password" yaml:"password"`
+	UserAgent    string `json:"user_agent" yaml:"user_agent"`
+	BaseURL      string `json:"base_url" yaml:"base_url"`
+	AuthURL      string `json:"auth_url" yaml:"auth_url"`
+	WebURL       string `json:"web_url" yaml:"web_url"`
+	Locale       string `json:"locale" yaml:"locale"`
+	Timeout      string `json:"timeout" yaml:"timeout"`
+
+	ExtraHeaders map[string]string `json:"extra_headers" yaml:"extra_headers"`
+
+	RateLimit *struct {
+		RequestsPerMinute  float64 `json:"requests_per_minute" yaml:"requests_per_minute"`
+		Burst              int     `json:"burst" yaml:"burst"`
+		ProactiveThreshold float64 `json:"proactive_threshold" yaml:"proactive_threshold"`
+	} `json:"rate_limit" yaml:"rate_limit"`
+
+	Retry *struct {
+		MaxRetries     int    `json:"max_retries" yaml:"max_retries"`
+		InitialBackoff string `json:"initial_backoff" yaml:"initial_backoff"`
+		MaxBackoff     string `json:"max_backoff" yaml:"max_backoff"`
+	} `json:"retry" yaml:"retry"`
+
+	Cache *struct {
+		PostRequirementsTTL string `json:"post_requirements_ttl" yaml:"post_requirements_ttl"`
+		SubredditInfoTTL    string `json:"subreddit_info_ttl" yaml:"subreddit_info_ttl"`
+	} `json:"cache" yaml:"cache"`
+
+	Logging *struct {
+		Level  string `json:"level" yaml:"level"`
+		Format string `json:"format" yaml:"format"`
+		Output string `json:"output" yaml:"output"`
+	} `json:"logging" yaml:"logging"`
+}
+
+// LoadConfigFile reads client settings from a JSON (.json) or YAML (.yaml, .yml) file.
+//
+// String values may reference environment variables as $VAR or ${VAR}, which keeps secrets
+// out of the file:
+//
+//	client_id: ${REDDIT_CLIENT_ID}
+//	client_secret: ${REDDIT_CLIENT_SECRET}
+//	user_agent: "server:mybot:1.0 by /u/me"
+//	timeout: 20s
+//	extra_headers:
+//	  X-Gateway-Key: ${GATEWAY_KEY}
+//	rate_limit:
+//	  requests_per_minute: 60
+//	  burst: 5
+//	retry:
+//	  max_retries: 3
+//	  initial_backoff: 1s
+//	cache:
+//	  post_requirements_ttl: 1h
+//	logging:
+//	  level: info
+//	  format: json
+//	  output: stderr
+//
+// Unknown keys are rejected so that typos do not silently fall back to defaults.
+// Omitted settings are left unset so NewClient applies its usual defaults.
+//
+// Returns a *errors.ConfigError if the file cannot be read or parsed, or a value is invalid.
+func LoadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &pkgerrs.ConfigError{Field: "path", Message: fmt.Sprintf("failed to read config file: %v", err)}
+	}
+
+	var fc fileConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&fc)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&fc)
+		if err == io.EOF {
+			err = nil
+		}
+	default:
+		return nil, &pkgerrs.ConfigError{Field: "path", Message: fmt.Sprintf("unsupported config file extension %q (want .json, .yaml, or .yml)", ext)}
+	}
+	if err != nil {
+		return nil, &pkgerrs.ConfigError{Field: "path", Message: fmt.Sprintf("failed to parse %s: %v", filepath.Base(path), err)}
+	}
+
+	return fc.toConfig()
+}
+
+// toConfig expands environment variables and converts the file settings into a Config.
+func (fc *fileConfig) toConfig() (*Config, error) {
+	config := &Config{
+		ClientID:     os.ExpandEnv(fc.ClientID),
+		ClientSecret: os.ExpandEnv(fc.ClientSecret),
+		Username:     os.ExpandEnv(fc.Username),
+		Password:     os.ExpandEnv(fc.Password),
+		UserAgent:    os.ExpandEnv(fc.UserAgent),
+		BaseURL:      os.ExpandEnv(fc.BaseURL),
+		AuthURL:      os.ExpandEnv(fc.AuthURL),
+		WebURL:       os.ExpandEnv(fc.WebURL),
+		Locale:       os.ExpandEnv(fc.Locale),
+	}
+
+	if len(fc.ExtraHeaders) > 0 {
+		config.ExtraHeaders = make(map[string]string, len(fc.ExtraHeaders))
+		for name, value := range fc.ExtraHeaders {
+			config.ExtraHeaders[name] = os.ExpandEnv(value)
+		}
+	}
+
+	if fc.Timeout != "" {
+		timeout, err := parseFileDuration("timeout", fc.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		config.HTTPClient = &http.Client{Timeout: timeout}
+	}
+
+	if rl := fc.RateLimit; rl != nil {
+		config.RateLimitConfig = &RateLimitConfig{
+			RequestsPerMinute:  rl.RequestsPerMinute,
+			Burst:              rl.Burst,
+			ProactiveThreshold: rl.ProactiveThreshold,
+		}
+	}
+
+	if rc := fc.Retry; rc != nil {
+		if rc.MaxRetries < 0 {
+			return nil, &pkgerrs.ConfigError{Field: "retry.max_retries", Message: "must not be negative"}
+		}
+		retry := &RetryConfig{MaxRetries: rc.MaxRetries}
+		var err error
+		if rc.InitialBackoff != "" {
+			if retry.InitialBackoff, err = parseFileDuration("retry.initial_backoff", rc.InitialBackoff); err != nil {
+				return nil, err
+			}
+		}
+		if rc.MaxBackoff != "" {
+			if retry.MaxBackoff, err = parseFileDuration("retry.max_backoff", rc.MaxBackoff); err != nil {
+				return nil, err
+			}
+		}
+		config.RetryConfig = retry
+	}
+
+	if cc := fc.Cache; cc != nil && cc.PostRequirementsTTL != "" {
+		ttl, err := parseFileDuration("cache.post_requirements_ttl", cc.PostRequirementsTTL)
+		if err != nil {
+			return nil, err
+		}
+		config.PostRequirementsCacheTTL = ttl
+	}
+	if cc := fc.Cache; cc != nil && cc.SubredditInfoTTL != "" {
+		ttl, err := parseFileDuration("cache.subreddit_info_ttl", cc.SubredditInfoTTL)
+		if err != nil {
+			return nil, err
+		}
+		config.SubredditInfoCacheTTL = ttl
+	}
+
+	if lc := fc.Logging; lc != nil {
+		logger, err := newFileLogger(os.ExpandEnv(lc.Level), os.ExpandEnv(lc.Format), os.ExpandEnv(lc.Output))
+		if err != nil {
+			return nil, err
+		}
+		config.Logger = logger
+	}
+
+	return config, nil
+}
+
+// parseFileDuration parses a duration setting, requiring it to be positive.
+func parseFileDuration(field, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(os.ExpandEnv(value))
+	if err != nil || d <= 0 {
+		return 0, &pkgerrs.ConfigError{Field: field, Message: fmt.Sprintf("must be a positive duration such as \"30s\", got %q", value)}
+	}
+	return d, nil
+}
+
+// newFileLogger builds the logger described by the logging section.
+func newFileLogger(level, format, output string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if level != "" {
+		if err := lvl.UnmarshalText([]byte(level)); err != nil {
+			return nil, &pkgerrs.ConfigError{Field: "logging.level", Message: fmt.Sprintf("invalid log level %q (want debug, info, warn, or error)", level)}
+		}
+	}
+
+	var w io.Writer
+	switch strings.ToLower(output) {
+	case "", "stderr":
+		w = os.Stderr
+	case "stdout":
+		w = os.Stdout
+	default:
+		return nil, &pkgerrs.ConfigError{Field: "logging.output", Message: fmt.Sprintf("must be stdout or stderr, got %q", output)}
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "", "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, &pkgerrs.ConfigError{Field: "logging.format", Message: fmt.Sprintf("must be text or json, got %q", format)}
+	}
+}
@@ -0,0 +1,120 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func writeConfigFile(t *testing.T, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	t.Setenv("TEST_GRAW_SECRET", "from-env")
+
+	yamlPath := writeConfigFile(t, "graw.yaml", `
+client_id: my-id
+client_secret: ${TEST_GRAW_SECRET}
+user_agent: "server:bot:1.0 by /u/me"
+timeout: 20s
+extra_headers:
+  X-Gateway-Key: ${TEST_GRAW_SECRET}
+rate_limit:
+  requests_per_minute: 60
+  burst: 5
+retry:
+  max_retries: 3
+  initial_backoff: 1s
+  max_backoff: 10s
+cache:
+  post_requirements_ttl: 1h
+logging:
+  level: debug
+  format: json
+`)
+	jsonPath := writeConfigFile(t, "graw.json", `{
+  "client_id": "my-id",
+  "client_secret": "$TEST_GRAW_SECRET",
+  "user_agent": "server:bot:1.0 by /u/me",
+  "timeout": "20s",
+  "extra_headers": {"X-Gateway-Key": "${TEST_GRAW_SECRET}"},
+  "rate_limit": {"requests_per_minute": 60, "burst": 5},
+  "retry": {"max_retries": 3, "initial_backoff": "1s", "max_backoff": "10s"},
+  "cache": {"post_requirements_ttl": "1h"},
+  "logging": {"level": "debug", "format": "json"}
+}`)
+
+	for _, path := range []string{yamlPath, jsonPath} {
+		t.Run(filepath.Ext(path), func(t *testing.T) {
+			config, err := LoadConfigFile(path)
+			if err != nil {
+				t.Fatalf("LoadConfigFile returned error: %v", err)
+			}
+			if config.ClientID != "my-id" || config.ClientSecret != "from-env" {
+				t.Errorf("credentials = %q/%q, want my-id/from-env", config.ClientID, config.ClientSecret)
+			}
+			if config.UserAgent != "server:bot:1.0 by /u/me" {
+				t.Errorf("UserAgent = %q", config.UserAgent)
+			}
+			if config.HTTPClient == nil || config.HTTPClient.Timeout != 20*time.Second {
+				t.Errorf("HTTPClient timeout not applied: %+v", config.HTTPClient)
+			}
+			if config.ExtraHeaders["X-Gateway-Key"] != "from-env" {
+				t.Errorf("ExtraHeaders = %v", config.ExtraHeaders)
+			}
+			if config.RateLimitConfig == nil || config.RateLimitConfig.RequestsPerMinute != 60 || config.RateLimitConfig.Burst != 5 {
+				t.Errorf("RateLimitConfig = %+v", config.RateLimitConfig)
+			}
+			if config.RetryConfig == nil || config.RetryConfig.MaxRetries != 3 || config.RetryConfig.InitialBackoff != time.Second || config.RetryConfig.MaxBackoff != 10*time.Second {
+				t.Errorf("RetryConfig = %+v", config.RetryConfig)
+			}
+			if config.PostRequirementsCacheTTL != time.Hour {
+				t.Errorf("PostRequirementsCacheTTL = %v, want 1h", config.PostRequirementsCacheTTL)
+			}
+			if config.Logger == nil || !config.Logger.Enabled(context.Background(), slog.LevelDebug) {
+				t.Error("expected debug logger")
+			}
+		})
+	}
+}
+
+func TestLoadConfigFile_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		contents  string
+		wantField string
+	}{
+		{name: "unknown key", file: "c.yaml", contents: "client_idd: x\n", wantField: "path"},
+		{name: "unknown json key", file: "c.json", contents: `{"clientId": "x"}`, wantField: "path"},
+		{name: "unsupported extension", file: "c.toml", contents: "client_id = 'x'", wantField: "path"},
+		{name: "bad timeout", file: "c.yaml", contents: "timeout: soon\n", wantField: "timeout"},
+		{name: "negative retries", file: "c.yaml", contents: "retry:\n  max_retries: -1\n", wantField: "retry.max_retries"},
+		{name: "bad log format", file: "c.yaml", contents: "logging:\n  format: xml\n", wantField: "logging.format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfigFile(writeConfigFile(t, tt.file, tt.contents))
+			var cfgErr *pkgerrs.ConfigError
+			if !errors.As(err, &cfgErr) || cfgErr.Field != tt.wantField {
+				t.Errorf("LoadConfigFile() error = %v, want ConfigError for %s", err, tt.wantField)
+			}
+		})
+	}
+
+	if _, err := LoadConfigFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
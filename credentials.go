@@ -0,0 +1,94 @@
+package graw
+
+import (
+	"context"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// UpdateCredentials rotates the client's OAuth credentials without recreating the client.
+// It builds an authenticator for the new client ID and secret, and the new password when
+// the client uses user authentication, fetches a token with it to prove the credentials
+// work, and only then swaps it in. Requests already in flight finish with the token they
+// were sent with; later requests use the new credentials.
+//
+// The client keeps acting as the same account: a client created with a username and
+// password needs the new password (which may be unchanged), and an app-only client takes
+// an empty password. A client created with a refresh token takes an empty password and
+// keeps its current refresh token, which Reddit binds to the client ID; clientSecret may
+// be empty for installed apps. The Config passed to NewClient is not modified.
+//
+// Returns an error, leaving the current credentials in place, if a value is missing or
+// Reddit rejects the new credentials, and a *errors.StateError if the client gets its
+// tokens from a Config.TokenProvider, which manages its own credentials.
+func (r *Reddit) UpdateCredentials(ctx context.Context, clientID, clientSecret, password string) error {
+	if r.config.TokenProvider != nil {
+		return &pkgerrs.StateError{Operation: "update credentials", Message: "client uses a custom TokenProvider"}
+	}
+	if clientID == "" {
+		return &pkgerrs.ConfigError{Field: "ClientID", Message: "client ID is required"}
+	}
+	refreshAuth := r.config.RefreshToken != ""
+	if clientSecret == "" && !refreshAuth {
+		return &pkgerrs.ConfigError{Field: "ClientSecret", Message: "client secret is required"}
+	}
+
+	userAuth := r.config.Username != "" && r.config.Password != ""
+	grantType := "client_credentials"
+	username := ""
+	switch {
+	case refreshAuth && password != "":
+		return &pkgerrs.ConfigError{Field: "Password", Message: "client uses a refresh token and takes no password"}
+	case refreshAuth:
+		grantType = "refresh_token"
+	case userAuth && password == "":
+		return &pkgerrs.ConfigError{Field: "Password", Message: "password is required for user authentication"}
+	case userAuth:
+		grantType = "password"
+		username = r.config.Username
+	case password != "":
+		return &pkgerrs.ConfigError{Field: "Password", Message: "client uses app-only authentication and takes no password"}
+	}
+
+	auth, err := internal.NewAuthenticator(
+		r.config.HTTPClient,
+		username,
+		password,
+		clientID,
+		clientSecret,
+		r.config.UserAgent,
+		r.config.AuthURL,
+		grantType,
+		r.config.Logger,
+	)
+	if err != nil {
+		return &pkgerrs.AuthError{Message: "failed to create authenticator", Err: err}
+	}
+	if refreshAuth {
+		refreshToken := r.config.RefreshToken
+		if current, ok := r.tokenProvider().(*internal.Authenticator); ok && current.RefreshToken() != "" {
+			refreshToken = current.RefreshToken() // it may have been rotated since NewClient
+		}
+		auth.SetRefreshToken(refreshToken, r.config.OnRefreshToken)
+	}
+	if r.config.TokenStore != nil {
+		// The stored token belongs to the old credentials; save the new one instead.
+		auth.SetTokenStore(authTokenStore{store: r.config.TokenStore}, false)
+	}
+	if _, err := auth.GetToken(ctx); err != nil {
+		return &pkgerrs.AuthError{Message: "new credentials were rejected", Err: err}
+	}
+
+	r.authMu.Lock()
+	r.auth = auth
+	r.authMu.Unlock()
+	return nil
+}
+
+// tokenProvider returns the current TokenProvider.
+func (r *Reddit) tokenProvider() TokenProvider {
+	r.authMu.RLock()
+	defer r.authMu.RUnlock()
+	return r.auth
+}
@@ -0,0 +1,146 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// tokenServer issues "token-<clientID>" for any client whose secret is "secret-<clientID>".
+func tokenServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		id, secret, ok := req.BasicAuth()
+		if !ok || secret != "secret-"+id {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client"}`)
+			return
+		}
+		if err := req.ParseForm(); err != nil {
+			t.Errorf("ParseForm() error = %v", err)
+		}
+		if req.PostForm.Get("grant_type") == "password" && req.PostForm.Get("password") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"access_token":"token-%s","token_type":"bearer","expires_in":3600}`, id)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func authorization(t *testing.T, client *Reddit) string {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, "https://oauth.reddit.com/api/v1/me", nil)
+	if err := client.addAuthHeaders(context.Background(), req); err != nil {
+		t.Fatalf("addAuthHeaders() error = %v", err)
+	}
+	return req.Header.Get("Authorization")
+}
+
+func TestUpdateCredentials(t *testing.T) {
+	server := tokenServer(t)
+
+	t.Run("swaps in validated credentials", func(t *testing.T) {
+		client := newTestClient(&mockHTTPClient{}, &mockTokenProvider{token: "old"})
+		client.config.AuthURL = server.URL
+
+		if got := authorization(t, client); got != "Bearer old" {
+			t.Fatalf("before rotation Authorization = %q", got)
+		}
+		if err := client.UpdateCredentials(context.Background(), "new", "secret-new", ""); err != nil {
+			t.Fatalf("UpdateCredentials() error = %v", err)
+		}
+		if got := authorization(t, client); got != "Bearer token-new" {
+			t.Errorf("after rotation Authorization = %q, want %q", got, "Bearer token-new")
+		}
+	})
+
+	t.Run("keeps current credentials when rejected", func(t *testing.T) {
+		client := newTestClient(&mockHTTPClient{}, &mockTokenProvider{token: "old"})
+		client.config.AuthURL = server.URL
+
+		err := client.UpdateCredentials(context.Background(), "new", "wrong", "")
+		var authErr *pkgerrs.AuthError
+		if !errors.As(err, &authErr) {
+			t.Fatalf("UpdateCredentials() error = %v, want AuthError", err)
+		}
+		if got := authorization(t, client); got != "Bearer old" {
+			t.Errorf("Authorization = %q, want the old token", got)
+		}
+	})
+
+	t.Run("user auth requires password", func(t *testing.T) {
+		client := newTestClient(&mockHTTPClient{}, nil)
+		client.config.AuthURL = server.URL
+		client.config.Username = "someuser"
+		client.config.Password = "hunter2"
+
+		var cfgErr *pkgerrs.ConfigError
+		if err := client.UpdateCredentials(context.Background(), "new", "secret-new", ""); !errors.As(err, &cfgErr) || cfgErr.Field != "Password" {
+			t.Fatalf("UpdateCredentials() error = %v, want Password ConfigError", err)
+		}
+		if err := client.UpdateCredentials(context.Background(), "new", "secret-new", "hunter3"); err != nil {
+			t.Fatalf("UpdateCredentials() error = %v", err)
+		}
+	})
+
+	t.Run("app-only auth rejects password", func(t *testing.T) {
+		client := newTestClient(&mockHTTPClient{}, nil)
+		client.config.AuthURL = server.URL
+
+		var cfgErr *pkgerrs.ConfigError
+		if err := client.UpdateCredentials(context.Background(), "new", "secret-new", "hunter2"); !errors.As(err, &cfgErr) {
+			t.Fatalf("UpdateCredentials() error = %v, want ConfigError", err)
+		}
+	})
+
+	t.Run("missing client ID or secret", func(t *testing.T) {
+		client := newTestClient(&mockHTTPClient{}, nil)
+		var cfgErr *pkgerrs.ConfigError
+		if err := client.UpdateCredentials(context.Background(), "", "secret", ""); !errors.As(err, &cfgErr) || cfgErr.Field != "ClientID" {
+			t.Errorf("UpdateCredentials() error = %v, want ClientID ConfigError", err)
+		}
+		if err := client.UpdateCredentials(context.Background(), "id", "", ""); !errors.As(err, &cfgErr) || cfgErr.Field != "ClientSecret" {
+			t.Errorf("UpdateCredentials() error = %v, want ClientSecret ConfigError", err)
+		}
+	})
+}
+
+func TestUpdateCredentials_ConcurrentRequests(t *testing.T) {
+	server := tokenServer(t)
+	client := newTestClient(&mockHTTPClient{}, &mockTokenProvider{token: "old"})
+	client.config.AuthURL = server.URL
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				req, _ := http.NewRequest(http.MethodGet, "https://oauth.reddit.com/", nil)
+				if err := client.addAuthHeaders(context.Background(), req); err != nil {
+					t.Errorf("addAuthHeaders() error = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 5; i++ {
+		id := fmt.Sprintf("app%d", i)
+		if err := client.UpdateCredentials(context.Background(), id, "secret-"+id, ""); err != nil {
+			t.Errorf("UpdateCredentials() error = %v", err)
+		}
+	}
+	wg.Wait()
+
+	if got := authorization(t, client); got != "Bearer token-app4" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer token-app4")
+	}
+}
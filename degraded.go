@@ -0,0 +1,32 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// pauseIfDegraded reports whether err says Reddit is overloaded or in read-only mode. If
+// so, it logs one informational message and waits for the suggested retry interval (or
+// until ctx is done), so that polling loops back off quietly instead of logging a warning
+// on every tick.
+func (r *Reddit) pauseIfDegraded(ctx context.Context, operation string, err error) bool {
+	var degraded *pkgerrs.ServiceDegradedError
+	if !errors.As(err, &degraded) {
+		return false
+	}
+	if r.config != nil && r.config.Logger != nil {
+		r.config.Logger.LogAttrs(ctx, slog.LevelInfo, "reddit is degraded; pausing",
+			slog.String("operation", operation),
+			slog.Bool("read_only", degraded.IsReadOnly()),
+			slog.Duration("retry_after", degraded.RetryAfter))
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(degraded.RetryAfter):
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestPauseIfDegraded(t *testing.T) {
+	r := newTestClient(&mockHTTPClient{}, nil)
+	ctx := context.Background()
+
+	if r.pauseIfDegraded(ctx, "test", errors.New("boom")) {
+		t.Error("pauseIfDegraded() = true for an unrelated error")
+	}
+
+	degraded := pkgerrs.ClassifyAPIError(&pkgerrs.APIError{StatusCode: 503, RetryAfter: 20 * time.Millisecond}, pkgerrs.ResourceContext{})
+	start := time.Now()
+	if !r.pauseIfDegraded(ctx, "test", fmt.Errorf("wrapped: %w", degraded)) {
+		t.Fatal("pauseIfDegraded() = false for a ServiceDegradedError")
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("paused for %v, want at least the 20ms retry interval", elapsed)
+	}
+
+	readOnly := pkgerrs.ClassifyAPIError(&pkgerrs.APIError{ErrorCode: "READ_ONLY_MODE"}, pkgerrs.ResourceContext{})
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	start = time.Now()
+	if !r.pauseIfDegraded(cancelled, "test", readOnly) {
+		t.Fatal("pauseIfDegraded() = false for a read-only error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("pause ignored context cancellation, took %v", elapsed)
+	}
+}
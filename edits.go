@@ -0,0 +1,117 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// WatchForEdits re-fetches the given comments on an interval and emits an event whenever
+// one of them is edited. Each event carries the body from the previous fetch (Before) and
+// the current body (After), so edit-tracking bots can diff revisions.
+//
+// The comments are fetched once before WatchForEdits returns, to record their starting bodies;
+// edits made before that point are not reported. Later fetch failures are logged and retried
+// on the next tick rather than ending the watch. Comments that disappear (deleted or removed
+// from the listing) stop being reported until they reappear.
+//
+// The returned channel is closed when ctx is cancelled. Consumers should keep draining it;
+// by default the watcher blocks until each event is received. Config.StreamBuffer can
+// buffer the channel and drop events instead.
+//
+// Returns an error if:
+//   - request is nil, has no comment IDs, or has more than MaxInfoFullnames
+//   - Any comment ID is not a valid fullname
+//   - The initial fetch fails
+func (r *Reddit) WatchForEdits(ctx context.Context, request *types.EditWatchRequest) (<-chan *types.EditEvent, error) {
+	if request == nil {
+		return nil, &pkgerrs.ConfigError{Message: "edit watch request cannot be nil"}
+	}
+	for _, id := range request.CommentIDs {
+		if !strings.HasPrefix(id, string(types.KIND_COMMENT)) {
+			return nil, &pkgerrs.ConfigError{Field: "CommentIDs", Message: fmt.Sprintf("not a comment fullname: %q", id)}
+		}
+	}
+	interval := request.Interval
+	if interval <= 0 {
+		interval = DefaultEditWatchInterval
+	}
+
+	ids := append([]string(nil), request.CommentIDs...)
+	snapshot, err := r.GetInfo(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]*types.Comment, len(snapshot.Comments))
+	for _, c := range snapshot.Comments {
+		known[c.Name] = c
+	}
+
+	sender := newStreamSender[*types.EditEvent](r)
+	go func() {
+		defer close(sender.ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			var current *types.InfoResponse
+			err := r.safeCall(ctx, "watch for edits", func() (err error) {
+				current, err = r.GetInfo(ctx, ids)
+				return err
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if r.pauseIfDegraded(ctx, "watch for edits", err) {
+					continue
+				}
+				if r.config != nil && r.config.Logger != nil {
+					r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "edit watch fetch failed",
+						slog.Int("comments", len(ids)),
+						slog.String("error", err.Error()))
+				}
+				continue
+			}
+
+			for _, c := range current.Comments {
+				prev, seen := known[c.Name]
+				known[c.Name] = c
+				if !seen || !commentEdited(prev, c) {
+					continue
+				}
+				editedAt, _ := c.Edited.Time()
+				event := &types.EditEvent{
+					Comment:  c,
+					Before:   prev.Body,
+					After:    c.Body,
+					EditedAt: editedAt,
+				}
+				if !sender.send(ctx, event) {
+					return
+				}
+			}
+		}
+	}()
+
+	return sender.ch, nil
+}
+
+// commentEdited reports whether next is a newer revision of prev.
+func commentEdited(prev, next *types.Comment) bool {
+	if prev.Body != next.Body {
+		return true
+	}
+	return next.Edited.After(prev.Edited)
+}
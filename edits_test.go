@@ -0,0 +1,80 @@
+package graw
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestClient_WatchForEdits(t *testing.T) {
+	var fetches atomic.Int32
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			switch fetches.Add(1) {
+			case 1:
+				*v = *listingThing(t, commentThing(t, "c1", "original", false), commentThing(t, "c2", "steady", false))
+			default:
+				*v = *listingThing(t, commentThing(t, "c1", "revised", 1700000100.0), commentThing(t, "c2", "steady", false))
+			}
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := client.WatchForEdits(ctx, &types.EditWatchRequest{
+		CommentIDs: []string{"t1_c1", "t1_c2"},
+		Interval:   10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("WatchForEdits returned error: %v", err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Comment.ID != "c1" {
+			t.Errorf("event comment = %q, want c1", ev.Comment.ID)
+		}
+		if ev.Before != "original" || ev.After != "revised" {
+			t.Errorf("event bodies = %q -> %q, want original -> revised", ev.Before, ev.After)
+		}
+		if !ev.EditedAt.Equal(time.Unix(1700000100, 0)) {
+			t.Errorf("EditedAt = %v, want %v", ev.EditedAt, time.Unix(1700000100, 0))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for edit event")
+	}
+
+	// Later polls see no further changes, so cancelling must close the channel without more events.
+	cancel()
+	for ev := range events {
+		if ev.Comment.ID == "c2" {
+			t.Errorf("unexpected event for unchanged comment c2")
+		}
+	}
+}
+
+func TestClient_WatchForEdits_InvalidRequest(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	tests := []struct {
+		name    string
+		request *types.EditWatchRequest
+	}{
+		{name: "nil request", request: nil},
+		{name: "no comments", request: &types.EditWatchRequest{}},
+		{name: "post fullname", request: &types.EditWatchRequest{CommentIDs: []string{"t3_abc"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.WatchForEdits(context.Background(), tt.request); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
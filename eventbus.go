@@ -0,0 +1,264 @@
+package graw
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// DefaultEventBufferSize is the channel buffer each EventBus subscription gets.
+const DefaultEventBufferSize = 64
+
+// EventKind identifies what happened in an Event.
+type EventKind string
+
+const (
+	EventPostCreated    EventKind = "post_created"    // Event.Post is set
+	EventCommentCreated EventKind = "comment_created" // Event.Comment is set
+	EventCommentEdited  EventKind = "comment_edited"  // Event.Comment and Event.Edit are set
+)
+
+// Event is a typed notification published on an EventBus. Only the fields for its Kind are set.
+type Event struct {
+	Kind EventKind
+	// Time is when the event was published.
+	Time time.Time
+
+	Post    *types.Post
+	Comment *types.Comment
+	Edit    *types.EditEvent
+}
+
+// Subreddit returns the subreddit the event's post or comment belongs to, or "".
+func (e *Event) Subreddit() string {
+	switch {
+	case e.Post != nil:
+		return e.Post.Subreddit
+	case e.Comment != nil:
+		return e.Comment.Subreddit
+	}
+	return ""
+}
+
+// EventFilter selects which events a subscriber receives. A nil filter receives everything.
+type EventFilter func(*Event) bool
+
+// EventKinds returns a filter matching events of any of the given kinds.
+func EventKinds(kinds ...EventKind) EventFilter {
+	return func(e *Event) bool {
+		for _, k := range kinds {
+			if e.Kind == k {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// EventSubreddits returns a filter matching events from any of the given subreddits,
+// compared case-insensitively.
+func EventSubreddits(subreddits ...string) EventFilter {
+	return func(e *Event) bool {
+		sub := e.Subreddit()
+		for _, s := range subreddits {
+			if strings.EqualFold(sub, s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// EventBus fans events from any number of producers out to any number of subscribers.
+// Streams are connected with PublishPosts, PublishComments, and PublishEdits; other producers
+// can call Publish directly.
+//
+// Delivery applies backpressure: Publish waits for each matching subscriber's buffer to have
+// room, so events are not dropped, but a subscriber that stops reading stalls its producers.
+// Subscribers should cancel their context when they are done.
+//
+// An EventBus is safe for concurrent use.
+type EventBus struct {
+	mu     sync.RWMutex
+	subs   map[*eventSubscription]struct{}
+	closed bool
+}
+
+// eventSubscription is one subscriber's channel and filter.
+type eventSubscription struct {
+	filter EventFilter
+	events chan *Event
+	// done is closed when the subscription ends, before events is closed.
+	done chan struct{}
+	once sync.Once
+
+	// mu is held for reading while an event is sent on events and for writing to close it.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewEventBus creates an empty EventBus.
+func NewEventBus() *EventBus {
+	return &EventBus{subs: make(map[*eventSubscription]struct{})}
+}
+
+// Subscribe returns a channel of events matching filter. The channel is closed when ctx is
+// cancelled or the bus is closed. Pass a nil filter to receive every event.
+func (b *EventBus) Subscribe(ctx context.Context, filter EventFilter) <-chan *Event {
+	sub := &eventSubscription{
+		filter: filter,
+		events: make(chan *Event, DefaultEventBufferSize),
+		done:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(sub.events)
+		return sub.events
+	}
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.unsubscribe(sub)
+		case <-sub.done:
+		}
+	}()
+	return sub.events
+}
+
+// unsubscribe removes sub and closes its channel.
+func (b *EventBus) unsubscribe(sub *eventSubscription) {
+	b.mu.Lock()
+	delete(b.subs, sub)
+	b.mu.Unlock()
+	sub.close()
+}
+
+// stop marks the subscription as ended.
+func (sub *eventSubscription) stop() {
+	sub.once.Do(func() { close(sub.done) })
+}
+
+// close ends the subscription and closes its channel once no Publish is sending on it.
+func (sub *eventSubscription) close() {
+	// Release any Publish blocked on this subscriber first, so the write lock can be taken.
+	sub.stop()
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	if !sub.closed {
+		sub.closed = true
+		close(sub.events)
+	}
+}
+
+// deliver sends event to the subscriber, waiting for room in its buffer.
+func (sub *eventSubscription) deliver(ctx context.Context, event *Event) error {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+	if sub.closed {
+		return nil
+	}
+	select {
+	case sub.events <- event:
+	case <-sub.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// Publish delivers event to every subscriber whose filter matches. Time is set if zero.
+//
+// Returns an error if the bus is closed or ctx is cancelled before delivery completes.
+func (b *EventBus) Publish(ctx context.Context, event *Event) error {
+	if event == nil {
+		return &pkgerrs.ConfigError{Message: "event cannot be nil"}
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	// Deliver outside the lock, so a slow subscriber does not block Subscribe, unsubscribe,
+	// or Close.
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return &pkgerrs.StateError{Operation: "publish event", Message: "event bus is closed"}
+	}
+	subs := make([]*eventSubscription, 0, len(b.subs))
+	for sub := range b.subs {
+		if sub.filter == nil || sub.filter(event) {
+			subs = append(subs, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range subs {
+		if err := sub.deliver(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close ends every subscription and rejects further publishes. It is safe to call more than once.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	b.closed = true
+	subs := b.subs
+	b.subs = make(map[*eventSubscription]struct{})
+	b.mu.Unlock()
+
+	for sub := range subs {
+		sub.close()
+	}
+}
+
+// PublishPosts publishes an EventPostCreated for each post received, e.g. from StreamNewPosts.
+// It returns when posts is closed, ctx is cancelled, or the bus is closed.
+func (b *EventBus) PublishPosts(ctx context.Context, posts <-chan *types.Post) error {
+	return forwardEvents(ctx, b, posts, func(p *types.Post) *Event {
+		return &Event{Kind: EventPostCreated, Post: p}
+	})
+}
+
+// PublishComments publishes an EventCommentCreated for each comment received, e.g. from
+// StreamPostComments. It returns when comments is closed, ctx is cancelled, or the bus is closed.
+func (b *EventBus) PublishComments(ctx context.Context, comments <-chan *types.Comment) error {
+	return forwardEvents(ctx, b, comments, func(c *types.Comment) *Event {
+		return &Event{Kind: EventCommentCreated, Comment: c}
+	})
+}
+
+// PublishEdits publishes an EventCommentEdited for each edit received from WatchForEdits.
+// It returns when edits is closed, ctx is cancelled, or the bus is closed.
+func (b *EventBus) PublishEdits(ctx context.Context, edits <-chan *types.EditEvent) error {
+	return forwardEvents(ctx, b, edits, func(e *types.EditEvent) *Event {
+		return &Event{Kind: EventCommentEdited, Comment: e.Comment, Edit: e}
+	})
+}
+
+// forwardEvents publishes wrap(v) for every value received from ch.
+func forwardEvents[T any](ctx context.Context, b *EventBus, ch <-chan T, wrap func(T) *Event) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case v, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			if err := b.Publish(ctx, wrap(v)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func receiveEvent(t *testing.T, events <-chan *Event) *Event {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("subscription closed unexpectedly")
+		}
+		return e
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return nil
+}
+
+func TestEventBus_FiltersBySubscriber(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	all := bus.Subscribe(ctx, nil)
+	comments := bus.Subscribe(ctx, EventKinds(EventCommentCreated, EventCommentEdited))
+	golang := bus.Subscribe(ctx, EventSubreddits("GoLang"))
+
+	post := &Event{Kind: EventPostCreated, Post: &types.Post{Subreddit: "golang"}}
+	comment := &Event{Kind: EventCommentCreated, Comment: &types.Comment{Subreddit: "rust"}}
+	for _, e := range []*Event{post, comment} {
+		if err := bus.Publish(ctx, e); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+
+	if e := receiveEvent(t, all); e != post || e.Time.IsZero() {
+		t.Errorf("all: first event = %+v, want post with time set", e)
+	}
+	if e := receiveEvent(t, all); e != comment {
+		t.Errorf("all: second event = %+v, want comment", e)
+	}
+	if e := receiveEvent(t, comments); e != comment {
+		t.Errorf("comments: event = %+v, want comment", e)
+	}
+	if e := receiveEvent(t, golang); e != post {
+		t.Errorf("golang: event = %+v, want post", e)
+	}
+	select {
+	case e := <-golang:
+		t.Errorf("golang: unexpected event %+v", e)
+	default:
+	}
+}
+
+func TestEventBus_UnsubscribeReleasesBlockedPublish(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+	subCtx, unsubscribe := context.WithCancel(context.Background())
+	events := bus.Subscribe(subCtx, nil)
+
+	// Fill the subscriber's buffer, then publish once more without reading.
+	for i := 0; i < DefaultEventBufferSize; i++ {
+		if err := bus.Publish(context.Background(), &Event{Kind: EventPostCreated}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	done := make(chan error, 1)
+	go func() { done <- bus.Publish(context.Background(), &Event{Kind: EventPostCreated}) }()
+
+	unsubscribe()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Publish returned %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish still blocked after the subscriber left")
+	}
+
+	for range events {
+	}
+}
+
+func TestEventBus_BlockedPublishDoesNotHoldBus(t *testing.T) {
+	bus := NewEventBus()
+	// The filter reports when Publish reaches the subscriber whose buffer is full.
+	var published int
+	blocked := make(chan struct{})
+	stalled := bus.Subscribe(context.Background(), func(*Event) bool {
+		if published++; published > DefaultEventBufferSize {
+			close(blocked)
+		}
+		return true
+	})
+	for i := 0; i < DefaultEventBufferSize; i++ {
+		if err := bus.Publish(context.Background(), &Event{Kind: EventPostCreated}); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	done := make(chan error, 1)
+	go func() { done <- bus.Publish(context.Background(), &Event{Kind: EventPostCreated}) }()
+	<-blocked
+
+	// While Publish waits on the stalled subscriber, the bus still takes new subscribers.
+	subscribed := make(chan struct{})
+	go func() {
+		bus.Subscribe(context.Background(), nil)
+		close(subscribed)
+	}()
+	select {
+	case <-subscribed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Subscribe blocked behind a stalled Publish")
+	}
+
+	bus.Close()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Publish returned %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish still blocked after Close")
+	}
+	for range stalled {
+	}
+}
+
+func TestEventBus_Close(t *testing.T) {
+	bus := NewEventBus()
+	events := bus.Subscribe(context.Background(), nil)
+	bus.Close()
+	bus.Close()
+
+	if _, ok := <-events; ok {
+		t.Error("expected subscription to be closed")
+	}
+	err := bus.Publish(context.Background(), &Event{Kind: EventPostCreated})
+	var stateErr *pkgerrs.StateError
+	if !errors.As(err, &stateErr) {
+		t.Errorf("expected StateError after Close, got %v", err)
+	}
+	if _, ok := <-bus.Subscribe(context.Background(), nil); ok {
+		t.Error("expected Subscribe on a closed bus to return a closed channel")
+	}
+}
+
+func TestEventBus_PublishFromStreams(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := bus.Subscribe(ctx, nil)
+
+	posts := make(chan *types.Post, 1)
+	edits := make(chan *types.EditEvent, 1)
+	posts <- &types.Post{Title: "hello"}
+	close(posts)
+	edits <- &types.EditEvent{Comment: &types.Comment{Body: "new"}, Before: "old", After: "new"}
+	close(edits)
+
+	if err := bus.PublishPosts(ctx, posts); err != nil {
+		t.Fatalf("PublishPosts: %v", err)
+	}
+	if err := bus.PublishEdits(ctx, edits); err != nil {
+		t.Fatalf("PublishEdits: %v", err)
+	}
+
+	if e := receiveEvent(t, events); e.Kind != EventPostCreated || e.Post.Title != "hello" {
+		t.Errorf("first event = %+v", e)
+	}
+	if e := receiveEvent(t, events); e.Kind != EventCommentEdited || e.Edit.Before != "old" || e.Comment.Body != "new" {
+		t.Errorf("second event = %+v", e)
+	}
+}
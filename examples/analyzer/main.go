@@ -35,6 +35,7 @@ const (
 // CommentStats holds statistical information about comments
 type CommentStats struct {
 	TotalComments   int
+	ScoredComments  int // Comments whose score is visible; hidden scores are excluded from score stats
 	TotalScore      int
 	AverageScore    float64
 	MaxScore        int
@@ -53,23 +54,17 @@ type AuthorStat struct {
 }
 
 func main() {
-	// Get credentials from environment
-	clientID := os.Getenv("REDDIT_CLIENT_ID")
-	clientSecret := os.Getenv("REDDIT_CLIENT_SECRET")
-
-	if clientID == "" || clientSecret == "" {
-		log.Fatal("REDDIT_CLIENT_ID and REDDIT_CLIENT_SECRET environment variables are required")
+	// Load credentials and settings from REDDIT_* environment variables
+	config, err := graw.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
-
-	// Create logger (suppress debug logs for cleaner output)
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
-
-	// Create client configuration
-	config := &graw.Config{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		UserAgent:    "comment-analyzer/1.0 (analysis example)",
-		Logger:       logger,
+	if config.UserAgent == "" {
+		config.UserAgent = "comment-analyzer/1.0 (analysis example)"
+	}
+	if config.Logger == nil {
+		// Suppress debug logs for cleaner output
+		config.Logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelWarn}))
 	}
 
 	// Create the client
@@ -177,13 +172,16 @@ func calculateStats(comments []*types.Comment) CommentStats {
 			continue
 		}
 
-		// Score statistics
-		stats.TotalScore += comment.Score
-		if comment.Score > stats.MaxScore {
-			stats.MaxScore = comment.Score
-		}
-		if comment.Score < stats.MinScore {
-			stats.MinScore = comment.Score
+		// Score statistics, skipping comments whose score is still hidden by the subreddit
+		if comment.ScoreKnown() {
+			stats.ScoredComments++
+			stats.TotalScore += comment.Score
+			if comment.Score > stats.MaxScore {
+				stats.MaxScore = comment.Score
+			}
+			if comment.Score < stats.MinScore {
+				stats.MinScore = comment.Score
+			}
 		}
 
 		// Author statistics
@@ -192,13 +190,17 @@ func calculateStats(comments []*types.Comment) CommentStats {
 			stats.DeletedComments++
 		} else {
 			stats.AuthorActivity[author]++
-			authorScores[author] += comment.Score
+			if comment.ScoreKnown() {
+				authorScores[author] += comment.Score
+			}
 		}
 	}
 
-	// Calculate average
-	if stats.TotalComments > 0 {
-		stats.AverageScore = float64(stats.TotalScore) / float64(stats.TotalComments)
+	// Calculate average over comments with visible scores
+	if stats.ScoredComments > 0 {
+		stats.AverageScore = float64(stats.TotalScore) / float64(stats.ScoredComments)
+	} else {
+		stats.MinScore = 0
 	}
 
 	// Count unique authors
@@ -237,6 +239,7 @@ func displayStats(stats CommentStats) {
 		float64(stats.DeletedComments)/float64(stats.TotalComments)*100)
 
 	fmt.Println("\nScore Statistics:")
+	fmt.Printf("  Comments With Visible Scores: %d (%d hidden)\n", stats.ScoredComments, stats.TotalComments-stats.ScoredComments)
 	fmt.Printf("  Total Score: %d\n", stats.TotalScore)
 	fmt.Printf("  Average Score: %.2f\n", stats.AverageScore)
 	fmt.Printf("  Highest Score: %d\n", stats.MaxScore)
@@ -36,23 +36,16 @@ const (
 )
 
 func main() {
-	// Get credentials from environment
-	clientID := os.Getenv("REDDIT_CLIENT_ID")
-	clientSecret := os.Getenv("REDDIT_CLIENT_SECRET")
-
-	if clientID == "" || clientSecret == "" {
-		log.Fatal("REDDIT_CLIENT_ID and REDDIT_CLIENT_SECRET environment variables are required")
+	// Load credentials and settings from REDDIT_* environment variables
+	config, err := graw.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
-
-	// Create logger
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
-
-	// Create client configuration
-	config := &graw.Config{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		UserAgent:    "subreddit-monitor/1.0 (monitoring example)",
-		Logger:       logger,
+	if config.UserAgent == "" {
+		config.UserAgent = "subreddit-monitor/1.0 (monitoring example)"
+	}
+	if config.Logger == nil {
+		config.Logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
 	}
 
 	// Create the client
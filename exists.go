@@ -0,0 +1,95 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// ExistsPost reports whether a post exists, with its status, using a single /api/info
+// lookup. The ID may be given with or without the "t3_" prefix.
+//
+// Removed and deleted posts exist, with status ContentRemoved or ContentDeleted. Posts that
+// do not exist, and posts the client cannot see (e.g. in private subreddits), are reported
+// as ContentNotFound.
+//
+// Returns an error if the ID is invalid or the request fails.
+func (r *Reddit) ExistsPost(ctx context.Context, postID string) (bool, types.ContentStatus, error) {
+	postID = strings.TrimPrefix(postID, string(types.KIND_POST))
+	if err := r.validator.ValidatePostID(postID); err != nil {
+		return false, "", err
+	}
+
+	info, err := r.GetInfo(ctx, []string{string(types.KIND_POST) + postID})
+	if err != nil {
+		return false, "", err
+	}
+	if len(info.Posts) == 0 {
+		return false, types.ContentNotFound, nil
+	}
+	return true, postStatus(info.Posts[0]), nil
+}
+
+// postStatus derives a post's ContentStatus from its removal category.
+func postStatus(post *types.Post) types.ContentStatus {
+	if post.RemovedByCategory == nil {
+		return types.ContentActive
+	}
+	switch *post.RemovedByCategory {
+	case "deleted", "author":
+		return types.ContentDeleted
+	default:
+		return types.ContentRemoved
+	}
+}
+
+// ExistsSubreddit reports whether a subreddit exists, with its status, by fetching its
+// about page.
+//
+// Private, quarantined, and gated subreddits exist, with the matching status. Banned
+// subreddits are reported as not existing with status ContentBanned, and unknown names
+// as ContentNotFound.
+//
+// Returns an error if the name is invalid or the request fails for another reason.
+func (r *Reddit) ExistsSubreddit(ctx context.Context, name string) (bool, types.ContentStatus, error) {
+	name, err := r.validator.NormalizeSubredditName(name)
+	if err != nil {
+		return false, "", err
+	}
+	// A cached entry may predate a ban or the subreddit going private.
+	sub, err := r.fetchSubreddit(ctx, name)
+	if err == nil {
+		if sub.Quarantine {
+			return true, types.ContentQuarantined, nil
+		}
+		return true, types.ContentActive, nil
+	}
+
+	var notFound *pkgerrs.NotFoundError
+	if errors.As(err, &notFound) {
+		if notFound.IsBanned() {
+			return false, types.ContentBanned, nil
+		}
+		return false, types.ContentNotFound, nil
+	}
+	var forbidden *pkgerrs.ForbiddenError
+	if errors.As(err, &forbidden) && forbidden.Err != nil {
+		switch forbidden.Err.Reason {
+		case pkgerrs.ReasonPrivate:
+			return true, types.ContentPrivate, nil
+		case pkgerrs.ReasonQuarantined:
+			return true, types.ContentQuarantined, nil
+		case pkgerrs.ReasonGated:
+			return true, types.ContentGated, nil
+		}
+	}
+	// For unknown names Reddit may answer with a search listing instead of a subreddit.
+	var parseErr *pkgerrs.ParseError
+	if errors.As(err, &parseErr) {
+		return false, types.ContentNotFound, nil
+	}
+	return false, "", err
+}
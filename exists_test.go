@@ -0,0 +1,174 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestExistsPost(t *testing.T) {
+	removedPost := func(category string) *types.Thing {
+		thing := submitPostThing(t, "abc123", "title", "gopher", time.Unix(1700000000, 0))
+		var data map[string]any
+		if err := json.Unmarshal(thing.Data, &data); err != nil {
+			t.Fatalf("unmarshal post: %v", err)
+		}
+		data["removed_by_category"] = category
+		raw, err := json.Marshal(data)
+		if err != nil {
+			t.Fatalf("marshal post: %v", err)
+		}
+		return &types.Thing{Kind: "t3", Data: raw}
+	}
+
+	tests := []struct {
+		name       string
+		children   []*types.Thing
+		wantExists bool
+		wantStatus types.ContentStatus
+	}{
+		{"active", []*types.Thing{submitPostThing(t, "abc123", "title", "gopher", time.Unix(1700000000, 0))}, true, types.ContentActive},
+		{"missing", nil, false, types.ContentNotFound},
+		{"removed", []*types.Thing{removedPost("moderator")}, true, types.ContentRemoved},
+		{"deleted", []*types.Thing{removedPost("deleted")}, true, types.ContentDeleted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotIDs string
+			mock := &mockHTTPClient{
+				doFunc: func(req *http.Request, v *types.Thing) error {
+					gotIDs = req.URL.Query().Get("id")
+					*v = *listingThing(t, tt.children...)
+					return nil
+				},
+			}
+			client := newTestClient(mock, nil)
+
+			exists, status, err := client.ExistsPost(context.Background(), "t3_abc123")
+			if err != nil {
+				t.Fatalf("ExistsPost returned error: %v", err)
+			}
+			if gotIDs != "t3_abc123" {
+				t.Errorf("id param = %q, want %q", gotIDs, "t3_abc123")
+			}
+			if exists != tt.wantExists || status != tt.wantStatus {
+				t.Errorf("ExistsPost() = %v, %q, want %v, %q", exists, status, tt.wantExists, tt.wantStatus)
+			}
+		})
+	}
+
+	t.Run("invalid id", func(t *testing.T) {
+		client := newTestClient(&mockHTTPClient{}, nil)
+		if _, _, err := client.ExistsPost(context.Background(), "bad id!"); err == nil {
+			t.Error("expected error for invalid post ID")
+		}
+	})
+}
+
+func TestExistsSubreddit(t *testing.T) {
+	subredditThing := func(quarantine bool) *types.Thing {
+		data, err := json.Marshal(map[string]any{
+			"id":           "2qh1i",
+			"name":         "t5_2qh1i",
+			"display_name": "golang",
+			"quarantine":   quarantine,
+		})
+		if err != nil {
+			t.Fatalf("marshal subreddit: %v", err)
+		}
+		return &types.Thing{Kind: "t5", Data: data}
+	}
+	networkErr := errors.New("connection reset")
+
+	tests := []struct {
+		name       string
+		respond    func(v *types.Thing) error
+		wantExists bool
+		wantStatus types.ContentStatus
+		wantErr    error
+	}{
+		{
+			name:       "active",
+			respond:    func(v *types.Thing) error { *v = *subredditThing(false); return nil },
+			wantExists: true,
+			wantStatus: types.ContentActive,
+		},
+		{
+			name:       "quarantined",
+			respond:    func(v *types.Thing) error { *v = *subredditThing(true); return nil },
+			wantExists: true,
+			wantStatus: types.ContentQuarantined,
+		},
+		{
+			name:       "search redirect",
+			respond:    func(v *types.Thing) error { *v = *listingThing(t); return nil },
+			wantStatus: types.ContentNotFound,
+		},
+		{
+			name:       "not found",
+			respond:    func(*types.Thing) error { return &pkgerrs.APIError{StatusCode: http.StatusNotFound} },
+			wantStatus: types.ContentNotFound,
+		},
+		{
+			name: "banned",
+			respond: func(*types.Thing) error {
+				return &pkgerrs.APIError{StatusCode: http.StatusNotFound, Reason: pkgerrs.ReasonBanned}
+			},
+			wantStatus: types.ContentBanned,
+		},
+		{
+			name: "private",
+			respond: func(*types.Thing) error {
+				return &pkgerrs.APIError{StatusCode: http.StatusForbidden, Reason: pkgerrs.ReasonPrivate}
+			},
+			wantExists: true,
+			wantStatus: types.ContentPrivate,
+		},
+		{
+			name: "gated",
+			respond: func(*types.Thing) error {
+				return &pkgerrs.APIError{StatusCode: http.StatusForbidden, Reason: pkgerrs.ReasonGated}
+			},
+			wantExists: true,
+			wantStatus: types.ContentGated,
+		},
+		{
+			name:    "request failure",
+			respond: func(*types.Thing) error { return networkErr },
+			wantErr: networkErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockHTTPClient{
+				doFunc: func(req *http.Request, v *types.Thing) error {
+					if req.URL.Path != "/r/golang/about" {
+						t.Errorf("unexpected path %q", req.URL.Path)
+					}
+					return tt.respond(v)
+				},
+			}
+			client := newTestClient(mock, nil)
+
+			exists, status, err := client.ExistsSubreddit(context.Background(), "golang")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ExistsSubreddit() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExistsSubreddit returned error: %v", err)
+			}
+			if exists != tt.wantExists || status != tt.wantStatus {
+				t.Errorf("ExistsSubreddit() = %v, %q, want %v, %q", exists, status, tt.wantExists, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package graw
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// TokenCounter estimates how many model tokens a piece of text uses.
+type TokenCounter func(text string) int
+
+// EstimateTokens is the default TokenCounter. It assumes about four characters per token,
+// which is close for English text with common tokenizers.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// ThreadExportOptions controls ExportThread. The zero value exports every comment Reddit
+// will return, estimating tokens with EstimateTokens and without chunking.
+type ThreadExportOptions struct {
+	// MaxDepth, when positive, drops comments nested deeper than MaxDepth levels;
+	// 1 keeps only top-level comments.
+	MaxDepth int
+	// SkipMore leaves comments behind "load more" stubs unloaded. Their IDs are listed in
+	// ThreadExport.Unloaded.
+	SkipMore bool
+	// CountTokens sets each item's Tokens. Defaults to EstimateTokens.
+	CountTokens TokenCounter
+	// ChunkTokens, when positive, groups consecutive comments into Chunks of at most
+	// ChunkTokens tokens. A comment larger than ChunkTokens gets a chunk of its own.
+	ChunkTokens int
+}
+
+// ThreadExport is a post and its comments normalized for text-processing pipelines.
+// Comments are flattened depth-first, each parent before its replies, with siblings ordered
+// by creation time and then ID, so exporting an unchanged thread always yields the same order
+// regardless of how Reddit sorted it.
+type ThreadExport struct {
+	Post     ThreadPost      `json:"post"`
+	Comments []ThreadComment `json:"comments"`
+	// Chunks groups Comments when ThreadExportOptions.ChunkTokens is set.
+	Chunks []ThreadChunk `json:"chunks,omitempty"`
+	// Unloaded lists IDs of comments that could not be loaded, or were skipped with SkipMore.
+	Unloaded []string `json:"unloaded,omitempty"`
+}
+
+// ThreadPost is the exported post. Body is the self-text, empty for link posts.
+type ThreadPost struct {
+	ID          string    `json:"id"`
+	Subreddit   string    `json:"subreddit"`
+	Author      string    `json:"author"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	URL         string    `json:"url"`
+	Score       int       `json:"score"`
+	NumComments int       `json:"num_comments"`
+	CreatedAt   time.Time `json:"created_at"`
+	Tokens      int       `json:"tokens"`
+}
+
+// ThreadComment is one exported comment. Depth is 0 for top-level comments; comments whose
+// parent could not be loaded are also placed at depth 0. ParentID is the parent's fullname.
+type ThreadComment struct {
+	ID        string     `json:"id"`
+	ParentID  string     `json:"parent_id"`
+	Depth     int        `json:"depth"`
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	Score     int        `json:"score"`
+	CreatedAt time.Time  `json:"created_at"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+	Tokens    int        `json:"tokens"`
+}
+
+// ThreadChunk is the half-open range Comments[Start:End] and its total token count.
+type ThreadChunk struct {
+	Start  int `json:"start"`
+	End    int `json:"end"`
+	Tokens int `json:"tokens"`
+}
+
+// ExportThread loads a post and its full comment tree, following "load more" stubs, and
+// returns it as a ThreadExport. The post ID may be given with or without the "t3_" prefix.
+// opts may be nil.
+//
+// If loading hidden comments fails partway, the comments loaded so far are exported, the
+// rest are listed in Unloaded, and a *errors.PartialResultError is returned with the export.
+//
+// Returns an error if the post ID is invalid or the post cannot be loaded.
+func (r *Reddit) ExportThread(ctx context.Context, postID string, opts *ThreadExportOptions) (*ThreadExport, error) {
+	if opts == nil {
+		opts = &ThreadExportOptions{}
+	}
+	postID = strings.TrimPrefix(postID, string(types.KIND_POST))
+	if err := r.validator.ValidatePostID(postID); err != nil {
+		return nil, err
+	}
+	if opts.MaxDepth < 0 || opts.ChunkTokens < 0 {
+		return nil, &pkgerrs.ConfigError{Field: "ThreadExportOptions", Message: "MaxDepth and ChunkTokens cannot be negative"}
+	}
+
+	params := url.Values{}
+	if opts.MaxDepth > 0 {
+		params.Set("depth", strconv.Itoa(opts.MaxDepth))
+	}
+	resp, err := r.fetchComments(ctx, "comments/"+postID, params)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Post == nil {
+		request := &types.CommentsRequest{PostID: postID, RequirePost: true}
+		if err := r.fillMissingPosts(ctx, []*types.CommentsRequest{request}, []*types.CommentsResponse{resp}); err != nil {
+			return nil, err
+		}
+	}
+
+	comments := flattenComments(resp.Comments)
+	var unloaded []string
+	var loadErr error
+	if opts.SkipMore {
+		unloaded = resp.MoreIDs
+	} else {
+		var more []*types.Comment
+		more, unloaded, loadErr = r.loadThreadMore(ctx, postID, resp.MoreIDs)
+		comments = append(comments, more...)
+	}
+	r.annotateComments(ctx, "export thread", comments)
+
+	count := opts.CountTokens
+	if count == nil {
+		count = EstimateTokens
+	}
+	export := &ThreadExport{
+		Post:     newThreadPost(resp.Post, count),
+		Comments: orderThreadComments(string(types.KIND_POST)+postID, comments, opts.MaxDepth, count),
+		Unloaded: unloaded,
+	}
+	if opts.ChunkTokens > 0 {
+		export.Chunks = chunkThreadComments(export.Comments, opts.ChunkTokens)
+	}
+	return export, loadErr
+}
+
+// loadThreadMore loads the comments behind ids. It returns the loaded comments, the IDs
+// left unloaded, and a PartialResultError if a request failed.
+func (r *Reddit) loadThreadMore(ctx context.Context, postID string, ids []string) ([]*types.Comment, []string, error) {
+	loaded, err := r.GetAllMoreComments(ctx, &types.MoreCommentsRequest{LinkID: postID, CommentIDs: ids})
+	if err == nil {
+		return loaded, nil, nil
+	}
+	got := make(map[string]bool, len(loaded))
+	for _, c := range loaded {
+		got[c.ID] = true
+	}
+	unloaded := slices.DeleteFunc(slices.Clone(ids), func(id string) bool { return got[id] })
+	var partial *pkgerrs.PartialResultError
+	if !errors.As(err, &partial) {
+		err = &pkgerrs.PartialResultError{Operation: "export thread", Total: 1, Err: err}
+	}
+	return loaded, unloaded, err
+}
+
+// newThreadPost converts post, which may be nil if Reddit did not return it.
+func newThreadPost(post *types.Post, count TokenCounter) ThreadPost {
+	if post == nil {
+		return ThreadPost{}
+	}
+	return ThreadPost{
+		ID:          post.ID,
+		Subreddit:   post.Subreddit,
+		Author:      post.Author,
+		Title:       post.Title,
+		Body:        post.SelfText,
+		URL:         post.URL,
+		Score:       post.Score,
+		NumComments: post.NumComments,
+		CreatedAt:   unixTime(post.CreatedUTC),
+		Tokens:      count(post.Title) + count(post.SelfText),
+	}
+}
+
+// orderThreadComments rebuilds the tree below postName from the comments' parent IDs and
+// flattens it depth-first, ordering siblings by creation time and then ID. Comments whose
+// parent is missing start new top-level branches.
+func orderThreadComments(postName string, comments []*types.Comment, maxDepth int, count TokenCounter) []ThreadComment {
+	byName := make(map[string]bool, len(comments))
+	for _, c := range comments {
+		byName[c.Name] = true
+	}
+	children := make(map[string][]*types.Comment)
+	var roots []*types.Comment
+	for _, c := range comments {
+		if c.ParentID == postName || !byName[c.ParentID] {
+			roots = append(roots, c)
+		} else {
+			children[c.ParentID] = append(children[c.ParentID], c)
+		}
+	}
+
+	out := make([]ThreadComment, 0, len(comments))
+	var walk func(level []*types.Comment, depth int)
+	walk = func(level []*types.Comment, depth int) {
+		if maxDepth > 0 && depth >= maxDepth {
+			return
+		}
+		slices.SortFunc(level, func(a, b *types.Comment) int {
+			return cmp.Or(cmp.Compare(a.CreatedUTC, b.CreatedUTC), cmp.Compare(a.ID, b.ID))
+		})
+		for _, c := range level {
+			out = append(out, newThreadComment(c, depth, count))
+			walk(children[c.Name], depth+1)
+		}
+	}
+	walk(roots, 0)
+	return out
+}
+
+// newThreadComment converts c, placing it at the given depth.
+func newThreadComment(c *types.Comment, depth int, count TokenCounter) ThreadComment {
+	tc := ThreadComment{
+		ID:        c.ID,
+		ParentID:  c.ParentID,
+		Depth:     depth,
+		Author:    c.Author,
+		Body:      c.Body,
+		Score:     c.Score,
+		CreatedAt: unixTime(c.CreatedUTC),
+		Tokens:    count(c.Body),
+	}
+	if edited, ok := c.Edited.Time(); ok {
+		tc.EditedAt = &edited
+	}
+	return tc
+}
+
+// chunkThreadComments greedily groups consecutive comments into chunks of at most limit tokens.
+func chunkThreadComments(comments []ThreadComment, limit int) []ThreadChunk {
+	var chunks []ThreadChunk
+	current := ThreadChunk{}
+	for i, c := range comments {
+		if current.End > current.Start && current.Tokens+c.Tokens > limit {
+			chunks = append(chunks, current)
+			current = ThreadChunk{Start: i, End: i}
+		}
+		current.End = i + 1
+		current.Tokens += c.Tokens
+	}
+	if current.End > current.Start {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// unixTime converts a Reddit timestamp in seconds to a UTC time.
+func unixTime(seconds float64) time.Time {
+	return time.Unix(int64(seconds), 0).UTC()
+}
@@ -0,0 +1,133 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// exportThreadMock serves post1 with comments c1 (reply c3) and c2, and c4 behind a stub
+// under c1. moreErr, if set, fails the morechildren request.
+func exportThreadMock(t *testing.T, base time.Time, moreErr error) *mockHTTPClient {
+	at := func(s int) time.Time { return base.Add(time.Duration(s) * time.Second) }
+	return &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			if req.URL.Path != "/comments/post1" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			return []*types.Thing{
+				listingThing(t, submitPostThing(t, "post1", "Release thread", "poster", base)),
+				listingThing(t,
+					// Reddit's order is not chronological; the export should be.
+					replyThing(t, "c2", "t3_post1", at(2)),
+					replyThing(t, "c1", "t3_post1", at(1), replyThing(t, "c3", "t1_c1", at(5))),
+					moreThing(t, "t1_c1", "c4"),
+				),
+			}, nil
+		},
+		doMoreChildrenFunc: func(req *http.Request) ([]*types.Thing, error) {
+			if moreErr != nil {
+				return nil, moreErr
+			}
+			return []*types.Thing{replyThing(t, "c4", "t1_c1", at(3))}, nil
+		},
+	}
+}
+
+func TestClient_ExportThread(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	client := newTestClient(exportThreadMock(t, base, nil), nil)
+
+	export, err := client.ExportThread(context.Background(), "t3_post1", &ThreadExportOptions{
+		CountTokens: func(text string) int { return len(text) },
+		ChunkTokens: 20,
+	})
+	if err != nil {
+		t.Fatalf("ExportThread returned error: %v", err)
+	}
+
+	if export.Post.ID != "post1" || export.Post.Title != "Release thread" || !export.Post.CreatedAt.Equal(base) {
+		t.Errorf("unexpected post %+v", export.Post)
+	}
+	var ids []string
+	var depths []int
+	for _, c := range export.Comments {
+		ids = append(ids, c.ID)
+		depths = append(depths, c.Depth)
+	}
+	if want := []string{"c1", "c4", "c3", "c2"}; !slices.Equal(ids, want) {
+		t.Errorf("comment order = %v, want %v", ids, want)
+	}
+	if want := []int{0, 1, 1, 0}; !slices.Equal(depths, want) {
+		t.Errorf("depths = %v, want %v", depths, want)
+	}
+	if c := export.Comments[1]; c.ParentID != "t1_c1" || c.Author != "gopher" || c.Tokens != len("comment c4") {
+		t.Errorf("unexpected comment %+v", c)
+	}
+	if len(export.Unloaded) != 0 {
+		t.Errorf("Unloaded = %v, want none", export.Unloaded)
+	}
+
+	// Each body is 10 tokens, so chunks hold two comments.
+	want := []ThreadChunk{{Start: 0, End: 2, Tokens: 20}, {Start: 2, End: 4, Tokens: 20}}
+	if !slices.Equal(export.Chunks, want) {
+		t.Errorf("Chunks = %+v, want %+v", export.Chunks, want)
+	}
+}
+
+func TestClient_ExportThread_Options(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("skip more and max depth", func(t *testing.T) {
+		mock := exportThreadMock(t, base, nil)
+		mock.doMoreChildrenFunc = func(req *http.Request) ([]*types.Thing, error) {
+			t.Error("morechildren should not be requested with SkipMore")
+			return nil, nil
+		}
+		export, err := newTestClient(mock, nil).ExportThread(context.Background(), "post1", &ThreadExportOptions{MaxDepth: 1, SkipMore: true})
+		if err != nil {
+			t.Fatalf("ExportThread returned error: %v", err)
+		}
+		if len(export.Comments) != 2 || export.Comments[0].ID != "c1" || export.Comments[1].ID != "c2" {
+			t.Errorf("Comments = %+v, want top-level c1 and c2", export.Comments)
+		}
+		if !slices.Equal(export.Unloaded, []string{"c4"}) {
+			t.Errorf("Unloaded = %v, want [c4]", export.Unloaded)
+		}
+	})
+
+	t.Run("morechildren failure", func(t *testing.T) {
+		mock := exportThreadMock(t, base, &pkgerrs.APIError{StatusCode: http.StatusInternalServerError, Message: "request failed"})
+		export, err := newTestClient(mock, nil).ExportThread(context.Background(), "post1", nil)
+		var partial *pkgerrs.PartialResultError
+		if !errors.As(err, &partial) {
+			t.Fatalf("expected PartialResultError, got %T: %v", err, err)
+		}
+		if export == nil || len(export.Comments) != 3 || !slices.Equal(export.Unloaded, []string{"c4"}) {
+			t.Errorf("unexpected partial export %+v", export)
+		}
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := newTestClient(&mockHTTPClient{}, nil).ExportThread(context.Background(), "post1", &ThreadExportOptions{ChunkTokens: -1})
+		var configErr *pkgerrs.ConfigError
+		if !errors.As(err, &configErr) {
+			t.Errorf("expected ConfigError, got %T: %v", err, err)
+		}
+	})
+}
+
+func TestChunkThreadComments_OversizedComment(t *testing.T) {
+	comments := []ThreadComment{{Tokens: 3}, {Tokens: 50}, {Tokens: 4}, {Tokens: 4}}
+	got := chunkThreadComments(comments, 10)
+	want := []ThreadChunk{{Start: 0, End: 1, Tokens: 3}, {Start: 1, End: 2, Tokens: 50}, {Start: 2, End: 4, Tokens: 8}}
+	if !slices.Equal(got, want) {
+		t.Errorf("chunkThreadComments() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,78 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// GetLinkFlairTemplates lists the link flair templates a subreddit offers for posts. Pass a
+// template's ID as SubmitRequest.FlairID to use it.
+//
+// The subreddit may be given as "golang", "r/golang", or "/r/golang".
+//
+// Returns an error if the subreddit name is invalid or the API request fails, e.g. with a
+// *errors.ForbiddenError when the subreddit does not let users choose flair.
+func (r *Reddit) GetLinkFlairTemplates(ctx context.Context, subreddit string) ([]types.FlairTemplate, error) {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(LinkFlairURLFormat, subreddit)
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var templates []types.FlairTemplate
+	if err := r.httpClient.DoJSON(req, &templates); err != nil {
+		return nil, wrapDoError(err, "get link flair templates", path)
+	}
+	return templates, nil
+}
+
+// requiredFlair returns the flair ID to submit request with. If the subreddit requires flair
+// and request has no FlairID, it returns the ID of the template whose text matches
+// FlairText, or a FlairRequiredError listing the templates when none does.
+func (r *Reddit) requiredFlair(ctx context.Context, subreddit string, request *types.SubmitRequest) (string, error) {
+	if request.FlairID != "" {
+		return request.FlairID, nil
+	}
+	requirements, err := r.GetPostRequirements(ctx, subreddit)
+	if err != nil {
+		return "", err
+	}
+	if !requirements.IsFlairRequired {
+		return "", nil
+	}
+
+	templates, err := r.GetLinkFlairTemplates(ctx, subreddit)
+	if err != nil {
+		return "", err
+	}
+	if text := strings.TrimSpace(request.FlairText); text != "" {
+		for _, t := range templates {
+			if strings.EqualFold(strings.TrimSpace(t.Text), text) {
+				return t.ID, nil
+			}
+		}
+	}
+	choices := make([]pkgerrs.FlairChoice, len(templates))
+	for i, t := range templates {
+		choices[i] = pkgerrs.FlairChoice{ID: t.ID, Text: t.Text}
+	}
+	return "", &pkgerrs.FlairRequiredError{
+		ResourceContext: pkgerrs.ResourceContext{Operation: "submit", URL: SubmitURL, Subreddit: subreddit},
+		Templates:       choices,
+	}
+}
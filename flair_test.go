@@ -0,0 +1,130 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const flairTemplatesJSON = `[
+	{"id": "f-question", "text": "Question", "text_editable": false, "mod_only": false, "css_class": "q", "background_color": "#ff4500", "text_color": "light"},
+	{"id": "f-news", "text": "News", "text_editable": true, "mod_only": false},
+	{"id": "f-announce", "text": "Announcement", "mod_only": true}
+]`
+
+// flairMock serves post requirements, link flair templates, and submissions, recording the
+// submitted form in submitted.
+func flairMock(t *testing.T, flairRequired bool, submitted *http.Request) *mockHTTPClient {
+	return &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			switch req.URL.Path {
+			case "/api/v1/golang/post_requirements":
+				return json.Unmarshal([]byte(fmt.Sprintf(`{"is_flair_required": %t}`, flairRequired)), v)
+			case "/r/golang/api/link_flair_v2":
+				return json.Unmarshal([]byte(flairTemplatesJSON), v)
+			case "/" + SubmitURL:
+				if err := req.ParseForm(); err != nil {
+					t.Fatal(err)
+				}
+				*submitted = *req
+				return json.Unmarshal([]byte(`{"json":{"errors":[],"data":{"id":"new1","name":"t3_new1","url":"https://www.reddit.com/r/golang/comments/new1/"}}}`), v)
+			default:
+				t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+				return nil
+			}
+		},
+	}
+}
+
+func TestClient_GetLinkFlairTemplates(t *testing.T) {
+	var submitted http.Request
+	templates, err := newTestClient(flairMock(t, true, &submitted), nil).GetLinkFlairTemplates(context.Background(), "r/golang")
+	if err != nil {
+		t.Fatalf("GetLinkFlairTemplates() error = %v", err)
+	}
+	if len(templates) != 3 {
+		t.Fatalf("got %d templates, want 3", len(templates))
+	}
+	want := types.FlairTemplate{ID: "f-question", Text: "Question", CSSClass: "q", BackgroundColor: "#ff4500", TextColor: "light"}
+	if templates[0] != want {
+		t.Errorf("templates[0] = %+v, want %+v", templates[0], want)
+	}
+	if !templates[1].TextEditable || !templates[2].ModOnly {
+		t.Errorf("flags not decoded: %+v", templates)
+	}
+}
+
+func TestSubmitPost_CheckFlair(t *testing.T) {
+	draft := func(flairID, flairText string) *types.SubmitRequest {
+		return &types.SubmitRequest{
+			Subreddit:  "golang",
+			Title:      "How do I use iterators?",
+			Kind:       types.SubmitKindSelf,
+			Text:       "body",
+			FlairID:    flairID,
+			FlairText:  flairText,
+			CheckFlair: true,
+		}
+	}
+
+	t.Run("attaches the template matching FlairText", func(t *testing.T) {
+		var submitted http.Request
+		client := newTestClient(flairMock(t, true, &submitted), nil)
+		if _, err := client.SubmitPost(context.Background(), draft("", "question")); err != nil {
+			t.Fatalf("SubmitPost() error = %v", err)
+		}
+		if got := submitted.PostForm.Get("flair_id"); got != "f-question" {
+			t.Errorf("flair_id = %q, want f-question", got)
+		}
+	})
+
+	t.Run("missing flair lists templates without posting", func(t *testing.T) {
+		var submitted http.Request
+		client := newTestClient(flairMock(t, true, &submitted), nil)
+		_, err := client.SubmitPost(context.Background(), draft("", "Off topic"))
+		var flairErr *pkgerrs.FlairRequiredError
+		if !errors.As(err, &flairErr) {
+			t.Fatalf("SubmitPost() error = %v, want FlairRequiredError", err)
+		}
+		if len(flairErr.Templates) != 3 || flairErr.Templates[0] != (pkgerrs.FlairChoice{ID: "f-question", Text: "Question"}) || flairErr.Subreddit != "golang" {
+			t.Errorf("FlairRequiredError = %+v", flairErr)
+		}
+		if submitted.URL != nil {
+			t.Error("post was submitted")
+		}
+	})
+
+	t.Run("explicit FlairID skips the lookup", func(t *testing.T) {
+		var submitted http.Request
+		mock := flairMock(t, true, &submitted)
+		doJSON := mock.doJSONFunc
+		mock.doJSONFunc = func(req *http.Request, v any) error {
+			if req.URL.Path != "/"+SubmitURL {
+				t.Errorf("unexpected lookup %s", req.URL.Path)
+			}
+			return doJSON(req, v)
+		}
+		if _, err := newTestClient(mock, nil).SubmitPost(context.Background(), draft("f-news", "Go 1.30 released")); err != nil {
+			t.Fatalf("SubmitPost() error = %v", err)
+		}
+		if got := submitted.PostForm.Get("flair_id"); got != "f-news" {
+			t.Errorf("flair_id = %q, want f-news", got)
+		}
+	})
+
+	t.Run("flair not required", func(t *testing.T) {
+		var submitted http.Request
+		if _, err := newTestClient(flairMock(t, false, &submitted), nil).SubmitPost(context.Background(), draft("", "")); err != nil {
+			t.Fatalf("SubmitPost() error = %v", err)
+		}
+		if submitted.PostForm.Has("flair_id") {
+			t.Errorf("flair_id = %q, want none", submitted.PostForm.Get("flair_id"))
+		}
+	})
+}
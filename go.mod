@@ -2,4 +2,9 @@ module github.com/jamesprial/go-reddit-api-wrapper
 
 go 1.25.0
 
-require golang.org/x/time v0.13.0
+require (
+	github.com/klauspost/compress v1.18.0
+	golang.org/x/sync v0.17.0
+	golang.org/x/time v0.13.0
+	gopkg.in/yaml.v3 v3.0.1
+)
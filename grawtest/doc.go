@@ -0,0 +1,3 @@
+// Package grawtest provides helpers for testing code built on graw against mock Reddit
+// servers, such as those started with net/http/httptest.
+package grawtest
@@ -0,0 +1,145 @@
+package grawtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// FixtureSubreddit is the subreddit of every generated post and comment.
+	FixtureSubreddit = "grawtest"
+	// FixturePostID is the ID of the post generated by NewCommentTree.
+	FixturePostID = "fx1"
+	// fixtureCreated is the creation time, in Unix seconds, of the newest generated item.
+	fixtureCreated = 1700000000
+	// fixtureSubredditID is the fullname of FixtureSubreddit.
+	fixtureSubredditID = "t5_2fx1"
+)
+
+// NewListing returns a Listing of nPosts self posts in FixtureSubreddit, newest first, as
+// Reddit returns from /r/{subreddit}/new. Posts have IDs "p1", "p2", ... in base36 and
+// creation times a minute apart. Every post passes graw's validation, so the listing can
+// be served from a mock server or fed to benchmarks as a realistic page.
+func NewListing(nPosts int) []byte {
+	children := make([]any, nPosts)
+	for i := range children {
+		id := "p" + strconv.FormatInt(int64(i+1), 36)
+		children[i] = thing("t3", fixturePost(id, fixtureCreated-int64(i)*60))
+	}
+	return mustMarshal(listing(children))
+}
+
+// NewCommentTree returns the two-listing response Reddit serves from
+// /comments/{FixturePostID}: the post, then a comment tree depth levels deep in which every
+// comment above the last level has branching replies. The tree holds
+// branching + branching² + ... + branching^depth comments, so keep both small; Reddit
+// itself nests at most 10 levels per response. Comments have IDs "c1", "c2", ... in
+// depth-first order, and siblings are created a second apart, oldest first.
+func NewCommentTree(depth, branching int) []byte {
+	g := &treeGenerator{}
+	comments := g.level("t3_"+FixturePostID, 0, depth, branching)
+	post := fixturePost(FixturePostID, fixtureCreated)
+	post["num_comments"] = g.count
+	return mustMarshal([]any{
+		listing([]any{thing("t3", post)}),
+		listing(comments),
+	})
+}
+
+// treeGenerator numbers comments as NewCommentTree creates them.
+type treeGenerator struct {
+	count int
+}
+
+// level returns branching comments replying to parent, each with its own subtree, or nil
+// once depth levels have been generated.
+func (g *treeGenerator) level(parent string, current, depth, branching int) []any {
+	if current >= depth || branching <= 0 {
+		return nil
+	}
+	comments := make([]any, branching)
+	for i := range comments {
+		g.count++
+		id := "c" + strconv.FormatInt(int64(g.count), 36)
+		comment := fixtureComment(id, parent, current, fixtureCreated+int64(g.count))
+		if replies := g.level("t1_"+id, current+1, depth, branching); replies != nil {
+			comment["replies"] = listing(replies)
+		}
+		comments[i] = thing("t1", comment)
+	}
+	return comments
+}
+
+// fixturePost returns the data of a valid self post.
+func fixturePost(id string, created int64) map[string]any {
+	permalink := fmt.Sprintf("/r/%s/comments/%s/generated_post_%s/", FixtureSubreddit, id, id)
+	return map[string]any{
+		"id":              id,
+		"name":            "t3_" + id,
+		"title":           "Generated post " + id,
+		"author":          "author_" + id,
+		"subreddit":       FixtureSubreddit,
+		"subreddit_id":    fixtureSubredditID,
+		"permalink":       permalink,
+		"url":             "https://www.reddit.com" + permalink,
+		"domain":          "self." + FixtureSubreddit,
+		"is_self":         true,
+		"selftext":        "Body of generated post " + id + ".",
+		"score":           10,
+		"ups":             10,
+		"downs":           0,
+		"upvote_ratio":    0.9,
+		"num_comments":    0,
+		"created":         created,
+		"created_utc":     created,
+		"edited":          false,
+		"over_18":         false,
+		"link_flair_text": nil,
+	}
+}
+
+// fixtureComment returns the data of a valid comment at the given depth, with no replies.
+func fixtureComment(id, parent string, depth int, created int64) map[string]any {
+	return map[string]any{
+		"id":           id,
+		"name":         "t1_" + id,
+		"parent_id":    parent,
+		"link_id":      "t3_" + FixturePostID,
+		"author":       "author_" + id,
+		"body":         "Generated comment " + id + ".",
+		"subreddit":    FixtureSubreddit,
+		"subreddit_id": fixtureSubredditID,
+		"permalink":    fmt.Sprintf("/r/%s/comments/%s/generated_post_%s/%s/", FixtureSubreddit, FixturePostID, FixturePostID, id),
+		"score":        1,
+		"ups":          1,
+		"downs":        0,
+		"depth":        depth,
+		"created":      created,
+		"created_utc":  created,
+		"edited":       false,
+		"replies":      "",
+	}
+}
+
+// thing wraps data in a Thing of the given kind.
+func thing(kind string, data any) map[string]any {
+	return map[string]any{"kind": kind, "data": data}
+}
+
+// listing wraps children in a single-page Listing.
+func listing(children []any) map[string]any {
+	if children == nil {
+		children = []any{}
+	}
+	return thing("Listing", map[string]any{"after": nil, "before": nil, "children": children})
+}
+
+// mustMarshal encodes v, which is built from maps and slices and cannot fail to encode.
+func mustMarshal(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
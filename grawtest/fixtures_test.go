@@ -0,0 +1,76 @@
+package grawtest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestNewListing_PassesValidation(t *testing.T) {
+	var thing types.Thing
+	if err := json.Unmarshal(NewListing(250), &thing); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	posts, err := internal.NewParser().ExtractPosts(context.Background(), &thing)
+	if err != nil {
+		t.Fatalf("ExtractPosts returned error: %v", err)
+	}
+	if len(posts) != 250 {
+		t.Fatalf("got %d posts, want 250", len(posts))
+	}
+	seen := make(map[string]bool)
+	for i, p := range posts {
+		if seen[p.ID] {
+			t.Errorf("duplicate post ID %s", p.ID)
+		}
+		seen[p.ID] = true
+		if i > 0 && p.CreatedUTC >= posts[i-1].CreatedUTC {
+			t.Errorf("post %d is not older than post %d", i, i-1)
+		}
+	}
+}
+
+func TestNewCommentTree_PassesValidation(t *testing.T) {
+	tests := []struct {
+		depth, branching int
+		want             int
+	}{
+		{depth: 0, branching: 3, want: 0},
+		{depth: 1, branching: 5, want: 5},
+		{depth: 3, branching: 3, want: 3 + 9 + 27},
+		{depth: 10, branching: 1, want: 10},
+	}
+	for _, tt := range tests {
+		var things []*types.Thing
+		if err := json.Unmarshal(NewCommentTree(tt.depth, tt.branching), &things); err != nil {
+			t.Fatalf("Unmarshal returned error: %v", err)
+		}
+		resp, err := internal.NewParser().ExtractPostAndComments(context.Background(), things)
+		if err != nil {
+			t.Fatalf("ExtractPostAndComments(%d, %d) returned error: %v", tt.depth, tt.branching, err)
+		}
+		if resp.Post == nil || resp.Post.ID != FixturePostID || resp.Post.NumComments != tt.want {
+			t.Errorf("NewCommentTree(%d, %d) post = %+v, want %s with %d comments", tt.depth, tt.branching, resp.Post, FixturePostID, tt.want)
+		}
+
+		count, maxDepth := 0, 0
+		var walk func(comments []*types.Comment, depth int)
+		walk = func(comments []*types.Comment, depth int) {
+			for _, c := range comments {
+				count++
+				maxDepth = max(maxDepth, depth)
+				walk(c.Replies, depth+1)
+			}
+		}
+		walk(resp.Comments, 1)
+		if count != tt.want {
+			t.Errorf("NewCommentTree(%d, %d) has %d comments, want %d", tt.depth, tt.branching, count, tt.want)
+		}
+		if tt.want > 0 && maxDepth != tt.depth {
+			t.Errorf("NewCommentTree(%d, %d) is %d levels deep", tt.depth, tt.branching, maxDepth)
+		}
+	}
+}
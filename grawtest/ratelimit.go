@@ -0,0 +1,156 @@
+package grawtest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRateLimit is the number of requests a RateLimiter allows per window, matching
+	// Reddit's OAuth limit.
+	DefaultRateLimit = 600
+	// DefaultRateLimitWindow is the length of a RateLimiter's window.
+	DefaultRateLimitWindow = 10 * time.Minute
+)
+
+// RateLimitConfig configures a RateLimiter.
+type RateLimitConfig struct {
+	// Limit is the number of requests allowed per window. Defaults to DefaultRateLimit.
+	Limit int
+	// Window is the length of a rate-limit window. Defaults to DefaultRateLimitWindow.
+	Window time.Duration
+	// Now returns the current time. Set it to a fake clock for deterministic reset values.
+	// Defaults to time.Now.
+	Now func() time.Time
+}
+
+// RateLimiter is middleware that simulates Reddit's rate limiting in front of a mock API.
+// Every response carries X-Ratelimit-Used, X-Ratelimit-Remaining, and X-Ratelimit-Reset
+// headers computed from a fixed window. Once the window's requests are used up, requests are
+// answered 429 Too Many Requests with a Retry-After of the time left in the window, until
+// the window resets.
+//
+// Tests can also set the remaining quota with SetRemaining, to exercise proactive
+// throttling, and queue 429 responses with Inject429, to exercise backoff. Requests to
+// TokenPath pass through untouched, so the limiter can wrap a whole mux.
+//
+// A RateLimiter is safe for concurrent use.
+type RateLimiter struct {
+	next   http.Handler
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	used        int
+	injected    []time.Duration
+	requests    int
+	throttled   int
+}
+
+// NewRateLimiter returns a RateLimiter that serves allowed requests with next:
+//
+//	limiter := grawtest.NewRateLimiter(mux, grawtest.RateLimitConfig{Limit: 10})
+//	server := httptest.NewServer(limiter)
+func NewRateLimiter(next http.Handler, config RateLimitConfig) *RateLimiter {
+	l := &RateLimiter{next: next, limit: config.Limit, window: config.Window, now: config.Now}
+	if l.limit <= 0 {
+		l.limit = DefaultRateLimit
+	}
+	if l.window <= 0 {
+		l.window = DefaultRateLimitWindow
+	}
+	if l.now == nil {
+		l.now = time.Now
+	}
+	l.windowStart = l.now()
+	return l
+}
+
+// SetRemaining sets the quota left in the current window and the time until it resets.
+// reset is capped at the window length.
+func (l *RateLimiter) SetRemaining(remaining int, reset time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.used = min(max(l.limit-remaining, 0), l.limit)
+	l.windowStart = l.now().Add(min(reset, l.window) - l.window)
+}
+
+// Inject429 queues one 429 Too Many Requests response per value, answered to the next
+// requests in order. Each response's Retry-After header is the value in whole seconds,
+// rounded up; a zero value sends no Retry-After header. Injected responses do not use quota.
+func (l *RateLimiter) Inject429(retryAfter ...time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.injected = append(l.injected, retryAfter...)
+}
+
+// Requests returns the number of requests received, excluding token requests.
+func (l *RateLimiter) Requests() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.requests
+}
+
+// Throttled returns the number of requests answered with 429 Too Many Requests.
+func (l *RateLimiter) Throttled() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.throttled
+}
+
+// ServeHTTP implements http.Handler.
+func (l *RateLimiter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == TokenPath {
+		l.next.ServeHTTP(w, r)
+		return
+	}
+
+	l.mu.Lock()
+	l.requests++
+	now := l.now()
+	if now.Sub(l.windowStart) >= l.window {
+		l.windowStart, l.used = now, 0
+	}
+	reset := l.windowStart.Add(l.window).Sub(now)
+
+	var retryAfter time.Duration
+	throttle := false
+	switch {
+	case len(l.injected) > 0:
+		retryAfter, l.injected = l.injected[0], l.injected[1:]
+		throttle = true
+	case l.used >= l.limit:
+		retryAfter = reset
+		throttle = true
+	default:
+		l.used++
+	}
+	if throttle {
+		l.throttled++
+	}
+	used, remaining := l.used, l.limit-l.used
+	l.mu.Unlock()
+
+	h := w.Header()
+	h.Set("X-Ratelimit-Used", strconv.Itoa(used))
+	h.Set("X-Ratelimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-Ratelimit-Reset", strconv.Itoa(ceilSeconds(reset)))
+	if !throttle {
+		l.next.ServeHTTP(w, r)
+		return
+	}
+	if retryAfter > 0 {
+		h.Set("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+	}
+	writeJSON(w, http.StatusTooManyRequests, map[string]any{"message": "Too Many Requests", "error": http.StatusTooManyRequests})
+}
+
+// ceilSeconds returns d in whole seconds, rounded up.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
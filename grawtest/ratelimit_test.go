@@ -0,0 +1,86 @@
+package grawtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	mux := http.NewServeMux()
+	HandleToken(mux, StaticTokenHandler("test-token"))
+	mux.HandleFunc("/r/golang/hot", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(NewListing(1))
+	})
+	limiter := NewRateLimiter(mux, RateLimitConfig{Limit: 3, Window: time.Minute, Now: func() time.Time { return now }})
+	server := httptest.NewServer(limiter)
+	defer server.Close()
+
+	type want struct {
+		status                       int
+		used, remaining, reset, wait string
+	}
+	check := func(w want) {
+		t.Helper()
+		resp, err := server.Client().Get(server.URL + "/r/golang/hot")
+		if err != nil {
+			t.Fatalf("GET returned error: %v", err)
+		}
+		resp.Body.Close()
+		h := resp.Header
+		got := want{resp.StatusCode, h.Get("X-Ratelimit-Used"), h.Get("X-Ratelimit-Remaining"), h.Get("X-Ratelimit-Reset"), h.Get("Retry-After")}
+		if got != w {
+			t.Errorf("response = %+v, want %+v", got, w)
+		}
+	}
+
+	check(want{http.StatusOK, "1", "2", "60", ""})
+	now = now.Add(10 * time.Second)
+	check(want{http.StatusOK, "2", "1", "50", ""})
+	check(want{http.StatusOK, "3", "0", "50", ""})
+	// Quota exhausted: throttled until the window resets.
+	check(want{http.StatusTooManyRequests, "3", "0", "50", "50"})
+
+	limiter.Inject429(1500*time.Millisecond, 0)
+	now = now.Add(50 * time.Second)
+	check(want{http.StatusTooManyRequests, "0", "3", "60", "2"})
+	check(want{http.StatusTooManyRequests, "0", "3", "60", ""})
+	check(want{http.StatusOK, "1", "2", "60", ""})
+
+	limiter.SetRemaining(1, 5*time.Second)
+	check(want{http.StatusOK, "3", "0", "5", ""})
+
+	// Token requests are neither counted nor limited.
+	resp, err := server.Client().Post(server.URL+TokenPath, "application/x-www-form-urlencoded", strings.NewReader("grant_type=client_credentials"))
+	if err != nil {
+		t.Fatalf("POST returned error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.Header.Get("X-Ratelimit-Used") != "" {
+		t.Error("token response has rate-limit headers")
+	}
+	if limiter.Requests() != 8 || limiter.Throttled() != 3 {
+		t.Errorf("Requests() = %d, Throttled() = %d, want 8 and 3", limiter.Requests(), limiter.Throttled())
+	}
+}
+
+func TestRateLimiter_HeadersParse(t *testing.T) {
+	limiter := NewRateLimiter(http.NotFoundHandler(), RateLimitConfig{})
+	limiter.SetRemaining(42, 30*time.Second)
+	rec := httptest.NewRecorder()
+	limiter.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/r/golang/new", nil))
+
+	now := time.Now()
+	info, ok := internal.ParseRateLimitHeaders(rec.Header(), now)
+	if !ok {
+		t.Fatal("ParseRateLimitHeaders found no headers")
+	}
+	if info.Used != DefaultRateLimit-41 || info.Remaining != 41 || !info.ResetAt.Equal(now.Add(30*time.Second)) {
+		t.Errorf("info = %+v", info)
+	}
+}
@@ -0,0 +1,104 @@
+package grawtest
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// TokenPath is the path of Reddit's OAuth2 token endpoint, relative to the auth URL.
+const TokenPath = "/api/v1/access_token"
+
+// TokenRequest is a token request as sent by graw's authenticator: a form POST with the
+// client credentials in basic auth.
+type TokenRequest struct {
+	GrantType    string // "client_credentials", "password", "authorization_code", or "refresh_token"
+	Username     string // Set for the password grant
+	Password     string // Set for the password grant
+	Code         string // Set for the authorization_code grant
+	RedirectURI  string // Set for the authorization_code grant
+	RefreshToken string // Set for the refresh_token grant
+	ClientID     string
+	ClientSecret string // Empty for installed apps
+	UserAgent    string
+}
+
+// TokenResponse is the JSON body Reddit returns from the token endpoint.
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+	// RefreshToken is issued by the authorization_code grant for permanent authorizations,
+	// and may be rotated by the refresh_token grant.
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// NewTokenResponse returns a bearer token response for accessToken that expires in an hour.
+func NewTokenResponse(accessToken string) TokenResponse {
+	return TokenResponse{AccessToken: accessToken, TokenType: "bearer", ExpiresIn: 3600, Scope: "*"}
+}
+
+// ParseTokenRequest reads a token request. It returns ok false if r is not a form POST with
+// basic auth and a grant_type, which Reddit would reject.
+func ParseTokenRequest(r *http.Request) (req *TokenRequest, ok bool) {
+	if r.Method != http.MethodPost {
+		return nil, false
+	}
+	clientID, clientSecret, hasAuth := r.BasicAuth()
+	if !hasAuth || r.ParseForm() != nil || r.PostForm.Get("grant_type") == "" {
+		return nil, false
+	}
+	return &TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Username:     r.PostForm.Get("username"),
+		Password:     r.PostForm.Get("password"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		UserAgent:    r.UserAgent(),
+	}, true
+}
+
+// TokenHandler returns a handler that answers valid token requests with the response from
+// issue. When issue returns nil, the handler responds 401 Unauthorized, as Reddit does for
+// bad credentials. Malformed requests get 400 Bad Request.
+func TokenHandler(issue func(*TokenRequest) *TokenResponse) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, ok := ParseTokenRequest(r)
+		if !ok {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+			return
+		}
+		resp := issue(req)
+		if resp == nil {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"message": "Unauthorized", "error": http.StatusUnauthorized})
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+// StaticTokenHandler returns a handler that issues NewTokenResponse(accessToken) to every
+// valid token request.
+func StaticTokenHandler(accessToken string) http.Handler {
+	resp := NewTokenResponse(accessToken)
+	return TokenHandler(func(*TokenRequest) *TokenResponse { return &resp })
+}
+
+// HandleToken registers handler at TokenPath on mux:
+//
+//	mux := http.NewServeMux()
+//	grawtest.HandleToken(mux, grawtest.StaticTokenHandler("test-token"))
+//	server := httptest.NewServer(mux)
+func HandleToken(mux *http.ServeMux, handler http.Handler) {
+	mux.Handle(TokenPath, handler)
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
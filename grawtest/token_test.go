@@ -0,0 +1,94 @@
+package grawtest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestTokenHandler_WithAuthenticator(t *testing.T) {
+	var got *TokenRequest
+	mux := http.NewServeMux()
+	HandleToken(mux, TokenHandler(func(req *TokenRequest) *TokenResponse {
+		got = req
+		if req.Password != "hunter2" {
+			return nil
+		}
+		resp := NewTokenResponse("abc")
+		return &resp
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	auth, err := internal.NewAuthenticator(server.Client(), "gopher", "hunter2", "id", "secret", "test/1.0", server.URL, "password", nil)
+	if err != nil {
+		t.Fatalf("NewAuthenticator returned error: %v", err)
+	}
+	token, err := auth.GetToken(context.Background())
+	if err != nil {
+		t.Fatalf("GetToken returned error: %v", err)
+	}
+	if token != "abc" {
+		t.Errorf("token = %q, want abc", token)
+	}
+	want := TokenRequest{GrantType: "password", Username: "gopher", Password: "hunter2", ClientID: "id", ClientSecret: "secret", UserAgent: "test/1.0"}
+	if got == nil || *got != want {
+		t.Errorf("token request = %+v, want %+v", got, want)
+	}
+
+	bad, err := internal.NewAuthenticator(server.Client(), "gopher", "wrong", "id", "secret", "test/1.0", server.URL, "password", nil)
+	if err != nil {
+		t.Fatalf("NewAuthenticator returned error: %v", err)
+	}
+	_, err = bad.GetToken(context.Background())
+	var authErr *pkgerrs.AuthError
+	if !errors.As(err, &authErr) || authErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("GetToken() error = %v, want 401 AuthError", err)
+	}
+}
+
+func TestStaticTokenHandler_RejectsMalformed(t *testing.T) {
+	handler := StaticTokenHandler("abc")
+
+	tests := []struct {
+		name string
+		req  func() *http.Request
+	}{
+		{"get", func() *http.Request { return httptest.NewRequest(http.MethodGet, TokenPath, nil) }},
+		{"no basic auth", func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader("grant_type=client_credentials"))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			return r
+		}},
+		{"no grant type", func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(""))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.SetBasicAuth("id", "secret")
+			return r
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, tt.req())
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", rec.Code)
+			}
+		})
+	}
+
+	r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader("grant_type=client_credentials"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.SetBasicAuth("id", "secret")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, r)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"access_token":"abc"`) {
+		t.Errorf("valid request: status %d, body %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,123 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const (
+	// trackJitter is the fraction by which TrackSubscriberCount varies each interval, so
+	// that trackers started together do not fetch in lockstep.
+	trackJitter = 0.1
+	// trackMaxBackoffFactor caps the delay after repeated failures, as a multiple of the interval.
+	trackMaxBackoffFactor = 8
+)
+
+// SubredditStatsSink receives the samples recorded by TrackSubscriberCount.
+type SubredditStatsSink interface {
+	RecordSubredditStats(ctx context.Context, stats types.SubredditStats) error
+}
+
+// SubredditStatsSinkFunc adapts a function to the SubredditStatsSink interface.
+type SubredditStatsSinkFunc func(ctx context.Context, stats types.SubredditStats) error
+
+// RecordSubredditStats calls f.
+func (f SubredditStatsSinkFunc) RecordSubredditStats(ctx context.Context, stats types.SubredditStats) error {
+	return f(ctx, stats)
+}
+
+// TrackSubscriberCount records a subreddit's subscriber and active-user counts in sink
+// every interval, starting immediately, until ctx is cancelled. Each interval is varied by
+// up to 10% so that many trackers spread their requests out. An interval of zero uses
+// DefaultTrackInterval. Each fetch bypasses the GetSubreddit cache.
+//
+// Fetch failures are logged and retried with exponential backoff, doubling the delay after
+// each consecutive failure up to 8 times the interval; the first success restores the
+// normal interval.
+//
+// Returns an error if the subreddit name is invalid, sink is nil, or sink returns an
+// error. Otherwise it returns ctx.Err() once ctx is cancelled.
+func (r *Reddit) TrackSubscriberCount(ctx context.Context, subreddit string, interval time.Duration, sink SubredditStatsSink) error {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return err
+	}
+	if sink == nil {
+		return &pkgerrs.ConfigError{Field: "sink", Message: "subreddit stats sink cannot be nil"}
+	}
+	if interval <= 0 {
+		interval = DefaultTrackInterval
+	}
+
+	failures := 0
+	var delay time.Duration
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		var sub *types.SubredditData
+		err := r.safeCall(ctx, "track subscriber count", func() (err error) {
+			sub, err = r.fetchSubreddit(ctx, subreddit)
+			return err
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failures++
+			delay = trackBackoff(interval, failures)
+			var degraded *pkgerrs.ServiceDegradedError
+			if errors.As(err, &degraded) {
+				delay = max(delay, degraded.RetryAfter)
+			}
+			if r.config != nil && r.config.Logger != nil {
+				r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "subscriber count fetch failed",
+					slog.String("subreddit", subreddit),
+					slog.Int("failures", failures),
+					slog.Duration("retry_in", delay),
+					slog.String("error", err.Error()))
+			}
+			continue
+		}
+
+		failures = 0
+		delay = jitter(interval, trackJitter)
+		stats := types.SubredditStats{
+			Subreddit:   sub.DisplayName,
+			Time:        time.Now(),
+			Subscribers: sub.Subscribers,
+			ActiveUsers: sub.AccountsActive,
+		}
+		if stats.Subreddit == "" {
+			stats.Subreddit = subreddit
+		}
+		if err := sink.RecordSubredditStats(ctx, stats); err != nil {
+			return err
+		}
+	}
+}
+
+// trackBackoff returns the delay after the given number of consecutive failures.
+func trackBackoff(interval time.Duration, failures int) time.Duration {
+	limit := interval * trackMaxBackoffFactor
+	delay := interval
+	for i := 1; i < failures && delay < limit; i++ {
+		delay *= 2
+	}
+	return min(delay, limit)
+}
+
+// jitter returns d varied randomly by up to fraction of its length in either direction.
+func jitter(d time.Duration, fraction float64) time.Duration {
+	spread := float64(d) * fraction
+	return d + time.Duration((rand.Float64()*2-1)*spread)
+}
@@ -0,0 +1,104 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestTrackSubscriberCount(t *testing.T) {
+	var fetches int
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			fetches++
+			if fetches == 2 {
+				return errors.New("temporary failure")
+			}
+			data, err := json.Marshal(map[string]any{
+				"id":              "2qh1i",
+				"name":            "t5_2qh1i",
+				"display_name":    "golang",
+				"subscribers":     1000 + fetches,
+				"accounts_active": 42,
+			})
+			if err != nil {
+				t.Fatalf("marshal subreddit: %v", err)
+			}
+			*v = types.Thing{Kind: "t5", Data: data}
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var got []types.SubredditStats
+	sink := SubredditStatsSinkFunc(func(_ context.Context, s types.SubredditStats) error {
+		got = append(got, s)
+		if len(got) == 2 {
+			cancel()
+		}
+		return nil
+	})
+
+	err := client.TrackSubscriberCount(ctx, "r/golang", 5*time.Millisecond, sink)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("TrackSubscriberCount() error = %v, want context.Canceled", err)
+	}
+	if fetches != 3 || len(got) != 2 {
+		t.Fatalf("fetches = %d, samples = %d; want 3 and 2", fetches, len(got))
+	}
+	if got[0].Subreddit != "golang" || got[0].Subscribers != 1001 || got[0].ActiveUsers != 42 || got[1].Subscribers != 1003 {
+		t.Errorf("samples = %+v", got)
+	}
+}
+
+func TestTrackSubscriberCount_Errors(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = types.Thing{Kind: "t5", Data: json.RawMessage(`{"id":"2qh1i","name":"t5_2qh1i","display_name":"golang"}`)}
+			return nil
+		},
+	}, nil)
+	nopSink := SubredditStatsSinkFunc(func(context.Context, types.SubredditStats) error { return nil })
+
+	if err := client.TrackSubscriberCount(context.Background(), "bad name!", time.Second, nopSink); err == nil {
+		t.Error("expected error for invalid subreddit name")
+	}
+	if err := client.TrackSubscriberCount(context.Background(), "golang", time.Second, nil); err == nil {
+		t.Error("expected error for nil sink")
+	}
+	sinkErr := errors.New("disk full")
+	failing := SubredditStatsSinkFunc(func(context.Context, types.SubredditStats) error { return sinkErr })
+	if err := client.TrackSubscriberCount(context.Background(), "golang", time.Hour, failing); !errors.Is(err, sinkErr) {
+		t.Errorf("error = %v, want %v", err, sinkErr)
+	}
+}
+
+func TestTrackBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{4, 8 * time.Minute},
+		{10, 8 * time.Minute},
+	}
+	for _, tt := range tests {
+		if got := trackBackoff(time.Minute, tt.failures); got != tt.want {
+			t.Errorf("trackBackoff(1m, %d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+
+	for range 100 {
+		if d := jitter(time.Minute, 0.1); d < 54*time.Second || d > 66*time.Second {
+			t.Fatalf("jitter(1m, 0.1) = %v, want within 10%%", d)
+		}
+	}
+}
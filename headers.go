@@ -0,0 +1,115 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// reservedHeaders are set by the client itself and cannot be replaced by ExtraHeaders
+// or a HeaderProvider.
+var reservedHeaders = map[string]bool{
+	"Authorization":  true,
+	"User-Agent":     true,
+	"Content-Type":   true,
+	"Content-Length": true,
+	"Host":           true,
+}
+
+// HeaderProvider supplies headers for each API request, such as a signature or a
+// short-lived token required by an API gateway. It is called after the client has set its
+// own headers, so implementations can sign the finished request. Implementations must be
+// safe for concurrent use.
+type HeaderProvider interface {
+	RequestHeaders(ctx context.Context, req *http.Request) (http.Header, error)
+}
+
+// HeaderProviderFunc adapts an ordinary function to the HeaderProvider interface.
+type HeaderProviderFunc func(ctx context.Context, req *http.Request) (http.Header, error)
+
+// RequestHeaders calls f(ctx, req).
+func (f HeaderProviderFunc) RequestHeaders(ctx context.Context, req *http.Request) (http.Header, error) {
+	return f(ctx, req)
+}
+
+// validateExtraHeaders checks that headers are well formed and do not replace a reserved header.
+func validateExtraHeaders(field string, headers http.Header) error {
+	for name, values := range headers {
+		if err := validateHeaderName(name); err != nil {
+			return &pkgerrs.ConfigError{Field: field, Message: err.Error()}
+		}
+		for _, value := range values {
+			if strings.ContainsAny(value, "\r\n\x00") {
+				return &pkgerrs.ConfigError{Field: field, Message: fmt.Sprintf("header %s has an invalid value", name)}
+			}
+		}
+	}
+	return nil
+}
+
+// validateHeaderName rejects empty, malformed, and reserved header names.
+func validateHeaderName(name string) error {
+	if name == "" {
+		return fmt.Errorf("header name is empty")
+	}
+	for _, c := range name {
+		if c <= ' ' || c >= 0x7f || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, c) {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+	}
+	if reservedHeaders[http.CanonicalHeaderKey(name)] {
+		return fmt.Errorf("header %s is set by the client and cannot be overridden", http.CanonicalHeaderKey(name))
+	}
+	return nil
+}
+
+// validateLocale checks that locale looks like a BCP 47 language tag.
+func validateLocale(locale string) error {
+	if locale == "" {
+		return nil
+	}
+	for i, part := range strings.Split(locale, "-") {
+		valid := len(part) >= 1 && len(part) <= 8
+		for _, c := range part {
+			isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+			valid = valid && (isLetter || (i > 0 && c >= '0' && c <= '9'))
+		}
+		if !valid || (i == 0 && len(part) < 2) {
+			return &pkgerrs.ConfigError{Field: "Locale", Message: fmt.Sprintf("invalid language tag %q", locale)}
+		}
+	}
+	return nil
+}
+
+// addExtraHeaders applies the configured Locale, ExtraHeaders, and HeaderProvider to req.
+func (r *Reddit) addExtraHeaders(ctx context.Context, req *http.Request) error {
+	if r.config == nil {
+		return nil
+	}
+	if r.config.Locale != "" {
+		req.Header.Set("Accept-Language", r.config.Locale)
+	}
+	for name, value := range r.config.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
+	if r.config.HeaderProvider == nil {
+		return nil
+	}
+	headers, err := r.config.HeaderProvider.RequestHeaders(ctx, req)
+	if err != nil {
+		return fmt.Errorf("header provider failed: %w", err)
+	}
+	if err := validateExtraHeaders("HeaderProvider", headers); err != nil {
+		return err
+	}
+	for name, values := range headers {
+		req.Header.Del(name)
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	return nil
+}
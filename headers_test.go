@@ -0,0 +1,127 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestExtraHeadersAndHeaderProvider(t *testing.T) {
+	var got *http.Request
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			got = req
+			*v = *listingThing(t)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	client.config.ExtraHeaders = map[string]string{"X-Gateway-Key": "key"}
+	client.config.HeaderProvider = HeaderProviderFunc(func(ctx context.Context, req *http.Request) (http.Header, error) {
+		if req.Header.Get("Authorization") == "" || req.Header.Get("X-Gateway-Key") != "key" {
+			t.Error("provider called before the client's headers were set")
+		}
+		return http.Header{"X-Signature": {"sig:" + req.URL.Path}}, nil
+	})
+
+	if _, err := client.GetNew(context.Background(), &types.PostsRequest{Subreddit: "golang"}); err != nil {
+		t.Fatalf("GetNew returned error: %v", err)
+	}
+	if got.Header.Get("X-Gateway-Key") != "key" || got.Header.Get("X-Signature") != "sig:/r/golang/new" {
+		t.Errorf("headers = %v", got.Header)
+	}
+
+	client.config.HeaderProvider = HeaderProviderFunc(func(ctx context.Context, req *http.Request) (http.Header, error) {
+		return http.Header{"Authorization": {"Basic abc"}}, nil
+	})
+	_, err := client.GetNew(context.Background(), &types.PostsRequest{Subreddit: "golang"})
+	var authErr *pkgerrs.AuthError
+	if !errors.As(err, &authErr) {
+		t.Errorf("expected AuthError when the provider overrides Authorization, got %v", err)
+	}
+
+	client.config.HeaderProvider = HeaderProviderFunc(func(ctx context.Context, req *http.Request) (http.Header, error) {
+		return nil, errors.New("signer unavailable")
+	})
+	if _, err := client.GetNew(context.Background(), &types.PostsRequest{Subreddit: "golang"}); !errors.As(err, &authErr) {
+		t.Errorf("expected AuthError when the provider fails, got %v", err)
+	}
+}
+
+func TestNewClient_InvalidExtraHeaders(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"stub","token_type":"bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	for name, headers := range map[string]map[string]string{
+		"reserved":      {"user-agent": "other"},
+		"invalid name":  {"X Gateway": "key"},
+		"invalid value": {"X-Gateway-Key": "key\r\nInjected: 1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewClient(&Config{
+				ClientID:     "id",
+				ClientSecret: "secret",
+				UserAgent:    "tester",
+				AuthURL:      tokenServer.URL + "/",
+				BaseURL:      tokenServer.URL + "/",
+				HTTPClient:   tokenServer.Client(),
+				ExtraHeaders: headers,
+			})
+			var configErr *pkgerrs.ConfigError
+			if !errors.As(err, &configErr) || configErr.Field != "ExtraHeaders" {
+				t.Errorf("expected ExtraHeaders ConfigError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLocale(t *testing.T) {
+	var got *http.Request
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			got = req
+			*v = *listingThing(t)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	client.config.Locale = "pt-BR"
+
+	if _, err := client.GetNew(context.Background(), &types.PostsRequest{Subreddit: "golang"}); err != nil {
+		t.Fatalf("GetNew returned error: %v", err)
+	}
+	if lang := got.Header.Get("Accept-Language"); lang != "pt-BR" {
+		t.Errorf("Accept-Language = %q, want pt-BR", lang)
+	}
+
+	// An explicit ExtraHeaders entry takes precedence.
+	client.config.ExtraHeaders = map[string]string{"Accept-Language": "pt-BR, en;q=0.5"}
+	if _, err := client.GetNew(context.Background(), &types.PostsRequest{Subreddit: "golang"}); err != nil {
+		t.Fatalf("GetNew returned error: %v", err)
+	}
+	if lang := got.Header.Get("Accept-Language"); lang != "pt-BR, en;q=0.5" {
+		t.Errorf("Accept-Language = %q, want the ExtraHeaders value", lang)
+	}
+}
+
+func TestValidateLocale(t *testing.T) {
+	for _, locale := range []string{"", "de", "pt-BR", "zh-Hant-TW", "es-419"} {
+		if err := validateLocale(locale); err != nil {
+			t.Errorf("validateLocale(%q) error = %v", locale, err)
+		}
+	}
+	for _, locale := range []string{"e", "de_DE", "en-", "de-DE\r\nX: 1", "1a", "toolonglanguage"} {
+		var configErr *pkgerrs.ConfigError
+		if err := validateLocale(locale); !errors.As(err, &configErr) || configErr.Field != "Locale" {
+			t.Errorf("validateLocale(%q) error = %v, want Locale ConfigError", locale, err)
+		}
+	}
+}
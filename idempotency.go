@@ -0,0 +1,222 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// duplicateSearchLimit is how many of the subreddit's newest posts are checked
+// when looking for a submission whose outcome was unknown.
+const duplicateSearchLimit = 50
+
+// duplicateClockSkew allows for differences between local and Reddit clocks when
+// matching a post's creation time against the original attempt.
+const duplicateClockSkew = 5 * time.Minute
+
+// submissionState tracks one idempotency key.
+type submissionState struct {
+	// firstAttempt is when the key was first submitted.
+	firstAttempt time.Time
+	// inFlight is set while a submission with this key is running.
+	inFlight bool
+	// result is set once the submission is known to have succeeded.
+	result *types.SubmitResponse
+}
+
+// submissionLedger remembers SubmitRequest idempotency keys for SubmissionIdempotencyWindow.
+// The zero value is ready to use.
+type submissionLedger struct {
+	mu      sync.Mutex
+	entries map[string]*submissionState
+}
+
+// begin marks key as in flight, returning its prior state (nil if unseen).
+// It fails if another submission with the same key is already running.
+func (l *submissionLedger) begin(key string, now time.Time) (*submissionState, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.entries == nil {
+		l.entries = make(map[string]*submissionState)
+	}
+	for k, st := range l.entries {
+		if !st.inFlight && now.Sub(st.firstAttempt) > SubmissionIdempotencyWindow {
+			delete(l.entries, k)
+		}
+	}
+
+	st, ok := l.entries[key]
+	if !ok {
+		l.entries[key] = &submissionState{firstAttempt: now, inFlight: true}
+		return nil, nil
+	}
+	if st.inFlight {
+		return nil, &pkgerrs.StateError{Operation: "submit", Message: "a submission with idempotency key " + key + " is already in progress"}
+	}
+	prior := *st
+	st.inFlight = true
+	return &prior, nil
+}
+
+// finish records the outcome of an attempt. A nil result with forget set discards the key,
+// so that a definitively rejected submission can be retried from scratch.
+func (l *submissionLedger) finish(key string, result *types.SubmitResponse, forget bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	st, ok := l.entries[key]
+	if !ok {
+		return
+	}
+	if forget {
+		delete(l.entries, key)
+		return
+	}
+	st.inFlight = false
+	if result != nil {
+		st.result = result
+	}
+}
+
+// submitIdempotent runs send unless request.IdempotencyKey shows the post already exists.
+func (r *Reddit) submitIdempotent(ctx context.Context, request *types.SubmitRequest, send func() (*types.SubmitResponse, error)) (*types.SubmitResponse, error) {
+	key := request.IdempotencyKey
+	if key == "" {
+		return send()
+	}
+
+	prior, err := r.submissions.begin(key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if prior != nil {
+		if prior.result != nil {
+			r.submissions.finish(key, nil, false)
+			reused := *prior.result
+			reused.Reused = true
+			return &reused, nil
+		}
+
+		// An earlier attempt failed without a definitive answer; check whether it went through.
+		existing, err := r.findSubmittedDuplicate(ctx, request, prior.firstAttempt)
+		if err != nil {
+			r.submissions.finish(key, nil, false)
+			return nil, err
+		}
+		if existing != nil {
+			r.submissions.finish(key, existing, false)
+			return existing, nil
+		}
+	}
+
+	result, err := send()
+	switch {
+	case err == nil:
+		r.submissions.finish(key, result, false)
+	case isAmbiguousSubmitError(err):
+		// Keep the key so the next attempt checks for a duplicate before posting.
+		r.submissions.finish(key, nil, false)
+	default:
+		r.submissions.finish(key, nil, true)
+	}
+	return result, err
+}
+
+// findSubmittedDuplicate looks for a post matching request that the authenticated user
+// created since the given attempt, returning nil if none is found.
+func (r *Reddit) findSubmittedDuplicate(ctx context.Context, request *types.SubmitRequest, since time.Time) (*types.SubmitResponse, error) {
+	author, err := r.submittingUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := r.GetNew(ctx, &types.PostsRequest{
+		Subreddit:  request.Subreddit,
+		Pagination: types.Pagination{Limit: duplicateSearchLimit},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := float64(since.Add(-duplicateClockSkew).Unix())
+	for _, post := range recent.Posts {
+		if post == nil || post.CreatedUTC < cutoff {
+			continue
+		}
+		if !strings.EqualFold(post.Author, author) || post.Title != request.Title {
+			continue
+		}
+		if request.Kind == types.SubmitKindLink && post.URL != request.URL {
+			continue
+		}
+		return &types.SubmitResponse{
+			ID:     post.ID,
+			Name:   post.Name,
+			URL:    "https://www.reddit.com" + post.Permalink,
+			Reused: true,
+		}, nil
+	}
+	return nil, nil
+}
+
+// findCommentDuplicate looks for a comment with text replying to parent that the
+// authenticated user created since the given attempt, returning nil if none is found.
+func (r *Reddit) findCommentDuplicate(ctx context.Context, parent, text string, since time.Time) (*types.Comment, error) {
+	author, err := r.submittingUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recent, err := r.GetUserComments(ctx, author, WithLimit(duplicateSearchLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := float64(since.Add(-duplicateClockSkew).Unix())
+	for _, comment := range recent.Items {
+		if comment == nil || comment.CreatedUTC < cutoff {
+			continue
+		}
+		if comment.ParentID == parent && strings.TrimSpace(comment.Body) == strings.TrimSpace(text) {
+			return comment, nil
+		}
+	}
+	return nil, nil
+}
+
+// submittingUser returns the username posts are submitted as, preferring the configured
+// Username over a call to Me.
+func (r *Reddit) submittingUser(ctx context.Context) (string, error) {
+	if r.config != nil && r.config.Username != "" {
+		return r.config.Username, nil
+	}
+	me, err := r.Me(ctx)
+	if err != nil {
+		return "", err
+	}
+	return me.Name, nil
+}
+
+// isAmbiguousSubmitError reports whether a failed submission may still have created the post,
+// e.g. because the request timed out or Reddit returned a server error.
+func isAmbiguousSubmitError(err error) bool {
+	var apiErr *pkgerrs.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	var reqErr *pkgerrs.RequestError
+	if errors.As(err, &reqErr) {
+		// Requests that could not be built were never sent
+		return reqErr.Operation != "create request"
+	}
+	var parseErr *pkgerrs.ParseError
+	return errors.As(err, &parseErr) || errors.Is(err, context.DeadlineExceeded)
+}
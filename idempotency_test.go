@@ -0,0 +1,219 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// submitPostThing builds a t3 Thing as returned in a subreddit's new listing.
+func submitPostThing(t *testing.T, id, title, author string, created time.Time) *types.Thing {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"id":           id,
+		"name":         "t3_" + id,
+		"title":        title,
+		"author":       author,
+		"subreddit":    "golang",
+		"subreddit_id": "t5_2qh1i",
+		"permalink":    "/r/golang/comments/" + id + "/slug/",
+		"url":          "https://www.reddit.com/r/golang/comments/" + id + "/slug/",
+		"created":      float64(created.Unix()),
+		"created_utc":  float64(created.Unix()),
+		"score":        1,
+		"ups":          1,
+	})
+	if err != nil {
+		t.Fatalf("marshal post: %v", err)
+	}
+	return &types.Thing{Kind: "t3", Data: data}
+}
+
+// submitMock answers /api/submit with submitFunc, and r/golang/new with the given posts.
+func submitMock(t *testing.T, submitFunc func() error, listing ...*types.Thing) *mockHTTPClient {
+	return &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.URL.Path != "/"+SubmitURL {
+				t.Errorf("unexpected POST %s", req.URL.Path)
+			}
+			if err := submitFunc(); err != nil {
+				return err
+			}
+			return json.Unmarshal([]byte(`{"json":{"errors":[],"data":{"id":"new1","name":"t3_new1","url":"https://www.reddit.com/r/golang/comments/new1/"}}}`), v)
+		},
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			if !strings.HasSuffix(req.URL.Path, "/r/golang/new") {
+				t.Errorf("unexpected GET %s", req.URL.Path)
+			}
+			*v = *listingThing(t, listing...)
+			return nil
+		},
+	}
+}
+
+// newSubmitTestClient returns a test client authenticated as the user "Gopher".
+func newSubmitTestClient(mock *mockHTTPClient) *Reddit {
+	client := newTestClient(mock, nil)
+	client.config.Username = "Gopher"
+	return client
+}
+
+func TestSubmitPost_IdempotencyKey(t *testing.T) {
+	request := func() *types.SubmitRequest {
+		return &types.SubmitRequest{
+			Subreddit:      "golang",
+			Title:          "Hello gophers",
+			Kind:           types.SubmitKindSelf,
+			Text:           "body",
+			IdempotencyKey: "key-1",
+		}
+	}
+
+	t.Run("repeated key after success returns the first post", func(t *testing.T) {
+		var calls int32
+		client := newSubmitTestClient(submitMock(t, func() error {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}))
+
+		first, err := client.SubmitPost(context.Background(), request())
+		if err != nil {
+			t.Fatalf("first SubmitPost: %v", err)
+		}
+		if first.Name != "t3_new1" || first.Reused {
+			t.Errorf("first = %+v", first)
+		}
+		second, err := client.SubmitPost(context.Background(), request())
+		if err != nil {
+			t.Fatalf("second SubmitPost: %v", err)
+		}
+		if second.Name != "t3_new1" || !second.Reused {
+			t.Errorf("second = %+v, want reused t3_new1", second)
+		}
+		if calls != 1 {
+			t.Errorf("submit called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("retry after timeout finds the existing post", func(t *testing.T) {
+		var calls int32
+		existing := submitPostThing(t, "dup1", "Hello gophers", "gopher", time.Now())
+		client := newSubmitTestClient(submitMock(t, func() error {
+			atomic.AddInt32(&calls, 1)
+			return context.DeadlineExceeded
+		}, existing))
+
+		if _, err := client.SubmitPost(context.Background(), request()); err == nil {
+			t.Fatal("expected first attempt to fail")
+		}
+		resp, err := client.SubmitPost(context.Background(), request())
+		if err != nil {
+			t.Fatalf("retry SubmitPost: %v", err)
+		}
+		if resp.Name != "t3_dup1" || !resp.Reused {
+			t.Errorf("retry = %+v, want reused t3_dup1", resp)
+		}
+		if calls != 1 {
+			t.Errorf("submit called %d times, want 1", calls)
+		}
+	})
+
+	t.Run("retry after timeout submits when no post exists", func(t *testing.T) {
+		var calls int32
+		stale := submitPostThing(t, "old1", "Hello gophers", "gopher", time.Now().Add(-time.Hour))
+		other := submitPostThing(t, "oth1", "Hello gophers", "someone_else", time.Now())
+		client := newSubmitTestClient(submitMock(t, func() error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return &pkgerrs.APIError{StatusCode: http.StatusBadGateway, Message: "bad gateway"}
+			}
+			return nil
+		}, stale, other))
+
+		if _, err := client.SubmitPost(context.Background(), request()); err == nil {
+			t.Fatal("expected first attempt to fail")
+		}
+		resp, err := client.SubmitPost(context.Background(), request())
+		if err != nil {
+			t.Fatalf("retry SubmitPost: %v", err)
+		}
+		if resp.Name != "t3_new1" || resp.Reused {
+			t.Errorf("retry = %+v, want new t3_new1", resp)
+		}
+		if calls != 2 {
+			t.Errorf("submit called %d times, want 2", calls)
+		}
+	})
+
+	t.Run("definitive rejection forgets the key", func(t *testing.T) {
+		var calls int32
+		client := newSubmitTestClient(submitMock(t, func() error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return &pkgerrs.APIError{StatusCode: http.StatusBadRequest, Message: "bad request"}
+			}
+			return nil
+		}))
+
+		if _, err := client.SubmitPost(context.Background(), request()); err == nil {
+			t.Fatal("expected first attempt to fail")
+		}
+		resp, err := client.SubmitPost(context.Background(), request())
+		if err != nil {
+			t.Fatalf("retry SubmitPost: %v", err)
+		}
+		if resp.Reused {
+			t.Errorf("retry = %+v, want a fresh submission", resp)
+		}
+	})
+
+	t.Run("concurrent use of a key is rejected", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{})
+		client := newSubmitTestClient(submitMock(t, func() error {
+			close(started)
+			<-release
+			return nil
+		}))
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := client.SubmitPost(context.Background(), request())
+			done <- err
+		}()
+		<-started
+
+		_, err := client.SubmitPost(context.Background(), request())
+		var stateErr *pkgerrs.StateError
+		if !errors.As(err, &stateErr) {
+			t.Errorf("expected StateError for concurrent key, got %v", err)
+		}
+		close(release)
+		if err := <-done; err != nil {
+			t.Errorf("first SubmitPost: %v", err)
+		}
+	})
+}
+
+func TestSubmissionLedger_Expires(t *testing.T) {
+	var l submissionLedger
+	start := time.Now()
+	if _, err := l.begin("k", start); err != nil {
+		t.Fatal(err)
+	}
+	l.finish("k", &types.SubmitResponse{Name: "t3_a"}, false)
+
+	prior, err := l.begin("k", start.Add(SubmissionIdempotencyWindow+time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prior != nil {
+		t.Errorf("expected expired key to be forgotten, got %+v", prior)
+	}
+}
@@ -0,0 +1,263 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
+)
+
+// GetInfo looks up posts and comments by fullname (e.g. "t3_abc123", "t1_def456").
+// Items that no longer exist or are not visible to the client are omitted from the result,
+// so the response may contain fewer items than requested.
+//
+// Returns an error if:
+//   - No fullnames are provided, or more than MaxInfoFullnames
+//   - Any fullname is malformed
+//   - The API request fails
+func (r *Reddit) GetInfo(ctx context.Context, fullnames []string) (*types.InfoResponse, error) {
+	if len(fullnames) == 0 {
+		return nil, &pkgerrs.ConfigError{Field: "fullnames", Message: "at least one fullname is required"}
+	}
+	if len(fullnames) > MaxInfoFullnames {
+		return nil, &pkgerrs.ConfigError{
+			Field:   "fullnames",
+			Message: fmt.Sprintf("too many fullnames: %d (max %d)", len(fullnames), MaxInfoFullnames),
+		}
+	}
+	for _, name := range fullnames {
+		if err := r.validator.ValidatePaginationToken(name); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, rateLimit := r.recordResponse(ctx)
+	params := url.Values{}
+	params.Set("id", strings.Join(fullnames, ","))
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, InfoURL, nil, params)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: InfoURL, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var result types.Thing
+	if err := r.httpClient.Do(req, &result); err != nil {
+		return nil, wrapDoError(err, "get info", InfoURL)
+	}
+
+	parsed, err := r.parser.ParseThing(ctx, &result)
+	if err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "parse info", Err: err}
+	}
+	listing, ok := parsed.(*types.ListingData)
+	if !ok {
+		return nil, &pkgerrs.ParseError{Operation: "parse info", Err: fmt.Errorf("expected Listing, got %s", result.Kind)}
+	}
+
+	response := &types.InfoResponse{RateLimit: rateLimit.Info(), Meta: rateLimit.Meta()}
+	for _, child := range listing.Children {
+		item, err := r.parser.ParseThing(ctx, child)
+		if err != nil {
+			return nil, &pkgerrs.ParseError{Operation: "parse info", Err: err}
+		}
+		switch v := item.(type) {
+		case *types.Post:
+			r.attachSubredditInfo(v)
+			response.Posts = append(response.Posts, v)
+		case *types.Comment:
+			response.Comments = append(response.Comments, v)
+		}
+	}
+	return response, nil
+}
+
+// GetSubreddits looks up many subreddits by name in one /api/info request and returns their
+// data keyed by name, as given without any "r/" prefix. It suits dashboards that track
+// many communities, where calling GetSubreddit for each would cost one request apiece.
+//
+// Subreddits found in the GetSubreddit cache are not requested again, and the results are
+// cached for GetSubreddit. Subreddits that do not exist or are not visible to the client are
+// missing from the map. Names are matched case-insensitively; as with GetSubreddit, the
+// returned data is shared with the cache and must not be modified.
+//
+// Returns an error if:
+//   - No names are provided, or more than MaxInfoFullnames
+//   - Any name is invalid
+//   - The API request fails
+func (r *Reddit) GetSubreddits(ctx context.Context, names []string) (map[string]*types.SubredditData, error) {
+	if len(names) == 0 {
+		return nil, &pkgerrs.ConfigError{Field: "names", Message: "at least one subreddit name is required"}
+	}
+	if len(names) > MaxInfoFullnames {
+		return nil, &pkgerrs.ConfigError{
+			Field:   "names",
+			Message: fmt.Sprintf("too many subreddit names: %d (max %d)", len(names), MaxInfoFullnames),
+		}
+	}
+
+	result := make(map[string]*types.SubredditData, len(names))
+	wanted := make(map[string][]string) // lowercase name -> names as requested
+	var missing []string
+	for _, name := range names {
+		name, err := r.validator.NormalizeSubredditName(name)
+		if err != nil {
+			return nil, err
+		}
+		key := strings.ToLower(name)
+		if entry, ok := r.cachedSubreddit(key); ok && entry.about {
+			result[name] = entry.data
+			continue
+		}
+		if _, ok := wanted[key]; !ok {
+			missing = append(missing, name)
+		}
+		wanted[key] = append(wanted[key], name)
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	params := url.Values{}
+	params.Set("sr_name", strings.Join(missing, ","))
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, InfoURL, nil, params)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: InfoURL, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var resp types.Thing
+	if err := r.httpClient.Do(req, &resp); err != nil {
+		return nil, wrapDoError(err, "get subreddits", InfoURL)
+	}
+
+	parsed, err := r.parser.ParseThing(ctx, &resp)
+	if err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "parse subreddits", Err: err}
+	}
+	listing, ok := parsed.(*types.ListingData)
+	if !ok {
+		return nil, &pkgerrs.ParseError{Operation: "parse subreddits", Err: fmt.Errorf("expected Listing, got %s", resp.Kind)}
+	}
+	for _, child := range listing.Children {
+		item, err := r.parser.ParseThing(ctx, child)
+		if err != nil {
+			return nil, &pkgerrs.ParseError{Operation: "parse subreddits", Err: err}
+		}
+		subreddit, ok := item.(*types.SubredditData)
+		if !ok {
+			continue
+		}
+		r.rememberSubreddit(subreddit, true)
+		for _, name := range wanted[strings.ToLower(subreddit.DisplayName)] {
+			result[name] = subreddit
+		}
+	}
+	return result, nil
+}
+
+// GetPostByID fetches one post by ID, with or without the "t3_" prefix. Unlike GetComments,
+// it does not load the post's comments.
+//
+// Returns a *errors.NotFoundError if the post does not exist or is not visible to the
+// client, or another error if the ID is invalid or the API request fails.
+func (r *Reddit) GetPostByID(ctx context.Context, id string) (*types.Post, error) {
+	posts, err := r.GetPostsByIDs(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(posts) == 0 {
+		return nil, pkgerrs.ClassifyAPIError(
+			&pkgerrs.APIError{StatusCode: http.StatusNotFound, Message: "post not found"},
+			pkgerrs.ResourceContext{Operation: "get post", URL: InfoURL, PostID: strings.TrimPrefix(id, string(types.KIND_POST))},
+		)
+	}
+	return posts[0], nil
+}
+
+// GetPostsByIDs fetches posts by ID, with or without the "t3_" prefix, in the order given.
+// Posts that do not exist or are not visible to the client are omitted, and duplicate IDs
+// are returned once. Any number of IDs may be given; they are looked up MaxInfoFullnames at
+// a time with GetInfo.
+//
+// If a request fails after others have succeeded, the posts found so far are returned with
+// a *errors.PartialResultError wrapping the cause.
+//
+// Returns an error if no IDs are given, any ID is invalid, or a request fails.
+func (r *Reddit) GetPostsByIDs(ctx context.Context, ids []string) ([]*types.Post, error) {
+	return getByIDs(ctx, r, "get posts", types.KIND_POST, ids,
+		func(info *types.InfoResponse) []*types.Post { return info.Posts },
+		func(p *types.Post) string { return p.ID })
+}
+
+// GetCommentsByIDs fetches comments by ID, with or without the "t1_" prefix, in the order
+// given, without their replies. It behaves like GetPostsByIDs.
+func (r *Reddit) GetCommentsByIDs(ctx context.Context, ids []string) ([]*types.Comment, error) {
+	return getByIDs(ctx, r, "get comments by id", types.KIND_COMMENT, ids,
+		func(info *types.InfoResponse) []*types.Comment { return info.Comments },
+		func(c *types.Comment) string { return c.ID })
+}
+
+// getByIDs looks up the things of one kind with the given IDs through GetInfo, returning
+// them in the order of ids. items picks the things of that kind from a response, and id
+// returns a thing's ID.
+func getByIDs[T any](ctx context.Context, r *Reddit, operation string, kind types.KindPrefix, ids []string,
+	items func(*types.InfoResponse) []T, id func(T) string) ([]T, error) {
+	if len(ids) == 0 {
+		return nil, &pkgerrs.ConfigError{Field: "ids", Message: "at least one ID is required"}
+	}
+	var order []string
+	seen := make(map[string]bool, len(ids))
+	for _, raw := range ids {
+		bare := strings.TrimPrefix(raw, string(kind))
+		if !validation.IsValidBase36(bare) {
+			return nil, &pkgerrs.ConfigError{Field: "ids", Message: fmt.Sprintf("invalid ID: %q", raw)}
+		}
+		if !seen[bare] {
+			seen[bare] = true
+			order = append(order, bare)
+		}
+	}
+
+	found := make(map[string]T, len(order))
+	collect := func() []T {
+		result := make([]T, 0, len(found))
+		for _, bare := range order {
+			if item, ok := found[bare]; ok {
+				result = append(result, item)
+			}
+		}
+		return result
+	}
+	chunks := slices.Collect(slices.Chunk(order, MaxInfoFullnames))
+	for i, chunk := range chunks {
+		fullnames := make([]string, len(chunk))
+		for j, bare := range chunk {
+			fullnames[j] = string(kind) + bare
+		}
+		info, err := r.GetInfo(ctx, fullnames)
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			return collect(), &pkgerrs.PartialResultError{Operation: operation, Completed: i, Total: len(chunks), Err: err}
+		}
+		for _, item := range items(info) {
+			found[id(item)] = item
+		}
+	}
+	return collect(), nil
+}
@@ -0,0 +1,256 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// commentThing builds a t1 Thing that passes the parser's validation.
+func commentThing(t *testing.T, id, body string, edited any) *types.Thing {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"id":           id,
+		"name":         "t1_" + id,
+		"body":         body,
+		"author":       "gopher",
+		"subreddit":    "golang",
+		"parent_id":    "t3_post1",
+		"link_id":      "t3_post1",
+		"created":      1700000000.0,
+		"created_utc":  1700000000.0,
+		"edited":       edited,
+		"score":        1,
+		"ups":          1,
+		"subreddit_id": "t5_2qh1i",
+	})
+	if err != nil {
+		t.Fatalf("marshal comment: %v", err)
+	}
+	return &types.Thing{Kind: "t1", Data: data}
+}
+
+// listingThing wraps children in a Listing Thing.
+func listingThing(t *testing.T, children ...*types.Thing) *types.Thing {
+	t.Helper()
+	data, err := json.Marshal(types.ListingData{Children: children})
+	if err != nil {
+		t.Fatalf("marshal listing: %v", err)
+	}
+	return &types.Thing{Kind: "Listing", Data: data}
+}
+
+func TestClient_GetInfo(t *testing.T) {
+	var gotIDs string
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			if req.URL.Path != "/"+InfoURL {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			gotIDs = req.URL.Query().Get("id")
+			*v = *listingThing(t, commentThing(t, "c1", "hello", false))
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	resp, err := client.GetInfo(context.Background(), []string{"t1_c1", "t3_post1"})
+	if err != nil {
+		t.Fatalf("GetInfo returned error: %v", err)
+	}
+	if gotIDs != "t1_c1,t3_post1" {
+		t.Errorf("id param = %q, want %q", gotIDs, "t1_c1,t3_post1")
+	}
+	if len(resp.Comments) != 1 || resp.Comments[0].Body != "hello" {
+		t.Errorf("GetInfo() comments = %+v, want one comment with body hello", resp.Comments)
+	}
+
+	tooMany := make([]string, MaxInfoFullnames+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("t1_%d", i)
+	}
+	tests := []struct {
+		name      string
+		fullnames []string
+		wantErr   string
+	}{
+		{name: "empty", fullnames: nil, wantErr: "at least one fullname"},
+		{name: "too many", fullnames: tooMany, wantErr: "too many fullnames"},
+		{name: "malformed", fullnames: []string{"abc"}, wantErr: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetInfo(context.Background(), tt.fullnames)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_GetSubreddits(t *testing.T) {
+	subredditThing := func(id, name string) *types.Thing {
+		data, err := json.Marshal(map[string]any{
+			"id":           id,
+			"name":         "t5_" + id,
+			"display_name": name,
+		})
+		if err != nil {
+			t.Fatalf("marshal subreddit: %v", err)
+		}
+		return &types.Thing{Kind: "t5", Data: data}
+	}
+
+	var calls int
+	var gotNames string
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			calls++
+			if req.URL.Path != "/"+InfoURL {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			gotNames = req.URL.Query().Get("sr_name")
+			*v = *listingThing(t, subredditThing("2rc7j", "golang"), subredditThing("2qh0y", "rust"))
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	subs, err := client.GetSubreddits(context.Background(), []string{"r/GoLang", "rust", "golang", "nosuchsub"})
+	if err != nil {
+		t.Fatalf("GetSubreddits returned error: %v", err)
+	}
+	if gotNames != "GoLang,rust,nosuchsub" {
+		t.Errorf("sr_name param = %q, want %q", gotNames, "GoLang,rust,nosuchsub")
+	}
+	if len(subs) != 3 || subs["GoLang"] == nil || subs["golang"] != subs["GoLang"] || subs["rust"] == nil {
+		t.Errorf("GetSubreddits() = %v, want GoLang, golang, and rust", subs)
+	}
+	if _, ok := subs["nosuchsub"]; ok {
+		t.Error("GetSubreddits() returned a missing subreddit")
+	}
+
+	// Results are cached, so a second lookup needs no request.
+	subs, err = client.GetSubreddits(context.Background(), []string{"golang", "RUST"})
+	if err != nil {
+		t.Fatalf("cached GetSubreddits returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("requests = %d, want 1", calls)
+	}
+	if subs["RUST"] == nil || subs["RUST"].ID != "2qh0y" {
+		t.Errorf("cached GetSubreddits() = %v, want RUST", subs)
+	}
+
+	tooMany := make([]string, MaxInfoFullnames+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("sub%d", i)
+	}
+	tests := []struct {
+		name    string
+		names   []string
+		wantErr string
+	}{
+		{name: "empty", names: nil, wantErr: "at least one subreddit"},
+		{name: "too many", names: tooMany, wantErr: "too many subreddit names"},
+		{name: "invalid", names: []string{"not a name"}, wantErr: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetSubreddits(context.Background(), tt.names)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_GetPostsByIDs(t *testing.T) {
+	var requests []string
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			ids := req.URL.Query().Get("id")
+			requests = append(requests, ids)
+			var children []*types.Thing
+			// Reddit returns items in its own order; reverse to check the client reorders.
+			fullnames := strings.Split(ids, ",")
+			for i := len(fullnames) - 1; i >= 0; i-- {
+				kind, id, _ := strings.Cut(fullnames[i], "_")
+				switch {
+				case id == "gone":
+				case kind == "t3":
+					children = append(children, submitPostThing(t, id, "Post "+id, "gopher", time.Unix(1700000000, 0)))
+				case kind == "t1":
+					children = append(children, commentThing(t, id, "comment "+id, false))
+				}
+			}
+			*v = *listingThing(t, children...)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	posts, err := client.GetPostsByIDs(ctx, []string{"bbb", "t3_aaa", "gone", "t3_bbb"})
+	if err != nil {
+		t.Fatalf("GetPostsByIDs returned error: %v", err)
+	}
+	if len(posts) != 2 || posts[0].ID != "bbb" || posts[1].ID != "aaa" {
+		t.Errorf("GetPostsByIDs() = %v, want bbb and aaa in request order", posts)
+	}
+	if requests[0] != "t3_bbb,t3_aaa,t3_gone" {
+		t.Errorf("id param = %q, want deduplicated fullnames", requests[0])
+	}
+
+	// More IDs than one /api/info request takes are split.
+	requests = nil
+	many := make([]string, MaxInfoFullnames+20)
+	for i := range many {
+		many[i] = fmt.Sprintf("p%d", i)
+	}
+	posts, err = client.GetPostsByIDs(ctx, many)
+	if err != nil {
+		t.Fatalf("GetPostsByIDs(%d) returned error: %v", len(many), err)
+	}
+	if len(requests) != 2 || len(posts) != len(many) || posts[len(posts)-1].ID != many[len(many)-1] {
+		t.Errorf("GetPostsByIDs(%d) sent %d requests and returned %d posts", len(many), len(requests), len(posts))
+	}
+
+	post, err := client.GetPostByID(ctx, "t3_aaa")
+	if err != nil || post.ID != "aaa" {
+		t.Errorf("GetPostByID() = %v, %v, want post aaa", post, err)
+	}
+	var notFound *pkgerrs.NotFoundError
+	if _, err := client.GetPostByID(ctx, "gone"); !errors.As(err, &notFound) {
+		t.Errorf("GetPostByID(gone) error = %v, want NotFoundError", err)
+	}
+
+	comments, err := client.GetCommentsByIDs(ctx, []string{"t1_c2", "c1"})
+	if err != nil {
+		t.Fatalf("GetCommentsByIDs returned error: %v", err)
+	}
+	if len(comments) != 2 || comments[0].ID != "c2" || comments[1].ID != "c1" {
+		t.Errorf("GetCommentsByIDs() = %v, want c2 and c1", comments)
+	}
+
+	var configErr *pkgerrs.ConfigError
+	for _, ids := range [][]string{nil, {"t3_ABC"}, {"t1_abc"}} {
+		if _, err := client.GetPostsByIDs(ctx, ids); !errors.As(err, &configErr) {
+			t.Errorf("GetPostsByIDs(%q) error = %v, want ConfigError", ids, err)
+		}
+	}
+}
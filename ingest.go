@@ -0,0 +1,332 @@
+package graw
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/parse"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const (
+	// DefaultIngestMaxLineSize is the longest archive line read when IngestOptions.MaxLineSize
+	// is zero.
+	DefaultIngestMaxLineSize = 16 << 20
+	// ingestZstdMaxWindow is the largest zstd window accepted. Pushshift dumps are compressed
+	// with a 2 GiB window, well above the decoder's default limit.
+	ingestZstdMaxWindow = 1 << 31
+)
+
+var (
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// IngestOptions configures IngestPosts and IngestComments.
+type IngestOptions struct {
+	// Parser configures parsing of comment trees in the archive. Optional.
+	Parser *ParserOptions
+	// Logger receives a warning for each line that cannot be parsed. Optional.
+	Logger *slog.Logger
+	// Buffer is the size of the returned channel. Optional; zero means unbuffered.
+	Buffer int
+	// MaxLineSize is the longest line accepted, in bytes. Optional; defaults to
+	// DefaultIngestMaxLineSize. A longer line ends the ingestion with an error.
+	MaxLineSize int
+	// Strict ends the ingestion at the first line that cannot be parsed. By default such
+	// lines are logged, counted in IngestStats.Invalid, and skipped.
+	Strict bool
+}
+
+// IngestStats counts the lines an ingestion has read.
+type IngestStats struct {
+	// Lines is the number of non-blank lines read.
+	Lines int
+	// Emitted is the number of posts or comments sent on the channel.
+	Emitted int
+	// Skipped is the number of valid lines holding other kinds of objects, such as comments
+	// in IngestPosts.
+	Skipped int
+	// Invalid is the number of lines that could not be parsed.
+	Invalid int
+}
+
+// Ingestion reports the progress and outcome of IngestPosts or IngestComments.
+type Ingestion struct {
+	done chan struct{}
+
+	mu    sync.Mutex
+	stats IngestStats
+	err   error
+}
+
+// Stats returns the counts so far.
+func (in *Ingestion) Stats() IngestStats {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.stats
+}
+
+// Wait blocks until the ingestion's channel is closed and returns the final counts and the
+// error that ended it, if any: a read or decompression failure, an over-long line, an
+// invalid line in Strict mode, or ctx being cancelled. Reaching the end of the archive is
+// not an error.
+func (in *Ingestion) Wait() (IngestStats, error) {
+	<-in.done
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.stats, in.err
+}
+
+// IngestPosts reads an archive of newline-delimited Reddit JSON and emits its posts on the
+// returned channel, like StreamNewPosts emits live ones, so the same code can consume both.
+// The channel is closed at the end of the archive, on a fatal error, or when ctx is
+// cancelled; call Ingestion.Wait afterwards to learn why.
+//
+// Each line holds one object, either in Reddit's {"kind", "data"} envelope, a whole Listing
+// as saved from the API, or bare as in Pushshift dumps; bare objects with a title are posts
+// and those with a body are comments. Legacy fields archives fill inconsistently (name,
+// created, ups, and downs) are derived from id, created_utc, and score before validation.
+// The archive may be compressed with zstd or gzip, which is detected from its first bytes.
+// Lines holding other kinds of objects are skipped.
+//
+// Returns an error if opts is invalid or src is compressed but cannot be decompressed.
+func IngestPosts(ctx context.Context, src io.Reader, opts *IngestOptions) (<-chan *types.Post, *Ingestion, error) {
+	return ingest(ctx, src, opts, parse.KindPost, func(ctx context.Context, p *parse.Parser, thing *types.Thing) ([]*types.Post, error) {
+		if thing.Kind == parse.KindListing {
+			return p.ExtractPosts(ctx, thing)
+		}
+		post, err := p.ParsePost(ctx, thing)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Post{post}, nil
+	})
+}
+
+// IngestComments is like IngestPosts for comments. Comments with replies in the archive are
+// emitted as trees; Pushshift dumps store every comment on its own line, without replies.
+func IngestComments(ctx context.Context, src io.Reader, opts *IngestOptions) (<-chan *types.Comment, *Ingestion, error) {
+	return ingest(ctx, src, opts, parse.KindComment, func(ctx context.Context, p *parse.Parser, thing *types.Thing) ([]*types.Comment, error) {
+		if thing.Kind == parse.KindListing {
+			comments, _, err := p.ExtractComments(ctx, thing)
+			return comments, err
+		}
+		comment, err := p.ParseComment(ctx, thing)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Comment{comment}, nil
+	})
+}
+
+// ingest reads src line by line in the background, sending the T values extract finds in
+// lines of the wanted kind, or in listings.
+func ingest[T any](ctx context.Context, src io.Reader, opts *IngestOptions, kind string, extract func(context.Context, *parse.Parser, *types.Thing) ([]T, error)) (<-chan T, *Ingestion, error) {
+	if src == nil {
+		return nil, nil, &pkgerrs.ConfigError{Field: "src", Message: "archive reader cannot be nil"}
+	}
+	if opts == nil {
+		opts = &IngestOptions{}
+	}
+	if opts.Buffer < 0 {
+		return nil, nil, &pkgerrs.ConfigError{Field: "Buffer", Message: "buffer size cannot be negative"}
+	}
+	if opts.MaxLineSize < 0 {
+		return nil, nil, &pkgerrs.ConfigError{Field: "MaxLineSize", Message: "max line size cannot be negative"}
+	}
+	if opts.Parser != nil && opts.Parser.MaxDepth < 0 {
+		return nil, nil, &pkgerrs.ConfigError{Field: "Parser.MaxDepth", Message: "max depth cannot be negative"}
+	}
+	maxLine := opts.MaxLineSize
+	if maxLine == 0 {
+		maxLine = DefaultIngestMaxLineSize
+	}
+	parserOpts := &parse.Options{Logger: opts.Logger}
+	if opts.Parser != nil {
+		parserOpts.MaxDepth = opts.Parser.MaxDepth
+		parserOpts.LenientCursors = opts.Parser.LenientCursors
+	}
+	parser := parse.New(parserOpts)
+
+	r, closeReader, err := decompress(src)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan T, opts.Buffer)
+	in := &Ingestion{done: make(chan struct{})}
+	go func() {
+		defer close(in.done)
+		defer close(ch)
+		defer closeReader()
+
+		err := func() error {
+			scanner := bufio.NewScanner(r)
+			scanner.Buffer(make([]byte, 0, min(maxLine, 64<<10)), maxLine)
+			for lineNo := 1; scanner.Scan(); lineNo++ {
+				line := bytes.TrimSpace(scanner.Bytes())
+				if len(line) == 0 {
+					continue
+				}
+				in.update(func(s *IngestStats) { s.Lines++ })
+
+				thing, err := archiveThing(line)
+				if err == nil && thing == nil {
+					in.update(func(s *IngestStats) { s.Skipped++ })
+					continue
+				}
+				var values []T
+				if err == nil {
+					if thing.Kind != kind && thing.Kind != parse.KindListing {
+						in.update(func(s *IngestStats) { s.Skipped++ })
+						continue
+					}
+					values, err = extract(ctx, parser, thing)
+				}
+				if err != nil {
+					err = &pkgerrs.ParseError{Operation: "ingest", Message: fmt.Sprintf("line %d: %v", lineNo, err), Err: err}
+					if opts.Strict {
+						return err
+					}
+					if opts.Logger != nil {
+						opts.Logger.LogAttrs(ctx, slog.LevelWarn, "skipping invalid archive line",
+							slog.Int("line", lineNo),
+							slog.String("error", err.Error()))
+					}
+					in.update(func(s *IngestStats) { s.Invalid++ })
+					continue
+				}
+
+				for _, v := range values {
+					select {
+					case ch <- v:
+						in.update(func(s *IngestStats) { s.Emitted++ })
+					case <-ctx.Done():
+						return &pkgerrs.ClientError{Err: ctx.Err()}
+					}
+				}
+				if ctx.Err() != nil {
+					return &pkgerrs.ClientError{Err: ctx.Err()}
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				if errors.Is(err, bufio.ErrTooLong) {
+					return &pkgerrs.ParseError{Operation: "ingest", Message: fmt.Sprintf("line longer than %d bytes", maxLine), Err: err}
+				}
+				return &pkgerrs.ClientError{Message: "failed to read archive", Err: err}
+			}
+			return nil
+		}()
+		in.mu.Lock()
+		in.err = err
+		in.mu.Unlock()
+	}()
+	return ch, in, nil
+}
+
+// update changes the ingestion's counts under its lock.
+func (in *Ingestion) update(fn func(*IngestStats)) {
+	in.mu.Lock()
+	fn(&in.stats)
+	in.mu.Unlock()
+}
+
+// decompress returns a reader of src's content, unwrapping zstd or gzip compression, and a
+// function that releases the decompressor.
+func decompress(src io.Reader) (io.Reader, func(), error) {
+	buffered := bufio.NewReader(src)
+	head, _ := buffered.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(head, zstdMagic):
+		dec, err := zstd.NewReader(buffered, zstd.WithDecoderMaxWindow(ingestZstdMaxWindow), zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, nil, &pkgerrs.ParseError{Operation: "ingest", Message: "invalid zstd archive", Err: err}
+		}
+		return dec, dec.Close, nil
+	case bytes.HasPrefix(head, gzipMagic):
+		dec, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, nil, &pkgerrs.ParseError{Operation: "ingest", Message: "invalid gzip archive", Err: err}
+		}
+		return dec, func() { _ = dec.Close() }, nil
+	}
+	return buffered, func() {}, nil
+}
+
+// archiveThing decodes one archive line into a Thing, normalizing bare post and comment
+// objects. It returns nil if the line is a bare object that is neither.
+func archiveThing(line []byte) (*types.Thing, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, err
+	}
+	if _, hasKind := fields["kind"]; hasKind {
+		if _, hasData := fields["data"]; hasData {
+			thing, err := parse.Unmarshal(line)
+			if err != nil || (thing.Kind != parse.KindPost && thing.Kind != parse.KindComment) {
+				return thing, err
+			}
+			if err := json.Unmarshal(thing.Data, &fields); err != nil {
+				return nil, err
+			}
+			return normalizeArchiveObject(thing.Kind, fields)
+		}
+	}
+
+	switch {
+	case fields["title"] != nil:
+		return normalizeArchiveObject(parse.KindPost, fields)
+	case fields["body"] != nil:
+		return normalizeArchiveObject(parse.KindComment, fields)
+	}
+	return nil, nil
+}
+
+// normalizeArchiveObject fills the fields archives leave out or store inconsistently, which
+// validation would otherwise reject, and wraps the object in a Thing of kind.
+func normalizeArchiveObject(kind string, fields map[string]json.RawMessage) (*types.Thing, error) {
+	// Early Pushshift dumps store timestamps as strings.
+	if raw := fields["created_utc"]; len(raw) > 0 && raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("invalid created_utc %q", s)
+		}
+		fields["created_utc"] = json.RawMessage(s)
+	}
+	if fields["created_utc"] != nil {
+		fields["created"] = fields["created_utc"]
+	}
+	if _, ok := fields["name"]; !ok {
+		var id string
+		if err := json.Unmarshal(fields["id"], &id); err == nil && id != "" {
+			fields["name"], _ = json.Marshal(kind + "_" + id)
+		}
+	}
+	// ups and downs are legacy fields Reddit no longer fills; score is authoritative.
+	if fields["score"] != nil {
+		fields["ups"] = fields["score"]
+		fields["downs"] = json.RawMessage("0")
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	return parse.Wrap(kind, data), nil
+}
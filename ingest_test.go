@@ -0,0 +1,135 @@
+package graw
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// archiveLines is a small dump mixing Pushshift-style bare objects, an API envelope, a saved
+// listing, a blank line, and an invalid line.
+var archiveLines = []string{
+	`{"id":"ps1","title":"First","author":"gopher","subreddit":"golang","permalink":"/r/golang/comments/ps1/first/","url":"https://www.reddit.com/r/golang/comments/ps1/first/","score":12,"ups":15,"downs":3,"created_utc":"1500000000"}`,
+	`{"id":"c1","body":"A comment","author":"gopher","subreddit":"golang","link_id":"t3_ps1","parent_id":"t3_ps1","score":2,"created_utc":1500000100}`,
+	``,
+	`{"kind":"t3","data":{"id":"ps2","name":"t3_ps2","title":"Second","author":"gopher","subreddit":"golang","permalink":"/r/golang/comments/ps2/second/","url":"https://example.com/","score":3,"created_utc":1500000200}}`,
+	`{"id":"bad","title":""}`,
+	strings.TrimSpace(string(grawtest.NewListing(2))),
+	`{"subscribers":10}`,
+}
+
+func TestIngestPosts(t *testing.T) {
+	archive := []byte(strings.Join(archiveLines, "\n"))
+	var zstdArchive bytes.Buffer
+	enc, err := zstd.NewWriter(&zstdArchive)
+	if err != nil {
+		t.Fatalf("zstd writer: %v", err)
+	}
+	_, _ = enc.Write(archive)
+	_ = enc.Close()
+	var gzipArchive bytes.Buffer
+	gz := gzip.NewWriter(&gzipArchive)
+	_, _ = gz.Write(archive)
+	_ = gz.Close()
+
+	for name, data := range map[string][]byte{"plain": archive, "zstd": zstdArchive.Bytes(), "gzip": gzipArchive.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			posts, ingestion, err := IngestPosts(context.Background(), bytes.NewReader(data), nil)
+			if err != nil {
+				t.Fatalf("IngestPosts returned error: %v", err)
+			}
+			var ids []string
+			for post := range posts {
+				ids = append(ids, post.ID)
+			}
+			stats, err := ingestion.Wait()
+			if err != nil {
+				t.Fatalf("Wait returned error: %v", err)
+			}
+			if want := []string{"ps1", "ps2", "p1", "p2"}; !slices.Equal(ids, want) {
+				t.Errorf("posts = %v, want %v", ids, want)
+			}
+			if want := (IngestStats{Lines: 6, Emitted: 4, Skipped: 2, Invalid: 1}); stats != want {
+				t.Errorf("stats = %+v, want %+v", stats, want)
+			}
+		})
+	}
+}
+
+func TestIngestComments(t *testing.T) {
+	comments, ingestion, err := IngestComments(context.Background(), strings.NewReader(strings.Join(archiveLines, "\n")), &IngestOptions{Buffer: 4})
+	if err != nil {
+		t.Fatalf("IngestComments returned error: %v", err)
+	}
+	var got []*types.Comment
+	for c := range comments {
+		got = append(got, c)
+	}
+	if _, err := ingestion.Wait(); err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d comments, want 1", len(got))
+	}
+	if c := got[0]; c.Name != "t1_c1" || c.Created.Created != 1500000100 || c.Body != "A comment" {
+		t.Errorf("comment = %+v", c)
+	}
+}
+
+func TestIngest_Errors(t *testing.T) {
+	posts, ingestion, err := IngestPosts(context.Background(), strings.NewReader(strings.Join(archiveLines, "\n")), &IngestOptions{Strict: true})
+	if err != nil {
+		t.Fatalf("IngestPosts returned error: %v", err)
+	}
+	for range posts {
+	}
+	var parseErr *pkgerrs.ParseError
+	if stats, err := ingestion.Wait(); !errors.As(err, &parseErr) || stats.Emitted != 2 {
+		t.Errorf("strict Wait = %+v, %v; want ParseError after 2 posts", stats, err)
+	}
+
+	posts, ingestion, err = IngestPosts(context.Background(), strings.NewReader(archiveLines[0]), &IngestOptions{MaxLineSize: 16})
+	if err != nil {
+		t.Fatalf("IngestPosts returned error: %v", err)
+	}
+	for range posts {
+	}
+	if _, err := ingestion.Wait(); !errors.As(err, &parseErr) {
+		t.Errorf("long line error = %v, want ParseError", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	posts, ingestion, err = IngestPosts(ctx, strings.NewReader(strings.Join(archiveLines, "\n")), nil)
+	if err != nil {
+		t.Fatalf("IngestPosts returned error: %v", err)
+	}
+	<-posts
+	cancel()
+	if _, err := ingestion.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Wait error = %v, want context.Canceled", err)
+	}
+
+	posts, ingestion, err = IngestPosts(context.Background(), bytes.NewReader([]byte{0x28, 0xb5, 0x2f, 0xfd, 0, 1, 2}), nil)
+	if err == nil {
+		for range posts {
+		}
+		_, err = ingestion.Wait()
+	}
+	if err == nil {
+		t.Error("corrupt zstd archive returned nil error")
+	}
+	var configErr *pkgerrs.ConfigError
+	if _, _, err := IngestPosts(context.Background(), nil, nil); !errors.As(err, &configErr) {
+		t.Errorf("nil reader error = %v, want ConfigError", err)
+	}
+}
@@ -18,6 +18,8 @@ import (
 
 const (
 	defaultTokenEndpointPath = "api/v1/access_token"
+	// storedTokenMargin is how long before its expiry a stored access token stops being reused
+	storedTokenMargin = time.Minute
 )
 
 // tokenCache holds cached token data immutably
@@ -41,6 +43,26 @@ type Authenticator struct {
 	cachedToken atomic.Pointer[tokenCache]
 	// Mutex to prevent concurrent token refreshes
 	tokenMu sync.Mutex
+
+	// refreshToken, when set, is exchanged for access tokens in place of formData. Reddit may
+	// rotate it with each refresh; onRotate is then called with the new one. Both are
+	// guarded by tokenMu.
+	refreshToken string
+	onRotate     func(refreshToken string)
+
+	// store, when set, persists tokens across restarts. loaded records that its token has
+	// been read. Both are guarded by tokenMu.
+	store  TokenStore
+	loaded bool
+}
+
+// TokenStore persists the authenticator's tokens, so a restarted program can reuse them
+// instead of authenticating again.
+type TokenStore interface {
+	// Load returns the stored tokens, or nil if none are stored.
+	Load(ctx context.Context) (*Grant, error)
+	// Save replaces the stored tokens.
+	Save(ctx context.Context, grant *Grant) error
 }
 
 // NewAuthenticator creates a new authenticator.
@@ -84,13 +106,78 @@ func NewAuthenticator(httpClient *http.Client, username, password, clientID, cli
 }
 
 type tokenResponse struct {
-	AccessToken string `json:"access_token"`
-	TokenType   string `json:"token_type"`
-	ExpiresIn   int    `json:"expires_in"`
-	Scope       string `json:"scope"`
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Grant is the result of exchanging an authorization code.
+type Grant struct {
+	AccessToken  string
+	RefreshToken string // Empty unless a permanent grant was requested
+	Scope        string // Space-separated scopes the user granted
+	Expiry       time.Time
+}
+
+// SetRefreshToken makes the authenticator obtain access tokens with the refresh_token grant.
+// When Reddit rotates the refresh token, onRotate (which may be nil) is called with the new
+// one while the authenticator's lock is held, so it must not request a token itself.
+func (a *Authenticator) SetRefreshToken(refreshToken string, onRotate func(refreshToken string)) {
+	a.tokenMu.Lock()
+	defer a.tokenMu.Unlock()
+	a.refreshToken = refreshToken
+	a.onRotate = onRotate
+}
+
+// SetTokenStore makes the authenticator save every token it obtains to store. If load is
+// true, the first GetToken reuses the stored access token while it is valid, and the stored
+// refresh token replaces the configured one, since it may have been rotated since. Store
+// failures are logged and do not fail authentication.
+func (a *Authenticator) SetTokenStore(store TokenStore, load bool) {
+	a.tokenMu.Lock()
+	defer a.tokenMu.Unlock()
+	a.store = store
+	a.loaded = !load
+}
+
+// RefreshToken returns the current refresh token, or "" if the authenticator uses another grant.
+func (a *Authenticator) RefreshToken() string {
+	a.tokenMu.Lock()
+	defer a.tokenMu.Unlock()
+	return a.refreshToken
+}
+
+// ExchangeCode performs the authorization_code grant, trading a code from Reddit's authorize
+// redirect for tokens. The access token is cached and the refresh token, if any, is used for
+// later refreshes, so the authenticator can serve the authorizing user's requests.
+func (a *Authenticator) ExchangeCode(ctx context.Context, code, redirectURI string) (*Grant, error) {
+	a.tokenMu.Lock()
+	defer a.tokenMu.Unlock()
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	tokenResp, expiry, err := a.requestToken(ctx, form)
+	if err != nil {
+		return nil, err
+	}
+	if tokenResp.RefreshToken != "" {
+		a.refreshToken = tokenResp.RefreshToken
+	}
+	grant := &Grant{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		Scope:        tokenResp.Scope,
+		Expiry:       expiry,
+	}
+	a.saveToken(ctx, grant)
+	return grant, nil
 }
 
-// GetToken performs the password grant flow to get an access token.
+// GetToken performs the configured grant flow to get an access token.
 func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	// Check cache first - lock-free read
 	if cached := a.cachedToken.Load(); cached != nil {
@@ -122,26 +209,102 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 		}
 	}
 
+	if token, ok := a.loadToken(ctx); ok {
+		return token, nil
+	}
+
 	// Definitely need to fetch new token
-	data := a.formData.Encode()
+	form := *a.formData
+	if a.refreshToken != "" {
+		form = url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", a.refreshToken)
+	}
+	tokenResp, expiry, err := a.requestToken(ctx, form)
+	if err != nil {
+		return "", err
+	}
+	if a.refreshToken != "" && tokenResp.RefreshToken != "" && tokenResp.RefreshToken != a.refreshToken {
+		a.refreshToken = tokenResp.RefreshToken
+		if a.onRotate != nil {
+			a.onRotate(tokenResp.RefreshToken)
+		}
+	}
+	a.saveToken(ctx, &Grant{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: a.refreshToken,
+		Scope:        tokenResp.Scope,
+		Expiry:       expiry,
+	})
+	return tokenResp.AccessToken, nil
+}
+
+// loadToken reads the store the first time it is called, adopting a stored refresh token
+// and caching a stored access token that is not about to expire. It reports whether that
+// access token can be used. The caller must hold tokenMu.
+func (a *Authenticator) loadToken(ctx context.Context) (string, bool) {
+	if a.store == nil || a.loaded {
+		return "", false
+	}
+	a.loaded = true
+
+	grant, err := a.store.Load(ctx)
+	if err != nil {
+		a.logAuthError(ctx, "failed to load stored token", err)
+		return "", false
+	}
+	if grant == nil {
+		return "", false
+	}
+	if a.refreshToken != "" && grant.RefreshToken != "" {
+		a.refreshToken = grant.RefreshToken
+	}
+	expiry := grant.Expiry.Add(-storedTokenMargin)
+	if grant.AccessToken == "" || !time.Now().Before(expiry) {
+		return "", false
+	}
+	a.cachedToken.Store(&tokenCache{token: grant.AccessToken, expiry: expiry})
+	if a.logger != nil {
+		a.logger.LogAttrs(ctx, slog.LevelDebug, "using stored reddit token",
+			slog.Time("expires_at", grant.Expiry))
+	}
+	return grant.AccessToken, true
+}
+
+// saveToken writes grant to the store, if there is one. The caller must hold tokenMu.
+func (a *Authenticator) saveToken(ctx context.Context, grant *Grant) {
+	if a.store == nil {
+		return
+	}
+	a.loaded = true // a token saved now supersedes whatever was stored before
+	if err := a.store.Save(ctx, grant); err != nil {
+		a.logAuthError(ctx, "failed to save token", err)
+	}
+}
+
+// requestToken posts form to the token endpoint, validates the response, and caches the
+// access token. It returns the response and the token's actual expiry. The caller must
+// hold tokenMu.
+func (a *Authenticator) requestToken(ctx context.Context, form url.Values) (*tokenResponse, time.Time, error) {
+	data := form.Encode()
 	start := time.Now()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.tokenURL.String(), strings.NewReader(data))
 	if err != nil {
 		a.logAuthError(ctx, "failed to create token request", err)
-		return "", &pkgerrs.AuthError{Err: fmt.Errorf("failed to create token request: %w", err)}
+		return nil, time.Time{}, &pkgerrs.AuthError{Err: fmt.Errorf("failed to create token request: %w", err)}
 	}
 
 	req.SetBasicAuth(a.clientID, a.clientSecret)
 	req.Header.Set("User-Agent", a.userAgent)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	a.logAuthRequest(ctx)
+	a.logAuthRequest(ctx, form.Get("grant_type"))
 
 	resp, err := a.client.Do(req)
 	if err != nil {
 		a.logAuthError(ctx, "failed to execute token request", err)
-		return "", &pkgerrs.AuthError{Err: fmt.Errorf("failed to execute token request: %w", err)}
+		return nil, time.Time{}, &pkgerrs.AuthError{Err: fmt.Errorf("failed to execute token request: %w", err)}
 	}
 	defer resp.Body.Close()
 
@@ -151,7 +314,7 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	if err != nil {
 		a.logAuthError(ctx, "failed to read token response", err)
 		// Error reading the response body.
-		return "", &pkgerrs.AuthError{
+		return nil, time.Time{}, &pkgerrs.AuthError{
 			StatusCode: resp.StatusCode,
 			Err:        fmt.Errorf("failed to read response body: %w", err),
 		}
@@ -162,7 +325,7 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 		var extraByte [1]byte
 		if n, _ := resp.Body.Read(extraByte[:]); n > 0 {
 			a.logAuthError(ctx, "response body too large", fmt.Errorf("exceeded max size of %d bytes", maxResponseBodySize))
-			return "", &pkgerrs.AuthError{
+			return nil, time.Time{}, &pkgerrs.AuthError{
 				StatusCode: resp.StatusCode,
 				Err:        fmt.Errorf("response body exceeded max size of %d bytes", maxResponseBodySize),
 			}
@@ -173,7 +336,7 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	a.logAuthHTTPResult(ctx, resp.StatusCode, duration, bodyBytes)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", &pkgerrs.AuthError{
+		return nil, time.Time{}, &pkgerrs.AuthError{
 			StatusCode: resp.StatusCode,
 			Body:       string(bodyBytes),
 		}
@@ -182,7 +345,7 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	var tokenResp tokenResponse
 	if err := json.Unmarshal(bodyBytes, &tokenResp); err != nil {
 		a.logAuthError(ctx, "failed to decode token response", err)
-		return "", &pkgerrs.AuthError{
+		return nil, time.Time{}, &pkgerrs.AuthError{
 			StatusCode: resp.StatusCode,
 			Body:       string(bodyBytes),
 			Err:        fmt.Errorf("failed to unmarshal token response: %w", err),
@@ -192,7 +355,7 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	if tokenResp.AccessToken == "" {
 		emptyErr := fmt.Errorf("access token was empty in response")
 		a.logAuthError(ctx, "received empty access token", emptyErr)
-		return "", &pkgerrs.AuthError{
+		return nil, time.Time{}, &pkgerrs.AuthError{
 			StatusCode: resp.StatusCode,
 			Body:       string(bodyBytes),
 			Err:        emptyErr,
@@ -204,7 +367,7 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	if tokenResp.ExpiresIn < 0 {
 		expiryErr := fmt.Errorf("invalid expires_in value: %d (cannot be negative)", tokenResp.ExpiresIn)
 		a.logAuthError(ctx, "received negative expires_in", expiryErr)
-		return "", &pkgerrs.AuthError{
+		return nil, time.Time{}, &pkgerrs.AuthError{
 			StatusCode: resp.StatusCode,
 			Body:       string(bodyBytes),
 			Err:        expiryErr,
@@ -213,7 +376,7 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 	if tokenResp.ExpiresIn > maxTokenExpirySeconds {
 		expiryErr := fmt.Errorf("invalid expires_in value: %d (exceeds maximum of %d seconds)", tokenResp.ExpiresIn, maxTokenExpirySeconds)
 		a.logAuthError(ctx, "received expires_in exceeding maximum", expiryErr)
-		return "", &pkgerrs.AuthError{
+		return nil, time.Time{}, &pkgerrs.AuthError{
 			StatusCode: resp.StatusCode,
 			Body:       string(bodyBytes),
 			Err:        expiryErr,
@@ -236,17 +399,18 @@ func (a *Authenticator) GetToken(ctx context.Context) (string, error) {
 		expiryDuration = minCacheDuration
 	}
 
+	now := time.Now()
 	a.cachedToken.Store(&tokenCache{
 		token:  tokenResp.AccessToken,
-		expiry: time.Now().Add(expiryDuration),
+		expiry: now.Add(expiryDuration),
 	})
 
 	a.logAuthSuccess(ctx, duration, tokenResp)
 
-	return tokenResp.AccessToken, nil
+	return &tokenResp, now.Add(actualExpiry), nil
 }
 
-func (a *Authenticator) logAuthRequest(ctx context.Context) {
+func (a *Authenticator) logAuthRequest(ctx context.Context, grantType string) {
 	if a.logger == nil {
 		return
 	}
@@ -256,9 +420,7 @@ func (a *Authenticator) logAuthRequest(ctx context.Context) {
 	if a.tokenURL != nil {
 		attrs = append(attrs, slog.String("url", a.tokenURL.String()))
 	}
-	if a.formData != nil {
-		attrs = append(attrs, slog.String("grant_type", a.formData.Get("grant_type")))
-	}
+	attrs = append(attrs, slog.String("grant_type", grantType))
 
 	a.logger.LogAttrs(ctx, slog.LevelDebug, "requesting reddit access token", attrs...)
 }
@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
 )
@@ -581,3 +582,69 @@ func TestAuthError_Unwrap(t *testing.T) {
 		t.Error("Unwrap should return nil for an error with no inner Err")
 	}
 }
+
+// memoryTokenStore records saved grants and returns the last one from Load.
+type memoryTokenStore struct {
+	grant *Grant
+	saves int
+}
+
+func (s *memoryTokenStore) Load(context.Context) (*Grant, error) { return s.grant, nil }
+
+func (s *memoryTokenStore) Save(_ context.Context, grant *Grant) error {
+	copied := *grant
+	s.grant = &copied
+	s.saves++
+	return nil
+}
+
+func TestAuthenticator_TokenStore(t *testing.T) {
+	var refreshTokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		refreshTokens = append(refreshTokens, r.PostForm.Get("refresh_token"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"fresh","token_type":"bearer","expires_in":3600,"scope":"read","refresh_token":"rotated"}`))
+	}))
+	defer server.Close()
+
+	newAuth := func(store TokenStore, load bool) *Authenticator {
+		auth, err := NewAuthenticator(server.Client(), "", "", "id", "", "agent", server.URL, "refresh_token", nil)
+		if err != nil {
+			t.Fatalf("NewAuthenticator returned error: %v", err)
+		}
+		auth.SetRefreshToken("configured", nil)
+		auth.SetTokenStore(store, load)
+		return auth
+	}
+	ctx := context.Background()
+
+	// A valid stored access token is reused without a request.
+	store := &memoryTokenStore{grant: &Grant{AccessToken: "stored", RefreshToken: "saved", Expiry: time.Now().Add(time.Hour)}}
+	auth := newAuth(store, true)
+	if token, err := auth.GetToken(ctx); err != nil || token != "stored" {
+		t.Fatalf("GetToken = %q, %v; want the stored token", token, err)
+	}
+	if len(refreshTokens) != 0 || auth.RefreshToken() != "saved" {
+		t.Errorf("requests = %v, refresh token = %q; want none and the stored one", refreshTokens, auth.RefreshToken())
+	}
+
+	// An expiring stored token is refreshed with the stored refresh token, and the result saved.
+	store = &memoryTokenStore{grant: &Grant{AccessToken: "stale", RefreshToken: "saved", Expiry: time.Now().Add(30 * time.Second)}}
+	auth = newAuth(store, true)
+	if token, err := auth.GetToken(ctx); err != nil || token != "fresh" {
+		t.Fatalf("GetToken = %q, %v; want a fresh token", token, err)
+	}
+	if len(refreshTokens) != 1 || refreshTokens[0] != "saved" {
+		t.Errorf("refresh tokens sent = %v, want [saved]", refreshTokens)
+	}
+	if store.saves != 1 || store.grant.AccessToken != "fresh" || store.grant.RefreshToken != "rotated" || store.grant.Expiry.Before(time.Now().Add(59*time.Minute)) {
+		t.Errorf("saved %d grants, last %+v", store.saves, store.grant)
+	}
+
+	// Without load, the stored token is ignored but new tokens are still saved.
+	store = &memoryTokenStore{grant: &Grant{AccessToken: "stored", Expiry: time.Now().Add(time.Hour)}}
+	if token, err := newAuth(store, false).GetToken(ctx); err != nil || token != "fresh" || store.grant.AccessToken != "fresh" {
+		t.Errorf("GetToken without load = %q, %v; stored %+v", token, err, store.grant)
+	}
+}
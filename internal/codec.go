@@ -0,0 +1,43 @@
+package internal
+
+import "encoding/json"
+
+// Codec decodes JSON response bodies. Implementations must honor json.Unmarshaler and
+// encoding/json struct tags, and be safe for concurrent use.
+type Codec interface {
+	Unmarshal(data []byte, v any) error
+}
+
+// StdCodec is the default Codec, backed by encoding/json.
+type StdCodec struct{}
+
+// Unmarshal calls json.Unmarshal.
+func (StdCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// SetCodec replaces the decoder used for response bodies. A nil codec restores StdCodec.
+func (c *Client) SetCodec(codec Codec) {
+	c.codec = codec
+}
+
+// unmarshal decodes data with the configured codec, or encoding/json if none is set.
+func (c *Client) unmarshal(data []byte, v any) error {
+	if c.codec == nil {
+		return json.Unmarshal(data, v)
+	}
+	return c.codec.Unmarshal(data, v)
+}
+
+// SetCodec replaces the decoder used for Thing data. A nil codec restores StdCodec.
+func (p *Parser) SetCodec(codec Codec) {
+	p.codec = codec
+}
+
+// unmarshal decodes data with the configured codec, or encoding/json if none is set.
+func (p *Parser) unmarshal(data []byte, v any) error {
+	if p.codec == nil {
+		return json.Unmarshal(data, v)
+	}
+	return p.codec.Unmarshal(data, v)
+}
@@ -0,0 +1,60 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// decoderCodec decodes with a json.Decoder, an alternative to StdCodec to compare against.
+// Add a case to BenchmarkListingDecode to measure a third-party codec such as sonic.
+type decoderCodec struct{}
+
+func (decoderCodec) Unmarshal(data []byte, v any) error {
+	return json.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// BenchmarkListingDecode measures decoding a 100-post listing and parsing each post, the
+// work done for every listing page, with different codecs.
+func BenchmarkListingDecode(b *testing.B) {
+	payload := grawtest.NewListing(100)
+	codecs := []struct {
+		name  string
+		codec Codec
+	}{
+		{"encoding/json", StdCodec{}},
+		{"json.Decoder", decoderCodec{}},
+	}
+
+	for _, bc := range codecs {
+		b.Run(bc.name, func(b *testing.B) {
+			client := &Client{}
+			client.SetCodec(bc.codec)
+			parser := NewParser()
+			parser.SetCodec(bc.codec)
+			ctx := context.Background()
+
+			b.SetBytes(int64(len(payload)))
+			b.ReportAllocs()
+			for b.Loop() {
+				var thing types.Thing
+				if err := client.unmarshal(payload, &thing); err != nil {
+					b.Fatal(err)
+				}
+				parsed, err := parser.ParseThing(ctx, &thing)
+				if err != nil {
+					b.Fatal(err)
+				}
+				for _, child := range parsed.(*types.ListingData).Children {
+					if _, err := parser.ParseThing(ctx, child); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// countingCodec decodes with encoding/json and counts its calls.
+type countingCodec struct {
+	calls atomic.Int64
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	c.calls.Add(1)
+	return json.Unmarshal(data, v)
+}
+
+func TestClient_SetCodec(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"kind":"t2","data":{"name":"test","id":"123"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(http.DefaultClient, server.URL, "test/1.0", nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	codec := &countingCodec{}
+	client.SetCodec(codec)
+
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "api/v1/me", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	var thing types.Thing
+	if err := client.Do(req, &thing); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if thing.Kind != "t2" || codec.calls.Load() != 1 {
+		t.Errorf("kind = %q, codec calls = %d; want t2 decoded by the codec", thing.Kind, codec.calls.Load())
+	}
+
+	client.SetCodec(nil)
+	req, _ = client.NewRequest(context.Background(), http.MethodGet, "api/v1/me", nil)
+	if err := client.Do(req, &thing); err != nil {
+		t.Fatalf("Do with default codec: %v", err)
+	}
+	if codec.calls.Load() != 1 {
+		t.Errorf("codec called after being removed")
+	}
+}
+
+func TestParser_SetCodec(t *testing.T) {
+	parser := NewParser()
+	codec := &countingCodec{}
+	parser.SetCodec(codec)
+
+	var listing types.Thing
+	if err := json.Unmarshal(grawtest.NewListing(3), &listing); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := parser.ParseThing(context.Background(), &listing)
+	if err != nil {
+		t.Fatalf("ParseThing: %v", err)
+	}
+	data, ok := parsed.(*types.ListingData)
+	if !ok || len(data.Children) != 3 {
+		t.Fatalf("parsed %T with %v", parsed, parsed)
+	}
+	if codec.calls.Load() != 1 {
+		t.Errorf("codec calls = %d, want 1 for the listing", codec.calls.Load())
+	}
+	for _, child := range data.Children {
+		if _, err := parser.ParseThing(context.Background(), child); err != nil {
+			t.Fatalf("ParseThing(post): %v", err)
+		}
+	}
+	if codec.calls.Load() != 4 {
+		t.Errorf("codec calls = %d, want one per post as well", codec.calls.Load())
+	}
+}
@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// Host selects which Reddit host serves an endpoint.
+type Host int
+
+const (
+	// HostAPI is the OAuth API host (oauth.reddit.com), the Client's BaseURL. Authenticated
+	// requests go here.
+	HostAPI Host = iota
+	// HostWeb is the website host (www.reddit.com), the Client's WebURL. It serves public
+	// endpoints such as api/v1/scopes and .json pages, and does not accept OAuth tokens.
+	HostWeb
+)
+
+// String returns the host's name.
+func (h Host) String() string {
+	switch h {
+	case HostAPI:
+		return "api"
+	case HostWeb:
+		return "web"
+	default:
+		return fmt.Sprintf("Host(%d)", int(h))
+	}
+}
+
+// Endpoint is a path relative to the base URL of the host that serves it.
+type Endpoint struct {
+	Host Host
+	Path string
+}
+
+// APIEndpoint returns path on the OAuth API host.
+func APIEndpoint(path string) Endpoint {
+	return Endpoint{Host: HostAPI, Path: path}
+}
+
+// WebEndpoint returns path on the website host.
+func WebEndpoint(path string) Endpoint {
+	return Endpoint{Host: HostWeb, Path: path}
+}
+
+// SetWebURL sets the base URL of HostWeb endpoints. An empty string restores RedditWebURL.
+func (c *Client) SetWebURL(webURL string) error {
+	if webURL == "" {
+		c.WebURL = nil
+		return nil
+	}
+	parsed, err := parseBaseURL(webURL)
+	if err != nil {
+		return &pkgerrs.ClientError{Err: err}
+	}
+	c.WebURL = parsed
+	return nil
+}
+
+// baseURL returns the base URL of host.
+func (c *Client) baseURL(host Host) (*url.URL, error) {
+	switch host {
+	case HostAPI:
+		return c.BaseURL, nil
+	case HostWeb:
+		if c.WebURL != nil {
+			return c.WebURL, nil
+		}
+		return parseBaseURL(RedditWebURL)
+	default:
+		return nil, fmt.Errorf("unknown host %v", host)
+	}
+}
+
+// NewEndpointRequest creates a request for endpoint, resolving its path against the base
+// URL of the endpoint's host. Like NewRequest, it sets no authentication headers.
+func (c *Client) NewEndpointRequest(ctx context.Context, method string, endpoint Endpoint, body io.Reader, params ...url.Values) (*http.Request, error) {
+	base, err := c.baseURL(endpoint.Host)
+	if err != nil {
+		return nil, &pkgerrs.ClientError{Err: err}
+	}
+	u, err := base.Parse(endpoint.Path)
+	if err != nil {
+		return nil, &pkgerrs.ClientError{Err: err}
+	}
+
+	// Add query parameters if provided
+	if len(params) > 0 && params[0] != nil {
+		q := u.Query()
+		for key, values := range params[0] {
+			for _, value := range values {
+				q.Add(key, value)
+			}
+		}
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, &pkgerrs.ClientError{Err: err}
+	}
+
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	return req, nil
+}
+
+// parseBaseURL parses a base URL, adding the trailing slash paths are resolved against.
+func parseBaseURL(raw string) (*url.URL, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(parsed.Path, "/") {
+		parsed.Path += "/"
+	}
+	return parsed, nil
+}
@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestClient_NewEndpointRequest(t *testing.T) {
+	client, err := NewClient(nil, "https://oauth.example.com/api-root", "test/1.0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		webURL   string
+		endpoint Endpoint
+		params   url.Values
+		want     string
+	}{
+		{"api host", "", APIEndpoint("api/v1/me"), nil, "https://oauth.example.com/api-root/api/v1/me"},
+		{"default web host", "", WebEndpoint("api/v1/scopes"), nil, "https://www.reddit.com/api/v1/scopes"},
+		{"custom web host", "http://127.0.0.1:8080", WebEndpoint("r/golang/about.json"), url.Values{"raw_json": {"1"}}, "http://127.0.0.1:8080/r/golang/about.json?raw_json=1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := client.SetWebURL(tt.webURL); err != nil {
+				t.Fatalf("SetWebURL: %v", err)
+			}
+			req, err := client.NewEndpointRequest(ctx, http.MethodGet, tt.endpoint, nil, tt.params)
+			if err != nil {
+				t.Fatalf("NewEndpointRequest: %v", err)
+			}
+			if got := req.URL.String(); got != tt.want {
+				t.Errorf("URL = %s, want %s", got, tt.want)
+			}
+			if req.UserAgent() != "test/1.0" {
+				t.Errorf("User-Agent = %q", req.UserAgent())
+			}
+		})
+	}
+
+	if _, err := client.NewEndpointRequest(ctx, http.MethodGet, Endpoint{Host: Host(7), Path: "x"}, nil); err == nil {
+		t.Error("expected an error for an unknown host")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -28,6 +29,8 @@ const (
 	initialBufferSize = 8 * 1024 // 8KB - increased for better performance
 	// maxResponseBodySize limits the size of HTTP response bodies to prevent DoS
 	maxResponseBodySize = 10 * 1024 * 1024 // 10MB
+	// maxDegradedScanBytes limits how much of an error body is searched for maintenance text
+	maxDegradedScanBytes = 16 * 1024
 )
 
 var (
@@ -104,6 +107,7 @@ const (
 type Client struct {
 	client          *http.Client
 	BaseURL         *url.URL
+	WebURL          *url.URL // base of HostWeb endpoints; nil means RedditWebURL
 	UserAgent       string
 	logger          *slog.Logger
 	maxLogBodyBytes int
@@ -111,6 +115,44 @@ type Client struct {
 	limiter            *rate.Limiter
 	forceWaitUntil     atomic.Int64 // Unix nanoseconds
 	rateLimitThreshold float64      // When to start proactive throttling
+
+	retry    RetryConfig
+	notFound *notFoundCache // nil unless SetNotFoundCache was called
+	timing   timingTotals   // phases of every request sent, from httptrace
+
+	inFlight         atomic.Int64 // requests sent and awaiting a complete response
+	rateLimitWaiters atomic.Int64 // requests blocked in waitForRateLimit
+
+	codec Codec // decodes response bodies; nil means encoding/json
+}
+
+// ClientStats is a snapshot of the client's request activity.
+type ClientStats struct {
+	// InFlight is the number of requests sent to Reddit whose responses have not been read.
+	InFlight int
+	// RateLimitWaiters is the number of requests waiting for the local rate limiter or a
+	// delay requested by Reddit.
+	RateLimitWaiters int
+	// ThrottledUntil is when the current delay requested by Reddit ends, or zero if none.
+	ThrottledUntil time.Time
+	// NotFoundCacheHits is the number of requests answered with a cached 404.
+	NotFoundCacheHits int64
+	// Timing sums the phases of every request sent.
+	Timing types.RequestTimingTotals
+}
+
+// Stats returns a snapshot of the client's request activity.
+func (c *Client) Stats() ClientStats {
+	stats := ClientStats{
+		InFlight:          int(c.inFlight.Load()),
+		RateLimitWaiters:  int(c.rateLimitWaiters.Load()),
+		NotFoundCacheHits: c.notFound.hitCount(),
+		Timing:            c.timing.snapshot(),
+	}
+	if until := c.forceWaitUntil.Load(); until != 0 && time.Now().UnixNano() < until {
+		stats.ThrottledUntil = time.Unix(0, until)
+	}
+	return stats
 }
 
 // RateLimitConfig controls how requests are throttled before reaching Reddit.
@@ -137,13 +179,10 @@ func NewClientWithRateLimit(httpClient *http.Client, baseURL string, userAgent s
 		httpClient = http.DefaultClient
 	}
 
-	parsedURL, err := url.Parse(baseURL)
+	parsedURL, err := parseBaseURL(baseURL)
 	if err != nil {
 		return nil, &pkgerrs.ClientError{Err: err}
 	}
-	if !strings.HasSuffix(parsedURL.Path, "/") {
-		parsedURL.Path += "/"
-	}
 
 	// Build rate limiter with config
 	limiter := buildLimiter(cfg)
@@ -182,54 +221,39 @@ func (c *Client) SetLogBodyLimit(limit int) {
 // Optional query parameters can be provided as url.Values.
 // Note: The caller is responsible for setting authentication headers.
 func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Reader, params ...url.Values) (*http.Request, error) {
-	u, err := c.BaseURL.Parse(path)
-	if err != nil {
-		return nil, &pkgerrs.ClientError{Err: err}
-	}
-
-	// Add query parameters if provided
-	if len(params) > 0 && params[0] != nil {
-		q := u.Query()
-		for key, values := range params[0] {
-			for _, value := range values {
-				q.Add(key, value)
-			}
-		}
-		u.RawQuery = q.Encode()
-	}
-
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
-	if err != nil {
-		return nil, &pkgerrs.ClientError{Err: err}
-	}
-
-	req.Header.Set("User-Agent", c.UserAgent)
-
-	return req, nil
+	return c.NewEndpointRequest(ctx, method, APIEndpoint(path), body, params...)
 }
 
-// doRequest handles the common HTTP request flow and returns raw response body.
+// doRequestOnce handles the common HTTP request flow and returns raw response body.
 // This centralizes rate limiting, logging, and error handling for all HTTP operations.
-func (c *Client) doRequest(req *http.Request) ([]byte, *http.Response, error) {
+// Retries are layered on top by doRequest.
+func (c *Client) doRequestOnce(req *http.Request) ([]byte, *http.Response, error) {
 	ctx := req.Context()
 	start := time.Now()
 
 	// Rate limiting
-	if err := c.waitForRateLimit(ctx); err != nil {
+	c.rateLimitWaiters.Add(1)
+	err := c.waitForRateLimit(ctx)
+	c.rateLimitWaiters.Add(-1)
+	if err != nil {
 		c.logWaitFailure(ctx, req, err)
 		return nil, nil, &pkgerrs.ClientError{Err: err}
 	}
 
 	// Execute request
-	resp, err := c.client.Do(req)
+	c.inFlight.Add(1)
+	defer c.inFlight.Add(-1)
+	tracedReq, trace := traceRequest(req)
+	resp, err := c.client.Do(tracedReq)
 	if err != nil {
 		c.logTransportError(ctx, req, time.Since(start), err)
-		return nil, nil, &pkgerrs.ClientError{Err: err}
+		return nil, nil, &pkgerrs.ClientError{Err: transportError(ctx, err), Timing: c.finishTrace(trace)}
 	}
 	defer resp.Body.Close()
 
 	// Apply rate limit headers
 	c.applyRateHeaders(resp)
+	recordRateLimit(ctx, resp)
 
 	// Read body using pooled buffer with size limit to prevent DoS
 	buf := getBuffer()
@@ -240,7 +264,7 @@ func (c *Client) doRequest(req *http.Request) ([]byte, *http.Response, error) {
 	bytesRead, err := io.Copy(buf, limitedReader)
 	if err != nil {
 		c.logBodyReadError(ctx, req, resp, time.Since(start), err)
-		return nil, resp, &pkgerrs.ClientError{Err: err}
+		return nil, resp, &pkgerrs.ClientError{Err: transportError(ctx, err), Timing: c.finishTrace(trace)}
 	}
 
 	// Check if we hit the size limit
@@ -250,9 +274,10 @@ func (c *Client) doRequest(req *http.Request) ([]byte, *http.Response, error) {
 		if n, _ := resp.Body.Read(extraByte[:]); n > 0 {
 			err := fmt.Errorf("response body exceeded max size of %d bytes", maxResponseBodySize)
 			c.logBodyReadError(ctx, req, resp, time.Since(start), err)
-			return nil, resp, &pkgerrs.ClientError{Err: err}
+			return nil, resp, &pkgerrs.ClientError{Err: err, Timing: c.finishTrace(trace)}
 		}
 	}
+	timing := c.finishTrace(trace)
 
 	// Copy buffer contents to returned byte slice
 	bodyBytes := make([]byte, buf.Len())
@@ -262,12 +287,57 @@ func (c *Client) doRequest(req *http.Request) ([]byte, *http.Response, error) {
 
 	// Check HTTP status
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return bodyBytes, resp, &pkgerrs.APIError{StatusCode: resp.StatusCode, Message: "request failed"}
+		return bodyBytes, resp, &pkgerrs.APIError{
+			StatusCode: resp.StatusCode,
+			Message:    "request failed",
+			Reason:     errorReason(bodyBytes),
+			RetryAfter: retryAfter(resp),
+			Timing:     timing,
+		}
 	}
 
 	return bodyBytes, resp, nil
 }
 
+// errorReason extracts the reason Reddit reports in an error body, such as
+// {"reason": "private", "message": "Forbidden", "error": 403}. Bodies without one are
+// checked for Reddit's heavy-load and read-only maintenance pages. Returns "" if none apply.
+func errorReason(body []byte) string {
+	if len(body) > 0 && body[0] == '{' {
+		var payload struct {
+			Reason string `json:"reason"`
+		}
+		if err := json.Unmarshal(body, &payload); err == nil && payload.Reason != "" {
+			return payload.Reason
+		}
+	}
+	return degradedReason(body)
+}
+
+// degradedReason recognizes the text of Reddit's maintenance and overload responses.
+func degradedReason(body []byte) string {
+	if len(body) > maxDegradedScanBytes {
+		body = body[:maxDegradedScanBytes]
+	}
+	text := strings.ToLower(string(body))
+	switch {
+	case strings.Contains(text, "read_only_mode"), strings.Contains(text, "read-only mode"), strings.Contains(text, "read only mode"):
+		return pkgerrs.ReasonReadOnly
+	case strings.Contains(text, "heavy load"), strings.Contains(text, "servers are busy"):
+		return pkgerrs.ReasonHeavyLoad
+	}
+	return ""
+}
+
+// retryAfter returns the wait requested by a Retry-After header given in seconds, or zero.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.ParseFloat(resp.Header.Get("Retry-After"), ParseFloatBitSize)
+	if err != nil || seconds <= 0 || math.IsInf(seconds, 0) {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
 // Do sends an API request and returns the API response. The API response is
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occurred.
@@ -278,7 +348,25 @@ func (c *Client) Do(req *http.Request, v *types.Thing) error {
 	}
 
 	if v != nil && len(bodyBytes) > 0 {
-		if err := json.Unmarshal(bodyBytes, v); err != nil {
+		if err := c.unmarshal(bodyBytes, v); err != nil {
+			c.logDecodeError(req.Context(), req, resp, err)
+			return &pkgerrs.ClientError{Err: err}
+		}
+	}
+
+	return nil
+}
+
+// DoJSON sends an API request and decodes the JSON response body into v.
+// Used for endpoints that return plain JSON objects rather than Things.
+func (c *Client) DoJSON(req *http.Request, v any) error {
+	bodyBytes, resp, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	if v != nil && len(bodyBytes) > 0 {
+		if err := c.unmarshal(bodyBytes, v); err != nil {
 			c.logDecodeError(req.Context(), req, resp, err)
 			return &pkgerrs.ClientError{Err: err}
 		}
@@ -300,13 +388,13 @@ func (c *Client) DoThingArray(req *http.Request) ([]*types.Thing, error) {
 
 	if len(bodyBytes) > 0 && bodyBytes[0] == '[' {
 		// It's an array response
-		if err := json.Unmarshal(bodyBytes, &result); err != nil {
+		if err := c.unmarshal(bodyBytes, &result); err != nil {
 			return nil, &pkgerrs.ClientError{Err: fmt.Errorf("failed to parse array response: %w", err)}
 		}
 	} else if len(bodyBytes) > 0 && bodyBytes[0] == '{' {
 		// It's a single object - could be a Listing or an error
 		var singleThing types.Thing
-		if err := json.Unmarshal(bodyBytes, &singleThing); err != nil {
+		if err := c.unmarshal(bodyBytes, &singleThing); err != nil {
 			// Check if it's an error response
 			var errObj struct {
 				Error   string `json:"error"`
@@ -348,13 +436,13 @@ func (c *Client) DoMoreChildren(req *http.Request) ([]*types.Thing, error) {
 		} `json:"json"`
 	}
 
-	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+	if err := c.unmarshal(bodyBytes, &response); err != nil {
 		return nil, &pkgerrs.ClientError{Err: fmt.Errorf("failed to parse morechildren response: %w", err)}
 	}
 
 	// Check for API errors
 	if len(response.JSON.Errors) > 0 {
-		return nil, &pkgerrs.APIError{StatusCode: resp.StatusCode, Message: fmt.Sprintf("API error: %v", response.JSON.Errors[0])}
+		return nil, pkgerrs.NewAPIErrorFromList(resp.StatusCode, response.JSON.Errors)
 	}
 
 	return response.JSON.Data.Things, nil
@@ -540,6 +628,15 @@ func (c *Client) logWaitFailure(ctx context.Context, req *http.Request, err erro
 	)
 }
 
+// transportError classifies a network failure, leaving errors caused by ctx ending as they
+// are so callers can still match context.Canceled and context.DeadlineExceeded.
+func transportError(ctx context.Context, err error) error {
+	if ctx != nil && ctx.Err() != nil {
+		return err
+	}
+	return classifyTransportError(err)
+}
+
 func (c *Client) logTransportError(ctx context.Context, req *http.Request, duration time.Duration, err error) {
 	if c.logger == nil {
 		return
@@ -974,6 +974,9 @@ func TestClient_DoMoreChildren_APIError(t *testing.T) {
 	if !bytes.Contains([]byte(apiErr.Error()), []byte("THREAD_LOCKED")) {
 		t.Errorf("expected error to contain 'THREAD_LOCKED', got %q", apiErr.Error())
 	}
+	if len(apiErr.Sub) != 1 || apiErr.Sub[0].ErrorCode != pkgerrs.ReasonThreadLocked || apiErr.Sub[0].Message != "that comment is archived" {
+		t.Errorf("expected one structured THREAD_LOCKED sub error, got %+v", apiErr.Sub)
+	}
 }
 
 func TestClient_DoMoreChildren_InvalidJSON(t *testing.T) {
@@ -1035,3 +1038,183 @@ func TestClient_DoMoreChildren_MalformedStructure(t *testing.T) {
 		t.Fatalf("expected empty Things for missing data.things field, got %d", len(things))
 	}
 }
+
+func TestClient_DoJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"is_flair_required":true,"title_text_max_length":100}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.Client(), server.URL+"/", "agent", nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "api/v1/golang/post_requirements", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var out struct {
+		IsFlairRequired    bool `json:"is_flair_required"`
+		TitleTextMaxLength int  `json:"title_text_max_length"`
+	}
+	if err := c.DoJSON(req, &out); err != nil {
+		t.Fatalf("DoJSON returned error: %v", err)
+	}
+	if !out.IsFlairRequired || out.TitleTextMaxLength != 100 {
+		t.Errorf("unexpected decode result: %+v", out)
+	}
+}
+
+func TestClient_DoJSON_InvalidJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{not json`))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.Client(), server.URL+"/", "agent", nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "anything", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var out map[string]any
+	err = c.DoJSON(req, &out)
+	var clientErr *pkgerrs.ClientError
+	if !errors.As(err, &clientErr) {
+		t.Fatalf("expected ClientError, got %T: %v", err, err)
+	}
+}
+
+func TestClient_DoCapturesErrorReason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"reason": "private", "message": "Forbidden", "error": 403}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL+"/", "agent", nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "r/secret/hot", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	err = client.Do(req, &types.Thing{})
+	var apiErr *pkgerrs.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %T: %v", err, err)
+	}
+	if apiErr.Reason != "private" {
+		t.Errorf("Reason = %q, want %q", apiErr.Reason, "private")
+	}
+}
+
+func TestClient_DoCapturesDegradedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "90")
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`<html><body>All of our servers are busy right now. Reddit is under heavy load.</body></html>`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL+"/", "agent", nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	req, err := client.NewRequest(context.Background(), http.MethodGet, "r/golang/hot", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	err = client.Do(req, &types.Thing{})
+	var apiErr *pkgerrs.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %T: %v", err, err)
+	}
+	if apiErr.Reason != pkgerrs.ReasonHeavyLoad {
+		t.Errorf("Reason = %q, want %q", apiErr.Reason, pkgerrs.ReasonHeavyLoad)
+	}
+	if apiErr.RetryAfter != 90*time.Second {
+		t.Errorf("RetryAfter = %v, want 90s", apiErr.RetryAfter)
+	}
+}
+
+func TestDegradedReason(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"json": {"errors": [["READ_ONLY_MODE", "reddit is in read-only mode", ""]]}}`, pkgerrs.ReasonReadOnly},
+		{`<p>Reddit is in read only mode for maintenance.</p>`, pkgerrs.ReasonReadOnly},
+		{`<p>we're sorry, but you've hit reddit during HEAVY LOAD</p>`, pkgerrs.ReasonHeavyLoad},
+		{`<p>Bad gateway</p>`, ""},
+		{``, ""},
+	}
+	for _, tt := range tests {
+		if got := errorReason([]byte(tt.body)); got != tt.want {
+			t.Errorf("errorReason(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestClient_Stats(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+		_, _ = w.Write([]byte(`{"kind":"Listing","data":{"children":[]}}`))
+	}))
+	defer server.Close()
+
+	// One request per minute with a burst of one: the second request waits on the limiter.
+	client, err := NewClientWithRateLimit(server.Client(), server.URL+"/", "agent", nil, RateLimitConfig{RequestsPerMinute: 1, Burst: 1})
+	if err != nil {
+		t.Fatalf("NewClientWithRateLimit returned error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			req, err := client.NewRequest(ctx, http.MethodGet, "r/golang/new", nil)
+			if err != nil {
+				errs <- err
+				return
+			}
+			errs <- client.Do(req, &types.Thing{})
+		}()
+	}
+	<-entered
+
+	deadline := time.Now().Add(time.Second)
+	var stats ClientStats
+	for time.Now().Before(deadline) {
+		if stats = client.Stats(); stats.InFlight == 1 && stats.RateLimitWaiters == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if stats.InFlight != 1 || stats.RateLimitWaiters != 1 {
+		t.Errorf("Stats() = %+v, want 1 in flight and 1 waiter", stats)
+	}
+
+	cancel()
+	close(release)
+	<-errs
+	<-errs
+	if stats := client.Stats(); stats.InFlight != 0 || stats.RateLimitWaiters != 0 {
+		t.Errorf("Stats() after requests = %+v, want zero counts", stats)
+	}
+}
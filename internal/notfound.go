@@ -0,0 +1,140 @@
+package internal
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+const (
+	// DefaultNotFoundTTL is how long a 404 is remembered the first time when none is configured
+	DefaultNotFoundTTL = 10 * time.Minute
+	// DefaultNotFoundMaxTTL caps the growing TTL of a URL that keeps returning 404
+	DefaultNotFoundMaxTTL = 24 * time.Hour
+	// DefaultNotFoundMaxEntries bounds how many URLs the negative cache remembers
+	DefaultNotFoundMaxEntries = 10000
+)
+
+// NotFoundCacheConfig controls the negative cache of GET requests that returned 404.
+type NotFoundCacheConfig struct {
+	// TTL is how long a URL's first 404 is remembered. Defaults to DefaultNotFoundTTL.
+	TTL time.Duration
+	// MaxTTL caps the TTL, which doubles each time a remembered URL returns 404 again.
+	// Defaults to DefaultNotFoundMaxTTL.
+	MaxTTL time.Duration
+	// MaxEntries bounds the number of URLs remembered. Defaults to DefaultNotFoundMaxEntries.
+	MaxEntries int
+}
+
+// notFoundEntry records a URL that returned 404.
+type notFoundEntry struct {
+	until  time.Time     // requests before this return the cached error
+	ttl    time.Duration // lifetime of the current entry; doubles on each repeated 404
+	reason string        // Reddit's reason, e.g. "banned"
+}
+
+// notFoundCache remembers URLs that returned 404, so repeated lookups of deleted or banned
+// content fail locally instead of spending rate limit budget.
+type notFoundCache struct {
+	cfg NotFoundCacheConfig
+
+	mu      sync.Mutex
+	entries map[string]*notFoundEntry
+	hits    int64
+}
+
+// SetNotFoundCache enables the negative cache of 404 responses to GET requests.
+func (c *Client) SetNotFoundCache(cfg NotFoundCacheConfig) {
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultNotFoundTTL
+	}
+	if cfg.MaxTTL <= 0 {
+		cfg.MaxTTL = DefaultNotFoundMaxTTL
+	}
+	if cfg.MaxTTL < cfg.TTL {
+		cfg.MaxTTL = cfg.TTL
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = DefaultNotFoundMaxEntries
+	}
+	c.notFound = &notFoundCache{cfg: cfg, entries: make(map[string]*notFoundEntry)}
+}
+
+// lookup returns the cached 404 for req, or nil if there is none.
+func (n *notFoundCache) lookup(req *http.Request, now time.Time) error {
+	if n == nil || req.Method != http.MethodGet {
+		return nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	entry, ok := n.entries[req.URL.String()]
+	if !ok || !now.Before(entry.until) {
+		return nil
+	}
+	n.hits++
+	return &pkgerrs.APIError{StatusCode: http.StatusNotFound, Message: "not found (cached)", Reason: entry.reason}
+}
+
+// record updates the cache with the outcome of req: a 404 is remembered, for twice as long
+// as last time if the URL was already known, while a success or another 4xx forgets the URL.
+// Server and transport errors say nothing about the content and leave it as it was.
+func (n *notFoundCache) record(req *http.Request, err error, now time.Time) {
+	if n == nil || req.Method != http.MethodGet {
+		return
+	}
+	key := req.URL.String()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	apiErr, ok := err.(*pkgerrs.APIError)
+	if !ok || apiErr.StatusCode != http.StatusNotFound {
+		if err == nil || (ok && apiErr.StatusCode < http.StatusInternalServerError) {
+			delete(n.entries, key)
+		}
+		return
+	}
+
+	entry, known := n.entries[key]
+	if !known {
+		if len(n.entries) >= n.cfg.MaxEntries {
+			n.evict(now)
+		}
+		entry = &notFoundEntry{ttl: n.cfg.TTL}
+		n.entries[key] = entry
+	} else {
+		entry.ttl = min(entry.ttl*2, n.cfg.MaxTTL)
+	}
+	entry.until = now.Add(entry.ttl)
+	entry.reason = apiErr.Reason
+}
+
+// evict makes room for one entry, dropping expired entries whose TTL has not grown, or
+// failing that the entry closest to expiry. The caller must hold n.mu.
+func (n *notFoundCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range n.entries {
+		if !now.Before(entry.until) && entry.ttl == n.cfg.TTL {
+			delete(n.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.until.Before(oldest) {
+			oldestKey, oldest = key, entry.until
+		}
+	}
+	if len(n.entries) >= n.cfg.MaxEntries {
+		delete(n.entries, oldestKey)
+	}
+}
+
+// hitCount returns the number of requests answered from the cache.
+func (n *notFoundCache) hitCount() int64 {
+	if n == nil {
+		return 0
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.hits
+}
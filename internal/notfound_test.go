@@ -0,0 +1,92 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestClient_NotFoundCache(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"reason":"banned","message":"Not Found","error":404}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL+"/", "agent", nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	client.SetNotFoundCache(NotFoundCacheConfig{})
+
+	for i := range 3 {
+		req, err := client.NewRequest(context.Background(), http.MethodGet, "r/gone/about", nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+		var apiErr *pkgerrs.APIError
+		if err := client.DoJSON(req, nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Reason != "banned" {
+			t.Errorf("request %d error = %v, want banned 404", i, err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d calls, want 1", got)
+	}
+	if hits := client.Stats().NotFoundCacheHits; hits != 2 {
+		t.Errorf("NotFoundCacheHits = %d, want 2", hits)
+	}
+
+	// Other methods are always sent.
+	req, _ := client.NewRequest(context.Background(), http.MethodPost, "r/gone/about", nil)
+	_ = client.DoJSON(req, nil)
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server saw %d calls after POST, want 2", got)
+	}
+}
+
+func TestNotFoundCache_Backoff(t *testing.T) {
+	client := &Client{}
+	client.SetNotFoundCache(NotFoundCacheConfig{TTL: time.Minute, MaxTTL: 3 * time.Minute, MaxEntries: 2})
+	cache := client.notFound
+	req := httptest.NewRequest(http.MethodGet, "https://oauth.reddit.com/comments/abc", nil)
+	notFound := &pkgerrs.APIError{StatusCode: http.StatusNotFound}
+	now := time.Unix(1700000000, 0)
+
+	// Each repeated 404 doubles the TTL up to MaxTTL.
+	for _, ttl := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		cache.record(req, notFound, now)
+		if cache.lookup(req, now.Add(ttl-time.Second)) == nil {
+			t.Errorf("entry expired before %v", ttl)
+		}
+		now = now.Add(ttl)
+		if cache.lookup(req, now) != nil {
+			t.Errorf("entry outlived %v", ttl)
+		}
+	}
+
+	// Server errors keep the entry; a success forgets it.
+	cache.record(req, &pkgerrs.APIError{StatusCode: http.StatusServiceUnavailable}, now)
+	if _, ok := cache.entries[req.URL.String()]; !ok {
+		t.Error("503 removed the entry")
+	}
+	cache.record(req, nil, now)
+	if _, ok := cache.entries[req.URL.String()]; ok {
+		t.Error("success kept the entry")
+	}
+
+	// The cache stays within MaxEntries.
+	for _, path := range []string{"a", "b", "c"} {
+		cache.record(httptest.NewRequest(http.MethodGet, "https://oauth.reddit.com/"+path, nil), notFound, now)
+	}
+	if len(cache.entries) != 2 {
+		t.Errorf("cache holds %d entries, want 2", len(cache.entries))
+	}
+}
@@ -2,12 +2,16 @@ package internal
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
 	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
 	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
 )
@@ -15,10 +19,48 @@ import (
 // MaxCommentDepth is the maximum depth of nested comments to prevent stack overflow attacks
 const MaxCommentDepth = 50
 
+// RedditWebURL is the base of links that open in Reddit's website rather than the API
+const RedditWebURL = "https://www.reddit.com/"
+
 // Parser handles parsing of Reddit API responses with context support and optimized performance
 type Parser struct {
 	logger *slog.Logger
 	pool   sync.Pool // Reuse parsing structures for better performance
+	codec  Codec     // decodes Thing data; nil means encoding/json
+
+	maxDepth int          // levels of a comment tree to parse; 0 means up to MaxCommentDepth
+	cursors  CursorPolicy // treatment of listing cursors that are not fullnames
+}
+
+// CursorPolicy says how listing cursors (after and before) that are not fullnames are
+// treated. Reddit occasionally sends valid cursors in other formats.
+type CursorPolicy int
+
+const (
+	// CursorStrict rejects cursors that are not fullnames.
+	CursorStrict CursorPolicy = iota
+	// CursorLenient logs and accepts cursors that are not fullnames, as long as they are
+	// short printable tokens that are safe to send back.
+	CursorLenient
+)
+
+// maxCursorLength bounds the cursors CursorLenient accepts.
+const maxCursorLength = 100
+
+// validCursor reports whether token is acceptable under policy.
+func validCursor(token string, policy CursorPolicy) bool {
+	if validation.IsValidFullname(token) {
+		return true
+	}
+	if policy != CursorLenient || len(token) > maxCursorLength {
+		return false
+	}
+	for i := 0; i < len(token); i++ {
+		if token[i] <= ' ' || token[i] > '~' {
+			return false
+		}
+	}
+	return true
 }
 
 // NewParser creates a new parser instance with an optional logger.
@@ -41,6 +83,34 @@ func NewParser(logger ...*slog.Logger) *Parser {
 	}
 }
 
+// SetMaxDepth limits how many levels of a comment tree are parsed: 1 parses only top-level
+// comments, 2 adds their direct replies, and so on. Replies below the limit are recorded in
+// their parent's MoreChildrenIDs instead of being parsed. Zero removes the limit, leaving
+// only MaxCommentDepth. The limit does not apply to lazily loaded replies.
+func (p *Parser) SetMaxDepth(depth int) {
+	p.maxDepth = max(depth, 0)
+}
+
+type lazyRepliesKey struct{}
+
+// SetCursorPolicy sets how ParseListing treats cursors that are not fullnames. The default
+// is CursorStrict.
+func (p *Parser) SetCursorPolicy(policy CursorPolicy) {
+	p.cursors = policy
+}
+
+// WithLazyReplies returns a context in which parsed comments keep their replies unparsed in
+// RawReplies, to be parsed later with ParseReplies.
+func WithLazyReplies(ctx context.Context) context.Context {
+	return context.WithValue(ctx, lazyRepliesKey{}, true)
+}
+
+// lazyReplies reports whether ctx came from WithLazyReplies.
+func lazyReplies(ctx context.Context) bool {
+	lazy, _ := ctx.Value(lazyRepliesKey{}).(bool)
+	return lazy
+}
+
 // parseContext holds state for parsing operations
 type parseContext struct {
 	depth   int
@@ -99,7 +169,7 @@ func (p *Parser) ParseListing(ctx context.Context, thing *types.Thing) (*types.L
 	}
 
 	var result types.ListingData
-	if err := json.Unmarshal(thing.Data, &result); err != nil {
+	if err := p.unmarshal(thing.Data, &result); err != nil {
 		if p.logger != nil {
 			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse listing data",
 				slog.String("error", err.Error()))
@@ -108,24 +178,36 @@ func (p *Parser) ParseListing(ctx context.Context, thing *types.Thing) (*types.L
 	}
 
 	// Validate pagination tokens
-	if result.AfterFullname != "" && !validation.IsValidFullname(result.AfterFullname) {
-		if p.logger != nil {
-			p.logger.LogAttrs(ctx, slog.LevelWarn, "invalid AfterFullname from Reddit API",
-				slog.String("after", result.AfterFullname))
-		}
-		return nil, fmt.Errorf("invalid AfterFullname from Reddit API: %s", result.AfterFullname)
+	if err := p.checkCursor(ctx, "AfterFullname", "after", result.AfterFullname); err != nil {
+		return nil, err
 	}
-	if result.BeforeFullname != "" && !validation.IsValidFullname(result.BeforeFullname) {
-		if p.logger != nil {
-			p.logger.LogAttrs(ctx, slog.LevelWarn, "invalid BeforeFullname from Reddit API",
-				slog.String("before", result.BeforeFullname))
-		}
-		return nil, fmt.Errorf("invalid BeforeFullname from Reddit API: %s", result.BeforeFullname)
+	if err := p.checkCursor(ctx, "BeforeFullname", "before", result.BeforeFullname); err != nil {
+		return nil, err
 	}
 
 	return &result, nil
 }
 
+// checkCursor validates a listing cursor under the parser's CursorPolicy, logging cursors
+// that are not fullnames whether or not they are accepted.
+func (p *Parser) checkCursor(ctx context.Context, field, key, token string) error {
+	if token == "" || validation.IsValidFullname(token) {
+		return nil
+	}
+	accepted := validCursor(token, p.cursors)
+	if p.logger != nil {
+		msg := "invalid " + field + " from Reddit API"
+		if accepted {
+			msg = "accepting non-fullname " + field + " from Reddit API"
+		}
+		p.logger.LogAttrs(ctx, slog.LevelWarn, msg, slog.String(key, token))
+	}
+	if !accepted {
+		return fmt.Errorf("invalid %s from Reddit API: %s", field, token)
+	}
+	return nil
+}
+
 // ParsePost extracts a Post from a Thing of kind "t3".
 func (p *Parser) ParsePost(ctx context.Context, thing *types.Thing) (*types.Post, error) {
 	if thing == nil {
@@ -136,7 +218,7 @@ func (p *Parser) ParsePost(ctx context.Context, thing *types.Thing) (*types.Post
 	}
 
 	var result types.Post
-	if err := json.Unmarshal(thing.Data, &result); err != nil {
+	if err := p.unmarshal(thing.Data, &result); err != nil {
 		if p.logger != nil {
 			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse post data",
 				slog.String("error", err.Error()))
@@ -182,7 +264,7 @@ func (p *Parser) ParseComment(ctx context.Context, thing *types.Thing, pc *parse
 		Replies json.RawMessage `json:"replies"`
 	}
 
-	if err := json.Unmarshal(thing.Data, &data); err != nil {
+	if err := p.unmarshal(thing.Data, &data); err != nil {
 		if p.logger != nil {
 			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse comment data",
 				slog.String("error", err.Error()))
@@ -209,9 +291,17 @@ func (p *Parser) ParseComment(ctx context.Context, thing *types.Thing, pc *parse
 	}
 	pc.seenIDs[data.ID] = true
 
-	// Parse replies if present
+	// Parse replies if present, keep them raw in lazy mode, or stop at the depth limit
 	if len(data.Replies) > 0 && !bytes.Equal(data.Replies, []byte(`""`)) {
-		if err := p.parseReplies(ctx, &data.Comment, data.Replies, pc); err != nil {
+		if lazyReplies(ctx) {
+			data.Comment.RawReplies = data.Replies
+		} else if p.maxDepth > 0 && pc.depth+1 >= p.maxDepth {
+			if err := p.truncateReplies(ctx, &data.Comment, data.Replies); err != nil && p.logger != nil {
+				p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse truncated replies",
+					slog.String("error", err.Error()),
+					slog.String("comment_id", data.ID))
+			}
+		} else if err := p.parseReplies(ctx, &data.Comment, data.Replies, pc); err != nil {
 			if p.logger != nil {
 				p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse replies",
 					slog.String("error", err.Error()),
@@ -224,10 +314,29 @@ func (p *Parser) ParseComment(ctx context.Context, thing *types.Thing, pc *parse
 	return &data.Comment, nil
 }
 
+// ParseReplies parses the RawReplies of a comment loaded with WithLazyReplies into its
+// Replies and MoreChildrenIDs. The replies' own replies are left in their RawReplies.
+func (p *Parser) ParseReplies(ctx context.Context, comment *types.Comment) error {
+	if comment == nil {
+		return fmt.Errorf("comment is nil")
+	}
+	if len(comment.RawReplies) == 0 {
+		return nil
+	}
+
+	pc := p.pool.Get().(*parseContext)
+	defer p.pool.Put(pc)
+	pc.depth = 1
+	clear(pc.seenIDs)
+	pc.seenIDs[comment.ID] = true
+
+	return p.parseReplies(WithLazyReplies(ctx), comment, comment.RawReplies, pc)
+}
+
 // parseReplies handles the replies field parsing with error recovery
 func (p *Parser) parseReplies(ctx context.Context, comment *types.Comment, repliesData json.RawMessage, pc *parseContext) error {
 	var repliesThing types.Thing
-	if err := json.Unmarshal(repliesData, &repliesThing); err != nil {
+	if err := p.unmarshal(repliesData, &repliesThing); err != nil {
 		return fmt.Errorf("failed to unmarshal replies: %w", err)
 	}
 
@@ -257,6 +366,10 @@ func (p *Parser) parseReplies(ctx context.Context, comment *types.Comment, repli
 			if err != nil {
 				continue
 			}
+			if more.IsContinueThread() {
+				comment.ContinueThread = true
+				continue
+			}
 			comment.MoreChildrenIDs = append(comment.MoreChildrenIDs, more.Children...)
 		}
 	}
@@ -264,6 +377,49 @@ func (p *Parser) parseReplies(ctx context.Context, comment *types.Comment, repli
 	return nil
 }
 
+// truncateReplies stands in for parseReplies below the depth limit. Instead of parsing the
+// replies it records their IDs, and those of any "more" children, in MoreChildrenIDs so
+// they can be loaded later with morechildren, and marks the comment Truncated.
+func (p *Parser) truncateReplies(ctx context.Context, comment *types.Comment, repliesData json.RawMessage) error {
+	var repliesThing types.Thing
+	if err := p.unmarshal(repliesData, &repliesThing); err != nil {
+		return fmt.Errorf("failed to unmarshal replies: %w", err)
+	}
+	if repliesThing.Kind != "Listing" {
+		return fmt.Errorf("expected Listing for replies, got %s", repliesThing.Kind)
+	}
+	listingData, err := p.ParseListing(ctx, &repliesThing)
+	if err != nil {
+		return fmt.Errorf("failed to parse replies listing: %w", err)
+	}
+
+	for _, child := range listingData.Children {
+		switch child.Kind {
+		case "t1":
+			var id struct {
+				ID string `json:"id"`
+			}
+			if err := p.unmarshal(child.Data, &id); err != nil || id.ID == "" {
+				continue
+			}
+			comment.MoreChildrenIDs = append(comment.MoreChildrenIDs, id.ID)
+			comment.Truncated = true
+
+		case "more":
+			more, err := p.ParseMore(ctx, child)
+			if err != nil {
+				continue
+			}
+			if more.IsContinueThread() {
+				comment.ContinueThread = true
+				continue
+			}
+			comment.MoreChildrenIDs = append(comment.MoreChildrenIDs, more.Children...)
+		}
+	}
+	return nil
+}
+
 // ParseSubreddit extracts a SubredditData from a Thing of kind "t5".
 func (p *Parser) ParseSubreddit(ctx context.Context, thing *types.Thing) (*types.SubredditData, error) {
 	if thing == nil {
@@ -274,7 +430,7 @@ func (p *Parser) ParseSubreddit(ctx context.Context, thing *types.Thing) (*types
 	}
 
 	var result types.SubredditData
-	if err := json.Unmarshal(thing.Data, &result); err != nil {
+	if err := p.unmarshal(thing.Data, &result); err != nil {
 		if p.logger != nil {
 			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse subreddit data",
 				slog.String("error", err.Error()))
@@ -304,7 +460,7 @@ func (p *Parser) ParseAccount(ctx context.Context, thing *types.Thing) (*types.A
 	}
 
 	var result types.AccountData
-	if err := json.Unmarshal(thing.Data, &result); err != nil {
+	if err := p.unmarshal(thing.Data, &result); err != nil {
 		if p.logger != nil {
 			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse account data",
 				slog.String("error", err.Error()))
@@ -334,7 +490,7 @@ func (p *Parser) ParseMessage(ctx context.Context, thing *types.Thing) (*types.M
 	}
 
 	var result types.MessageData
-	if err := json.Unmarshal(thing.Data, &result); err != nil {
+	if err := p.unmarshal(thing.Data, &result); err != nil {
 		if p.logger != nil {
 			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse message data",
 				slog.String("error", err.Error()))
@@ -364,7 +520,7 @@ func (p *Parser) ParseMore(ctx context.Context, thing *types.Thing) (*types.More
 	}
 
 	var result types.MoreData
-	if err := json.Unmarshal(thing.Data, &result); err != nil {
+	if err := p.unmarshal(thing.Data, &result); err != nil {
 		if p.logger != nil {
 			p.logger.LogAttrs(ctx, slog.LevelWarn, "failed to parse more data",
 				slog.String("error", err.Error()))
@@ -509,8 +665,47 @@ func (p *Parser) collectMoreIDsWithDepth(comment *types.Comment, depth int) []st
 	return moreIDs
 }
 
+// continueThreadLinks collects a link for every comment in the trees whose replies were
+// cut off for depth, in depth-first order.
+func continueThreadLinks(comments []*types.Comment, post *types.Post) []types.Permalink {
+	var links []types.Permalink
+	var walk func(c *types.Comment, depth int)
+	walk = func(c *types.Comment, depth int) {
+		if depth > MaxCommentDepth {
+			return
+		}
+		if c.ContinueThread {
+			links = append(links, types.Permalink{ParentID: c.Name, Depth: depth + 1, URL: commentURL(c, post)})
+		}
+		for _, reply := range c.Replies {
+			walk(reply, depth+1)
+		}
+	}
+	for _, c := range comments {
+		walk(c, 0)
+	}
+	return links
+}
+
+// commentURL returns the www.reddit.com URL of c's thread, building it from the post or
+// c's link ID when Reddit did not send a permalink.
+func commentURL(c *types.Comment, post *types.Post) string {
+	switch {
+	case c.Permalink != "":
+		return RedditWebURL + strings.TrimPrefix(c.Permalink, "/")
+	case post != nil && post.Permalink != "":
+		return RedditWebURL + strings.TrimPrefix(post.Permalink, "/") + c.ID + "/"
+	default:
+		return RedditWebURL + "comments/" + strings.TrimPrefix(c.LinkID, "t3_") + "/_/" + c.ID + "/"
+	}
+}
+
 // ExtractPostAndComments parses the typical response from GetComments which contains
 // [post_listing, comments_listing]. Returns the extracted post and comments data.
+//
+// If only one of the two listings parses, the result holds that half with Partial set and
+// the failure in PostErr or CommentsErr, and is returned with a *errors.PartialResultError.
+// Only the error is returned when neither parses.
 func (p *Parser) ExtractPostAndComments(ctx context.Context, response []*types.Thing) (*types.CommentsResponse, error) {
 	if len(response) == 0 {
 		return nil, fmt.Errorf("empty response")
@@ -523,12 +718,14 @@ func (p *Parser) ExtractPostAndComments(ctx context.Context, response []*types.T
 	result := &types.CommentsResponse{}
 
 	if len(response) >= 2 {
-		// Standard format: first is post, second is comments
+		// Standard format: first is post, second is comments. A half that fails to parse
+		// is reported in the result, so the other half is not lost with it.
 		posts, err := p.ExtractPosts(ctx, response[0])
-		if err == nil && len(posts) > 0 {
+		if err != nil {
+			result.PostErr = fmt.Errorf("failed to extract post: %w", err)
+		} else if len(posts) > 0 {
 			result.Post = posts[0]
 		}
-		// Even if post extraction fails, try to extract comments
 
 		// Second element should be the comments listing - extract pagination info
 		if response[1] != nil && response[1].Kind == "Listing" {
@@ -542,16 +739,25 @@ func (p *Parser) ExtractPostAndComments(ctx context.Context, response []*types.T
 		// Extract comments from the listing
 		comments, moreIDs, err := p.ExtractComments(ctx, response[1])
 		if err != nil {
-			// If we have a post but no comments, return the post
-			if result.Post != nil {
-				return result, fmt.Errorf("failed to extract comments: %w", err)
-			}
-			// If we have neither post nor comments, return error
-			return nil, fmt.Errorf("failed to extract both post and comments")
+			result.CommentsErr = fmt.Errorf("failed to extract comments: %w", err)
+		} else {
+			result.Comments = comments
+			result.MoreIDs = moreIDs
+			result.ContinueThreadLinks = continueThreadLinks(comments, result.Post)
 		}
 
-		result.Comments = comments
-		result.MoreIDs = moreIDs
+		if result.PostErr != nil && result.CommentsErr != nil {
+			return nil, fmt.Errorf("failed to extract both post and comments: %w", errors.Join(result.PostErr, result.CommentsErr))
+		}
+		if result.PostErr != nil || result.CommentsErr != nil {
+			result.Partial = true
+			return result, &pkgerrs.PartialResultError{
+				Operation: "extract post and comments",
+				Completed: 1,
+				Total:     2,
+				Err:       cmp.Or(result.PostErr, result.CommentsErr),
+			}
+		}
 		return result, nil
 	}
 
@@ -578,5 +784,6 @@ func (p *Parser) ExtractPostAndComments(ctx context.Context, response []*types.T
 
 	result.Comments = comments
 	result.MoreIDs = moreIDs
+	result.ContinueThreadLinks = continueThreadLinks(comments, nil)
 	return result, nil
 }
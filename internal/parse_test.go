@@ -3,11 +3,17 @@ package internal
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
 	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
 )
 
 func TestNewParser(t *testing.T) {
@@ -552,6 +558,51 @@ func TestParseAccount(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "account named by username",
+			thing: &types.Thing{
+				Kind: "t2",
+				Data: json.RawMessage(`{
+					"name":"spez",
+					"id":"1w72",
+					"created":1118030400,
+					"created_utc":1118030400,
+					"is_blocked":true
+				}`),
+			},
+			expectError: false,
+		},
+		{
+			name: "suspended account",
+			thing: &types.Thing{
+				Kind: "t2",
+				Data: json.RawMessage(`{
+					"name":"suspended_user",
+					"is_suspended":true,
+					"awardee_karma":0,
+					"awarder_karma":0,
+					"is_blocked":false,
+					"total_karma":0
+				}`),
+			},
+			expectError: false,
+		},
+		{
+			name: "deleted account",
+			thing: &types.Thing{
+				Kind: "t2",
+				Data: json.RawMessage(`{"name":"[deleted]"}`),
+			},
+			expectError: false,
+		},
+		{
+			name: "active account without ID",
+			thing: &types.Thing{
+				Kind: "t2",
+				Data: json.RawMessage(`{"name":"someone","created":1234567890,"created_utc":1234567890}`),
+			},
+			expectError: true,
+		},
 		{
 			name: "invalid JSON",
 			thing: &types.Thing{
@@ -685,6 +736,21 @@ func TestParseMore(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "continue this thread",
+			thing: &types.Thing{
+				Kind: "more",
+				Data: json.RawMessage(`{
+					"children":[],
+					"count":0,
+					"depth":10,
+					"id":"_",
+					"name":"t1__",
+					"parent_id":"t1_abc123"
+				}`),
+			},
+			expectError: false,
+		},
 		{
 			name: "empty children",
 			thing: &types.Thing{
@@ -1373,7 +1439,7 @@ func TestExtractPostAndComments(t *testing.T) {
 					Data: json.RawMessage(`{}`),
 				},
 			},
-			expectError:    false, // Post extraction succeeds, comment extraction fails but error contains post
+			expectError:    false, // Post extraction succeeds, comment extraction fails and is reported as partial with an error
 			expectPost:     true,
 			expectComments: 0,
 			expectMore:     0,
@@ -1389,18 +1455,24 @@ func TestExtractPostAndComments(t *testing.T) {
 					t.Errorf("expected error but got none")
 				}
 			} else {
-				// For the "invalid second listing" case, we expect an error about comment extraction
-				// but the post should still be returned
+				if result == nil {
+					t.Fatal("expected result but got nil")
+				}
+
+				// For the "invalid second listing" case, the comment extraction error is
+				// reported in the result and the error, and the post is still returned
 				if tt.name == "invalid second listing" {
-					if err == nil {
-						t.Errorf("expected comment extraction error but got none")
+					var partialErr *pkgerrs.PartialResultError
+					if !errors.As(err, &partialErr) || partialErr.Completed != 1 || partialErr.Total != 2 {
+						t.Errorf("expected PartialResultError for 1 of 2 listings, got %v", err)
+					}
+					if !result.Partial || result.CommentsErr == nil {
+						t.Errorf("expected partial result with CommentsErr, got Partial=%v CommentsErr=%v", result.Partial, result.CommentsErr)
 					}
 				} else if err != nil {
 					t.Errorf("unexpected error: %v", err)
-				}
-
-				if result == nil {
-					t.Fatal("expected result but got nil")
+				} else if result.Partial {
+					t.Errorf("unexpected partial result: post error %v, comments error %v", result.PostErr, result.CommentsErr)
 				}
 
 				if tt.expectPost {
@@ -1503,12 +1575,19 @@ func TestExtractPostAndComments_EdgeCases(t *testing.T) {
 		}
 
 		result, err := parser.ExtractPostAndComments(context.Background(), response)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
+		var partialErr *pkgerrs.PartialResultError
+		if !errors.As(err, &partialErr) {
+			t.Fatalf("expected PartialResultError, got %v", err)
+		}
+		if result == nil {
+			t.Fatal("expected partial result but got nil")
 		}
 		if result.Post != nil {
 			t.Errorf("expected no post but got one")
 		}
+		if !result.Partial || result.PostErr == nil || result.CommentsErr != nil {
+			t.Errorf("expected partial result with PostErr only, got Partial=%v PostErr=%v CommentsErr=%v", result.Partial, result.PostErr, result.CommentsErr)
+		}
 		if len(result.Comments) != 1 {
 			t.Errorf("expected 1 comment, got %d", len(result.Comments))
 		}
@@ -2339,6 +2418,42 @@ func TestParseListing_MaliciousData(t *testing.T) {
 	}
 }
 
+func TestParseListing_LenientCursors(t *testing.T) {
+	parser := NewParser()
+	parser.SetCursorPolicy(CursorLenient)
+	validator := NewValidator()
+	validator.SetCursorPolicy(CursorLenient)
+
+	listing := func(after string) *types.Thing {
+		data, _ := json.Marshal(map[string]any{"after": after, "children": []any{}})
+		return &types.Thing{Kind: "Listing", Data: data}
+	}
+
+	for _, cursor := range []string{"T3_ABC123", "dXNlcjoxMjM0NQ==", "t3_abc123:5"} {
+		result, err := parser.ParseListing(context.Background(), listing(cursor))
+		if err != nil || result.AfterFullname != cursor {
+			t.Errorf("ParseListing(%q) = %v, %v; want cursor accepted", cursor, result, err)
+		}
+		if err := validator.ValidatePagination(&types.Pagination{After: cursor}); err != nil {
+			t.Errorf("ValidatePagination(%q) = %v, want nil", cursor, err)
+		}
+		if err := validator.ValidatePaginationToken(cursor); validation.IsValidFullname(cursor) != (err == nil) {
+			t.Errorf("ValidatePaginationToken(%q) = %v; fullname checks must stay strict", cursor, err)
+		}
+	}
+	for _, cursor := range []string{"t3_abc'; DROP TABLE--", "a\nb", strings.Repeat("a", maxCursorLength+1)} {
+		if _, err := parser.ParseListing(context.Background(), listing(cursor)); err == nil {
+			t.Errorf("ParseListing(%q) accepted an unsafe cursor", cursor)
+		}
+		if err := validator.ValidatePagination(&types.Pagination{Before: cursor}); err == nil {
+			t.Errorf("ValidatePagination(%q) accepted an unsafe cursor", cursor)
+		}
+	}
+	if err := NewValidator().ValidatePagination(&types.Pagination{After: "T3_ABC123"}); err == nil {
+		t.Error("strict validator accepted a non-fullname cursor")
+	}
+}
+
 // TestParseSubreddit_MaliciousData tests that malicious subreddit data is rejected
 func TestParseSubreddit_MaliciousData(t *testing.T) {
 	parser := NewParser()
@@ -2541,3 +2656,181 @@ func TestCommentTreeWithMoreIDs(t *testing.T) {
 		}
 	}
 }
+
+func TestExtractPostAndComments_ContinueThread(t *testing.T) {
+	parser := NewParser()
+
+	response := []*types.Thing{
+		{Kind: "Listing", Data: json.RawMessage(`{"children": [{"kind": "t3", "data": {
+			"id": "post1", "name": "t3_post1", "title": "Post", "author": "poster", "subreddit": "test", "url": "https://example.com",
+			"score": 1, "ups": 1, "downs": 0, "created": 1234567890, "created_utc": 1234567890, "permalink": "/r/test/comments/post1/post/"
+		}}]}`)},
+		{Kind: "Listing", Data: json.RawMessage(`{"children": [{"kind": "t1", "data": {
+			"id": "deep", "name": "t1_deep", "author": "user1", "body": "Deep comment",
+			"score": 1, "ups": 1, "downs": 0, "created": 1234567890, "created_utc": 1234567890,
+			"parent_id": "t3_post1", "link_id": "t3_post1", "subreddit": "test",
+			"replies": {"kind": "Listing", "data": {"children": [
+				{"kind": "more", "data": {"id": "_", "name": "t1__", "count": 0, "depth": 1, "parent_id": "t1_deep", "children": []}},
+				{"kind": "more", "data": {"id": "more1", "name": "t1_more1", "count": 2, "depth": 1, "parent_id": "t1_deep", "children": ["id1", "id2"]}}
+			]}}
+		}}]}`)},
+	}
+
+	result, err := parser.ExtractPostAndComments(context.Background(), response)
+	if err != nil {
+		t.Fatalf("ExtractPostAndComments failed: %v", err)
+	}
+	if len(result.Comments) != 1 || !result.Comments[0].ContinueThread {
+		t.Fatalf("expected the comment to be marked ContinueThread, got %+v", result.Comments)
+	}
+	if len(result.MoreIDs) != 2 {
+		t.Errorf("MoreIDs = %v, want only the expandable IDs", result.MoreIDs)
+	}
+	want := types.Permalink{
+		ParentID: "t1_deep",
+		Depth:    1,
+		URL:      "https://www.reddit.com/r/test/comments/post1/post/deep/",
+	}
+	if len(result.ContinueThreadLinks) != 1 || result.ContinueThreadLinks[0] != want {
+		t.Errorf("ContinueThreadLinks = %+v, want [%+v]", result.ContinueThreadLinks, want)
+	}
+}
+
+func TestCommentURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment *types.Comment
+		post    *types.Post
+		want    string
+	}{
+		{
+			name:    "comment permalink",
+			comment: &types.Comment{ThingData: types.ThingData{ID: "c1"}, Permalink: "/r/test/comments/p1/title/c1/"},
+			want:    "https://www.reddit.com/r/test/comments/p1/title/c1/",
+		},
+		{
+			name:    "post permalink",
+			comment: &types.Comment{ThingData: types.ThingData{ID: "c1"}},
+			post:    &types.Post{Permalink: "/r/test/comments/p1/title/"},
+			want:    "https://www.reddit.com/r/test/comments/p1/title/c1/",
+		},
+		{
+			name:    "link ID only",
+			comment: &types.Comment{ThingData: types.ThingData{ID: "c1"}, LinkID: "t3_p1"},
+			want:    "https://www.reddit.com/comments/p1/_/c1/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commentURL(tt.comment, tt.post); got != tt.want {
+				t.Errorf("commentURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_LazyReplies(t *testing.T) {
+	var things []*types.Thing
+	if err := json.Unmarshal(grawtest.NewCommentTree(3, 2), &things); err != nil {
+		t.Fatal(err)
+	}
+	parser := NewParser()
+	ctx := context.Background()
+
+	resp, err := parser.ExtractPostAndComments(WithLazyReplies(ctx), things)
+	if err != nil {
+		t.Fatalf("ExtractPostAndComments: %v", err)
+	}
+	if len(resp.Comments) != 2 {
+		t.Fatalf("got %d top-level comments, want 2", len(resp.Comments))
+	}
+	top := resp.Comments[0]
+	if len(top.Replies) != 0 || len(top.RawReplies) == 0 {
+		t.Fatalf("lazy comment has %d replies and %d raw bytes, want 0 and some", len(top.Replies), len(top.RawReplies))
+	}
+
+	if err := parser.ParseReplies(ctx, top); err != nil {
+		t.Fatalf("ParseReplies: %v", err)
+	}
+	if len(top.Replies) != 2 {
+		t.Fatalf("got %d replies, want 2", len(top.Replies))
+	}
+	for _, reply := range top.Replies {
+		if reply.ParentID != top.Name {
+			t.Errorf("reply parent = %s, want %s", reply.ParentID, top.Name)
+		}
+		if len(reply.Replies) != 0 || len(reply.RawReplies) == 0 {
+			t.Errorf("reply %s was parsed eagerly", reply.ID)
+		}
+	}
+
+	child := top.Replies[0]
+	if err := parser.ParseReplies(ctx, child); err != nil {
+		t.Fatalf("ParseReplies: %v", err)
+	}
+	if len(child.Replies) != 2 {
+		t.Fatalf("got %d replies at the last level, want 2", len(child.Replies))
+	}
+	for _, c := range child.Replies {
+		if len(c.RawReplies) != 0 || len(c.Replies) != 0 {
+			t.Errorf("leaf comment %s has replies", c.ID)
+		}
+	}
+
+	eager, err := parser.ExtractPostAndComments(ctx, things)
+	if err != nil {
+		t.Fatalf("ExtractPostAndComments: %v", err)
+	}
+	if got := eager.Comments[0]; len(got.Replies) != 2 || len(got.RawReplies) != 0 {
+		t.Errorf("eager comment has %d replies and %d raw bytes, want 2 and none", len(got.Replies), len(got.RawReplies))
+	}
+}
+
+func TestParser_MaxDepth(t *testing.T) {
+	var things []*types.Thing
+	if err := json.Unmarshal(grawtest.NewCommentTree(3, 2), &things); err != nil {
+		t.Fatal(err)
+	}
+	parser := NewParser()
+	parser.SetMaxDepth(2)
+
+	resp, err := parser.ExtractPostAndComments(context.Background(), things)
+	if err != nil {
+		t.Fatalf("ExtractPostAndComments: %v", err)
+	}
+	if len(resp.Comments) != 2 {
+		t.Fatalf("got %d top-level comments, want 2", len(resp.Comments))
+	}
+	top := resp.Comments[0]
+	if top.Truncated || len(top.Replies) != 2 || len(top.MoreChildrenIDs) != 0 {
+		t.Fatalf("top comment: truncated=%v, %d replies, more=%v; want parsed replies", top.Truncated, len(top.Replies), top.MoreChildrenIDs)
+	}
+	reply := top.Replies[0]
+	if !reply.Truncated || len(reply.Replies) != 0 {
+		t.Errorf("reply at the depth limit: truncated=%v, %d replies; want truncated with none", reply.Truncated, len(reply.Replies))
+	}
+	if want := []string{"c3", "c4"}; !slices.Equal(reply.MoreChildrenIDs, want) {
+		t.Errorf("MoreChildrenIDs = %v, want %v", reply.MoreChildrenIDs, want)
+	}
+	if len(resp.MoreIDs) != 8 {
+		t.Errorf("got %d MoreIDs, want the 8 comments below the limit: %v", len(resp.MoreIDs), resp.MoreIDs)
+	}
+
+	parser.SetMaxDepth(1)
+	resp, err = parser.ExtractPostAndComments(context.Background(), things)
+	if err != nil {
+		t.Fatalf("ExtractPostAndComments: %v", err)
+	}
+	if top := resp.Comments[0]; !top.Truncated || len(top.Replies) != 0 || len(top.MoreChildrenIDs) != 2 {
+		t.Errorf("MaxDepth 1: truncated=%v, %d replies, more=%v; want only top-level comments", top.Truncated, len(top.Replies), top.MoreChildrenIDs)
+	}
+
+	parser.SetMaxDepth(0)
+	resp, err = parser.ExtractPostAndComments(context.Background(), things)
+	if err != nil {
+		t.Fatalf("ExtractPostAndComments: %v", err)
+	}
+	if got := resp.Comments[0].Replies[0]; got.Truncated || len(got.Replies) != 2 {
+		t.Errorf("no limit: truncated=%v, %d replies; want the full tree", got.Truncated, len(got.Replies))
+	}
+}
@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// RateLimitRecorder collects the rate-limit headers of responses to requests made with a
+// context from WithRateLimitRecorder, and, once CaptureHeaders is called, selected
+// diagnostic headers. It is safe for concurrent use.
+type RateLimitRecorder struct {
+	mu      sync.Mutex
+	info    *types.RateLimitInfo
+	capture []string
+	meta    *types.ResponseMeta
+}
+
+type rateLimitRecorderKey struct{}
+
+// maxRateHeaderValue bounds parsed header values; Reddit's periods are ten minutes long.
+const maxRateHeaderValue = 1e6
+
+// WithRateLimitRecorder returns a context whose requests report their rate-limit headers
+// to a new recorder.
+func WithRateLimitRecorder(ctx context.Context) (context.Context, *RateLimitRecorder) {
+	rec := &RateLimitRecorder{}
+	return context.WithValue(ctx, rateLimitRecorderKey{}, rec), rec
+}
+
+// Info returns the state from the last response that carried rate-limit headers, or nil.
+func (r *RateLimitRecorder) Info() *types.RateLimitInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.info == nil {
+		return nil
+	}
+	info := *r.info
+	return &info
+}
+
+// CaptureHeaders makes the recorder keep the named response headers as well. It must be
+// called before the recorder's context is used.
+func (r *RateLimitRecorder) CaptureHeaders(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.capture = append(r.capture, names...)
+}
+
+// Meta returns the captured headers of the last response, or nil if header capture is off
+// or no response was received.
+func (r *RateLimitRecorder) Meta() *types.ResponseMeta {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.meta == nil {
+		return nil
+	}
+	meta := *r.meta
+	meta.Header = r.meta.Header.Clone()
+	return &meta
+}
+
+// recordRateLimit passes the response's rate-limit headers, and any headers it captures, to
+// the context's recorder, if any.
+func recordRateLimit(ctx context.Context, resp *http.Response) {
+	rec, ok := ctx.Value(rateLimitRecorderKey{}).(*RateLimitRecorder)
+	if !ok || resp == nil {
+		return
+	}
+	info, ok := ParseRateLimitHeaders(resp.Header, time.Now())
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if ok {
+		rec.info = info
+	}
+	if len(rec.capture) > 0 {
+		rec.meta = types.NewResponseMeta(resp.StatusCode, resp.Header, rec.capture)
+	}
+}
+
+// ParseRateLimitHeaders reads the X-Ratelimit-Used, -Remaining, and -Reset headers.
+// Reset is a number of seconds, converted to a time relative to now. Returns false if
+// none of the headers is present and valid.
+func ParseRateLimitHeaders(h http.Header, now time.Time) (*types.RateLimitInfo, bool) {
+	info := &types.RateLimitInfo{}
+	found := false
+	if v, ok := parseRateHeader(h, "X-Ratelimit-Used"); ok {
+		info.Used = int(v)
+		found = true
+	}
+	if v, ok := parseRateHeader(h, "X-Ratelimit-Remaining"); ok {
+		info.Remaining = v
+		found = true
+	}
+	if v, ok := parseRateHeader(h, "X-Ratelimit-Reset"); ok {
+		info.ResetAt = now.Add(time.Duration(v * float64(time.Second)))
+		found = true
+	}
+	return info, found
+}
+
+// parseRateHeader parses a non-negative, finite numeric header value.
+func parseRateHeader(h http.Header, name string) (float64, bool) {
+	v, err := strconv.ParseFloat(h.Get(name), ParseFloatBitSize)
+	if err != nil || v < 0 || math.IsInf(v, 0) || v > maxRateHeaderValue {
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name      string
+		headers   map[string]string
+		wantOK    bool
+		wantUsed  int
+		wantLeft  float64
+		wantReset time.Time
+	}{
+		{
+			name:      "all headers",
+			headers:   map[string]string{"X-Ratelimit-Used": "12", "X-Ratelimit-Remaining": "588.0", "X-Ratelimit-Reset": "300"},
+			wantOK:    true,
+			wantUsed:  12,
+			wantLeft:  588,
+			wantReset: now.Add(300 * time.Second),
+		},
+		{
+			name:     "partial",
+			headers:  map[string]string{"X-Ratelimit-Remaining": "10"},
+			wantOK:   true,
+			wantLeft: 10,
+		},
+		{
+			name:    "invalid values ignored",
+			headers: map[string]string{"X-Ratelimit-Used": "-1", "X-Ratelimit-Remaining": "Inf", "X-Ratelimit-Reset": "soon"},
+		},
+		{
+			name: "no headers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			info, ok := ParseRateLimitHeaders(h, now)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if info.Used != tt.wantUsed || info.Remaining != tt.wantLeft || !info.ResetAt.Equal(tt.wantReset) {
+				t.Errorf("info = %+v", info)
+			}
+		})
+	}
+}
+
+func TestRateLimitRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Ratelimit-Used", "5")
+		w.Header().Set("X-Ratelimit-Remaining", "595")
+		w.Header().Set("X-Ratelimit-Reset", "120")
+		w.Write([]byte(`{"kind":"Listing","data":{"children":[]}}`))
+	}))
+	defer server.Close()
+
+	c, err := NewClient(server.Client(), server.URL, "agent", nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, rec := WithRateLimitRecorder(context.Background())
+	if rec.Info() != nil {
+		t.Fatal("recorder should be empty before any request")
+	}
+	req, err := c.NewRequest(ctx, http.MethodGet, "/hot", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+	if err := c.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	info := rec.Info()
+	if info == nil || info.Used != 5 || info.Remaining != 595 || time.Until(info.ResetAt) <= 0 {
+		t.Errorf("recorded info = %+v", info)
+	}
+	if rec.Meta() != nil {
+		t.Error("recorder captured headers without CaptureHeaders")
+	}
+
+	ctx, rec = WithRateLimitRecorder(context.Background())
+	rec.CaptureHeaders("x-ratelimit-used", "X-Missing")
+	req, _ = c.NewRequest(ctx, http.MethodGet, "/hot", nil)
+	if err := c.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if meta := rec.Meta(); meta == nil || meta.StatusCode != http.StatusOK || len(meta.Header) != 1 || meta.Header.Get("X-Ratelimit-Used") != "5" {
+		t.Errorf("captured meta = %+v", meta)
+	}
+
+	// Requests without a recorder are unaffected.
+	req, _ = c.NewRequest(context.Background(), http.MethodGet, "/hot", nil)
+	if err := c.Do(req, nil); err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+}
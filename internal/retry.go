@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+const (
+	// DefaultRetryInitialBackoff is the delay before the first retry when none is configured
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+	// DefaultRetryMaxBackoff caps the delay between retries when none is configured
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryConfig controls automatic retries of idempotent requests that fail with a
+// retryable transport error or a transient HTTP status (429, 500, 502, 503, 504).
+// Transport errors that would only repeat, such as an unknown host or an untrusted
+// certificate, are not retried.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry. Defaults to DefaultRetryInitialBackoff.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the exponentially growing delay. Defaults to DefaultRetryMaxBackoff.
+	MaxBackoff time.Duration
+}
+
+// SetRetryConfig enables retries of idempotent requests. A zero MaxRetries disables them.
+func (c *Client) SetRetryConfig(cfg RetryConfig) {
+	if cfg.InitialBackoff <= 0 {
+		cfg.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if cfg.MaxBackoff < cfg.InitialBackoff {
+		cfg.MaxBackoff = cfg.InitialBackoff
+	}
+	c.retry = cfg
+}
+
+// doRequest performs the request, retrying transient failures of idempotent requests
+// according to the client's RetryConfig. Retry-After and rate limit headers from failed
+// attempts are honored by the rate limiter before the next attempt. GET requests for URLs
+// in the negative cache fail without being sent.
+func (c *Client) doRequest(req *http.Request) ([]byte, *http.Response, error) {
+	if err := c.notFound.lookup(req, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	body, resp, err := c.doRequestWithRetries(req)
+	c.notFound.record(req, err, time.Now())
+	return body, resp, err
+}
+
+// doRequestWithRetries performs the request, retrying as configured.
+func (c *Client) doRequestWithRetries(req *http.Request) ([]byte, *http.Response, error) {
+	body, resp, err := c.doRequestOnce(req)
+	if c.retry.MaxRetries <= 0 || !isIdempotent(req) {
+		return body, resp, err
+	}
+
+	ctx := req.Context()
+	for attempt := 1; attempt <= c.retry.MaxRetries && shouldRetry(ctx, resp, err); attempt++ {
+		delay := c.retryBackoff(attempt)
+		if c.logger != nil {
+			c.logger.LogAttrs(ctx, slog.LevelWarn, "retrying reddit request",
+				slog.String("method", req.Method),
+				slog.String("url", req.URL.String()),
+				slog.Int("attempt", attempt),
+				slog.Duration("backoff", delay),
+				slog.String("error", err.Error()))
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, &pkgerrs.ClientError{Err: ctx.Err()}
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			if fresh, bodyErr := req.GetBody(); bodyErr == nil {
+				req.Body = fresh
+			}
+		}
+		body, resp, err = c.doRequestOnce(req)
+	}
+	return body, resp, err
+}
+
+// retryBackoff returns the delay before the given retry attempt (starting at 1).
+func (c *Client) retryBackoff(attempt int) time.Duration {
+	delay := c.retry.InitialBackoff
+	for i := 1; i < attempt && delay < c.retry.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.retry.MaxBackoff {
+		delay = c.retry.MaxBackoff
+	}
+	return delay
+}
+
+// shouldRetry reports whether a failed attempt is worth repeating.
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var apiErr *pkgerrs.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+			http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var transportErr *pkgerrs.TransportError
+	if errors.As(err, &transportErr) {
+		return transportErr.Retryable()
+	}
+	// No response means the request failed in transport
+	return resp == nil
+}
+
+// isIdempotent reports whether req can be safely sent more than once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		statuses  []int
+		retries   int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "recovers after 503", method: http.MethodGet, statuses: []int{503, 502, 200}, retries: 3, wantCalls: 3},
+		{name: "gives up after max retries", method: http.MethodGet, statuses: []int{500, 500, 500, 500}, retries: 2, wantCalls: 3, wantErr: true},
+		{name: "does not retry 404", method: http.MethodGet, statuses: []int{404, 200}, retries: 3, wantCalls: 1, wantErr: true},
+		{name: "does not retry POST", method: http.MethodPost, statuses: []int{503, 200}, retries: 3, wantCalls: 1, wantErr: true},
+		{name: "disabled by default", method: http.MethodGet, statuses: []int{503, 200}, retries: 0, wantCalls: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := calls.Add(1)
+				w.WriteHeader(tt.statuses[n-1])
+				_, _ = w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.Client(), server.URL+"/", "agent", nil)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			client.SetRetryConfig(RetryConfig{MaxRetries: tt.retries, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond})
+
+			var body io.Reader
+			if tt.method == http.MethodPost {
+				body = strings.NewReader("a=b")
+			}
+			req, err := client.NewRequest(context.Background(), tt.method, "api/test", body)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+
+			err = client.DoJSON(req, &struct{}{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("server saw %d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClient_RetryStopsOnContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL+"/", "agent", nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	client.SetRetryConfig(RetryConfig{MaxRetries: 5, InitialBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, err := client.NewRequest(ctx, http.MethodGet, "api/test", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	err = client.DoJSON(req, nil)
+	var clientErr *pkgerrs.ClientError
+	if !errors.As(err, &clientErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context deadline ClientError, got %T: %v", err, err)
+	}
+}
+
+func TestClient_RetryBackoff(t *testing.T) {
+	client := &Client{}
+	client.SetRetryConfig(RetryConfig{MaxRetries: 5, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 350 * time.Millisecond})
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 350 * time.Millisecond, 350 * time.Millisecond}
+	for i, w := range want {
+		if got := client.retryBackoff(i + 1); got != w {
+			t.Errorf("retryBackoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+// failingTransport fails every request with err.
+type failingTransport struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (f *failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	f.calls.Add(1)
+	return nil, f.err
+}
+
+func TestClient_RetriesTransportErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantKind  pkgerrs.TransportErrorKind
+		wantCalls int32
+	}{
+		{name: "connection reset", err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, wantKind: pkgerrs.TransportReset, wantCalls: 3},
+		{name: "temporary DNS failure", err: &net.DNSError{Err: "server misbehaving", Name: "oauth.reddit.com", IsTemporary: true}, wantKind: pkgerrs.TransportDNS, wantCalls: 3},
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", Name: "oauth.reddit.com", IsNotFound: true}, wantKind: pkgerrs.TransportDNS, wantCalls: 1},
+		{name: "untrusted certificate", err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}, wantKind: pkgerrs.TransportTLS, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &failingTransport{err: tt.err}
+			client, err := NewClient(&http.Client{Transport: transport}, "https://oauth.reddit.com/", "agent", nil)
+			if err != nil {
+				t.Fatalf("NewClient returned error: %v", err)
+			}
+			client.SetRetryConfig(RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond})
+			req, err := client.NewRequest(context.Background(), http.MethodGet, "api/test", nil)
+			if err != nil {
+				t.Fatalf("NewRequest returned error: %v", err)
+			}
+
+			err = client.DoJSON(req, nil)
+			var transportErr *pkgerrs.TransportError
+			if !errors.As(err, &transportErr) || transportErr.Kind != tt.wantKind {
+				t.Fatalf("error = %v, want %s TransportError", err, tt.wantKind)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("error %v does not wrap %v", err, tt.err)
+			}
+			if got := transport.calls.Load(); got != tt.wantCalls {
+				t.Errorf("transport saw %d calls, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
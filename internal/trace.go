@@ -0,0 +1,145 @@
+package internal
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// requestTrace records the phases of one request through httptrace hooks. The hooks may run
+// on dialing goroutines, so every field is guarded by mu.
+type requestTrace struct {
+	mu           sync.Mutex
+	start        time.Time
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wrote        time.Time
+	timing       types.RequestTiming
+}
+
+// traceRequest returns a copy of req whose context reports to a new requestTrace. Hooks
+// already in req's context keep running.
+func traceRequest(req *http.Request) (*http.Request, *requestTrace) {
+	t := &requestTrace{start: time.Now()}
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.mu.Lock()
+			t.dnsStart = time.Now()
+			t.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.mu.Lock()
+			t.timing.DNS = since(t.dnsStart)
+			t.mu.Unlock()
+		},
+		ConnectStart: func(_, _ string) {
+			t.mu.Lock()
+			// With Happy Eyeballs several dials race; time the connection from the first.
+			if t.connectStart.IsZero() {
+				t.connectStart = time.Now()
+			}
+			t.mu.Unlock()
+		},
+		ConnectDone: func(_, _ string, err error) {
+			t.mu.Lock()
+			if err == nil {
+				t.timing.Connect = since(t.connectStart)
+			}
+			t.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			t.mu.Lock()
+			t.tlsStart = time.Now()
+			t.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			t.mu.Lock()
+			t.timing.TLS = since(t.tlsStart)
+			t.mu.Unlock()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			t.timing.ConnReused = info.Reused
+			t.mu.Unlock()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			t.mu.Lock()
+			t.wrote = time.Now()
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			t.mu.Lock()
+			t.timing.TimeToFirstByte = time.Since(t.start)
+			t.timing.Wait = since(t.wrote)
+			t.mu.Unlock()
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace)), t
+}
+
+// finish returns the timing of the request, ending it now.
+func (t *requestTrace) finish() *types.RequestTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	timing := t.timing
+	timing.Total = time.Since(t.start)
+	return &timing
+}
+
+// finishTrace ends trace and counts its timing in the client's totals.
+func (c *Client) finishTrace(trace *requestTrace) *types.RequestTiming {
+	timing := trace.finish()
+	c.timing.add(timing)
+	return timing
+}
+
+// since is time.Since, or zero if start was never recorded.
+func since(start time.Time) time.Duration {
+	if start.IsZero() {
+		return 0
+	}
+	return time.Since(start)
+}
+
+// timingTotals accumulates the timing of every request the client sends.
+type timingTotals struct {
+	requests    atomic.Int64
+	reusedConns atomic.Int64
+	dns         atomic.Int64
+	connect     atomic.Int64
+	tls         atomic.Int64
+	wait        atomic.Int64
+	total       atomic.Int64
+}
+
+// add counts timing in the totals.
+func (t *timingTotals) add(timing *types.RequestTiming) {
+	t.requests.Add(1)
+	if timing.ConnReused {
+		t.reusedConns.Add(1)
+	}
+	t.dns.Add(int64(timing.DNS))
+	t.connect.Add(int64(timing.Connect))
+	t.tls.Add(int64(timing.TLS))
+	t.wait.Add(int64(timing.Wait))
+	t.total.Add(int64(timing.Total))
+}
+
+// snapshot returns the current totals.
+func (t *timingTotals) snapshot() types.RequestTimingTotals {
+	return types.RequestTimingTotals{
+		Requests:    t.requests.Load(),
+		ReusedConns: t.reusedConns.Load(),
+		DNS:         time.Duration(t.dns.Load()),
+		Connect:     time.Duration(t.connect.Load()),
+		TLS:         time.Duration(t.tls.Load()),
+		Wait:        time.Duration(t.wait.Load()),
+		Total:       time.Duration(t.total.Load()),
+	}
+}
@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestClient_RequestTiming(t *testing.T) {
+	const delay = 20 * time.Millisecond
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/drop" {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		time.Sleep(delay)
+		if r.URL.Path == "/busy" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.Client(), server.URL+"/", "agent", nil)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	send := func(path string) error {
+		req, err := client.NewRequest(context.Background(), http.MethodGet, path, nil)
+		if err != nil {
+			t.Fatalf("NewRequest returned error: %v", err)
+		}
+		return client.DoJSON(req, nil)
+	}
+
+	for range 2 {
+		if err := send("ok"); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+	}
+	totals := client.Stats().Timing
+	if totals.Requests != 2 || totals.ReusedConns != 1 {
+		t.Errorf("Requests = %d, ReusedConns = %d; want 2 and 1", totals.Requests, totals.ReusedConns)
+	}
+	if totals.Connect <= 0 || totals.TLS <= 0 {
+		t.Errorf("Connect = %v, TLS = %v; want both positive for the first request", totals.Connect, totals.TLS)
+	}
+	if totals.Wait < 2*delay || totals.Total < totals.Wait {
+		t.Errorf("Wait = %v, Total = %v; want Wait >= %v and Total >= Wait", totals.Wait, totals.Total, 2*delay)
+	}
+
+	err = send("drop")
+	var clientErr *pkgerrs.ClientError
+	if !errors.As(err, &clientErr) || clientErr.Timing == nil {
+		t.Fatalf("dropped request error = %#v, want ClientError with Timing", err)
+	}
+	if clientErr.Timing.Total <= 0 || clientErr.Timing.TimeToFirstByte != 0 {
+		t.Errorf("dropped request timing = %+v", clientErr.Timing)
+	}
+	if got := client.Stats().Timing.Requests; got != 3 {
+		t.Errorf("Requests = %d after failure, want 3", got)
+	}
+
+	err = send("busy")
+	var apiErr *pkgerrs.APIError
+	if !errors.As(err, &apiErr) || apiErr.Timing == nil {
+		t.Fatalf("503 error = %#v, want APIError with Timing", err)
+	}
+	if apiErr.Timing.Wait < delay {
+		t.Errorf("503 timing Wait = %v, want >= %v", apiErr.Timing.Wait, delay)
+	}
+}
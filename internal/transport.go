@@ -0,0 +1,192 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+const (
+	// DefaultDialTimeout bounds establishing a single TCP connection
+	DefaultDialTimeout = 30 * time.Second
+	// DefaultDialKeepAlive is the TCP keep-alive period of dialed connections
+	DefaultDialKeepAlive = 30 * time.Second
+	// defaultDNSPort is used for DNS servers given without a port
+	defaultDNSPort = "53"
+)
+
+// NetworkConfig controls how the built-in transport resolves and connects to Reddit.
+type NetworkConfig struct {
+	// Resolver replaces the system resolver. Takes precedence over DNSServers.
+	Resolver *net.Resolver
+	// DNSServers are queried in order instead of the system resolver. Entries are
+	// "host:port" or a bare IP, which uses port 53.
+	DNSServers []string
+	// FallbackDelay is the Happy Eyeballs (RFC 6555) delay before racing an IPv4
+	// connection against a slow IPv6 one. Zero uses Go's default of 300ms; negative
+	// disables Happy Eyeballs.
+	FallbackDelay time.Duration
+	// IPv4Only dials over IPv4 only.
+	IPv4Only bool
+	// IPv4Fallback retries a failed dual-stack dial over IPv4 only.
+	IPv4Fallback bool
+	// SystemDNSFallback retries a dial whose custom DNS lookup failed with the system resolver.
+	SystemDNSFallback bool
+}
+
+// dialFunc matches net.Dialer.DialContext.
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// NewTransport returns a clone of http.DefaultTransport whose connections are dialed
+// according to cfg.
+func NewTransport(cfg NetworkConfig) (*http.Transport, error) {
+	resolver := cfg.Resolver
+	if resolver == nil && len(cfg.DNSServers) > 0 {
+		var err error
+		resolver, err = newServerResolver(cfg.DNSServers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:       DefaultDialTimeout,
+		KeepAlive:     DefaultDialKeepAlive,
+		FallbackDelay: cfg.FallbackDelay,
+		Resolver:      resolver,
+	}
+	var system dialFunc
+	if cfg.SystemDNSFallback && resolver != nil {
+		systemDialer := *dialer
+		systemDialer.Resolver = nil
+		system = systemDialer.DialContext
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = fallbackDial(dialer.DialContext, system, cfg)
+	return transport, nil
+}
+
+// fallbackDial wraps dial with the network restriction and fallbacks cfg asks for.
+// system, if non-nil, dials with the system resolver after a DNS failure.
+func fallbackDial(dial, system dialFunc, cfg NetworkConfig) dialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if cfg.IPv4Only && network == "tcp" {
+			network = "tcp4"
+		}
+		conn, err := dial(ctx, network, address)
+		if err == nil || ctx.Err() != nil {
+			return conn, err
+		}
+
+		var dnsErr *net.DNSError
+		if system != nil && errors.As(err, &dnsErr) {
+			if conn, sysErr := system(ctx, network, address); sysErr == nil {
+				return conn, nil
+			}
+			// Fall through with the original error; it names the configured resolver.
+		}
+		if cfg.IPv4Fallback && network == "tcp" && !errors.As(err, &dnsErr) {
+			if conn, v4Err := dial(ctx, "tcp4", address); v4Err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	}
+}
+
+// newServerResolver returns a pure-Go resolver that sends queries to servers, trying
+// each in order until one accepts the connection.
+func newServerResolver(servers []string) (*net.Resolver, error) {
+	addrs := make([]string, 0, len(servers))
+	for _, s := range servers {
+		addr, err := dnsServerAddr(s)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			var errs []error
+			for _, addr := range addrs {
+				conn, err := d.DialContext(ctx, network, addr)
+				if err == nil {
+					return conn, nil
+				}
+				errs = append(errs, err)
+				if ctx.Err() != nil {
+					break
+				}
+			}
+			return nil, errors.Join(errs...)
+		},
+	}, nil
+}
+
+// dnsServerAddr normalizes a DNS server to "ip:port", adding port 53 if none is given.
+func dnsServerAddr(server string) (string, error) {
+	if ip := net.ParseIP(server); ip != nil {
+		return net.JoinHostPort(ip.String(), defaultDNSPort), nil
+	}
+	host, port, err := net.SplitHostPort(server)
+	if err != nil || net.ParseIP(host) == nil || port == "" {
+		return "", fmt.Errorf("invalid DNS server %q: want an IP address or ip:port", server)
+	}
+	return server, nil
+}
+
+// classifyTransportError wraps a failure to send a request or read its response in a
+// *pkgerrs.TransportError describing which stage of the connection failed.
+func classifyTransportError(err error) *pkgerrs.TransportError {
+	transportErr := &pkgerrs.TransportError{Kind: pkgerrs.TransportOther, Err: err}
+
+	var (
+		dnsErr     *net.DNSError
+		certErr    *tls.CertificateVerificationError
+		alertErr   tls.AlertError
+		recordErr  tls.RecordHeaderError
+		unknownCA  x509.UnknownAuthorityError
+		hostErr    x509.HostnameError
+		invalidErr x509.CertificateInvalidError
+		opErr      *net.OpError
+		netErr     net.Error
+	)
+	switch {
+	case errors.As(err, &dnsErr):
+		transportErr.Kind = pkgerrs.TransportDNS
+		// An unknown host stays unknown; a failed or timed-out lookup may not
+		transportErr.Permanent = dnsErr.IsNotFound
+	case errors.As(err, &certErr), errors.As(err, &unknownCA), errors.As(err, &hostErr), errors.As(err, &invalidErr):
+		transportErr.Kind = pkgerrs.TransportTLS
+		transportErr.Permanent = true
+	case errors.As(err, &alertErr), errors.As(err, &recordErr):
+		// The server rejected the handshake or does not speak TLS
+		transportErr.Kind = pkgerrs.TransportTLS
+		transportErr.Permanent = true
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE), errors.Is(err, syscall.ECONNABORTED),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		transportErr.Kind = pkgerrs.TransportReset
+	case strings.Contains(err.Error(), "TLS handshake timeout"):
+		// http.Transport reports this as a plain error without a type to match
+		transportErr.Kind = pkgerrs.TransportTLS
+	case errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH),
+		errors.As(err, &opErr) && opErr.Op == "dial":
+		transportErr.Kind = pkgerrs.TransportConnect
+	case errors.As(err, &netErr) && netErr.Timeout():
+		transportErr.Kind = pkgerrs.TransportTimeout
+	}
+	return transportErr
+}
@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"syscall"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestFallbackDial(t *testing.T) {
+	dnsErr := &net.DNSError{Err: "no such host", Name: "oauth.reddit.com"}
+	connErr := errors.New("connect: network is unreachable")
+	ok, _ := net.Pipe()
+
+	tests := []struct {
+		name     string
+		cfg      NetworkConfig
+		system   bool
+		fail     map[string]error // network -> error returned by the primary dialer
+		wantErr  bool
+		wantNets []string
+	}{
+		{
+			name:     "IPv4 only",
+			cfg:      NetworkConfig{IPv4Only: true},
+			wantNets: []string{"tcp4"},
+		},
+		{
+			name:     "IPv4 fallback after connect failure",
+			cfg:      NetworkConfig{IPv4Fallback: true},
+			fail:     map[string]error{"tcp": connErr},
+			wantNets: []string{"tcp", "tcp4"},
+		},
+		{
+			name:     "no IPv4 fallback for DNS failures",
+			cfg:      NetworkConfig{IPv4Fallback: true},
+			fail:     map[string]error{"tcp": dnsErr},
+			wantErr:  true,
+			wantNets: []string{"tcp"},
+		},
+		{
+			name:     "fallback disabled",
+			fail:     map[string]error{"tcp": connErr},
+			wantErr:  true,
+			wantNets: []string{"tcp"},
+		},
+		{
+			name:     "system DNS fallback",
+			cfg:      NetworkConfig{SystemDNSFallback: true},
+			system:   true,
+			fail:     map[string]error{"tcp": dnsErr},
+			wantNets: []string{"tcp", "system tcp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var nets []string
+			primary := func(_ context.Context, network, _ string) (net.Conn, error) {
+				nets = append(nets, network)
+				if err := tt.fail[network]; err != nil {
+					return nil, err
+				}
+				return ok, nil
+			}
+			var system dialFunc
+			if tt.system {
+				system = func(_ context.Context, network, _ string) (net.Conn, error) {
+					nets = append(nets, "system "+network)
+					return ok, nil
+				}
+			}
+
+			_, err := fallbackDial(primary, system, tt.cfg)(context.Background(), "tcp", "oauth.reddit.com:443")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("dial error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(nets) != len(tt.wantNets) {
+				t.Fatalf("dialed %v, want %v", nets, tt.wantNets)
+			}
+			for i := range nets {
+				if nets[i] != tt.wantNets[i] {
+					t.Errorf("dialed %v, want %v", nets, tt.wantNets)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestDNSServerAddr(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "1.1.1.1", want: "1.1.1.1:53"},
+		{in: "2606:4700:4700::1111", want: "[2606:4700:4700::1111]:53"},
+		{in: "8.8.8.8:5353", want: "8.8.8.8:5353"},
+		{in: "[::1]:53", want: "[::1]:53"},
+		{in: "dns.google", wantErr: true},
+		{in: "dns.google:53", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := dnsServerAddr(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("dnsServerAddr(%q) = %q, %v; want %q, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	if _, err := NewTransport(NetworkConfig{DNSServers: []string{"not-an-ip"}}); err == nil {
+		t.Error("expected an error for an invalid DNS server")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	transport, err := NewTransport(NetworkConfig{IPv4Only: true, FallbackDelay: -1, DNSServers: []string{"127.0.0.1"}, SystemDNSFallback: true})
+	if err != nil {
+		t.Fatalf("NewTransport returned error: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request through transport failed: %v", err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestClassifyTransportError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantKind      pkgerrs.TransportErrorKind
+		wantRetryable bool
+	}{
+		{name: "unknown host", err: &net.DNSError{Err: "no such host", IsNotFound: true}, wantKind: pkgerrs.TransportDNS},
+		{name: "DNS timeout", err: &net.DNSError{Err: "i/o timeout", IsTimeout: true}, wantKind: pkgerrs.TransportDNS, wantRetryable: true},
+		{name: "refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, wantKind: pkgerrs.TransportConnect, wantRetryable: true},
+		{name: "reset", err: &url.Error{Op: "Get", Err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}}, wantKind: pkgerrs.TransportReset, wantRetryable: true},
+		{name: "closed early", err: io.ErrUnexpectedEOF, wantKind: pkgerrs.TransportReset, wantRetryable: true},
+		{name: "handshake timeout", err: errors.New("net/http: TLS handshake timeout"), wantKind: pkgerrs.TransportTLS, wantRetryable: true},
+		{name: "bad certificate", err: x509.HostnameError{Host: "oauth.reddit.com"}, wantKind: pkgerrs.TransportTLS},
+		{name: "alert", err: tls.AlertError(40), wantKind: pkgerrs.TransportTLS},
+		{name: "other", err: errors.New("something broke"), wantKind: pkgerrs.TransportOther, wantRetryable: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyTransportError(tt.err)
+			if got.Kind != tt.wantKind || got.Retryable() != tt.wantRetryable {
+				t.Errorf("classifyTransportError(%v) = %s, retryable %v; want %s, retryable %v", tt.err, got.Kind, got.Retryable(), tt.wantKind, tt.wantRetryable)
+			}
+		})
+	}
+}
@@ -34,7 +34,9 @@ const (
 )
 
 // Validator provides validation operations for Reddit API parameters.
-type Validator struct{}
+type Validator struct {
+	cursors CursorPolicy // treatment of pagination tokens that are not fullnames
+}
 
 // NewValidator creates a new Validator instance.
 func NewValidator() *Validator {
@@ -81,6 +83,31 @@ func (v *Validator) ValidateSubredditName(name string) error {
 	return nil
 }
 
+// NormalizeSubredditName accepts a subreddit name with or without an "r/" or "/r/" prefix,
+// validates it, and returns the bare name (e.g. "golang") for use in request paths.
+func (v *Validator) NormalizeSubredditName(name string) (string, error) {
+	normalized := validation.NormalizeSubreddit(name)
+	if err := v.ValidateSubredditName(normalized); err != nil {
+		return "", err
+	}
+	return normalized, nil
+}
+
+// SetCursorPolicy sets how ValidatePagination treats cursors that are not fullnames, so
+// cursors accepted by a lenient Parser can be passed back. The default is CursorStrict.
+// ValidatePaginationToken, which also checks fullnames that are not cursors, stays strict.
+func (v *Validator) SetCursorPolicy(policy CursorPolicy) {
+	v.cursors = policy
+}
+
+// validateCursor checks an after or before cursor under the validator's CursorPolicy.
+func (v *Validator) validateCursor(token string) error {
+	if v.cursors == CursorLenient && validCursor(token, CursorLenient) {
+		return nil
+	}
+	return v.ValidatePaginationToken(token)
+}
+
 // ValidatePagination checks if pagination parameters are valid.
 // Returns an error if the parameters are invalid.
 func (v *Validator) ValidatePagination(pagination *types.Pagination) error {
@@ -93,13 +120,13 @@ func (v *Validator) ValidatePagination(pagination *types.Pagination) error {
 	}
 	// Validate After token if present
 	if pagination.After != "" {
-		if err := v.ValidatePaginationToken(pagination.After); err != nil {
+		if err := v.validateCursor(pagination.After); err != nil {
 			return &pkgerrs.ConfigError{Field: "pagination.After", Message: fmt.Sprintf("invalid pagination token: %v", err)}
 		}
 	}
 	// Validate Before token if present
 	if pagination.Before != "" {
-		if err := v.ValidatePaginationToken(pagination.Before); err != nil {
+		if err := v.validateCursor(pagination.Before); err != nil {
 			return &pkgerrs.ConfigError{Field: "pagination.Before", Message: fmt.Sprintf("invalid pagination token: %v", err)}
 		}
 	}
@@ -225,13 +252,20 @@ func validateCommentID(id string) error {
 }
 
 // ValidateConfig validates the configuration fields and returns the validated/defaulted httpClient.
-// Returns an error if validation fails.
+// clientSecret may be empty: installed apps have none, so the caller decides whether it is
+// required. Returns an error if validation fails.
 func (v *Validator) ValidateConfig(clientID, clientSecret, userAgent string, httpClient *http.Client, logger *slog.Logger, defaultTimeout time.Duration) (*http.Client, error) {
 	// Validate required fields
-	if clientID == "" || clientSecret == "" {
+	if clientID == "" {
 		return nil, &pkgerrs.ConfigError{Message: "ClientID and ClientSecret are required"}
 	}
 
+	return v.ValidateHTTPClient(userAgent, httpClient, logger, defaultTimeout)
+}
+
+// ValidateHTTPClient validates the user agent and returns httpClient with its timeout
+// defaulted, or a new client if it is nil. Returns an error if validation fails.
+func (v *Validator) ValidateHTTPClient(userAgent string, httpClient *http.Client, logger *slog.Logger, defaultTimeout time.Duration) (*http.Client, error) {
 	// Validate user agent (should already be set by caller)
 	if err := v.ValidateUserAgent(userAgent); err != nil {
 		return nil, &pkgerrs.ConfigError{
@@ -70,6 +70,44 @@ func TestValidator_ValidateSubredditName(t *testing.T) {
 	}
 }
 
+func TestValidator_NormalizeSubredditName(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		wantError bool
+	}{
+		{name: "bare name", input: "golang", want: "golang"},
+		{name: "r/ prefix", input: "r/golang", want: "golang"},
+		{name: "/r/ prefix", input: "/r/golang", want: "golang"},
+		{name: "trailing slash", input: "/r/golang/", want: "golang"},
+		{name: "empty", input: "", wantError: true},
+		{name: "prefix only", input: "/r/", wantError: true},
+		{name: "prefixed invalid name", input: "r/go-lang", wantError: true},
+		{name: "path traversal", input: "r/../etc", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.NormalizeSubredditName(tt.input)
+			if tt.wantError {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeSubredditName(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidator_ValidatePagination(t *testing.T) {
 	v := NewValidator()
 
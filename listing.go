@@ -0,0 +1,163 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// fetchListing GETs a listing endpoint and returns its children of type T with the page's
+// cursors. Children of other kinds are skipped, as are children that fail to parse (with a
+// warning), so one bad item does not fail the whole page. Use T = any to keep every kind.
+//
+// operation names the request in errors; parseOperation names the parse step.
+func fetchListing[T any](ctx context.Context, r *Reddit, path string, params url.Values, operation, parseOperation string) (*types.Listing[T], error) {
+	ctx, rateLimit := r.recordResponse(ctx)
+	result, err := r.getListingThing(ctx, path, params, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	listing, err := parseListing[T](ctx, r, result, parseOperation)
+	if err != nil {
+		return nil, err
+	}
+	listing.RateLimit = rateLimit.Info()
+	listing.Meta = rateLimit.Meta()
+	return listing, nil
+}
+
+// rawListingResponse is the shape of listings whose children are plain objects rather than
+// things, such as the ban list and wiki revisions.
+type rawListingResponse[T any] struct {
+	Data struct {
+		Children []T    `json:"children"`
+		After    string `json:"after"`
+		Before   string `json:"before"`
+	} `json:"data"`
+}
+
+// fetchRawListing GETs a listing whose children are plain objects of type T and whose cursors
+// are IDs matching cursor rather than fullnames. opts may set the limit, a cursor, a timeout,
+// and extra parameters; sorting and caching are rejected. what names the listing in errors.
+func fetchRawListing[T any](ctx context.Context, r *Reddit, path string, cursor *regexp.Regexp, what, operation string, opts []ListingOption) (*types.Listing[T], error) {
+	call := newListingCall(nil, opts)
+	if call.sortSet {
+		return nil, &pkgerrs.ConfigError{Field: "Sort", Message: what + " cannot be sorted"}
+	}
+	if call.cache.enabled() {
+		return nil, &pkgerrs.ConfigError{Field: "Cache", Message: "caching is only supported for subreddit listings"}
+	}
+	pagination := call.request.Pagination
+	// WithAfter and WithBefore replace each other, so at most one cursor is set.
+	if c := pagination.After + pagination.Before; c != "" && !cursor.MatchString(c) {
+		return nil, &pkgerrs.ConfigError{Field: "pagination", Message: fmt.Sprintf("invalid %s cursor: %q", what, c)}
+	}
+	// The cursors are checked above; the validator checks the limit.
+	if err := r.validator.ValidatePagination(&types.Pagination{Limit: pagination.Limit}); err != nil {
+		return nil, err
+	}
+	ctx, cancel := call.context(ctx)
+	defer cancel()
+
+	params := buildPaginationParams(&pagination)
+	if err := addExtraParams(params, call.request.Params); err != nil {
+		return nil, err
+	}
+
+	ctx, rateLimit := r.recordResponse(ctx)
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil, params)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var resp rawListingResponse[T]
+	if err := r.httpClient.DoJSON(req, &resp); err != nil {
+		return nil, wrapDoError(err, operation, path)
+	}
+	return &types.Listing[T]{
+		Items:     resp.Data.Children,
+		After:     resp.Data.After,
+		Before:    resp.Data.Before,
+		RateLimit: rateLimit.Info(),
+		Meta:      rateLimit.Meta(),
+	}, nil
+}
+
+// getListingThing GETs a listing endpoint and returns the unparsed Listing thing. With
+// AllowNSFW, a quarantined or age-gated subreddit is retried once with the opt-in cookies.
+func (r *Reddit) getListingThing(ctx context.Context, path string, params url.Values, operation string) (*types.Thing, error) {
+	var result types.Thing
+	for optIn := false; ; optIn = true {
+		httpReq, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil, params)
+		if err != nil {
+			return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+		}
+
+		if err := r.addAuthHeaders(ctx, httpReq); err != nil {
+			return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+		}
+		if optIn {
+			addNSFWOptIn(httpReq)
+		}
+
+		err = wrapDoError(r.httpClient.Do(httpReq, &result), operation, path)
+		if err == nil {
+			return &result, nil
+		}
+		if optIn || !r.allowNSFW() || !isContentGate(err) {
+			return nil, err
+		}
+	}
+}
+
+// parseListing converts a Listing thing into a typed Listing, as described for fetchListing.
+func parseListing[T any](ctx context.Context, r *Reddit, thing *types.Thing, parseOperation string) (*types.Listing[T], error) {
+	parsed, err := r.parser.ParseThing(ctx, thing)
+	if err != nil {
+		return nil, &pkgerrs.ParseError{Operation: parseOperation, Err: err}
+	}
+	data, ok := parsed.(*types.ListingData)
+	if !ok {
+		return nil, &pkgerrs.ParseError{Operation: parseOperation, Err: fmt.Errorf("expected Listing, got %s", thing.Kind)}
+	}
+
+	listing := &types.Listing[T]{
+		Items:  make([]T, 0, len(data.Children)),
+		After:  data.AfterFullname,
+		Before: data.BeforeFullname,
+	}
+	for _, child := range data.Children {
+		if child == nil {
+			continue
+		}
+		item, err := r.parser.ParseThing(ctx, child)
+		if err != nil {
+			if r.config != nil && r.config.Logger != nil {
+				r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "skipping unparseable listing item",
+					slog.String("operation", parseOperation),
+					slog.String("kind", child.Kind),
+					slog.String("error", err.Error()))
+			}
+			continue
+		}
+		if post, ok := item.(*types.Post); ok {
+			r.attachSubredditInfo(post)
+		}
+		if typed, ok := item.(T); ok {
+			listing.Items = append(listing.Items, typed)
+		}
+	}
+	return listing, nil
+}
@@ -0,0 +1,88 @@
+package graw
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// maxCachedListings bounds the number of queries held in the listing cache. Once it is
+// full, expired listings are dropped first, then the least recently used.
+const maxCachedListings = 1000
+
+// cachePolicy says how long a cached listing may be served.
+type cachePolicy struct {
+	// ttl is how long a result is fresh.
+	ttl time.Duration
+	// maxStale is how long after ttl a result is still served while it is refreshed.
+	maxStale time.Duration
+}
+
+func (p cachePolicy) enabled() bool {
+	return p.ttl > 0 || p.maxStale > 0
+}
+
+// expires returns when a listing fetched at fetchedAt can no longer be served.
+func (p cachePolicy) expires(fetchedAt time.Time) time.Time {
+	return fetchedAt.Add(p.ttl + p.maxStale)
+}
+
+// cachedListing is an entry in Reddit.listingCache.
+type cachedListing struct {
+	value     any
+	fetchedAt time.Time
+	// refreshing is set while a background refresh is running, so only one runs per entry.
+	refreshing atomic.Bool
+}
+
+// cachedFetch returns the cached value for key according to policy, calling fetch when
+// there is no usable entry. Stale entries within policy.maxStale are returned at once and
+// refreshed in the background; refresh failures are logged and retried on a later call.
+// Errors are never cached.
+func cachedFetch[T any](ctx context.Context, r *Reddit, key string, policy cachePolicy, fetch func(context.Context) (T, error)) (T, error) {
+	if entry, ok := r.listingCache.get(key); ok {
+		age := time.Since(entry.fetchedAt)
+		if age < policy.ttl {
+			return entry.value.(T), nil
+		}
+		if age < policy.ttl+policy.maxStale {
+			if entry.refreshing.CompareAndSwap(false, true) {
+				go r.refreshListing(context.WithoutCancel(ctx), key, entry, policy, func(ctx context.Context) (any, error) {
+					return fetch(ctx)
+				})
+			}
+			return entry.value.(T), nil
+		}
+		r.listingCache.remove(key, entry)
+	}
+
+	value, err := fetch(ctx)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	now := time.Now()
+	r.listingCache.put(key, &cachedListing{value: value, fetchedAt: now}, policy.expires(now), maxCachedListings)
+	return value, nil
+}
+
+// refreshListing replaces a stale cache entry with a freshly fetched value.
+func (r *Reddit) refreshListing(ctx context.Context, key string, entry *cachedListing, policy cachePolicy, fetch func(context.Context) (any, error)) {
+	var value any
+	err := r.safeCall(ctx, "refresh cached listing", func() (err error) {
+		value, err = fetch(ctx)
+		return err
+	})
+	if err != nil {
+		entry.refreshing.Store(false)
+		if r.config != nil && r.config.Logger != nil {
+			r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "background listing refresh failed",
+				slog.String("key", key),
+				slog.String("error", err.Error()))
+		}
+		return
+	}
+	now := time.Now()
+	r.listingCache.replace(key, entry, &cachedListing{value: value, fetchedAt: now}, policy.expires(now))
+}
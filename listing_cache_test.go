@@ -0,0 +1,153 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// countingListingClient returns a client whose listing responses contain one post, named
+// after the number of requests made so far.
+func countingListingClient(t *testing.T, calls *atomic.Int32, fail *atomic.Bool) *Reddit {
+	return newTestClient(&mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			n := calls.Add(1)
+			if fail != nil && fail.Load() {
+				return errors.New("unavailable")
+			}
+			id := "p" + string(rune('0'+n))
+			*v = *listingThing(t, submitPostThing(t, id, "post", "gopher", time.Now()))
+			return nil
+		},
+	}, nil)
+}
+
+func firstPostID(t *testing.T, resp *types.PostsResponse) string {
+	t.Helper()
+	if resp == nil || len(resp.Posts) == 0 {
+		t.Fatal("empty response")
+	}
+	return resp.Posts[0].ID
+}
+
+func TestGetHot_CacheTTL(t *testing.T) {
+	var calls atomic.Int32
+	var fail atomic.Bool
+	client := countingListingClient(t, &calls, &fail)
+	ctx := context.Background()
+	req := &types.PostsRequest{Subreddit: "golang"}
+
+	first, err := client.GetHot(ctx, req, WithCacheTTL(time.Minute))
+	if err != nil {
+		t.Fatalf("GetHot: %v", err)
+	}
+	first.Posts[0].Title = "changed" // modifying a result must not affect the cache
+	first.Posts = nil
+
+	second, err := client.GetHot(ctx, req, WithCacheTTL(time.Minute))
+	if err != nil {
+		t.Fatalf("GetHot: %v", err)
+	}
+	if calls.Load() != 1 || firstPostID(t, second) != "p1" {
+		t.Errorf("expected cached p1 after 1 call, got %s after %d", firstPostID(t, second), calls.Load())
+	}
+	second.Posts[0].Title = "changed again"
+	if third, _ := client.GetHot(ctx, req, WithCacheTTL(time.Minute)); third.Posts[0].Title != "post" {
+		t.Errorf("cached post title = %q, want it unchanged by callers", third.Posts[0].Title)
+	}
+
+	// Different queries and uncached calls go to Reddit.
+	if _, err := client.GetHot(ctx, req, WithCacheTTL(time.Minute), WithLimit(5)); err != nil {
+		t.Fatalf("GetHot: %v", err)
+	}
+	if _, err := client.GetHot(ctx, req); err != nil {
+		t.Fatalf("GetHot: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+
+	// Errors are not cached.
+	fail.Store(true)
+	if _, err := client.GetNew(ctx, req, WithCacheTTL(time.Minute)); err == nil {
+		t.Fatal("expected error")
+	}
+	fail.Store(false)
+	if _, err := client.GetNew(ctx, req, WithCacheTTL(time.Minute)); err != nil {
+		t.Errorf("GetNew after failure: %v", err)
+	}
+}
+
+func TestGetHot_StaleWhileRevalidate(t *testing.T) {
+	var calls atomic.Int32
+	client := countingListingClient(t, &calls, nil)
+	ctx := context.Background()
+	opts := []ListingOption{WithCacheTTL(10 * time.Millisecond), WithSWR(time.Minute)}
+
+	if _, err := client.GetHot(ctx, nil, opts...); err != nil {
+		t.Fatalf("GetHot: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	// Stale: served immediately while a refresh runs in the background.
+	stale, err := client.GetHot(ctx, nil, opts...)
+	if err != nil {
+		t.Fatalf("GetHot: %v", err)
+	}
+	if id := firstPostID(t, stale); id != "p1" {
+		t.Errorf("stale result = %s, want p1", id)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		resp, err := client.GetHot(ctx, nil, WithCacheTTL(time.Minute))
+		if err != nil {
+			t.Fatalf("GetHot: %v", err)
+		}
+		if firstPostID(t, resp) == "p2" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("cache was not refreshed in the background")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2", calls.Load())
+	}
+}
+
+func TestCachedFetch_Bounded(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	ctx := context.Background()
+	fetch := func(context.Context) (int, error) { return 1, nil }
+
+	for i := range maxCachedListings + 10 {
+		if _, err := cachedFetch(ctx, client, fmt.Sprintf("r/golang/new?after=t3_%d", i), cachePolicy{ttl: time.Minute}, fetch); err != nil {
+			t.Fatalf("cachedFetch returned error: %v", err)
+		}
+	}
+	if n := client.listingCache.len(); n != maxCachedListings {
+		t.Errorf("cached listings = %d, want the bound %d", n, maxCachedListings)
+	}
+
+	// A listing past its TTL and stale window is dropped when looked up.
+	short := cachePolicy{ttl: time.Nanosecond}
+	if _, err := cachedFetch(ctx, client, "r/golang/hot", short, fetch); err != nil {
+		t.Fatalf("cachedFetch returned error: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	fails := func(context.Context) (int, error) { return 0, errors.New("unavailable") }
+	if _, err := cachedFetch(ctx, client, "r/golang/hot", short, fails); err == nil {
+		t.Fatal("expired listing was served")
+	}
+	if _, ok := client.listingCache.get("r/golang/hot"); ok {
+		t.Error("expired listing was kept")
+	}
+}
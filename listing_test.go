@@ -0,0 +1,224 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestFetchListing_FiltersByType(t *testing.T) {
+	mixed := listingThing(t,
+		submitPostThing(t, "p1", "first", "gopher", time.Now()),
+		commentThing(t, "c1", "a comment", false),
+		submitPostThing(t, "p2", "second", "gopher", time.Now()),
+	)
+	var data types.ListingData
+	if err := json.Unmarshal(mixed.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	data.AfterFullname = "t3_p2"
+	mixed.Data, _ = json.Marshal(data)
+
+	client := newTestClient(&mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = *mixed
+			return nil
+		},
+	}, nil)
+	ctx := context.Background()
+
+	posts, err := fetchListing[*types.Post](ctx, client, "user/gopher/overview", nil, "get overview", "parse overview")
+	if err != nil {
+		t.Fatalf("fetchListing: %v", err)
+	}
+	if posts.Len() != 2 || posts.Items[0].ID != "p1" || posts.Items[1].ID != "p2" || posts.After != "t3_p2" {
+		t.Errorf("posts = %+v", posts)
+	}
+
+	comments, err := fetchListing[*types.Comment](ctx, client, "user/gopher/overview", nil, "get overview", "parse overview")
+	if err != nil {
+		t.Fatalf("fetchListing: %v", err)
+	}
+	if comments.Len() != 1 || comments.Items[0].ID != "c1" {
+		t.Errorf("comments = %+v", comments)
+	}
+
+	all, err := fetchListing[any](ctx, client, "user/gopher/overview", nil, "get overview", "parse overview")
+	if err != nil {
+		t.Fatalf("fetchListing: %v", err)
+	}
+	if all.Len() != 3 {
+		t.Errorf("got %d items, want 3", all.Len())
+	}
+}
+
+func TestListingMethods(t *testing.T) {
+	page := listingThing(t, submitPostThing(t, "p1", "first", "gopher", time.Now()))
+	var data types.ListingData
+	if err := json.Unmarshal(page.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	data.AfterFullname = "t3_p1"
+	page.Data, _ = json.Marshal(data)
+
+	client := newTestClient(&mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = *page
+			return nil
+		},
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			return []*types.Thing{
+				listingThing(t, submitPostThing(t, "post1", "Title", "gopher", time.Unix(1700000000, 0))),
+				listingThing(t, commentThing(t, "c1", "hello", false), commentThing(t, "c2", "world", false)),
+			}, nil
+		},
+	}, nil)
+	ctx := context.Background()
+	scoped := client.WithDefaults(PostsDefaults{Subreddit: "golang"})
+
+	postCalls := map[string]func() (*types.Listing[*types.Post], error){
+		"GetHotListing": func() (*types.Listing[*types.Post], error) {
+			return client.GetHotListing(ctx, &types.PostsRequest{Subreddit: "golang"})
+		},
+		"GetNewListing": func() (*types.Listing[*types.Post], error) { return client.GetNewListing(ctx, nil) },
+		"GetPostsListing": func() (*types.Listing[*types.Post], error) {
+			return client.GetPostsListing(ctx, "golang", WithSort(TopWeek))
+		},
+		"SearchByFlairListing": func() (*types.Listing[*types.Post], error) {
+			return client.SearchByFlairListing(ctx, "golang", "Discussion", nil)
+		},
+		"ScopedClient.GetHotListing":   func() (*types.Listing[*types.Post], error) { return scoped.GetHotListing(ctx) },
+		"ScopedClient.GetNewListing":   func() (*types.Listing[*types.Post], error) { return scoped.GetNewListing(ctx) },
+		"ScopedClient.GetPostsListing": func() (*types.Listing[*types.Post], error) { return scoped.GetPostsListing(ctx) },
+	}
+	for name, call := range postCalls {
+		listing, err := call()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if listing.Len() != 1 || listing.Items[0].ID != "p1" || listing.After != "t3_p1" {
+			t.Errorf("%s = %+v, want p1 with the next-page cursor", name, listing)
+		}
+	}
+
+	comments, err := client.GetCommentsListing(ctx, &types.CommentsRequest{Subreddit: "golang", PostID: "post1"})
+	if err != nil {
+		t.Fatalf("GetCommentsListing: %v", err)
+	}
+	if comments.Len() != 2 || comments.Items[0].ID != "c1" {
+		t.Errorf("GetCommentsListing = %+v, want c1 and c2", comments)
+	}
+
+	if listing, err := client.GetHotListing(ctx, &types.PostsRequest{Subreddit: "a"}); listing != nil || err == nil {
+		t.Errorf("GetHotListing(invalid) = %v, %v; want nil and an error", listing, err)
+	}
+	if listing, err := client.GetCommentsListing(ctx, nil); listing != nil || err == nil {
+		t.Errorf("GetCommentsListing(nil) = %v, %v; want nil and an error", listing, err)
+	}
+}
+
+func TestFetchListing_NotAListing(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = *commentThing(t, "c1", "body", false)
+			return nil
+		},
+	}, nil)
+
+	_, err := fetchListing[*types.Post](context.Background(), client, "hot", nil, "get hot posts", "parse posts")
+	var parseErr *pkgerrs.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Operation != "parse posts" {
+		t.Errorf("expected ParseError for parse posts, got %v", err)
+	}
+}
+
+func TestGetHot_ReportsRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Ratelimit-Used", "3")
+		w.Header().Set("X-Ratelimit-Remaining", "597.0")
+		w.Header().Set("X-Ratelimit-Reset", "400")
+		json.NewEncoder(w).Encode(listingThing(t, submitPostThing(t, "p1", "post", "gopher", time.Now())))
+	}))
+	defer server.Close()
+
+	httpClient, err := internal.NewClient(server.Client(), server.URL, "test/1.0", nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client := newTestClient(httpClient, nil)
+
+	resp, err := client.GetHot(context.Background(), &types.PostsRequest{Subreddit: "golang"})
+	if err != nil {
+		t.Fatalf("GetHot: %v", err)
+	}
+	if resp.RateLimit == nil || resp.RateLimit.Used != 3 || resp.RateLimit.Remaining != 597 {
+		t.Fatalf("RateLimit = %+v", resp.RateLimit)
+	}
+	if reset := time.Until(resp.RateLimit.ResetAt); reset < 390*time.Second || reset > 400*time.Second {
+		t.Errorf("ResetAt is %v from now, want about 400s", reset)
+	}
+	if got := resp.Listing().RateLimit; got != resp.RateLimit {
+		t.Error("Listing() should carry the rate limit")
+	}
+}
+
+func TestParserOptions_LenientCursors(t *testing.T) {
+	const cursor = "dXNlcjoxMjM0NQ=="
+	var gotAfter string
+	mux := http.NewServeMux()
+	grawtest.HandleToken(mux, grawtest.StaticTokenHandler("test-token"))
+	mux.HandleFunc("/r/golang/hot", func(w http.ResponseWriter, r *http.Request) {
+		gotAfter = r.URL.Query().Get("after")
+		listing := listingThing(t, submitPostThing(t, "p1", "post", "gopher", time.Now()))
+		var data map[string]any
+		_ = json.Unmarshal(listing.Data, &data)
+		data["after"] = cursor
+		json.NewEncoder(w).Encode(map[string]any{"kind": "Listing", "data": data})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	config := &Config{
+		ClientID: "id", ClientSecret: "secret", UserAgent: "test/1.0",
+		BaseURL: server.URL + "/", AuthURL: server.URL + "/", WebURL: server.URL + "/",
+		HTTPClient: server.Client(),
+	}
+	ctx := context.Background()
+
+	strict, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if _, err := strict.GetHot(ctx, &types.PostsRequest{Subreddit: "golang"}); err == nil {
+		t.Error("strict client accepted a non-fullname cursor")
+	}
+
+	config.Parser = &ParserOptions{LenientCursors: true}
+	lenient, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	resp, err := lenient.GetHot(ctx, &types.PostsRequest{Subreddit: "golang"})
+	if err != nil {
+		t.Fatalf("GetHot returned error: %v", err)
+	}
+	if resp.AfterFullname != cursor || len(resp.Posts) != 1 {
+		t.Fatalf("AfterFullname = %q with %d posts, want %q and 1 post", resp.AfterFullname, len(resp.Posts), cursor)
+	}
+	// The accepted cursor can be passed back for the next page.
+	if _, err := lenient.GetHot(ctx, &types.PostsRequest{Subreddit: "golang", Pagination: types.Pagination{After: cursor}}); err != nil {
+		t.Fatalf("GetHot with the cursor returned error: %v", err)
+	}
+	if gotAfter != cursor {
+		t.Errorf("after = %q, want %q", gotAfter, cursor)
+	}
+}
@@ -0,0 +1,176 @@
+package graw
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// MaxImageUploadSize is the largest image UploadImage accepts, matching Reddit's limit.
+const MaxImageUploadSize = 20 << 20
+
+// imageMimeTypes are the image formats Reddit accepts for image posts, by file extension.
+var imageMimeTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+}
+
+// mediaLease is Reddit's reply to a media asset request: where and how to upload the file.
+type mediaLease struct {
+	Args struct {
+		Action string `json:"action"`
+		Fields []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"fields"`
+	} `json:"args"`
+}
+
+// UploadImage uploads an image to Reddit's media host and returns its URL, to be used as
+// the URL of a SubmitKindImage post:
+//
+//	mediaURL, err := client.UploadImage(ctx, "chart.png", file)
+//	resp, err := client.SubmitPost(ctx, &types.SubmitRequest{
+//		Subreddit: "golang", Title: "Benchmarks", Kind: types.SubmitKindImage, URL: mediaURL,
+//	})
+//
+// The format is taken from filename's extension: .png, .jpg, .jpeg, or .gif. Uploading
+// requires user authentication.
+//
+// Returns an error if:
+//   - filename has an unsupported extension
+//   - the image is larger than MaxImageUploadSize or cannot be read
+//   - Reddit refuses the upload lease or the media host rejects the upload
+func (r *Reddit) UploadImage(ctx context.Context, filename string, image io.Reader) (string, error) {
+	name := filepath.Base(filename)
+	mimeType, ok := imageMimeTypes[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return "", &pkgerrs.ConfigError{Field: "filename", Message: "image must be a .png, .jpg, .jpeg, or .gif file"}
+	}
+	if image == nil {
+		return "", &pkgerrs.ConfigError{Field: "image", Message: "image cannot be nil"}
+	}
+	data, err := io.ReadAll(io.LimitReader(image, MaxImageUploadSize+1))
+	if err != nil {
+		return "", &pkgerrs.RequestError{Operation: "read image", Err: err}
+	}
+	if len(data) == 0 {
+		return "", &pkgerrs.ConfigError{Field: "image", Message: "image is empty"}
+	}
+	if len(data) > MaxImageUploadSize {
+		return "", &pkgerrs.ConfigError{Field: "image", Message: fmt.Sprintf("image exceeds %d byte limit", MaxImageUploadSize)}
+	}
+
+	lease, err := r.leaseMedia(ctx, name, mimeType)
+	if err != nil {
+		return "", err
+	}
+	return r.uploadMedia(ctx, lease, name, mimeType, data)
+}
+
+// leaseMedia asks Reddit where to upload a media file.
+func (r *Reddit) leaseMedia(ctx context.Context, name, mimeType string) (lease *mediaLease, err error) {
+	form := url.Values{"filepath": {name}, "mimetype": {mimeType}}
+	record := WriteAuditRecord{
+		Time:      time.Now(),
+		Operation: "upload media",
+		Method:    http.MethodPost,
+		Endpoint:  MediaAssetURL,
+		Target:    name,
+		Payload:   summarizePayload(form),
+	}
+	defer func() {
+		record.Err = err
+		r.auditWrite(ctx, record)
+	}()
+
+	req, err := r.httpClient.NewRequest(ctx, http.MethodPost, MediaAssetURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: MediaAssetURL, Err: err}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	lease = &mediaLease{}
+	if err := r.httpClient.DoJSON(req, lease); err != nil {
+		return nil, wrapDoError(err, "upload media", MediaAssetURL)
+	}
+	if lease.Args.Action == "" {
+		return nil, &pkgerrs.ParseError{Operation: "upload media", Err: fmt.Errorf("lease has no upload URL")}
+	}
+	return lease, nil
+}
+
+// uploadMedia posts a file to the media host as the lease directs and returns its URL.
+func (r *Reddit) uploadMedia(ctx context.Context, lease *mediaLease, name, mimeType string, data []byte) (string, error) {
+	action := lease.Args.Action
+	if strings.HasPrefix(action, "//") {
+		action = "https:" + action
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	key := ""
+	for _, field := range lease.Args.Fields {
+		if field.Name == "key" {
+			key = field.Value
+		}
+		if err := w.WriteField(field.Name, field.Value); err != nil {
+			return "", &pkgerrs.RequestError{Operation: "encode upload", URL: action, Err: err}
+		}
+	}
+	if key == "" {
+		return "", &pkgerrs.ParseError{Operation: "upload media", Err: fmt.Errorf("lease has no key field")}
+	}
+	// The file must be the last field of the form.
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "file", "filename": name}))
+	header.Set("Content-Type", mimeType)
+	part, err := w.CreatePart(header)
+	if err == nil {
+		_, err = part.Write(data)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		return "", &pkgerrs.RequestError{Operation: "encode upload", URL: action, Err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, action, &body)
+	if err != nil {
+		return "", &pkgerrs.RequestError{Operation: "create request", URL: action, Err: err}
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	client := http.DefaultClient
+	if r.config != nil && r.config.HTTPClient != nil {
+		client = r.config.HTTPClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", &pkgerrs.RequestError{Operation: "upload media", URL: action, Err: err}
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", &pkgerrs.APIError{StatusCode: resp.StatusCode, Message: "media host rejected the upload"}
+	}
+	return strings.TrimSuffix(action, "/") + "/" + key, nil
+}
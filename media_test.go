@@ -0,0 +1,133 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestUploadImage_AndSubmit(t *testing.T) {
+	var uploaded map[string]string
+	host := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("parse upload: %v", err)
+		}
+		uploaded = map[string]string{"key": r.FormValue("key"), "acl": r.FormValue("acl")}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("upload has no file: %v", err)
+		}
+		content, _ := io.ReadAll(file)
+		uploaded["file"] = string(content)
+		uploaded["filename"] = header.Filename
+		uploaded["type"] = header.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer host.Close()
+
+	var submitted map[string]string
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			if err := req.ParseForm(); err != nil {
+				t.Fatalf("parse form: %v", err)
+			}
+			switch req.URL.Path {
+			case "/" + MediaAssetURL:
+				if req.PostForm.Get("filepath") != "chart.png" || req.PostForm.Get("mimetype") != "image/png" {
+					t.Errorf("lease form = %v", req.PostForm)
+				}
+				lease := `{"args":{"action":"` + host.URL + `","fields":[{"name":"acl","value":"private"},{"name":"key","value":"rte_images/abc.png"}]},"asset":{"asset_id":"abc"}}`
+				return json.Unmarshal([]byte(lease), v)
+			case "/" + SubmitURL:
+				submitted = map[string]string{"kind": req.PostForm.Get("kind"), "url": req.PostForm.Get("url")}
+				return json.Unmarshal([]byte(`{"json":{"errors":[],"data":{"user_submitted_page":"https://www.reddit.com/user/gopher/submitted/","websocket_url":"wss://ws.redditmedia.com/abc"}}}`), v)
+			}
+			t.Errorf("unexpected POST %s", req.URL.Path)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	mediaURL, err := client.UploadImage(ctx, "/tmp/chart.png", strings.NewReader("PNGDATA"))
+	if err != nil {
+		t.Fatalf("UploadImage returned error: %v", err)
+	}
+	if want := host.URL + "/rte_images/abc.png"; mediaURL != want {
+		t.Errorf("media URL = %q, want %q", mediaURL, want)
+	}
+	want := map[string]string{"key": "rte_images/abc.png", "acl": "private", "file": "PNGDATA", "filename": "chart.png", "type": "image/png"}
+	for k, v := range want {
+		if uploaded[k] != v {
+			t.Errorf("uploaded %s = %q, want %q", k, uploaded[k], v)
+		}
+	}
+
+	resp, err := client.SubmitPost(ctx, &types.SubmitRequest{Subreddit: "golang", Title: "Benchmarks", Kind: types.SubmitKindImage, URL: "https://reddit-uploaded-media.s3-accelerate.amazonaws.com/rte_images/abc.png"})
+	if err != nil {
+		t.Fatalf("SubmitPost returned error: %v", err)
+	}
+	if submitted["kind"] != "image" || !strings.HasSuffix(submitted["url"], "/rte_images/abc.png") {
+		t.Errorf("submitted form = %v", submitted)
+	}
+	if resp.WebsocketURL != "wss://ws.redditmedia.com/abc" || resp.ID != "" {
+		t.Errorf("response = %+v, want only the websocket URL", resp)
+	}
+}
+
+func TestUploadImage_Errors(t *testing.T) {
+	host := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer host.Close()
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			return json.Unmarshal([]byte(`{"args":{"action":"`+host.URL+`","fields":[{"name":"key","value":"k"}]}}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		filename  string
+		image     io.Reader
+		wantField string
+	}{
+		{name: "unsupported type", filename: "clip.mp4", image: strings.NewReader("x"), wantField: "filename"},
+		{name: "no extension", filename: "image", image: strings.NewReader("x"), wantField: "filename"},
+		{name: "empty", filename: "a.gif", image: strings.NewReader(""), wantField: "image"},
+		{name: "too large", filename: "a.JPG", image: io.LimitReader(zeroReader{}, MaxImageUploadSize+1), wantField: "image"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UploadImage(ctx, tt.filename, tt.image)
+			var configErr *pkgerrs.ConfigError
+			if !errors.As(err, &configErr) || configErr.Field != tt.wantField {
+				t.Errorf("error = %v, want ConfigError for %s", err, tt.wantField)
+			}
+		})
+	}
+
+	_, err := client.UploadImage(ctx, "a.png", strings.NewReader("x"))
+	var apiErr *pkgerrs.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("error = %v, want APIError 403 from the media host", err)
+	}
+}
+
+// zeroReader reads zero bytes forever.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
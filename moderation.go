@@ -0,0 +1,140 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
+)
+
+const (
+	// MaxBanDuration is the longest temporary ban, in days. Longer bans must be permanent.
+	MaxBanDuration = 999
+	// maxBanReasonLength and maxBanNoteLength are Reddit's limits on BanOptions.Reason and Note.
+	maxBanReasonLength = 100
+	maxBanNoteLength   = 300
+)
+
+// Subreddit user lists, the type parameter of the friend and unfriend endpoints.
+const (
+	relBanned      = "banned"
+	relMuted       = "muted"
+	relContributor = "contributor"
+)
+
+// banRelID matches the ID of a ban list entry, which pages the ban list.
+var banRelID = regexp.MustCompile(`^rb_[0-9a-z]+$`)
+
+// BanUser bans a user from a subreddit. opts may be nil for a permanent ban without reason or
+// message. Banning a user who is already banned replaces the ban, e.g. to change its
+// duration. The client must be authenticated as a moderator with the ban permission.
+//
+// The subreddit may be given as "golang", "r/golang", or "/r/golang", and the username with
+// or without a "u/" prefix.
+//
+// Returns an error if:
+//   - The subreddit name or username is invalid
+//   - opts.Duration is negative or above MaxBanDuration, or Reason or Note is too long
+//   - The API request fails, e.g. with a *errors.ForbiddenError for non-moderators
+func (r *Reddit) BanUser(ctx context.Context, subreddit, username string, opts *types.BanOptions) error {
+	if opts == nil {
+		opts = &types.BanOptions{}
+	}
+	if opts.Duration < 0 || opts.Duration > MaxBanDuration {
+		return &pkgerrs.ConfigError{
+			Field:   "Duration",
+			Message: fmt.Sprintf("ban duration must be between 1 and %d days, or 0 for a permanent ban", MaxBanDuration),
+		}
+	}
+	if len(opts.Reason) > maxBanReasonLength {
+		return &pkgerrs.ConfigError{Field: "Reason", Message: fmt.Sprintf("ban reason cannot exceed %d characters", maxBanReasonLength)}
+	}
+	if len(opts.Note) > maxBanNoteLength {
+		return &pkgerrs.ConfigError{Field: "Note", Message: fmt.Sprintf("ban note cannot exceed %d characters", maxBanNoteLength)}
+	}
+
+	form := url.Values{}
+	if opts.Duration > 0 {
+		form.Set("duration", strconv.Itoa(opts.Duration))
+	}
+	if opts.Reason != "" {
+		form.Set("ban_reason", opts.Reason)
+	}
+	if opts.Note != "" {
+		form.Set("note", opts.Note)
+	}
+	if opts.Message != "" {
+		form.Set("ban_message", opts.Message)
+	}
+	return r.setUserRelationship(ctx, "ban user", FriendURLFormat, subreddit, username, relBanned, form)
+}
+
+// UnbanUser lifts a user's ban from a subreddit. See BanUser for the accepted names.
+func (r *Reddit) UnbanUser(ctx context.Context, subreddit, username string) error {
+	return r.setUserRelationship(ctx, "unban user", UnfriendURLFormat, subreddit, username, relBanned, nil)
+}
+
+// MuteUser stops a user from messaging a subreddit's moderators for 72 hours. See BanUser
+// for the accepted names; muting needs the mail permission.
+func (r *Reddit) MuteUser(ctx context.Context, subreddit, username string) error {
+	return r.setUserRelationship(ctx, "mute user", FriendURLFormat, subreddit, username, relMuted, nil)
+}
+
+// UnmuteUser lifts a user's mute in a subreddit. See BanUser for the accepted names.
+func (r *Reddit) UnmuteUser(ctx context.Context, subreddit, username string) error {
+	return r.setUserRelationship(ctx, "unmute user", UnfriendURLFormat, subreddit, username, relMuted, nil)
+}
+
+// AddApprovedUser adds a user to a subreddit's approved users, who may post in restricted
+// and private subreddits. See BanUser for the accepted names.
+func (r *Reddit) AddApprovedUser(ctx context.Context, subreddit, username string) error {
+	return r.setUserRelationship(ctx, "add approved user", FriendURLFormat, subreddit, username, relContributor, nil)
+}
+
+// RemoveApprovedUser removes a user from a subreddit's approved users. See BanUser for the
+// accepted names.
+func (r *Reddit) RemoveApprovedUser(ctx context.Context, subreddit, username string) error {
+	return r.setUserRelationship(ctx, "remove approved user", UnfriendURLFormat, subreddit, username, relContributor, nil)
+}
+
+// setUserRelationship adds username to, or removes it from, the subreddit user list rel
+// through the friend or unfriend endpoint pathFormat.
+func (r *Reddit) setUserRelationship(ctx context.Context, operation, pathFormat, subreddit, username, rel string, form url.Values) error {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return err
+	}
+	username = validation.NormalizeUsername(username)
+	if !validation.IsValidUsername(username) {
+		return &pkgerrs.ConfigError{Field: "username", Message: fmt.Sprintf("invalid username: %q", username)}
+	}
+
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("name", username)
+	form.Set("type", rel)
+	return r.postForm(ctx, operation, fmt.Sprintf(pathFormat, subreddit), username, form, nil)
+}
+
+// GetBannedUsers retrieves a page of a subreddit's ban list, newest first. The client must
+// be authenticated as a moderator of the subreddit.
+//
+// The list is paged by ban ID ("rb_..."), not by fullname: pass Listing.After to WithAfter
+// for the next page. WithLimit and WithTimeout apply as usual, and WithParam("user", name)
+// looks up a single user's ban. Sorting and caching are not supported.
+//
+// Returns an error if the subreddit name or options are invalid, or the API request fails.
+func (r *Reddit) GetBannedUsers(ctx context.Context, subreddit string, opts ...ListingOption) (*types.Listing[types.BannedUser], error) {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf(BannedURLFormat, subreddit)
+	return fetchRawListing[types.BannedUser](ctx, r, path, banRelID, "ban list", "get banned users", opts)
+}
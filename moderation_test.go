@@ -0,0 +1,154 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestClient_BanUser(t *testing.T) {
+	var paths []string
+	var forms []url.Values
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			paths = append(paths, req.URL.Path)
+			forms = append(forms, form)
+			return json.Unmarshal([]byte(`{}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	err := client.BanUser(ctx, "r/golang", "u/spammer", &types.BanOptions{
+		Duration: 7,
+		Reason:   "Rule 2",
+		Note:     "repeated self-promotion",
+		Message:  "Please read the rules.",
+	})
+	if err != nil {
+		t.Fatalf("BanUser returned error: %v", err)
+	}
+	if paths[0] != "/r/golang/api/friend" {
+		t.Errorf("BanUser path = %q, want /r/golang/api/friend", paths[0])
+	}
+	want := map[string]string{
+		"name": "spammer", "type": "banned", "duration": "7", "ban_reason": "Rule 2",
+		"note": "repeated self-promotion", "ban_message": "Please read the rules.",
+	}
+	for key, value := range want {
+		if got := forms[0].Get(key); got != value {
+			t.Errorf("BanUser form %s = %q, want %q", key, got, value)
+		}
+	}
+
+	// A nil BanOptions is a permanent ban.
+	if err := client.BanUser(ctx, "golang", "spammer", nil); err != nil {
+		t.Fatalf("BanUser(nil) returned error: %v", err)
+	}
+	if forms[1].Has("duration") {
+		t.Errorf("permanent ban sent duration %q", forms[1].Get("duration"))
+	}
+
+	calls := []struct {
+		name     string
+		call     func() error
+		wantPath string
+		wantType string
+	}{
+		{"UnbanUser", func() error { return client.UnbanUser(ctx, "golang", "spammer") }, "/r/golang/api/unfriend", "banned"},
+		{"MuteUser", func() error { return client.MuteUser(ctx, "golang", "spammer") }, "/r/golang/api/friend", "muted"},
+		{"UnmuteUser", func() error { return client.UnmuteUser(ctx, "golang", "spammer") }, "/r/golang/api/unfriend", "muted"},
+		{"AddApprovedUser", func() error { return client.AddApprovedUser(ctx, "golang", "gopher") }, "/r/golang/api/friend", "contributor"},
+		{"RemoveApprovedUser", func() error { return client.RemoveApprovedUser(ctx, "golang", "gopher") }, "/r/golang/api/unfriend", "contributor"},
+	}
+	for _, tt := range calls {
+		if err := tt.call(); err != nil {
+			t.Fatalf("%s returned error: %v", tt.name, err)
+		}
+		last := len(paths) - 1
+		if paths[last] != tt.wantPath || forms[last].Get("type") != tt.wantType {
+			t.Errorf("%s sent %s type=%s, want %s type=%s", tt.name, paths[last], forms[last].Get("type"), tt.wantPath, tt.wantType)
+		}
+	}
+
+	sent := len(paths)
+	invalid := []struct {
+		name string
+		err  error
+	}{
+		{"negative duration", client.BanUser(ctx, "golang", "spammer", &types.BanOptions{Duration: -1})},
+		{"long duration", client.BanUser(ctx, "golang", "spammer", &types.BanOptions{Duration: MaxBanDuration + 1})},
+		{"long reason", client.BanUser(ctx, "golang", "spammer", &types.BanOptions{Reason: strings.Repeat("x", 101)})},
+		{"long note", client.BanUser(ctx, "golang", "spammer", &types.BanOptions{Note: strings.Repeat("x", 301)})},
+		{"invalid username", client.UnbanUser(ctx, "golang", "no spaces")},
+		{"invalid subreddit", client.MuteUser(ctx, "a", "spammer")},
+	}
+	for _, tt := range invalid {
+		var configErr *pkgerrs.ConfigError
+		if !errors.As(tt.err, &configErr) {
+			t.Errorf("%s: error = %v, want ConfigError", tt.name, tt.err)
+		}
+	}
+	if len(paths) != sent {
+		t.Errorf("invalid calls sent %d requests", len(paths)-sent)
+	}
+}
+
+func TestClient_GetBannedUsers(t *testing.T) {
+	var query url.Values
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.URL.Path != "/r/golang/about/banned" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			query = req.URL.Query()
+			return json.Unmarshal([]byte(`{"kind": "UserList", "data": {"children": [
+				{"date": 1700000000.0, "days_left": 3, "rel_id": "rb_1a", "id": "t2_aaa", "name": "spammer", "note": "Rule 2: self-promotion"},
+				{"date": 1690000000.0, "days_left": null, "rel_id": "rb_1b", "id": "t2_bbb", "name": "troll", "note": ""}
+			], "after": "rb_1b", "before": null}}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	banned, err := client.GetBannedUsers(ctx, "golang", WithLimit(2), WithAfter("rb_19"), WithParam("user", "spammer"))
+	if err != nil {
+		t.Fatalf("GetBannedUsers returned error: %v", err)
+	}
+	if query.Get("limit") != "2" || query.Get("after") != "rb_19" || query.Get("user") != "spammer" {
+		t.Errorf("query = %v, want limit=2 after=rb_19 user=spammer", query)
+	}
+	if banned.Len() != 2 || banned.After != "rb_1b" || banned.Before != "" {
+		t.Fatalf("GetBannedUsers() = %+v, want two users and after rb_1b", banned)
+	}
+	first, second := banned.Items[0], banned.Items[1]
+	if first.Name != "spammer" || first.RelID != "rb_1a" || first.DaysLeft == nil || *first.DaysLeft != 3 {
+		t.Errorf("first ban = %+v, want spammer with 3 days left", first)
+	}
+	if second.DaysLeft != nil {
+		t.Errorf("permanent ban DaysLeft = %d, want nil", *second.DaysLeft)
+	}
+
+	invalid := map[string][]ListingOption{
+		"fullname cursor": {WithAfter("t3_abc")},
+		"bad before":      {WithBefore("rb_ABC")},
+		"limit":           {WithLimit(101)},
+		"sort":            {WithSort(SortNew)},
+	}
+	for name, opts := range invalid {
+		var configErr *pkgerrs.ConfigError
+		if _, err := client.GetBannedUsers(ctx, "golang", opts...); !errors.As(err, &configErr) {
+			t.Errorf("%s: error = %v, want ConfigError", name, err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// EnableSubredditNotifications turns on the authenticated user's post notifications for a
+// subreddit, at level types.NotificationLevelLow or types.NotificationLevelFrequent. An
+// empty level means NotificationLevelLow. Requires user authentication.
+//
+// The endpoint is the one Reddit's own clients use and is not part of Reddit's documented
+// API, so it may change without notice. The new level shows in SubredditData.NotificationLevel
+// the next time the subreddit is fetched; its cached data is dropped.
+//
+// Returns an error if:
+//   - The subreddit name is invalid
+//   - The level is not low or frequent
+//   - The API request fails or Reddit rejects the change
+func (r *Reddit) EnableSubredditNotifications(ctx context.Context, subreddit string, level types.NotificationLevel) error {
+	switch level {
+	case "":
+		level = types.NotificationLevelLow
+	case types.NotificationLevelLow, types.NotificationLevelFrequent:
+	default:
+		return &pkgerrs.ConfigError{Field: "level", Message: fmt.Sprintf("notification level must be low or frequent, got %q", level)}
+	}
+	return r.setSubredditNotifications(ctx, "enable subreddit notifications", subreddit, level)
+}
+
+// DisableSubredditNotifications turns off the authenticated user's post notifications for a
+// subreddit. See EnableSubredditNotifications for the endpoint's caveats.
+//
+// Returns an error if the subreddit name is invalid or the API request fails.
+func (r *Reddit) DisableSubredditNotifications(ctx context.Context, subreddit string) error {
+	return r.setSubredditNotifications(ctx, "disable subreddit notifications", subreddit, types.NotificationLevelOff)
+}
+
+// setSubredditNotifications sets the user's notification level for a subreddit.
+func (r *Reddit) setSubredditNotifications(ctx context.Context, operation, subreddit string, level types.NotificationLevel) error {
+	name, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{}
+	form.Set("sr_name", name)
+	form.Set("level", string(level))
+	if err := r.postForm(ctx, operation, SubredditNotificationsURL, SubPrefixURL+name, form, nil); err != nil {
+		return err
+	}
+	r.forgetSubreddit(name)
+	return nil
+}
@@ -0,0 +1,109 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// newNotificationsServer serves r/golang's about data and the notification level endpoint,
+// which stores the level it receives.
+func newNotificationsServer(t *testing.T) (*Reddit, *int) {
+	t.Helper()
+	level, fetches := "off", 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/r/golang/about", func(w http.ResponseWriter, req *http.Request) {
+		fetches++
+		json.NewEncoder(w).Encode(map[string]any{"kind": "t5", "data": map[string]any{
+			"id": "2qh1i", "name": "t5_2qh1i", "display_name": "golang", "notification_level": level,
+		}})
+	})
+	mux.HandleFunc("/"+SubredditNotificationsURL, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.FormValue("sr_name") != "golang" || req.FormValue("api_type") != "json" {
+			t.Errorf("unexpected request %s with form %v", req.Method, req.Form)
+		}
+		if req.FormValue("level") == "invalid" {
+			w.Write([]byte(`{"json":{"errors":[["BAD_NOTIFICATION_LEVEL","invalid level","level"]]}}`))
+			return
+		}
+		level = req.FormValue("level")
+		w.Write([]byte(`{"json":{"errors":[]}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	httpClient, err := internal.NewClient(server.Client(), server.URL, "test/1.0", nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return newTestClient(httpClient, nil), &fetches
+}
+
+func TestClient_SubredditNotifications(t *testing.T) {
+	client, fetches := newNotificationsServer(t)
+	ctx := context.Background()
+
+	// levelOf fetches r/golang and returns its notification level.
+	levelOf := func() types.NotificationLevel {
+		t.Helper()
+		sub, err := client.GetSubreddit(ctx, "golang")
+		if err != nil {
+			t.Fatalf("GetSubreddit: %v", err)
+		}
+		if sub.NotificationLevel == nil {
+			t.Fatal("NotificationLevel is nil")
+		}
+		return *sub.NotificationLevel
+	}
+
+	if got := levelOf(); got != types.NotificationLevelOff {
+		t.Fatalf("initial level = %q, want off", got)
+	}
+	if err := client.EnableSubredditNotifications(ctx, "r/golang", ""); err != nil {
+		t.Fatalf("EnableSubredditNotifications: %v", err)
+	}
+	if got := levelOf(); got != types.NotificationLevelLow {
+		t.Errorf("level after enabling = %q, want low", got)
+	}
+	if err := client.EnableSubredditNotifications(ctx, "golang", types.NotificationLevelFrequent); err != nil {
+		t.Fatalf("EnableSubredditNotifications(frequent): %v", err)
+	}
+	if got := levelOf(); got != types.NotificationLevelFrequent {
+		t.Errorf("level after enabling frequent = %q, want frequent", got)
+	}
+	if err := client.DisableSubredditNotifications(ctx, "golang"); err != nil {
+		t.Fatalf("DisableSubredditNotifications: %v", err)
+	}
+	if got := levelOf(); got != types.NotificationLevelOff {
+		t.Errorf("level after disabling = %q, want off", got)
+	}
+	if *fetches != 4 {
+		t.Errorf("about fetched %d times, want 4: each change must drop the cached subreddit", *fetches)
+	}
+}
+
+func TestClient_SubredditNotifications_Errors(t *testing.T) {
+	client, _ := newNotificationsServer(t)
+	ctx := context.Background()
+
+	var configErr *pkgerrs.ConfigError
+	if err := client.EnableSubredditNotifications(ctx, "golang", types.NotificationLevelOff); !errors.As(err, &configErr) || configErr.Field != "level" {
+		t.Errorf("EnableSubredditNotifications(off) error = %v, want ConfigError for level", err)
+	}
+	if err := client.DisableSubredditNotifications(ctx, "not a subreddit!"); err == nil {
+		t.Error("DisableSubredditNotifications accepted an invalid subreddit name")
+	}
+
+	err := client.setSubredditNotifications(ctx, "enable subreddit notifications", "golang", "invalid")
+	var apiErr *pkgerrs.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode != "BAD_NOTIFICATION_LEVEL" {
+		t.Errorf("rejected change error = %v, want APIError BAD_NOTIFICATION_LEVEL", err)
+	}
+}
@@ -0,0 +1,34 @@
+package graw
+
+import (
+	"errors"
+	"net/http"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// Cookies the Reddit site sets when a user confirms they are over 18 and opts in to
+// quarantined and gated subreddits.
+const (
+	over18Cookie  = "over18=1"
+	optionsCookie = `_options=%7B%22pref_quarantine_optin%22%3A%20true%2C%20%22pref_gated_sr_optin%22%3A%20true%7D`
+)
+
+// allowNSFW reports whether the client may opt in to quarantined and age-gated content.
+func (r *Reddit) allowNSFW() bool {
+	return r.config != nil && r.config.AllowNSFW
+}
+
+// isContentGate reports whether err is Reddit refusing a subreddit because it is
+// quarantined or age-gated, which opting in can resolve.
+func isContentGate(err error) bool {
+	var forbidden *pkgerrs.ForbiddenError
+	return errors.As(err, &forbidden) && (forbidden.IsQuarantined() || forbidden.IsGated())
+}
+
+// addNSFWOptIn marks req as coming from a user who has confirmed they are over 18 and
+// opted in to quarantined and gated subreddits.
+func addNSFWOptIn(req *http.Request) {
+	req.Header.Add("Cookie", over18Cookie)
+	req.Header.Add("Cookie", optionsCookie)
+}
@@ -0,0 +1,93 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestFetchListing_NSFWOptIn(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowNSFW bool
+		reason    string
+		wantCalls int
+		wantErr   func(error) bool
+	}{
+		{name: "quarantined with AllowNSFW", allowNSFW: true, reason: pkgerrs.ReasonQuarantined, wantCalls: 2},
+		{name: "gated with AllowNSFW", allowNSFW: true, reason: pkgerrs.ReasonGated, wantCalls: 2},
+		{
+			name:      "quarantined without AllowNSFW",
+			reason:    pkgerrs.ReasonQuarantined,
+			wantCalls: 1,
+			wantErr: func(err error) bool {
+				var e *pkgerrs.ForbiddenError
+				return errors.As(err, &e) && e.IsQuarantined()
+			},
+		},
+		{
+			name:      "gated without AllowNSFW",
+			reason:    pkgerrs.ReasonGated,
+			wantCalls: 1,
+			wantErr: func(err error) bool {
+				var e *pkgerrs.ForbiddenError
+				return errors.As(err, &e) && e.IsGated()
+			},
+		},
+		{
+			name:      "private is not retried",
+			allowNSFW: true,
+			reason:    pkgerrs.ReasonPrivate,
+			wantCalls: 1,
+			wantErr: func(err error) bool {
+				var e *pkgerrs.ForbiddenError
+				return errors.As(err, &e) && e.IsPrivate()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			mock := &mockHTTPClient{
+				doFunc: func(req *http.Request, v *types.Thing) error {
+					calls++
+					cookies := strings.Join(req.Header.Values("Cookie"), "; ")
+					if !strings.Contains(cookies, "over18=1") {
+						return &pkgerrs.APIError{StatusCode: http.StatusForbidden, Message: "request failed", Reason: tt.reason}
+					}
+					if !strings.Contains(cookies, "pref_quarantine_optin") || !strings.Contains(cookies, "pref_gated_sr_optin") {
+						t.Errorf("opt-in cookies missing: %q", cookies)
+					}
+					*v = *listingThing(t, submitPostThing(t, "abc123", "Gated post", "poster", time.Now()))
+					return nil
+				},
+			}
+			client := newTestClient(mock, nil)
+			client.config.AllowNSFW = tt.allowNSFW
+
+			resp, err := client.GetHot(context.Background(), &types.PostsRequest{Subreddit: "gatedsub"})
+			if calls != tt.wantCalls {
+				t.Errorf("requests = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Errorf("unexpected error %T: %v", err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetHot returned error: %v", err)
+			}
+			if len(resp.Posts) != 1 || resp.Posts[0].ID != "abc123" {
+				t.Errorf("posts = %+v", resp.Posts)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package graw
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// AuthCodeConfig describes a web or installed app that acts for Reddit users who authorize
+// it, using the OAuth2 authorization-code flow:
+//
+//  1. Send the user to AuthCodeURL(state), with a random state stored in their session.
+//  2. Reddit redirects them to RedirectURI; get the code with AuthorizationCode.
+//  3. Trade the code for tokens with Exchange.
+//  4. Create a client with Config.RefreshToken set to the token's RefreshToken.
+type AuthCodeConfig struct {
+	// ClientID is the app's client ID. Required.
+	ClientID string
+	// ClientSecret is the app's secret. Leave empty for installed apps, which have none.
+	ClientSecret string
+	// RedirectURI is where Reddit sends the user after authorizing. It must match the
+	// redirect URI registered for the app exactly. Required.
+	RedirectURI string
+	// Scopes are the OAuth scopes to request, e.g. "identity" and "read". Required.
+	Scopes []string
+	// Permanent requests a refresh token, so the app keeps access after the first access
+	// token expires. Without it, access ends after an hour.
+	Permanent bool
+
+	// UserAgent identifies the app in the token request. Defaults to DefaultUserAgent.
+	UserAgent string
+	// AuthURL is Reddit's website URL, used for authorization and token requests.
+	// Defaults to DefaultAuthURL.
+	AuthURL string
+	// HTTPClient sends the token request. Defaults to a client with DefaultTimeout.
+	HTTPClient *http.Client
+}
+
+// Token is the result of exchanging an authorization code, and what a TokenStore persists.
+type Token struct {
+	// AccessToken authorizes API requests for an hour.
+	AccessToken string
+	// RefreshToken obtains new access tokens. It is only issued for Permanent authorizations;
+	// store it securely and pass it as Config.RefreshToken.
+	RefreshToken string
+	// Scopes are the scopes the user granted.
+	Scopes []string
+	// Expiry is when AccessToken expires.
+	Expiry time.Time
+}
+
+// AuthCodeURL returns the Reddit URL to send a user to so they can authorize the app.
+// state is returned unchanged in the redirect; make it random per request and check it with
+// AuthorizationCode to prevent cross-site request forgery.
+//
+// Returns an error if ClientID, RedirectURI, Scopes, or state is missing or invalid.
+func (c *AuthCodeConfig) AuthCodeURL(state string) (string, error) {
+	if err := c.validate(); err != nil {
+		return "", err
+	}
+	if state == "" {
+		return "", &pkgerrs.ConfigError{Field: "state", Message: "state is required"}
+	}
+	if len(c.Scopes) == 0 {
+		return "", &pkgerrs.ConfigError{Field: "Scopes", Message: "at least one scope is required"}
+	}
+	for _, scope := range c.Scopes {
+		if scope == "" || strings.ContainsFunc(scope, func(r rune) bool { return r <= ' ' || r == ',' }) {
+			return "", &pkgerrs.ConfigError{Field: "Scopes", Message: fmt.Sprintf("invalid scope %q", scope)}
+		}
+	}
+
+	base, err := url.Parse(c.authURL())
+	if err != nil {
+		return "", &pkgerrs.ConfigError{Field: "AuthURL", Message: fmt.Sprintf("invalid auth URL: %v", err)}
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	u := base.JoinPath(AuthorizeURL)
+	duration := "temporary"
+	if c.Permanent {
+		duration = "permanent"
+	}
+	u.RawQuery = url.Values{
+		"client_id":     {c.ClientID},
+		"response_type": {"code"},
+		"state":         {state},
+		"redirect_uri":  {c.RedirectURI},
+		"duration":      {duration},
+		"scope":         {strings.Join(c.Scopes, " ")},
+	}.Encode()
+	return u.String(), nil
+}
+
+// AuthorizationCode returns the code from the query of Reddit's redirect to RedirectURI,
+// after checking that its state is the one passed to AuthCodeURL.
+//
+// Returns an *errors.AuthError if the user declined, the state does not match, or the
+// redirect has no code.
+func AuthorizationCode(query url.Values, state string) (string, error) {
+	if reason := query.Get("error"); reason != "" {
+		return "", &pkgerrs.AuthError{Message: "authorization failed: " + reason}
+	}
+	if state == "" || subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+		return "", &pkgerrs.AuthError{Message: "authorization state does not match"}
+	}
+	code := query.Get("code")
+	if code == "" {
+		return "", &pkgerrs.AuthError{Message: "authorization redirect has no code"}
+	}
+	return code, nil
+}
+
+// Exchange trades an authorization code for tokens. Each code can be exchanged once, within
+// a few minutes of the redirect.
+//
+// Returns an error if code or the config is invalid, or Reddit rejects the exchange.
+func (c *AuthCodeConfig) Exchange(ctx context.Context, code string) (*Token, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if code == "" {
+		return nil, &pkgerrs.ConfigError{Field: "code", Message: "authorization code is required"}
+	}
+	userAgent := c.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	auth, err := internal.NewAuthenticator(httpClient, "", "", c.ClientID, c.ClientSecret, userAgent, c.authURL(), "authorization_code", nil)
+	if err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to create authenticator", Err: err}
+	}
+	grant, err := auth.ExchangeCode(ctx, code, c.RedirectURI)
+	if err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to exchange authorization code", Err: err}
+	}
+	return &Token{
+		AccessToken:  grant.AccessToken,
+		RefreshToken: grant.RefreshToken,
+		Scopes:       strings.Fields(grant.Scope),
+		Expiry:       grant.Expiry,
+	}, nil
+}
+
+// validate checks the fields both steps of the flow need.
+func (c *AuthCodeConfig) validate() error {
+	if c.ClientID == "" {
+		return &pkgerrs.ConfigError{Field: "ClientID", Message: "client ID is required"}
+	}
+	if c.RedirectURI == "" {
+		return &pkgerrs.ConfigError{Field: "RedirectURI", Message: "redirect URI is required"}
+	}
+	if u, err := url.Parse(c.RedirectURI); err != nil || u.Scheme == "" {
+		return &pkgerrs.ConfigError{Field: "RedirectURI", Message: fmt.Sprintf("invalid redirect URI %q", c.RedirectURI)}
+	}
+	return nil
+}
+
+// authURL returns AuthURL or its default.
+func (c *AuthCodeConfig) authURL() string {
+	if c.AuthURL == "" {
+		return DefaultAuthURL
+	}
+	return c.AuthURL
+}
@@ -0,0 +1,174 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestAuthCodeURL(t *testing.T) {
+	config := &AuthCodeConfig{
+		ClientID:    "app-id",
+		RedirectURI: "http://localhost:8080/callback",
+		Scopes:      []string{"identity", "read"},
+		Permanent:   true,
+	}
+	got, err := config.AuthCodeURL("xyz")
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned error: %v", err)
+	}
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("AuthCodeURL returned invalid URL %q: %v", got, err)
+	}
+	if u.Scheme+"://"+u.Host+u.Path != DefaultAuthURL+AuthorizeURL {
+		t.Errorf("URL = %s, want %s%s", got, DefaultAuthURL, AuthorizeURL)
+	}
+	want := url.Values{
+		"client_id":     {"app-id"},
+		"response_type": {"code"},
+		"state":         {"xyz"},
+		"redirect_uri":  {"http://localhost:8080/callback"},
+		"duration":      {"permanent"},
+		"scope":         {"identity read"},
+	}
+	if q := u.Query(); q.Encode() != want.Encode() {
+		t.Errorf("query = %v, want %v", q, want)
+	}
+
+	tests := []struct {
+		name      string
+		modify    func(c *AuthCodeConfig)
+		state     string
+		wantField string
+	}{
+		{name: "no client ID", modify: func(c *AuthCodeConfig) { c.ClientID = "" }, state: "s", wantField: "ClientID"},
+		{name: "no redirect", modify: func(c *AuthCodeConfig) { c.RedirectURI = "" }, state: "s", wantField: "RedirectURI"},
+		{name: "relative redirect", modify: func(c *AuthCodeConfig) { c.RedirectURI = "/callback" }, state: "s", wantField: "RedirectURI"},
+		{name: "no scopes", modify: func(c *AuthCodeConfig) { c.Scopes = nil }, state: "s", wantField: "Scopes"},
+		{name: "bad scope", modify: func(c *AuthCodeConfig) { c.Scopes = []string{"identity read"} }, state: "s", wantField: "Scopes"},
+		{name: "no state", modify: func(c *AuthCodeConfig) {}, wantField: "state"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := *config
+			tt.modify(&c)
+			_, err := c.AuthCodeURL(tt.state)
+			var configErr *pkgerrs.ConfigError
+			if !errors.As(err, &configErr) || configErr.Field != tt.wantField {
+				t.Errorf("error = %v, want ConfigError for %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestAuthorizationCode(t *testing.T) {
+	code, err := AuthorizationCode(url.Values{"state": {"xyz"}, "code": {"abc"}}, "xyz")
+	if err != nil || code != "abc" {
+		t.Errorf("AuthorizationCode = %q, %v; want abc", code, err)
+	}
+	for name, query := range map[string]url.Values{
+		"declined":       {"state": {"xyz"}, "error": {"access_denied"}},
+		"state mismatch": {"state": {"other"}, "code": {"abc"}},
+		"no code":        {"state": {"xyz"}},
+	} {
+		var authErr *pkgerrs.AuthError
+		if _, err := AuthorizationCode(query, "xyz"); !errors.As(err, &authErr) {
+			t.Errorf("%s: error = %v, want AuthError", name, err)
+		}
+	}
+}
+
+func TestAuthCodeFlow(t *testing.T) {
+	var mu sync.Mutex
+	var requests []grawtest.TokenRequest
+	mux := http.NewServeMux()
+	grawtest.HandleToken(mux, grawtest.TokenHandler(func(req *grawtest.TokenRequest) *grawtest.TokenResponse {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, *req)
+		resp := grawtest.NewTokenResponse("")
+		switch {
+		case req.GrantType == "authorization_code" && req.Code == "abc" && req.RedirectURI == "http://localhost/cb":
+			resp.AccessToken, resp.RefreshToken, resp.Scope = "access-1", "refresh-1", "identity read"
+		case req.GrantType == "refresh_token" && req.RefreshToken == "refresh-1":
+			resp.AccessToken, resp.RefreshToken = "access-2", "refresh-2"
+		default:
+			return nil
+		}
+		return &resp
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	app := &AuthCodeConfig{
+		ClientID:    "installed-app",
+		RedirectURI: "http://localhost/cb",
+		Scopes:      []string{"identity", "read"},
+		Permanent:   true,
+		AuthURL:     server.URL + "/",
+		HTTPClient:  server.Client(),
+	}
+	token, err := app.Exchange(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("Exchange returned error: %v", err)
+	}
+	if token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" || len(token.Scopes) != 2 || token.Expiry.IsZero() {
+		t.Errorf("token = %+v", token)
+	}
+	if _, err := app.Exchange(context.Background(), "used"); err == nil {
+		t.Error("Exchange with a rejected code returned nil error")
+	}
+
+	var rotated []string
+	config := &Config{
+		ClientID:       "installed-app",
+		RefreshToken:   token.RefreshToken,
+		OnRefreshToken: func(refreshToken string) { rotated = append(rotated, refreshToken) },
+		AuthURL:        server.URL + "/",
+		BaseURL:        server.URL + "/",
+		WebURL:         server.URL + "/",
+		HTTPClient:     server.Client(),
+	}
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	if len(rotated) != 1 || rotated[0] != "refresh-2" {
+		t.Errorf("rotated refresh tokens = %v, want [refresh-2]", rotated)
+	}
+	mu.Lock()
+	last := requests[len(requests)-1]
+	mu.Unlock()
+	if last.GrantType != "refresh_token" || last.ClientID != "installed-app" || last.ClientSecret != "" {
+		t.Errorf("refresh request = %+v, want an installed app's refresh_token grant", last)
+	}
+
+	// Rotating the client's credentials keeps the rotated refresh token, which the server
+	// no longer accepts here, so the new credentials are rejected.
+	if err := client.UpdateCredentials(context.Background(), "installed-app", "", "pw"); err == nil {
+		t.Error("UpdateCredentials with a password returned nil error")
+	}
+	if err := client.UpdateCredentials(context.Background(), "installed-app", "", ""); err == nil {
+		t.Error("UpdateCredentials with the rotated refresh token returned nil error")
+	}
+	mu.Lock()
+	last = requests[len(requests)-1]
+	mu.Unlock()
+	if last.RefreshToken != "refresh-2" {
+		t.Errorf("UpdateCredentials sent refresh token %q, want refresh-2", last.RefreshToken)
+	}
+
+	_, err = NewClient(&Config{ClientID: "id", RefreshToken: "r", Username: "gopher", Password: "pw"})
+	var configErr *pkgerrs.ConfigError
+	if !errors.As(err, &configErr) || configErr.Field != "RefreshToken" {
+		t.Errorf("NewClient with a refresh token and password error = %v, want RefreshToken ConfigError", err)
+	}
+}
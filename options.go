@@ -0,0 +1,165 @@
+package graw
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// ListingSort is a post listing order. For top and controversial listings, Time selects the
+// time range: "hour", "day", "week", "month", "year", or "all". Reddit uses "day" when Time
+// is empty.
+type ListingSort struct {
+	Order string
+	Time  string
+}
+
+// Post listing orders for WithSort.
+var (
+	SortHot    = ListingSort{Order: "hot"}
+	SortNew    = ListingSort{Order: "new"}
+	SortRising = ListingSort{Order: "rising"}
+
+	TopHour  = ListingSort{Order: "top", Time: "hour"}
+	TopDay   = ListingSort{Order: "top", Time: "day"}
+	TopWeek  = ListingSort{Order: "top", Time: "week"}
+	TopMonth = ListingSort{Order: "top", Time: "month"}
+	TopYear  = ListingSort{Order: "top", Time: "year"}
+	TopAll   = ListingSort{Order: "top", Time: "all"}
+
+	ControversialHour  = ListingSort{Order: "controversial", Time: "hour"}
+	ControversialDay   = ListingSort{Order: "controversial", Time: "day"}
+	ControversialWeek  = ListingSort{Order: "controversial", Time: "week"}
+	ControversialMonth = ListingSort{Order: "controversial", Time: "month"}
+	ControversialYear  = ListingSort{Order: "controversial", Time: "year"}
+	ControversialAll   = ListingSort{Order: "controversial", Time: "all"}
+)
+
+// validate checks the order and time range against the values Reddit accepts.
+func (s ListingSort) validate() error {
+	switch s.Order {
+	case "hot", "new", "rising":
+		if s.Time != "" {
+			return &pkgerrs.ConfigError{Field: "Sort", Message: "a time range is only supported for top and controversial listings"}
+		}
+	case "top", "controversial":
+		switch s.Time {
+		case "", "hour", "day", "week", "month", "year", "all":
+		default:
+			return &pkgerrs.ConfigError{Field: "Sort", Message: "invalid time range: " + s.Time}
+		}
+	default:
+		return &pkgerrs.ConfigError{Field: "Sort", Message: "invalid sort order: " + s.Order}
+	}
+	return nil
+}
+
+// ListingOption adjusts a single listing call. Options are applied in order on top of the
+// request struct, which is not modified, so they can be used alone for quick calls:
+//
+//	resp, err := client.GetPosts(ctx, "golang", graw.WithSort(graw.TopWeek), graw.WithLimit(50))
+type ListingOption func(*listingCall)
+
+// listingCall is the effective request after options have been applied.
+type listingCall struct {
+	request types.PostsRequest
+	sort    ListingSort
+	sortSet bool
+	timeout time.Duration
+	cache   cachePolicy
+}
+
+// WithLimit sets the number of items to fetch (at most 100).
+func WithLimit(limit int) ListingOption {
+	return func(c *listingCall) { c.request.Limit = limit }
+}
+
+// WithAfter fetches the page after the given fullname, replacing any Before cursor.
+func WithAfter(fullname string) ListingOption {
+	return func(c *listingCall) {
+		c.request.After = fullname
+		c.request.Before = ""
+	}
+}
+
+// WithBefore fetches the page before the given fullname, replacing any After cursor.
+func WithBefore(fullname string) ListingOption {
+	return func(c *listingCall) {
+		c.request.Before = fullname
+		c.request.After = ""
+	}
+}
+
+// WithSort selects the listing order. It is accepted by GetPosts; methods whose name fixes
+// the order, such as GetHot, reject it.
+func WithSort(sort ListingSort) ListingOption {
+	return func(c *listingCall) {
+		c.sort = sort
+		c.sortSet = true
+	}
+}
+
+// WithParam adds an extra query parameter, for options the wrapper does not model yet
+// (see PostsRequest.Params). It can be repeated to send several values for one key.
+func WithParam(key, value string) ListingOption {
+	return func(c *listingCall) {
+		if c.request.Params == nil {
+			c.request.Params = url.Values{}
+		}
+		c.request.Params.Add(key, value)
+	}
+}
+
+// WithCacheTTL serves the listing from the client's cache when the same query was fetched
+// less than ttl ago, and caches fresh results. Each call gets its own copy of the cached
+// posts, but data they point to, such as Preview and SrDetail, is shared between callers and
+// must be treated as read-only.
+//
+// The cache is keyed by path and query, so each cursor is a separate entry. It holds at most
+// 1000 queries; when it is full, expired entries are dropped first, then the least recently
+// used.
+func WithCacheTTL(ttl time.Duration) ListingOption {
+	return func(c *listingCall) { c.cache.ttl = ttl }
+}
+
+// WithSWR enables stale-while-revalidate: a cached listing older than its cache TTL but no
+// more than maxStale beyond it is returned immediately, and refreshed in the background for
+// later calls. Combine it with WithCacheTTL; on its own, every cached result is stale.
+// A listing is dropped from the cache once it is more than maxStale past its TTL, or
+// earlier when the 1000-query bound of WithCacheTTL needs room.
+func WithSWR(maxStale time.Duration) ListingOption {
+	return func(c *listingCall) { c.cache.maxStale = maxStale }
+}
+
+// WithTimeout bounds the call, including rate-limit waits and retries, by d.
+func WithTimeout(d time.Duration) ListingOption {
+	return func(c *listingCall) { c.timeout = d }
+}
+
+// newListingCall applies opts to a copy of request.
+func newListingCall(request *types.PostsRequest, opts []ListingOption) *listingCall {
+	call := &listingCall{}
+	if request != nil {
+		call.request = *request
+		// Copy Params so WithParam does not modify the caller's map.
+		call.request.Params = maps.Clone(request.Params)
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(call)
+		}
+	}
+	return call
+}
+
+// context returns ctx bounded by the call's timeout, if any.
+func (c *listingCall) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout > 0 {
+		return context.WithTimeout(ctx, c.timeout)
+	}
+	return ctx, func() {}
+}
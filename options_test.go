@@ -0,0 +1,165 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestListingOptions(t *testing.T) {
+	var gotReq *http.Request
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			gotReq = req
+			*v = *listingThing(t)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		call      func() error
+		wantPath  string
+		wantQuery string
+	}{
+		{
+			name: "options override request",
+			call: func() error {
+				req := &types.PostsRequest{Subreddit: "golang", Pagination: types.Pagination{Limit: 10, Before: "t3_old"}}
+				_, err := client.GetHot(ctx, req, WithLimit(50), WithAfter("t3_abc"))
+				if req.Limit != 10 || req.Before != "t3_old" {
+					t.Errorf("request was modified: %+v", req)
+				}
+				return err
+			},
+			wantPath:  "/r/golang/hot",
+			wantQuery: "after=t3_abc&limit=50",
+		},
+		{
+			name: "options without request",
+			call: func() error {
+				_, err := client.GetNew(ctx, nil, WithBefore("t3_abc"))
+				return err
+			},
+			wantPath:  "/new",
+			wantQuery: "before=t3_abc",
+		},
+		{
+			name: "get posts with sort",
+			call: func() error {
+				_, err := client.GetPosts(ctx, "r/golang", WithSort(TopWeek), WithLimit(5))
+				return err
+			},
+			wantPath:  "/r/golang/top",
+			wantQuery: "limit=5&t=week",
+		},
+		{
+			name: "get posts defaults to hot",
+			call: func() error {
+				_, err := client.GetPosts(ctx, "")
+				return err
+			},
+			wantPath: "/hot",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotReq = nil
+			if err := tt.call(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotReq.URL.Path != tt.wantPath {
+				t.Errorf("path = %q, want %q", gotReq.URL.Path, tt.wantPath)
+			}
+			if gotReq.URL.RawQuery != tt.wantQuery {
+				t.Errorf("query = %q, want %q", gotReq.URL.RawQuery, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestListingOptions_Errors(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			<-req.Context().Done()
+			return req.Context().Err()
+		},
+	}, nil)
+	ctx := context.Background()
+
+	var configErr *pkgerrs.ConfigError
+	if _, err := client.GetHot(ctx, nil, WithSort(TopWeek)); !errors.As(err, &configErr) {
+		t.Errorf("GetHot with WithSort: expected ConfigError, got %v", err)
+	}
+	if _, err := client.GetPosts(ctx, "golang", WithSort(ListingSort{Order: "new", Time: "week"})); !errors.As(err, &configErr) {
+		t.Errorf("time range on new: expected ConfigError, got %v", err)
+	}
+	if _, err := client.GetPosts(ctx, "golang", WithSort(ListingSort{Order: "best"})); !errors.As(err, &configErr) {
+		t.Errorf("unknown order: expected ConfigError, got %v", err)
+	}
+
+	start := time.Now()
+	_, err := client.GetHot(ctx, nil, WithTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WithTimeout: expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WithTimeout took %v", elapsed)
+	}
+}
+
+func TestExtraQueryParams(t *testing.T) {
+	var gotQuery url.Values
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			gotQuery = req.URL.Query()
+			*v = *listingThing(t)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	req := &types.PostsRequest{Subreddit: "golang", Params: url.Values{"sr_detail": {"true"}}}
+	if _, err := client.GetHot(ctx, req, WithParam("include_categories", "true"), WithLimit(5)); err != nil {
+		t.Fatalf("GetHot: %v", err)
+	}
+	if gotQuery.Get("sr_detail") != "true" || gotQuery.Get("include_categories") != "true" || gotQuery.Get("limit") != "5" {
+		t.Errorf("query = %v", gotQuery)
+	}
+	if _, ok := req.Params["include_categories"]; ok {
+		t.Error("WithParam modified the request's Params")
+	}
+
+	tests := []struct {
+		name   string
+		params url.Values
+	}{
+		{"reserved key", url.Values{"limit": {"500"}}},
+		{"reserved key case-insensitive", url.Values{"After": {"t3_x"}}},
+		{"empty key", url.Values{"": {"x"}}},
+		{"control characters", url.Values{"q": {"a\r\nb"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configErr *pkgerrs.ConfigError
+			_, err := client.GetHot(ctx, &types.PostsRequest{Params: tt.params})
+			if !errors.As(err, &configErr) {
+				t.Errorf("GetHot: expected ConfigError, got %v", err)
+			}
+			_, err = client.GetComments(ctx, &types.CommentsRequest{Subreddit: "golang", PostID: "abc123", Params: tt.params})
+			if !errors.As(err, &configErr) {
+				t.Errorf("GetComments: expected ConfigError, got %v", err)
+			}
+		})
+	}
+}
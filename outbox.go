@@ -0,0 +1,462 @@
+package graw
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
+)
+
+const (
+	// DefaultOutboxWriteInterval is the minimum time between writes sent by an Outbox.
+	DefaultOutboxWriteInterval = 2 * time.Second
+	// DefaultOutboxMaxAttempts is how many times an Outbox tries a job that keeps failing
+	// with transient errors before giving up on it.
+	DefaultOutboxMaxAttempts = 5
+	// DefaultOutboxRetryBackoff is the delay before the first retry of a failed job.
+	// It doubles with each further attempt, up to maxOutboxRetryBackoff.
+	DefaultOutboxRetryBackoff = 30 * time.Second
+
+	// maxOutboxRetryBackoff caps the delay between retries of one job.
+	maxOutboxRetryBackoff = 30 * time.Minute
+	// defaultRateLimitDelay is used when Reddit reports RATELIMIT without saying how long to wait.
+	defaultRateLimitDelay = time.Minute
+)
+
+// Outbox job actions.
+const (
+	OutboxActionSave    = "save"
+	OutboxActionSubmit  = "submit"
+	OutboxActionComment = "comment"
+)
+
+// OutboxJob is a queued write action. Jobs are persisted by an OutboxStore, so their fields
+// are JSON-encoded.
+type OutboxJob struct {
+	ID     string `json:"id"`
+	Action string `json:"action"`
+	// Payload holds the action's arguments.
+	Payload json.RawMessage `json:"payload"`
+
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	// Attempts counts sends that failed with a transient error. Rate-limited sends are not counted.
+	Attempts       int       `json:"attempts"`
+	FirstAttemptAt time.Time `json:"first_attempt_at,omitempty"`
+	// NextAttemptAt is the earliest time the job will be sent.
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+// OutboxStore persists queued jobs. Implementations must be safe for concurrent use.
+// MemoryOutboxStore and FileOutboxStore are provided; use a database-backed store to share
+// an outbox between processes.
+type OutboxStore interface {
+	// Put inserts the job, or replaces the stored job with the same ID.
+	Put(ctx context.Context, job *OutboxJob) error
+	// Delete removes the job with the given ID. Deleting a missing job is not an error.
+	Delete(ctx context.Context, id string) error
+	// List returns every stored job.
+	List(ctx context.Context) ([]*OutboxJob, error)
+}
+
+// OutboxConfig configures an Outbox.
+type OutboxConfig struct {
+	// Store persists jobs. Required.
+	Store OutboxStore
+
+	// WriteInterval is the minimum time between writes. Defaults to DefaultOutboxWriteInterval.
+	WriteInterval time.Duration
+
+	// MaxAttempts limits retries of jobs failing with transient errors such as timeouts or
+	// server errors. Defaults to DefaultOutboxMaxAttempts.
+	MaxAttempts int
+
+	// RetryBackoff is the delay before the first retry. Defaults to DefaultOutboxRetryBackoff.
+	RetryBackoff time.Duration
+
+	// OnResult, if set, is called when a job leaves the outbox: with a nil error once it has
+	// been sent, or with the final error when it was rejected or ran out of attempts.
+	OnResult func(job *OutboxJob, err error)
+}
+
+// Outbox queues write actions in a store and sends them from a single worker, so that queued
+// replies are not lost when Reddit throttles the account or the process restarts.
+//
+// The worker (Run) sends at most one write per WriteInterval. When Reddit answers with a
+// RATELIMIT error or HTTP 429 the job is postponed for the time Reddit asks for. Transient
+// failures are retried with exponential backoff; other errors drop the job.
+//
+// Submissions are retried with SubmitPost's duplicate detection, including after a restart:
+// a submit or comment job that already failed ambiguously is only re-sent if the post or
+// reply cannot be found.
+type Outbox struct {
+	r      *Reddit
+	config OutboxConfig
+	wake   chan struct{}
+
+	mu        sync.Mutex
+	lastWrite time.Time
+}
+
+// NewOutbox creates an Outbox that sends queued jobs with r. Jobs already in config.Store,
+// e.g. from a previous run, are sent once Run is called.
+//
+// Returns a *errors.ConfigError if config or config.Store is nil.
+func NewOutbox(r *Reddit, config *OutboxConfig) (*Outbox, error) {
+	if r == nil {
+		return nil, &pkgerrs.ConfigError{Message: "reddit client cannot be nil"}
+	}
+	if config == nil || config.Store == nil {
+		return nil, &pkgerrs.ConfigError{Field: "Store", Message: "outbox store is required"}
+	}
+	cfg := *config
+	if cfg.WriteInterval <= 0 {
+		cfg.WriteInterval = DefaultOutboxWriteInterval
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultOutboxMaxAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = DefaultOutboxRetryBackoff
+	}
+	return &Outbox{r: r, config: cfg, wake: make(chan struct{}, 1)}, nil
+}
+
+// saveJob is the payload of an OutboxActionSave job.
+type saveJob struct {
+	Fullname string `json:"fullname"`
+	Category string `json:"category,omitempty"`
+}
+
+// EnqueueSave queues a Save call and returns the job ID.
+//
+// Returns an error if the arguments are invalid or the job cannot be stored.
+func (o *Outbox) EnqueueSave(ctx context.Context, fullname, category string) (string, error) {
+	if err := o.r.validator.ValidatePaginationToken(fullname); err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(category, "\r\n") {
+		return "", &pkgerrs.ConfigError{Field: "category", Message: "category cannot contain newline characters"}
+	}
+	return o.enqueue(ctx, OutboxActionSave, saveJob{Fullname: fullname, Category: category})
+}
+
+// commentJob is the payload of an OutboxActionComment job.
+type commentJob struct {
+	Parent string `json:"parent"`
+	Text   string `json:"text"`
+}
+
+// EnqueueComment queues a SubmitComment call replying to a post or comment, and returns the
+// job ID. A reply whose attempt failed ambiguously, e.g. with a timeout, is only re-sent if
+// the account's recent comments do not already include it.
+//
+// Returns an error if the arguments are invalid or the job cannot be stored.
+func (o *Outbox) EnqueueComment(ctx context.Context, parentFullname, text string) (string, error) {
+	if err := validation.ValidateCommentDraft(parentFullname, text); err != nil {
+		return "", err
+	}
+	return o.enqueue(ctx, OutboxActionComment, commentJob{Parent: parentFullname, Text: text})
+}
+
+// EnqueueSubmit queues a SubmitPost call and returns the job ID. If request has no
+// IdempotencyKey, the job ID is used, so retries do not create duplicate posts.
+//
+// Returns an error if the request is invalid or the job cannot be stored.
+func (o *Outbox) EnqueueSubmit(ctx context.Context, request *types.SubmitRequest) (string, error) {
+	if err := validation.ValidateSubmission(request, nil); err != nil {
+		return "", err
+	}
+	id, err := newOutboxJobID()
+	if err != nil {
+		return "", err
+	}
+	req := *request
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = id
+	}
+	return id, o.put(ctx, id, OutboxActionSubmit, req)
+}
+
+// enqueue stores a new job for action with the given payload.
+func (o *Outbox) enqueue(ctx context.Context, action string, payload any) (string, error) {
+	id, err := newOutboxJobID()
+	if err != nil {
+		return "", err
+	}
+	return id, o.put(ctx, id, action, payload)
+}
+
+func (o *Outbox) put(ctx context.Context, id, action string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return &pkgerrs.ParseError{Operation: "encode outbox job", Err: err}
+	}
+	now := time.Now()
+	job := &OutboxJob{ID: id, Action: action, Payload: data, EnqueuedAt: now, NextAttemptAt: now}
+	if err := o.config.Store.Put(ctx, job); err != nil {
+		return err
+	}
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run sends queued jobs until ctx is cancelled, then returns ctx.Err(). Only one Run should
+// be active per store.
+//
+// Returns early with an error if the store cannot be read or updated.
+func (o *Outbox) Run(ctx context.Context) error {
+	for {
+		job, wait, err := o.nextJob(ctx)
+		if err != nil {
+			return err
+		}
+		if job == nil {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-o.wake:
+				timer.Stop()
+			case <-timer.C:
+			}
+			continue
+		}
+
+		if err := o.throttle(ctx); err != nil {
+			return err
+		}
+		if err := o.process(ctx, job); err != nil {
+			return err
+		}
+	}
+}
+
+// nextJob returns the due job enqueued first, or how long to wait for one.
+func (o *Outbox) nextJob(ctx context.Context) (*OutboxJob, time.Duration, error) {
+	jobs, err := o.config.Store.List(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if !jobs[i].NextAttemptAt.Equal(jobs[j].NextAttemptAt) {
+			return jobs[i].NextAttemptAt.Before(jobs[j].NextAttemptAt)
+		}
+		return jobs[i].EnqueuedAt.Before(jobs[j].EnqueuedAt)
+	})
+
+	// With nothing queued, sleep until Enqueue wakes the worker.
+	wait := time.Hour
+	now := time.Now()
+	for _, job := range jobs {
+		if !job.NextAttemptAt.After(now) {
+			return job, 0, nil
+		}
+		wait = job.NextAttemptAt.Sub(now)
+		break
+	}
+	return nil, wait, nil
+}
+
+// throttle waits until WriteInterval has passed since the previous write.
+func (o *Outbox) throttle(ctx context.Context) error {
+	o.mu.Lock()
+	wait := time.Until(o.lastWrite.Add(o.config.WriteInterval))
+	o.mu.Unlock()
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// process sends job and records the outcome in the store.
+func (o *Outbox) process(ctx context.Context, job *OutboxJob) error {
+	sendErr := o.r.safeCall(ctx, "outbox "+job.Action, func() error { return o.send(ctx, job) })
+	now := time.Now()
+	o.mu.Lock()
+	o.lastWrite = now
+	o.mu.Unlock()
+
+	if sendErr != nil && ctx.Err() != nil {
+		// Shutting down; leave the job for the next run.
+		return ctx.Err()
+	}
+
+	if sendErr == nil {
+		return o.finish(ctx, job, nil)
+	}
+
+	if delay, ok := rateLimitDelay(sendErr); ok {
+		job.NextAttemptAt = now.Add(delay)
+		job.LastError = sendErr.Error()
+		o.logJob(ctx, slog.LevelWarn, "outbox job rate limited", job, sendErr)
+		return o.config.Store.Put(ctx, job)
+	}
+
+	// Writes are rejected outright in read-only mode, so the job can wait it out.
+	var degraded *pkgerrs.ServiceDegradedError
+	if errors.As(sendErr, &degraded) && degraded.IsReadOnly() {
+		job.NextAttemptAt = now.Add(degraded.RetryAfter)
+		job.LastError = sendErr.Error()
+		o.logJob(ctx, slog.LevelInfo, "outbox job postponed; reddit is read-only", job, sendErr)
+		return o.config.Store.Put(ctx, job)
+	}
+
+	if !isAmbiguousSubmitError(sendErr) {
+		return o.finish(ctx, job, sendErr)
+	}
+
+	job.Attempts++
+	if job.FirstAttemptAt.IsZero() {
+		job.FirstAttemptAt = now
+	}
+	job.LastError = sendErr.Error()
+	if job.Attempts >= o.config.MaxAttempts {
+		return o.finish(ctx, job, sendErr)
+	}
+	backoff := o.config.RetryBackoff << (job.Attempts - 1)
+	if backoff <= 0 || backoff > maxOutboxRetryBackoff {
+		backoff = maxOutboxRetryBackoff
+	}
+	job.NextAttemptAt = now.Add(backoff)
+	o.logJob(ctx, slog.LevelWarn, "outbox job failed; will retry", job, sendErr)
+	return o.config.Store.Put(ctx, job)
+}
+
+// finish removes job from the store and reports its result.
+func (o *Outbox) finish(ctx context.Context, job *OutboxJob, err error) error {
+	if err != nil {
+		job.LastError = err.Error()
+		o.logJob(ctx, slog.LevelError, "outbox job dropped", job, err)
+	}
+	if storeErr := o.config.Store.Delete(ctx, job.ID); storeErr != nil {
+		return storeErr
+	}
+	if o.config.OnResult != nil {
+		o.config.OnResult(job, err)
+	}
+	return nil
+}
+
+// send performs the job's action.
+func (o *Outbox) send(ctx context.Context, job *OutboxJob) error {
+	switch job.Action {
+	case OutboxActionSave:
+		var p saveJob
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return &pkgerrs.ConfigError{Field: "Payload", Message: fmt.Sprintf("invalid save job: %v", err)}
+		}
+		return o.r.Save(ctx, p.Fullname, p.Category)
+
+	case OutboxActionSubmit:
+		var req types.SubmitRequest
+		if err := json.Unmarshal(job.Payload, &req); err != nil {
+			return &pkgerrs.ConfigError{Field: "Payload", Message: fmt.Sprintf("invalid submit job: %v", err)}
+		}
+		// After a restart the client no longer remembers the idempotency key, so look for
+		// the post an earlier ambiguous attempt may have created.
+		if job.Attempts > 0 {
+			existing, err := o.r.findSubmittedDuplicate(ctx, &req, job.FirstAttemptAt)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				return nil
+			}
+		}
+		_, err := o.r.SubmitPost(ctx, &req)
+		return err
+
+	case OutboxActionComment:
+		var p commentJob
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return &pkgerrs.ConfigError{Field: "Payload", Message: fmt.Sprintf("invalid comment job: %v", err)}
+		}
+		if job.Attempts > 0 {
+			existing, err := o.r.findCommentDuplicate(ctx, p.Parent, p.Text, job.FirstAttemptAt)
+			if err != nil {
+				return err
+			}
+			if existing != nil {
+				return nil
+			}
+		}
+		_, err := o.r.SubmitComment(ctx, p.Parent, p.Text)
+		return err
+
+	default:
+		return &pkgerrs.ConfigError{Field: "Action", Message: fmt.Sprintf("unknown outbox action %q", job.Action)}
+	}
+}
+
+func (o *Outbox) logJob(ctx context.Context, level slog.Level, msg string, job *OutboxJob, err error) {
+	if o.r.config == nil || o.r.config.Logger == nil {
+		return
+	}
+	o.r.config.Logger.LogAttrs(ctx, level, msg,
+		slog.String("job_id", job.ID),
+		slog.String("action", job.Action),
+		slog.Int("attempts", job.Attempts),
+		slog.Time("next_attempt", job.NextAttemptAt),
+		slog.String("error", err.Error()))
+}
+
+// rateLimitPattern extracts the wait from messages like "try again in 9 minutes".
+var rateLimitPattern = regexp.MustCompile(`(\d+)\s*(millisecond|second|minute|hour)s?`)
+
+// rateLimitDelay reports whether err is Reddit throttling writes, and for how long.
+func rateLimitDelay(err error) (time.Duration, bool) {
+	var apiErr *pkgerrs.APIError
+	if !errors.As(err, &apiErr) {
+		return 0, false
+	}
+	if apiErr.ErrorCode != "RATELIMIT" && apiErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	m := rateLimitPattern.FindStringSubmatch(apiErr.Message)
+	if m == nil {
+		return defaultRateLimitDelay, true
+	}
+	n, _ := strconv.Atoi(m[1])
+	unit := map[string]time.Duration{
+		"millisecond": time.Millisecond,
+		"second":      time.Second,
+		"minute":      time.Minute,
+		"hour":        time.Hour,
+	}[m[2]]
+	return time.Duration(n) * unit, true
+}
+
+// newOutboxJobID returns a random job ID.
+func newOutboxJobID() (string, error) {
+	var b [12]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", &pkgerrs.StateError{Operation: "enqueue", Message: fmt.Sprintf("failed to generate job ID: %v", err)}
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,129 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"sync"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// MemoryOutboxStore keeps outbox jobs in memory. Jobs do not survive a restart, so it is
+// mainly useful for tests and short-lived programs. The zero value is ready to use.
+type MemoryOutboxStore struct {
+	mu   sync.Mutex
+	jobs map[string]*OutboxJob
+}
+
+// Put stores a copy of job.
+func (s *MemoryOutboxStore) Put(_ context.Context, job *OutboxJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jobs == nil {
+		s.jobs = make(map[string]*OutboxJob)
+	}
+	copied := *job
+	s.jobs[job.ID] = &copied
+	return nil
+}
+
+// Delete removes the job with the given ID.
+func (s *MemoryOutboxStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, id)
+	return nil
+}
+
+// List returns copies of all stored jobs.
+func (s *MemoryOutboxStore) List(_ context.Context) ([]*OutboxJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs := make([]*OutboxJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		copied := *job
+		jobs = append(jobs, &copied)
+	}
+	return jobs, nil
+}
+
+// FileOutboxStore keeps outbox jobs in a JSON file, so queued writes survive restarts.
+// Every change rewrites the file via a temporary file and rename, so a crash leaves either
+// the old or the new contents. The file must not be shared between processes.
+type FileOutboxStore struct {
+	path string
+
+	mu   sync.Mutex
+	jobs MemoryOutboxStore
+}
+
+// NewFileOutboxStore opens the outbox file at path, loading any jobs left by a previous run.
+// The file is created on the first write if it does not exist.
+//
+// Returns a *errors.ConfigError if the file exists but cannot be read or decoded.
+func NewFileOutboxStore(path string) (*FileOutboxStore, error) {
+	s := &FileOutboxStore{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, &pkgerrs.ConfigError{Field: "path", Message: fmt.Sprintf("failed to read outbox file: %v", err)}
+	}
+
+	var jobs []*OutboxJob
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &jobs); err != nil {
+			return nil, &pkgerrs.ConfigError{Field: "path", Message: fmt.Sprintf("failed to parse outbox file: %v", err)}
+		}
+	}
+	for _, job := range jobs {
+		_ = s.jobs.Put(context.Background(), job)
+	}
+	return s, nil
+}
+
+// Put stores job and rewrites the file. If the file cannot be written, the stored jobs are
+// left unchanged.
+func (s *FileOutboxStore) Put(ctx context.Context, job *OutboxJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs, _ := s.jobs.List(ctx)
+	jobs = slices.DeleteFunc(jobs, func(j *OutboxJob) bool { return j.ID == job.ID })
+	if err := s.flush(append(jobs, job)); err != nil {
+		return err
+	}
+	return s.jobs.Put(ctx, job)
+}
+
+// Delete removes the job with the given ID and rewrites the file. If the file cannot be
+// written, the job is kept.
+func (s *FileOutboxStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	jobs, _ := s.jobs.List(ctx)
+	if err := s.flush(slices.DeleteFunc(jobs, func(j *OutboxJob) bool { return j.ID == id })); err != nil {
+		return err
+	}
+	return s.jobs.Delete(ctx, id)
+}
+
+// List returns all stored jobs.
+func (s *FileOutboxStore) List(ctx context.Context) ([]*OutboxJob, error) {
+	return s.jobs.List(ctx)
+}
+
+// flush writes jobs to the file atomically. The caller must hold s.mu.
+func (s *FileOutboxStore) flush(jobs []*OutboxJob) error {
+	data, err := json.MarshalIndent(jobs, "", "  ")
+	if err != nil {
+		return &pkgerrs.ParseError{Operation: "encode outbox file", Err: err}
+	}
+	return writeFileAtomic(s.path, data, "write outbox file")
+}
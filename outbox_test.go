@@ -0,0 +1,401 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// outboxResults collects OnResult callbacks.
+type outboxResults struct {
+	mu      sync.Mutex
+	results map[string]error
+	done    chan string
+}
+
+func newOutboxResults() *outboxResults {
+	return &outboxResults{results: make(map[string]error), done: make(chan string, 10)}
+}
+
+func (o *outboxResults) record(job *OutboxJob, err error) {
+	o.mu.Lock()
+	o.results[job.ID] = err
+	o.mu.Unlock()
+	o.done <- job.ID
+}
+
+func (o *outboxResults) wait(t *testing.T) string {
+	t.Helper()
+	select {
+	case id := <-o.done:
+		return id
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for outbox result")
+	}
+	return ""
+}
+
+func TestOutbox_SendsQueuedJobs(t *testing.T) {
+	var mu sync.Mutex
+	var saved []string
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			mu.Lock()
+			saved = append(saved, form.Get("id"))
+			mu.Unlock()
+			return json.Unmarshal([]byte(`{}`), v)
+		},
+	}
+	results := newOutboxResults()
+	store := &MemoryOutboxStore{}
+	outbox, err := NewOutbox(newTestClient(mock, nil), &OutboxConfig{
+		Store:         store,
+		WriteInterval: time.Millisecond,
+		OnResult:      results.record,
+	})
+	if err != nil {
+		t.Fatalf("NewOutbox: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go outbox.Run(ctx)
+
+	first, err := outbox.EnqueueSave(ctx, "t3_aaa111", "")
+	if err != nil {
+		t.Fatalf("EnqueueSave: %v", err)
+	}
+	if got := results.wait(t); got != first {
+		t.Errorf("finished job %s, want %s", got, first)
+	}
+	if _, err := outbox.EnqueueSave(ctx, "t1_bbb222", "later"); err != nil {
+		t.Fatalf("EnqueueSave: %v", err)
+	}
+	results.wait(t)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(saved) != 2 || saved[0] != "t3_aaa111" || saved[1] != "t1_bbb222" {
+		t.Errorf("saved = %v", saved)
+	}
+	if jobs, _ := store.List(ctx); len(jobs) != 0 {
+		t.Errorf("store still holds %d jobs", len(jobs))
+	}
+}
+
+func TestOutbox_RetryPolicy(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantKept     bool
+		wantAttempts int
+		wantDelay    time.Duration
+	}{
+		{
+			name:      "rate limited",
+			err:       &pkgerrs.APIError{StatusCode: http.StatusOK, ErrorCode: "RATELIMIT", Message: "you are doing that too much. try again in 9 minutes."},
+			wantKept:  true,
+			wantDelay: 9 * time.Minute,
+		},
+		{
+			name:      "too many requests",
+			err:       &pkgerrs.APIError{StatusCode: http.StatusTooManyRequests, Message: "too many requests"},
+			wantKept:  true,
+			wantDelay: defaultRateLimitDelay,
+		},
+		{
+			name:         "server error",
+			err:          &pkgerrs.APIError{StatusCode: http.StatusBadGateway, Message: "bad gateway"},
+			wantKept:     true,
+			wantAttempts: 1,
+			wantDelay:    time.Second,
+		},
+		{
+			name: "rejected",
+			err:  &pkgerrs.APIError{StatusCode: http.StatusOK, ErrorCode: "THREAD_LOCKED", Message: "that thread is locked"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockHTTPClient{
+				doJSONFunc: func(req *http.Request, v any) error { return tt.err },
+			}
+			store := &MemoryOutboxStore{}
+			var gotErr error
+			outbox, err := NewOutbox(newTestClient(mock, nil), &OutboxConfig{
+				Store:        store,
+				RetryBackoff: time.Second,
+				OnResult:     func(job *OutboxJob, err error) { gotErr = err },
+			})
+			if err != nil {
+				t.Fatalf("NewOutbox: %v", err)
+			}
+
+			ctx := context.Background()
+			if _, err := outbox.EnqueueSave(ctx, "t3_aaa111", ""); err != nil {
+				t.Fatalf("EnqueueSave: %v", err)
+			}
+			job, _, err := outbox.nextJob(ctx)
+			if err != nil || job == nil {
+				t.Fatalf("nextJob = %v, %v", job, err)
+			}
+			before := time.Now()
+			if err := outbox.process(ctx, job); err != nil {
+				t.Fatalf("process: %v", err)
+			}
+
+			jobs, _ := store.List(ctx)
+			if !tt.wantKept {
+				if len(jobs) != 0 {
+					t.Errorf("expected job to be dropped, store has %d", len(jobs))
+				}
+				if !errors.Is(gotErr, tt.err) {
+					t.Errorf("OnResult error = %v, want %v", gotErr, tt.err)
+				}
+				return
+			}
+			if len(jobs) != 1 {
+				t.Fatalf("expected job to be kept, store has %d", len(jobs))
+			}
+			kept := jobs[0]
+			if kept.Attempts != tt.wantAttempts {
+				t.Errorf("Attempts = %d, want %d", kept.Attempts, tt.wantAttempts)
+			}
+			if delay := kept.NextAttemptAt.Sub(before); delay < tt.wantDelay || delay > tt.wantDelay+time.Second {
+				t.Errorf("rescheduled after %v, want about %v", delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestOutbox_GivesUpAfterMaxAttempts(t *testing.T) {
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			return &pkgerrs.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+		},
+	}
+	store := &MemoryOutboxStore{}
+	var dropped bool
+	outbox, _ := NewOutbox(newTestClient(mock, nil), &OutboxConfig{
+		Store:       store,
+		MaxAttempts: 2,
+		OnResult:    func(*OutboxJob, error) { dropped = true },
+	})
+
+	ctx := context.Background()
+	outbox.EnqueueSave(ctx, "t3_aaa111", "")
+	for i := 0; i < 2; i++ {
+		jobs, _ := store.List(ctx)
+		if len(jobs) != 1 {
+			t.Fatalf("attempt %d: store has %d jobs", i, len(jobs))
+		}
+		if err := outbox.process(ctx, jobs[0]); err != nil {
+			t.Fatalf("process: %v", err)
+		}
+	}
+	if jobs, _ := store.List(ctx); len(jobs) != 0 || !dropped {
+		t.Errorf("expected job to be dropped after 2 attempts, store has %d", len(jobs))
+	}
+}
+
+func TestOutbox_SubmitAfterRestartFindsExistingPost(t *testing.T) {
+	var submits int
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			submits++
+			return nil
+		},
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = *listingThing(t, submitPostThing(t, "dup1", "Hello gophers", "gopher", time.Now()))
+			return nil
+		},
+	}
+	client := newSubmitTestClient(mock)
+	store := &MemoryOutboxStore{}
+	outbox, _ := NewOutbox(client, &OutboxConfig{Store: store})
+
+	ctx := context.Background()
+	id, err := outbox.EnqueueSubmit(ctx, &types.SubmitRequest{Subreddit: "golang", Title: "Hello gophers", Kind: types.SubmitKindSelf})
+	if err != nil {
+		t.Fatalf("EnqueueSubmit: %v", err)
+	}
+
+	// Simulate a previous run whose attempt timed out.
+	jobs, _ := store.List(ctx)
+	job := jobs[0]
+	job.Attempts, job.FirstAttemptAt = 1, time.Now()
+	store.Put(ctx, job)
+
+	var req types.SubmitRequest
+	json.Unmarshal(job.Payload, &req)
+	if req.IdempotencyKey != id {
+		t.Errorf("IdempotencyKey = %q, want job ID %q", req.IdempotencyKey, id)
+	}
+
+	if err := outbox.process(ctx, job); err != nil {
+		t.Fatalf("process: %v", err)
+	}
+	if submits != 0 {
+		t.Errorf("submitted %d times, want 0 since the post exists", submits)
+	}
+	if jobs, _ := store.List(ctx); len(jobs) != 0 {
+		t.Errorf("store still holds %d jobs", len(jobs))
+	}
+}
+
+func TestOutbox_CommentJobs(t *testing.T) {
+	var posted []url.Values
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			body, _ := io.ReadAll(req.Body)
+			form, _ := url.ParseQuery(string(body))
+			posted = append(posted, form)
+			thing, _ := json.Marshal(commentThing(t, "c9", form.Get("text"), false))
+			return json.Unmarshal([]byte(`{"json":{"errors":[],"data":{"things":[`+string(thing)+`]}}}`), v)
+		},
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			if req.URL.Path != "/user/Gopher/comments" {
+				t.Errorf("unexpected request %s", req.URL.Path)
+			}
+			*v = *listingThing(t, commentThing(t, "c1", "Thanks!", false))
+			return nil
+		},
+	}
+	store := &MemoryOutboxStore{}
+	outbox, _ := NewOutbox(newSubmitTestClient(mock), &OutboxConfig{Store: store})
+	ctx := context.Background()
+
+	// process sends the only queued job, optionally as a retry of an ambiguous attempt.
+	process := func(text string, retry bool) {
+		t.Helper()
+		if _, err := outbox.EnqueueComment(ctx, "t3_post1", text); err != nil {
+			t.Fatalf("EnqueueComment: %v", err)
+		}
+		jobs, _ := store.List(ctx)
+		job := jobs[0]
+		if retry {
+			job.Attempts, job.FirstAttemptAt = 1, time.Unix(1700000000, 0)
+		}
+		if err := outbox.process(ctx, job); err != nil {
+			t.Fatalf("process: %v", err)
+		}
+	}
+
+	process("Thanks!", false)
+	if len(posted) != 1 || posted[0].Get("thing_id") != "t3_post1" || posted[0].Get("text") != "Thanks!" {
+		t.Fatalf("posted = %v, want one reply to t3_post1", posted)
+	}
+
+	// A retried reply that already went through is not posted again.
+	process("Thanks!", true)
+	if len(posted) != 1 {
+		t.Errorf("retried reply posted again: %v", posted)
+	}
+	process("Another reply", true)
+	if len(posted) != 2 {
+		t.Errorf("retried reply not found in history was not posted: %v", posted)
+	}
+	if jobs, _ := store.List(ctx); len(jobs) != 0 {
+		t.Errorf("store still holds %d jobs", len(jobs))
+	}
+
+	var validationErr *pkgerrs.ValidationError
+	if _, err := outbox.EnqueueComment(ctx, "t5_2qh1i", "hi"); !errors.As(err, &validationErr) {
+		t.Errorf("EnqueueComment(t5_) error = %v, want ValidationError", err)
+	}
+}
+
+func TestFileOutboxStore_FailedWriteKeepsJobs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewFileOutboxStore(filepath.Join(dir, "outbox.json"))
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+	ctx := context.Background()
+	job := &OutboxJob{ID: "a", Action: OutboxActionSave, Payload: json.RawMessage(`{"fullname":"t3_x"}`)}
+	if err := store.Put(ctx, job); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	// With the directory gone every write fails, and memory must keep matching the file.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(ctx, &OutboxJob{ID: "b", Action: OutboxActionSave}); err == nil {
+		t.Error("Put succeeded without a directory")
+	}
+	if err := store.Delete(ctx, "a"); err == nil {
+		t.Error("Delete succeeded without a directory")
+	}
+	jobs, _ := store.List(ctx)
+	if len(jobs) != 1 || jobs[0].ID != "a" {
+		t.Errorf("jobs after failed writes = %+v, want only a", jobs)
+	}
+}
+
+func TestFileOutboxStore_SurvivesReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.json")
+	ctx := context.Background()
+
+	store, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("NewFileOutboxStore: %v", err)
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, id := range []string{"a", "b"} {
+		job := &OutboxJob{ID: id, Action: OutboxActionSave, Payload: json.RawMessage(`{"fullname":"t3_x"}`), EnqueuedAt: now, NextAttemptAt: now}
+		if err := store.Put(ctx, job); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	if err := store.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	reopened, err := NewFileOutboxStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	jobs, _ := reopened.List(ctx)
+	if len(jobs) != 1 || jobs[0].ID != "b" || !jobs[0].EnqueuedAt.Equal(now) {
+		t.Errorf("reopened jobs = %+v", jobs)
+	}
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	tests := []struct {
+		message string
+		want    time.Duration
+	}{
+		{"you are doing that too much. try again in 9 minutes.", 9 * time.Minute},
+		{"try again in 30 seconds", 30 * time.Second},
+		{"Take a break for 1 minute before trying again.", time.Minute},
+		{"slow down", defaultRateLimitDelay},
+	}
+	for _, tt := range tests {
+		got, ok := rateLimitDelay(&pkgerrs.APIError{ErrorCode: "RATELIMIT", Message: tt.message})
+		if !ok || got != tt.want {
+			t.Errorf("rateLimitDelay(%q) = %v, %v; want %v", tt.message, got, ok, tt.want)
+		}
+	}
+	if _, ok := rateLimitDelay(errors.New("other")); ok {
+		t.Error("plain errors should not be rate limits")
+	}
+}
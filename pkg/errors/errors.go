@@ -4,6 +4,9 @@ package errors
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
 )
 
 // joinParts joins error message parts with the specified separator.
@@ -91,6 +94,51 @@ func (e *StateError) Error() string {
 	return fmt.Sprintf("state error: %s", e.Message)
 }
 
+// PanicError reports a panic that was recovered in a background worker, so that one bad
+// response cannot crash the host process.
+type PanicError struct {
+	// Operation is the name of the operation the worker was performing
+	Operation string
+	// Value is the value passed to panic
+	Value any
+	// Stack is the goroutine stack at the time of the panic
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic during %s: %v", e.Operation, e.Value)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// PartialResultError reports that a multi-request operation stopped part way through. The
+// results fetched before the failure are returned alongside it.
+type PartialResultError struct {
+	// Operation is the name of the operation that was interrupted
+	Operation string
+	// Completed is the number of requests that succeeded
+	Completed int
+	// Total is the number of requests the operation needed
+	Total int
+	// Err is the cause, such as context.DeadlineExceeded
+	Err error
+}
+
+func (e *PartialResultError) Error() string {
+	return fmt.Sprintf("%s stopped after %d of %d requests: %v", e.Operation, e.Completed, e.Total, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PartialResultError) Unwrap() error {
+	return e.Err
+}
+
 // RequestError indicates a problem with making an API request.
 type RequestError struct {
 	// Operation is the name of the API operation that failed
@@ -101,6 +149,8 @@ type RequestError struct {
 	Message string
 	// Err contains the underlying error if available
 	Err error
+	// Timing breaks down the time of the last attempt, if it reached the network
+	Timing *types.RequestTiming
 }
 
 func (e *RequestError) Error() string {
@@ -159,16 +209,411 @@ type APIError struct {
 	Message string
 	// Details contains any additional error details from the API
 	Details interface{}
+	// Reason is the machine-readable reason Reddit gives for some failures,
+	// such as "private", "quarantined", "gated", or "banned" for subreddits
+	Reason string
+	// RetryAfter is the wait Reddit requested with a Retry-After header, or zero
+	RetryAfter time.Duration
+	// Field is the request field Reddit blamed, from the third element of a
+	// [code, message, field] error triple
+	Field string
+	// Sub holds every error of a response that reported several, such as the errors
+	// array of api/morechildren. The APIError's own code and message are the first's.
+	Sub []*APIError
+	// Timing breaks down the time the request took, for errors built from an HTTP status
+	Timing *types.RequestTiming
+}
+
+// NewAPIErrorFromList builds an APIError from the [code, message, field] triples Reddit
+// returns in the "errors" array of json API responses. Each triple becomes an entry of Sub,
+// and the first also sets the returned error's ErrorCode, Message, and Field.
+func NewAPIErrorFromList(statusCode int, errs [][]string) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Message: "request rejected", Details: errs}
+	for _, triple := range errs {
+		sub := &APIError{StatusCode: statusCode}
+		if len(triple) > 0 {
+			sub.ErrorCode = triple[0]
+		}
+		if len(triple) > 1 {
+			sub.Message = triple[1]
+		}
+		if len(triple) > 2 {
+			sub.Field = triple[2]
+		}
+		apiErr.Sub = append(apiErr.Sub, sub)
+	}
+	if len(apiErr.Sub) > 0 {
+		first := apiErr.Sub[0]
+		apiErr.ErrorCode, apiErr.Field = first.ErrorCode, first.Field
+		if first.Message != "" {
+			apiErr.Message = first.Message
+		}
+	}
+	return apiErr
+}
+
+// HasErrorCode reports whether e or any of its Sub errors has the given Reddit error code,
+// such as ReasonThreadLocked.
+func (e *APIError) HasErrorCode(code string) bool {
+	if e.ErrorCode == code {
+		return true
+	}
+	for _, sub := range e.Sub {
+		if sub != nil && sub.HasErrorCode(code) {
+			return true
+		}
+	}
+	return false
 }
 
 func (e *APIError) Error() string {
 	if e.ErrorCode != "" {
-		return fmt.Sprintf("reddit API error (status %d, code %s): %s", e.StatusCode, e.ErrorCode, e.Message)
+		msg := fmt.Sprintf("reddit API error (status %d, code %s): %s", e.StatusCode, e.ErrorCode, e.Message)
+		if len(e.Sub) > 1 {
+			msg += fmt.Sprintf(" (and %d more errors)", len(e.Sub)-1)
+		}
+		return msg
 	}
 	// Use the legacy format for backward compatibility
 	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Message)
 }
 
+// Reasons Reddit reports in error bodies for inaccessible subreddits.
+const (
+	ReasonPrivate     = "private"
+	ReasonQuarantined = "quarantined"
+	ReasonGated       = "gated"
+	ReasonBanned      = "banned"
+
+	// ReasonHeavyLoad marks Reddit's "under heavy load" error pages.
+	ReasonHeavyLoad = "heavy_load"
+	// ReasonReadOnly marks responses sent while Reddit is in read-only maintenance mode.
+	ReasonReadOnly = "read_only"
+
+	// ReasonInsufficientCoins and ReasonInsufficientCoinsWithAward are sent when an
+	// account cannot afford the award it tried to give.
+	ReasonInsufficientCoins          = "INSUFFICIENT_COINS"
+	ReasonInsufficientCoinsWithAward = "INSUFFICIENT_COINS_WITH_AWARD"
+
+	// ReasonThreadLocked and ReasonDeletedComment are error codes Reddit reports when
+	// loading or replying to comments of a locked or archived thread, or of a deleted comment.
+	ReasonThreadLocked   = "THREAD_LOCKED"
+	ReasonDeletedComment = "DELETED_COMMENT"
+
+	// ReasonFlairRequired is sent when a post is submitted without flair to a subreddit
+	// that requires it.
+	ReasonFlairRequired = "SUBMIT_VALIDATION_FLAIR_REQUIRED"
+)
+
+// Suggested waits before retrying when Reddit is degraded and does not send Retry-After.
+const (
+	DefaultDegradedRetryAfter = 30 * time.Second
+	DefaultReadOnlyRetryAfter = 5 * time.Minute
+)
+
+// ResourceContext identifies the Reddit resource a failed request targeted.
+type ResourceContext struct {
+	// Operation is the name of the API operation that failed
+	Operation string
+	// URL is the path or URL that was being accessed
+	URL string
+	// Subreddit is the subreddit the request targeted, if any
+	Subreddit string
+	// PostID is the post the request targeted, if any
+	PostID string
+}
+
+// describe renders the resource context for use in error messages.
+func (c ResourceContext) describe() string {
+	var parts []string
+	if c.Operation != "" {
+		parts = append(parts, "during "+c.Operation)
+	}
+	if c.Subreddit != "" {
+		parts = append(parts, "r/"+c.Subreddit)
+	}
+	if c.PostID != "" {
+		parts = append(parts, "post "+c.PostID)
+	}
+	if len(parts) == 0 && c.URL != "" {
+		parts = append(parts, c.URL)
+	}
+	return joinParts(parts, " ")
+}
+
+// formatStatusError builds the message shared by the typed HTTP status errors.
+func formatStatusError(kind string, ctx ResourceContext, apiErr *APIError) string {
+	msg := kind
+	if d := ctx.describe(); d != "" {
+		msg += " " + d
+	}
+	if apiErr != nil && apiErr.Reason != "" {
+		msg += fmt.Sprintf(" (reason: %s)", apiErr.Reason)
+	}
+	if apiErr != nil {
+		msg += ": " + apiErr.Error()
+	}
+	return msg
+}
+
+// ForbiddenError indicates Reddit refused access (HTTP 403), typically because the
+// subreddit is private or quarantined, or the account lacks permission.
+type ForbiddenError struct {
+	ResourceContext
+	// Err is the underlying API error
+	Err *APIError
+}
+
+func (e *ForbiddenError) Error() string {
+	return formatStatusError("forbidden", e.ResourceContext, e.Err)
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// IsPrivate reports whether Reddit said the subreddit is private.
+func (e *ForbiddenError) IsPrivate() bool {
+	return e.Err != nil && e.Err.Reason == ReasonPrivate
+}
+
+// IsQuarantined reports whether Reddit said the subreddit is quarantined.
+func (e *ForbiddenError) IsQuarantined() bool {
+	return e.Err != nil && e.Err.Reason == ReasonQuarantined
+}
+
+// IsGated reports whether Reddit said the subreddit is gated, i.e. requires opting in to
+// view content such as age-restricted material.
+func (e *ForbiddenError) IsGated() bool {
+	return e.Err != nil && e.Err.Reason == ReasonGated
+}
+
+// NotFoundError indicates the requested resource does not exist (HTTP 404).
+// Reddit also uses 404 for banned subreddits; see IsBanned.
+type NotFoundError struct {
+	ResourceContext
+	// Err is the underlying API error
+	Err *APIError
+}
+
+func (e *NotFoundError) Error() string {
+	return formatStatusError("not found", e.ResourceContext, e.Err)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// IsBanned reports whether Reddit said the subreddit has been banned.
+func (e *NotFoundError) IsBanned() bool {
+	return e.Err != nil && e.Err.Reason == ReasonBanned
+}
+
+// LegallyRestrictedError indicates the content is unavailable for legal reasons (HTTP 451),
+// usually because it is blocked in the requester's jurisdiction.
+type LegallyRestrictedError struct {
+	ResourceContext
+	// Err is the underlying API error
+	Err *APIError
+}
+
+func (e *LegallyRestrictedError) Error() string {
+	return formatStatusError("legally restricted", e.ResourceContext, e.Err)
+}
+
+func (e *LegallyRestrictedError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// ServiceUnavailableError indicates Reddit is temporarily unable to serve the request (HTTP 503).
+type ServiceUnavailableError struct {
+	ResourceContext
+	// Err is the underlying API error
+	Err *APIError
+}
+
+func (e *ServiceUnavailableError) Error() string {
+	return formatStatusError("service unavailable", e.ResourceContext, e.Err)
+}
+
+func (e *ServiceUnavailableError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// ServiceDegradedError indicates Reddit is overloaded or in read-only maintenance mode:
+// an HTTP 503, an "under heavy load" error page, or a READ_ONLY_MODE error. Such failures
+// usually clear up on their own, so callers should wait RetryAfter and try again rather
+// than treat them as permanent.
+//
+// For 503 responses, errors.As also matches *ServiceUnavailableError.
+type ServiceDegradedError struct {
+	ResourceContext
+	// Err is the underlying API error
+	Err *APIError
+	// RetryAfter is how long to wait before retrying: Reddit's Retry-After if it sent one,
+	// otherwise DefaultDegradedRetryAfter or, in read-only mode, DefaultReadOnlyRetryAfter.
+	RetryAfter time.Duration
+}
+
+func (e *ServiceDegradedError) Error() string {
+	label := "service degraded"
+	switch {
+	case e.IsReadOnly():
+		label = "reddit read-only mode"
+	case e.Err != nil && e.Err.StatusCode == 503:
+		label = "service unavailable"
+	}
+	return formatStatusError(label, e.ResourceContext, e.Err) + fmt.Sprintf(" (retry after %s)", e.RetryAfter)
+}
+
+func (e *ServiceDegradedError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// As lets errors.As match a 503 as *ServiceUnavailableError, the type used for 503s
+// before ServiceDegradedError was introduced.
+func (e *ServiceDegradedError) As(target any) bool {
+	if t, ok := target.(**ServiceUnavailableError); ok && e.Err != nil && e.Err.StatusCode == 503 {
+		*t = &ServiceUnavailableError{ResourceContext: e.ResourceContext, Err: e.Err}
+		return true
+	}
+	return false
+}
+
+// IsReadOnly reports whether Reddit is in read-only mode, in which reads may still work
+// but writes fail.
+func (e *ServiceDegradedError) IsReadOnly() bool {
+	return e.Err != nil && e.Err.isReadOnly()
+}
+
+// isReadOnly reports whether the error was sent in read-only mode, either as a reason
+// detected in an error page or as Reddit's READ_ONLY_MODE error code.
+func (e *APIError) isReadOnly() bool {
+	return e.Reason == ReasonReadOnly || e.ErrorCode == "READ_ONLY_MODE"
+}
+
+// InsufficientCoinsError indicates an award could not be given because the account does
+// not have enough coins for it.
+type InsufficientCoinsError struct {
+	ResourceContext
+	// Err is the underlying API error
+	Err *APIError
+}
+
+func (e *InsufficientCoinsError) Error() string {
+	return formatStatusError("insufficient coins", e.ResourceContext, e.Err)
+}
+
+func (e *InsufficientCoinsError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// FlairRequiredError indicates a post was rejected, or not sent, because the subreddit
+// requires link flair and none was chosen.
+type FlairRequiredError struct {
+	ResourceContext
+	// Templates lists the subreddit's link flair templates to choose from. It is set when
+	// the requirement was found before submitting (see SubmitRequest.CheckFlair); use
+	// GetLinkFlairTemplates for the templates' other details.
+	Templates []FlairChoice
+	// Err is the underlying API error, or nil if the post was stopped before it was sent
+	Err *APIError
+}
+
+// FlairChoice is a link flair template offered by a FlairRequiredError.
+type FlairChoice struct {
+	// ID is passed as SubmitRequest.FlairID to use the template
+	ID string
+	// Text is the flair's text, which SubmitRequest.FlairText can match
+	Text string
+}
+
+func (e *FlairRequiredError) Error() string {
+	msg := formatStatusError("post flair required", e.ResourceContext, e.Err)
+	if len(e.Templates) > 0 {
+		msg += fmt.Sprintf(" (%d flair templates available)", len(e.Templates))
+	}
+	return msg
+}
+
+func (e *FlairRequiredError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
+// isInsufficientCoins reports whether Reddit refused an award because the account lacks coins.
+func (e *APIError) isInsufficientCoins() bool {
+	for _, code := range []string{e.Reason, e.ErrorCode} {
+		if code == ReasonInsufficientCoins || code == ReasonInsufficientCoinsWithAward {
+			return true
+		}
+	}
+	return false
+}
+
+// newServiceDegradedError wraps apiErr, choosing the suggested retry interval.
+func newServiceDegradedError(apiErr *APIError, ctx ResourceContext) *ServiceDegradedError {
+	retryAfter := apiErr.RetryAfter
+	if retryAfter <= 0 {
+		retryAfter = DefaultDegradedRetryAfter
+		if apiErr.isReadOnly() {
+			retryAfter = DefaultReadOnlyRetryAfter
+		}
+	}
+	return &ServiceDegradedError{ResourceContext: ctx, Err: apiErr, RetryAfter: retryAfter}
+}
+
+// ClassifyAPIError converts an APIError into the typed error matching its HTTP status,
+// attaching the given resource context. Statuses without a dedicated type return apiErr unchanged.
+// The typed errors unwrap to apiErr, so errors.As(err, &apiErr) continues to work and
+// reaches the request's Timing.
+func ClassifyAPIError(apiErr *APIError, ctx ResourceContext) error {
+	if apiErr == nil {
+		return nil
+	}
+	if apiErr.Reason == ReasonHeavyLoad || apiErr.isReadOnly() {
+		return newServiceDegradedError(apiErr, ctx)
+	}
+	if apiErr.isInsufficientCoins() {
+		return &InsufficientCoinsError{ResourceContext: ctx, Err: apiErr}
+	}
+	if apiErr.ErrorCode == ReasonFlairRequired || apiErr.Reason == ReasonFlairRequired {
+		return &FlairRequiredError{ResourceContext: ctx, Err: apiErr}
+	}
+	switch apiErr.StatusCode {
+	case 403:
+		return &ForbiddenError{ResourceContext: ctx, Err: apiErr}
+	case 404:
+		return &NotFoundError{ResourceContext: ctx, Err: apiErr}
+	case 451:
+		return &LegallyRestrictedError{ResourceContext: ctx, Err: apiErr}
+	case 503:
+		return newServiceDegradedError(apiErr, ctx)
+	default:
+		return apiErr
+	}
+}
+
 // ClientError indicates a problem with the HTTP client operations.
 type ClientError struct {
 	// Operation describes what the client was trying to do
@@ -177,6 +622,8 @@ type ClientError struct {
 	Message string
 	// Err contains the underlying error if available
 	Err error
+	// Timing breaks down the time the request took, if it reached the network
+	Timing *types.RequestTiming
 }
 
 func (e *ClientError) Error() string {
@@ -202,3 +649,110 @@ func (e *ClientError) Error() string {
 func (e *ClientError) Unwrap() error {
 	return e.Err
 }
+
+// TransportErrorKind classifies a TransportError by the stage of the connection that failed.
+type TransportErrorKind string
+
+const (
+	// TransportDNS means the host name could not be resolved.
+	TransportDNS TransportErrorKind = "dns"
+	// TransportConnect means the connection was refused or the host was unreachable.
+	TransportConnect TransportErrorKind = "connect"
+	// TransportTLS means the TLS handshake failed.
+	TransportTLS TransportErrorKind = "tls"
+	// TransportReset means an established connection was reset or closed mid-request.
+	TransportReset TransportErrorKind = "reset"
+	// TransportTimeout means the connection or response timed out.
+	TransportTimeout TransportErrorKind = "timeout"
+	// TransportOther is any other failure below HTTP.
+	TransportOther TransportErrorKind = "other"
+)
+
+// TransportError indicates a request failed below HTTP, before Reddit sent a complete
+// response: a DNS lookup, connection, TLS handshake, or dropped connection. It is distinct
+// from an HTTP error status, which Reddit reports with an *APIError.
+type TransportError struct {
+	// Kind is the stage of the connection that failed
+	Kind TransportErrorKind
+	// Permanent is set for failures that repeat until something changes, such as an
+	// unknown host or an untrusted certificate
+	Permanent bool
+	// Err contains the underlying network error
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("%s transport error: %v", e.Kind, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Retryable reports whether sending the request again may succeed. Resets, timeouts,
+// refused connections, and temporary DNS failures are retryable; an unknown host or a
+// certificate that fails verification is not.
+func (e *TransportError) Retryable() bool {
+	return !e.Permanent
+}
+
+// FieldError describes a single invalid field found during request validation.
+type FieldError struct {
+	// Field is the name of the request field that failed validation
+	Field string
+	// Message explains why the field is invalid
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError aggregates every field problem found while validating a request
+// before it is sent, so callers can report all of them at once.
+type ValidationError struct {
+	// Operation is the name of the request being validated (e.g. "submit")
+	Operation string
+	// Fields contains one entry per invalid field, in validation order
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		parts = append(parts, f.Error())
+	}
+
+	prefix := "validation error"
+	if e.Operation != "" {
+		prefix = fmt.Sprintf("validation error during %s", e.Operation)
+	}
+	if len(parts) == 0 {
+		return prefix
+	}
+	return prefix + ": " + joinParts(parts, "; ")
+}
+
+// Add records a field problem.
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// HasField reports whether the named field failed validation.
+func (e *ValidationError) HasField(field string) bool {
+	for _, f := range e.Fields {
+		if f.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrOrNil returns e if any field problems were recorded, otherwise nil.
+// It always returns an untyped nil so the result can be compared to nil safely.
+func (e *ValidationError) ErrOrNil() error {
+	if e == nil || len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
@@ -1,9 +1,11 @@
 package errors
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestConfigError_Error(t *testing.T) {
@@ -157,6 +159,30 @@ func TestStateError_Error(t *testing.T) {
 	}
 }
 
+func TestPanicError(t *testing.T) {
+	cause := errors.New("nil map write")
+	err := &PanicError{Operation: "get comments", Value: cause}
+	if got, want := err.Error(), "panic during get comments: nil map write"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, cause) {
+		t.Error("expected PanicError to unwrap an error value")
+	}
+	if (&PanicError{Value: "boom"}).Unwrap() != nil {
+		t.Error("expected nil Unwrap for a non-error value")
+	}
+}
+
+func TestPartialResultError(t *testing.T) {
+	err := &PartialResultError{Operation: "get more comments", Completed: 2, Total: 5, Err: context.DeadlineExceeded}
+	if got, want := err.Error(), "get more comments stopped after 2 of 5 requests: context deadline exceeded"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected PartialResultError to unwrap its cause")
+	}
+}
+
 func TestRequestError_Error(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -327,6 +353,29 @@ func TestAPIError_Error(t *testing.T) {
 	}
 }
 
+func TestNewAPIErrorFromList(t *testing.T) {
+	err := NewAPIErrorFromList(200, [][]string{
+		{ReasonThreadLocked, "that thread is locked", "parent"},
+		{ReasonDeletedComment, "that comment has been deleted"},
+	})
+	if err.ErrorCode != ReasonThreadLocked || err.Message != "that thread is locked" || err.Field != "parent" {
+		t.Errorf("top-level error = %+v, want the first triple", err)
+	}
+	if len(err.Sub) != 2 || err.Sub[1].ErrorCode != ReasonDeletedComment || err.Sub[1].Field != "" {
+		t.Fatalf("Sub = %+v, want both triples", err.Sub)
+	}
+	if !err.HasErrorCode(ReasonDeletedComment) || err.HasErrorCode("RATELIMIT") {
+		t.Error("HasErrorCode does not match the Sub errors")
+	}
+	if !strings.Contains(err.Error(), "and 1 more errors") {
+		t.Errorf("Error() = %q, want the extra error counted", err.Error())
+	}
+
+	if empty := NewAPIErrorFromList(200, [][]string{{}}); empty.ErrorCode != "" || empty.Message != "request rejected" {
+		t.Errorf("empty triple = %+v, want a generic rejection", empty)
+	}
+}
+
 func TestClientError_Error(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -508,3 +557,174 @@ func TestErrorTypeAssertion(t *testing.T) {
 		}
 	})
 }
+
+func TestValidationError(t *testing.T) {
+	t.Run("no fields", func(t *testing.T) {
+		e := &ValidationError{Operation: "submit"}
+		if err := e.ErrOrNil(); err != nil {
+			t.Errorf("ErrOrNil() = %v, want nil", err)
+		}
+		if got := e.Error(); got != "validation error during submit" {
+			t.Errorf("Error() = %q", got)
+		}
+	})
+
+	t.Run("multiple fields", func(t *testing.T) {
+		e := &ValidationError{Operation: "submit"}
+		e.Add("Title", "title is required")
+		e.Add("URL", "url must use http or https")
+
+		err := e.ErrOrNil()
+		if err == nil {
+			t.Fatal("ErrOrNil() = nil, want error")
+		}
+
+		var ve *ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("errors.As failed for %T", err)
+		}
+		if !ve.HasField("Title") || !ve.HasField("URL") {
+			t.Errorf("HasField missing expected fields: %+v", ve.Fields)
+		}
+		if ve.HasField("Text") {
+			t.Error("HasField(Text) = true, want false")
+		}
+
+		msg := err.Error()
+		for _, want := range []string{"validation error during submit", "Title: title is required", "URL: url must use http or https"} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("Error() = %q, want to contain %q", msg, want)
+			}
+		}
+	})
+
+	t.Run("nil receiver", func(t *testing.T) {
+		var e *ValidationError
+		if err := e.ErrOrNil(); err != nil {
+			t.Errorf("ErrOrNil() on nil = %v, want nil", err)
+		}
+	})
+}
+
+func TestClassifyAPIError(t *testing.T) {
+	ctx := ResourceContext{Operation: "get comments", URL: "r/golang/comments/abc123", Subreddit: "golang", PostID: "abc123"}
+
+	tests := []struct {
+		name     string
+		apiErr   *APIError
+		check    func(error) bool
+		wantText string
+	}{
+		{
+			name:   "403 forbidden",
+			apiErr: &APIError{StatusCode: 403, Message: "request failed", Reason: ReasonPrivate},
+			check: func(err error) bool {
+				var e *ForbiddenError
+				return errors.As(err, &e) && e.IsPrivate() && !e.IsQuarantined() && e.Subreddit == "golang"
+			},
+			wantText: "forbidden during get comments r/golang post abc123 (reason: private)",
+		},
+		{
+			name:   "404 not found",
+			apiErr: &APIError{StatusCode: 404, Message: "request failed", Reason: ReasonBanned},
+			check: func(err error) bool {
+				var e *NotFoundError
+				return errors.As(err, &e) && e.IsBanned() && e.PostID == "abc123"
+			},
+			wantText: "not found during get comments",
+		},
+		{
+			name:   "451 legally restricted",
+			apiErr: &APIError{StatusCode: 451, Message: "request failed"},
+			check: func(err error) bool {
+				var e *LegallyRestrictedError
+				return errors.As(err, &e)
+			},
+			wantText: "legally restricted",
+		},
+		{
+			name:   "503 service unavailable",
+			apiErr: &APIError{StatusCode: 503, Message: "request failed"},
+			check: func(err error) bool {
+				var e *ServiceUnavailableError
+				return errors.As(err, &e)
+			},
+			wantText: "service unavailable",
+		},
+		{
+			name:   "503 is degraded with default retry",
+			apiErr: &APIError{StatusCode: 503, Message: "request failed"},
+			check: func(err error) bool {
+				var e *ServiceDegradedError
+				return errors.As(err, &e) && !e.IsReadOnly() && e.RetryAfter == DefaultDegradedRetryAfter
+			},
+			wantText: "(retry after 30s)",
+		},
+		{
+			name:   "heavy load page",
+			apiErr: &APIError{StatusCode: 502, Message: "request failed", Reason: ReasonHeavyLoad, RetryAfter: 10 * time.Second},
+			check: func(err error) bool {
+				var e *ServiceDegradedError
+				var unavailable *ServiceUnavailableError
+				return errors.As(err, &e) && e.RetryAfter == 10*time.Second && !errors.As(err, &unavailable)
+			},
+			wantText: "service degraded",
+		},
+		{
+			name:   "read-only mode",
+			apiErr: &APIError{StatusCode: 200, Message: "API error", ErrorCode: "READ_ONLY_MODE"},
+			check: func(err error) bool {
+				var e *ServiceDegradedError
+				return errors.As(err, &e) && e.IsReadOnly() && e.RetryAfter == DefaultReadOnlyRetryAfter
+			},
+			wantText: "reddit read-only mode",
+		},
+		{
+			name:   "insufficient coins",
+			apiErr: &APIError{StatusCode: 400, Message: "request failed", Reason: ReasonInsufficientCoinsWithAward},
+			check: func(err error) bool {
+				var e *InsufficientCoinsError
+				return errors.As(err, &e)
+			},
+			wantText: "insufficient coins during get comments",
+		},
+		{
+			name:   "flair required",
+			apiErr: &APIError{StatusCode: 200, Message: "Your post must contain post flair.", ErrorCode: ReasonFlairRequired},
+			check: func(err error) bool {
+				var e *FlairRequiredError
+				return errors.As(err, &e) && e.Templates == nil
+			},
+			wantText: "post flair required during get comments",
+		},
+		{
+			name:   "other status unchanged",
+			apiErr: &APIError{StatusCode: 500, Message: "request failed"},
+			check: func(err error) bool {
+				_, ok := err.(*APIError)
+				return ok
+			},
+			wantText: "status 500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ClassifyAPIError(tt.apiErr, ctx)
+			if !tt.check(err) {
+				t.Errorf("ClassifyAPIError() = %T, unexpected type or fields", err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr != tt.apiErr {
+				t.Errorf("expected classified error to unwrap to the original APIError")
+			}
+			if !strings.Contains(err.Error(), tt.wantText) {
+				t.Errorf("Error() = %q, want it to contain %q", err.Error(), tt.wantText)
+			}
+		})
+	}
+
+	if ClassifyAPIError(nil, ctx) != nil {
+		t.Error("ClassifyAPIError(nil) should return nil")
+	}
+}
@@ -0,0 +1,182 @@
+// Package parse turns raw Reddit JSON into the typed values of package types, using the
+// same parsing and validation the client applies to live API responses. It is meant for
+// Reddit data from other sources, such as archived API responses or Pushshift dumps.
+//
+// Reddit wraps every object in an envelope naming its kind, e.g.
+// {"kind": "t3", "data": {...}}. Use Unmarshal to decode an envelope, or Wrap when the
+// source stores bare objects, then pass the Thing to a Parser:
+//
+//	thing, err := parse.Unmarshal(line)
+//	if err != nil {
+//	    return err
+//	}
+//	v, err := parse.New(nil).ParseThing(ctx, thing)
+package parse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// Kinds of Reddit objects, as found in an envelope's "kind" field.
+const (
+	KindComment   = "t1"
+	KindAccount   = "t2"
+	KindPost      = "t3"
+	KindMessage   = "t4"
+	KindSubreddit = "t5"
+	KindMore      = "more"
+	KindListing   = "Listing"
+)
+
+// MaxCommentDepth is the deepest comment nesting a Parser accepts; deeper trees are
+// rejected to protect against stack exhaustion.
+const MaxCommentDepth = internal.MaxCommentDepth
+
+// Options configures a Parser.
+type Options struct {
+	// MaxDepth limits how many levels of a comment tree are parsed: 1 parses only top-level
+	// comments, 2 adds their direct replies, and so on. Replies below the limit are recorded
+	// in their parent's MoreChildrenIDs and the parent is marked Truncated. Optional. Zero
+	// parses up to MaxCommentDepth.
+	MaxDepth int
+	// Logger receives warnings about objects that fail to parse or validate. Optional.
+	Logger *slog.Logger
+	// LenientCursors accepts Listing after and before cursors that are not fullnames, with a
+	// warning, instead of rejecting the Listing. Optional.
+	LenientCursors bool
+}
+
+// Parser parses Reddit objects. It is safe for concurrent use.
+type Parser struct {
+	parser *internal.Parser
+}
+
+// New returns a Parser configured by opts, which may be nil.
+func New(opts *Options) *Parser {
+	if opts == nil {
+		opts = &Options{}
+	}
+	p := internal.NewParser(opts.Logger)
+	p.SetMaxDepth(opts.MaxDepth)
+	if opts.LenientCursors {
+		p.SetCursorPolicy(internal.CursorLenient)
+	}
+	return &Parser{parser: p}
+}
+
+// ParseThing parses thing according to its kind and returns a *types.Post, *types.Comment,
+// *types.AccountData, *types.MessageData, *types.SubredditData, *types.MoreData, or
+// *types.ListingData.
+//
+// Returns a *errors.ParseError if thing is nil, of an unknown kind, or fails validation.
+func (p *Parser) ParseThing(ctx context.Context, thing *types.Thing) (any, error) {
+	v, err := p.parser.ParseThing(ctx, thing)
+	if err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "parse thing", Err: err}
+	}
+	return v, nil
+}
+
+// ParsePost parses a Thing of kind t3.
+//
+// Returns a *errors.ParseError if thing is not a valid post.
+func (p *Parser) ParsePost(ctx context.Context, thing *types.Thing) (*types.Post, error) {
+	post, err := p.parser.ParsePost(ctx, thing)
+	if err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "parse post", Err: err}
+	}
+	return post, nil
+}
+
+// ParseComment parses a Thing of kind t1, including its tree of replies.
+//
+// Returns a *errors.ParseError if thing is not a valid comment.
+func (p *Parser) ParseComment(ctx context.Context, thing *types.Thing) (*types.Comment, error) {
+	if thing != nil && thing.Kind != KindComment {
+		return nil, &pkgerrs.ParseError{Operation: "parse comment", Message: fmt.Sprintf("expected t1 (Comment), got %s", thing.Kind)}
+	}
+	v, err := p.parser.ParseThing(ctx, thing)
+	if err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "parse comment", Err: err}
+	}
+	return v.(*types.Comment), nil
+}
+
+// ExtractPosts returns the posts in a Listing. Children that are not posts are ignored, and
+// posts that fail to parse or validate are skipped.
+//
+// Returns a *errors.ParseError if thing is not a valid Listing.
+func (p *Parser) ExtractPosts(ctx context.Context, thing *types.Thing) ([]*types.Post, error) {
+	posts, err := p.parser.ExtractPosts(ctx, thing)
+	if err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "extract posts", Err: err}
+	}
+	return posts, nil
+}
+
+// ExtractComments returns the comment trees in a Listing or single t1 Thing, together with
+// the IDs of comments Reddit did not include, from "more" objects at any depth.
+//
+// Returns a *errors.ParseError if thing is neither a Listing nor a comment.
+func (p *Parser) ExtractComments(ctx context.Context, thing *types.Thing) ([]*types.Comment, []string, error) {
+	if thing == nil {
+		return nil, nil, &pkgerrs.ParseError{Operation: "extract comments", Message: "thing is nil"}
+	}
+	comments, moreIDs, err := p.parser.ExtractComments(ctx, thing)
+	if err != nil {
+		return nil, nil, &pkgerrs.ParseError{Operation: "extract comments", Err: err}
+	}
+	return comments, moreIDs, nil
+}
+
+// ExtractPostAndComments parses a saved comments page: the two-element array Reddit returns
+// for a post's permalink, holding a Listing with the post and a Listing with its comments.
+//
+// If only one of the two listings parses, that half is returned with Partial set, together
+// with a *errors.PartialResultError. Returns a *errors.ParseError if response holds neither
+// a post nor comments.
+func (p *Parser) ExtractPostAndComments(ctx context.Context, response []*types.Thing) (*types.CommentsResponse, error) {
+	resp, err := p.parser.ExtractPostAndComments(ctx, response)
+	if err != nil && (resp == nil || !resp.Partial) {
+		return nil, &pkgerrs.ParseError{Operation: "extract post and comments", Err: err}
+	}
+	return resp, err
+}
+
+// Unmarshal decodes a Reddit object envelope, {"kind": ..., "data": ...}.
+//
+// Returns a *errors.ParseError if data is not valid JSON or has no kind.
+func Unmarshal(data []byte) (*types.Thing, error) {
+	var thing types.Thing
+	if err := json.Unmarshal(data, &thing); err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "unmarshal thing", Err: err}
+	}
+	if thing.Kind == "" {
+		return nil, &pkgerrs.ParseError{Operation: "unmarshal thing", Message: "object has no kind; use Wrap for bare objects"}
+	}
+	return &thing, nil
+}
+
+// UnmarshalArray decodes a JSON array of envelopes, such as a saved comments page.
+//
+// Returns a *errors.ParseError if data is not a valid array of objects.
+func UnmarshalArray(data []byte) ([]*types.Thing, error) {
+	var things []*types.Thing
+	if err := json.Unmarshal(data, &things); err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "unmarshal things", Err: err}
+	}
+	return things, nil
+}
+
+// Wrap puts a bare object, as stored by archives that drop the envelope, into a Thing of the
+// given kind.
+func Wrap(kind string, data json.RawMessage) *types.Thing {
+	return &types.Thing{Kind: kind, Data: data}
+}
@@ -0,0 +1,93 @@
+package parse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestExtractPostAndComments(t *testing.T) {
+	things, err := UnmarshalArray(grawtest.NewCommentTree(3, 2))
+	if err != nil {
+		t.Fatalf("UnmarshalArray returned error: %v", err)
+	}
+	resp, err := New(nil).ExtractPostAndComments(context.Background(), things)
+	if err != nil {
+		t.Fatalf("ExtractPostAndComments returned error: %v", err)
+	}
+	if resp.Post == nil || resp.Post.ID != grawtest.FixturePostID {
+		t.Errorf("Post = %+v, want %s", resp.Post, grawtest.FixturePostID)
+	}
+	if len(resp.Comments) != 2 || len(resp.Comments[0].Replies) != 2 {
+		t.Fatalf("got %d top-level comments, want a tree with 2 branches", len(resp.Comments))
+	}
+
+	shallow, err := New(&Options{MaxDepth: 1}).ExtractPostAndComments(context.Background(), things)
+	if err != nil {
+		t.Fatalf("ExtractPostAndComments with MaxDepth returned error: %v", err)
+	}
+	if c := shallow.Comments[0]; len(c.Replies) != 0 || !c.Truncated || len(c.MoreChildrenIDs) != 2 {
+		t.Errorf("MaxDepth 1 comment = %d replies, truncated %v, more %v", len(c.Replies), c.Truncated, c.MoreChildrenIDs)
+	}
+}
+
+func TestParseThing(t *testing.T) {
+	ctx := context.Background()
+	parser := New(nil)
+
+	listing, err := Unmarshal(grawtest.NewListing(3))
+	if err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	posts, err := parser.ExtractPosts(ctx, listing)
+	if err != nil || len(posts) != 3 {
+		t.Fatalf("ExtractPosts = %d posts, %v; want 3", len(posts), err)
+	}
+
+	// A bare post, as an archive stores it, parses once wrapped.
+	data, err := json.Marshal(posts[0])
+	if err != nil {
+		t.Fatalf("marshal post: %v", err)
+	}
+	v, err := parser.ParseThing(ctx, Wrap(KindPost, data))
+	if post, ok := v.(*types.Post); err != nil || !ok || post.ID != posts[0].ID {
+		t.Errorf("ParseThing(wrapped post) = %#v, %v", v, err)
+	}
+
+	if _, err := parser.ParseComment(ctx, Wrap(KindPost, data)); !isParseError(err) {
+		t.Errorf("ParseComment(post) error = %v, want ParseError", err)
+	}
+	if _, err := parser.ParsePost(ctx, Wrap(KindPost, json.RawMessage(`{"id":"x"}`))); !isParseError(err) {
+		t.Errorf("ParsePost(invalid post) error = %v, want ParseError", err)
+	}
+	if _, err := parser.ParseThing(ctx, Wrap("t9", data)); !isParseError(err) {
+		t.Errorf("ParseThing(unknown kind) error = %v, want ParseError", err)
+	}
+	if _, _, err := parser.ExtractComments(ctx, nil); !isParseError(err) {
+		t.Errorf("ExtractComments(nil) error = %v, want ParseError", err)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	for name, data := range map[string]string{
+		"invalid JSON": `{"kind":`,
+		"bare object":  `{"id":"abc","title":"hello"}`,
+	} {
+		if _, err := Unmarshal([]byte(data)); !isParseError(err) {
+			t.Errorf("%s: error = %v, want ParseError", name, err)
+		}
+	}
+	if _, err := UnmarshalArray([]byte(`{}`)); !isParseError(err) {
+		t.Errorf("UnmarshalArray(object) error = %v, want ParseError", err)
+	}
+}
+
+func isParseError(err error) bool {
+	var parseErr *pkgerrs.ParseError
+	return errors.As(err, &parseErr)
+}
@@ -0,0 +1,353 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// RichTextElement is the kind of a RichTextNode, Reddit's "e" field of rich text JSON.
+type RichTextElement string
+
+// Block elements of a RichTextDocument.
+const (
+	RichTextParagraph  RichTextElement = "par"
+	RichTextHeading    RichTextElement = "h"
+	RichTextBlockquote RichTextElement = "blockquote"
+	RichTextList       RichTextElement = "list"
+	RichTextListItem   RichTextElement = "li"
+	RichTextCodeBlock  RichTextElement = "code"
+	RichTextTable      RichTextElement = "table"
+	RichTextRule       RichTextElement = "hr"
+	RichTextImage      RichTextElement = "img"
+	RichTextGIF        RichTextElement = "gif"
+)
+
+// Inline elements of a RichTextDocument.
+const (
+	RichTextText             RichTextElement = "text"
+	RichTextLink             RichTextElement = "link"
+	RichTextUserMention      RichTextElement = "u/"
+	RichTextSubredditMention RichTextElement = "r/"
+	RichTextSpoiler          RichTextElement = "spoilertext"
+	RichTextLineBreak        RichTextElement = "br"
+	// RichTextRaw is a line of a code block.
+	RichTextRaw RichTextElement = "raw"
+)
+
+// RichTextFormat is a bit set of the formats applied to a range of a text node.
+type RichTextFormat int
+
+// Rich text formats. They combine, e.g. RichTextBold|RichTextItalic.
+const (
+	RichTextBold          RichTextFormat = 1
+	RichTextItalic        RichTextFormat = 2
+	RichTextUnderline     RichTextFormat = 4
+	RichTextStrikethrough RichTextFormat = 8
+	RichTextSuperscript   RichTextFormat = 32
+	RichTextInlineCode    RichTextFormat = 64
+)
+
+// RichTextStyle applies Format to Length characters of a node's Text, starting at Start.
+type RichTextStyle struct {
+	Format RichTextFormat
+	Start  int
+	Length int
+}
+
+// RichTextCell is a cell of a RichTextTable node.
+type RichTextCell struct {
+	// Align is the column alignment of a header cell: "L", "C", "R", or empty.
+	Align   string
+	Content []*RichTextNode
+}
+
+// RichTextNode is an element of a RichTextDocument. Which fields are set depends on Element.
+type RichTextNode struct {
+	Element RichTextElement
+
+	// Text is the text of a text, raw, or link node, the name of a mentioned user or
+	// subreddit without its prefix, or the caption of an image.
+	Text string
+	// URL is the target of a link node.
+	URL string
+	// Level is the level of a heading, 1 to 6.
+	Level int
+	// Ordered reports whether a list is numbered.
+	Ordered bool
+	// LeadingSlash reports whether a mention was written with a leading slash, e.g. "/u/spez".
+	LeadingSlash bool
+	// MediaID is the key of an image or GIF in the post's or comment's media metadata.
+	MediaID string
+	// Styles holds the formatting of a text or link node.
+	Styles []RichTextStyle
+	// Children holds the content of a paragraph, heading, blockquote, list, list item,
+	// code block, or spoiler.
+	Children []*RichTextNode
+	// Header and Rows hold the cells of a table.
+	Header []RichTextCell
+	Rows   [][]RichTextCell
+}
+
+// RichTextDocument is a post or comment body parsed from Reddit's rich text JSON ("rtjson").
+// Unlike the markdown body, it exposes links, mentions, and spoilers as typed nodes.
+type RichTextDocument struct {
+	Blocks []*RichTextNode
+}
+
+// rawRichTextNode is the wire shape of a rich text node. C holds the children of most
+// elements, but the rows of a table and the caption of an image.
+type rawRichTextNode struct {
+	E  string            `json:"e"`
+	T  string            `json:"t"`
+	U  string            `json:"u"`
+	L  json.RawMessage   `json:"l"`
+	O  bool              `json:"o"`
+	F  [][]int           `json:"f"`
+	ID string            `json:"id"`
+	A  string            `json:"a"`
+	C  json.RawMessage   `json:"c"`
+	H  []rawRichTextNode `json:"h"`
+}
+
+// ParseRichText parses Reddit's rich text JSON, an object with a "document" array of
+// blocks. Elements the client does not know are kept with their Element and Children.
+func ParseRichText(data []byte) (*RichTextDocument, error) {
+	var raw struct {
+		Document []rawRichTextNode `json:"document"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid rich text: %w", err)
+	}
+	blocks, err := convertRichTextNodes(raw.Document)
+	if err != nil {
+		return nil, err
+	}
+	return &RichTextDocument{Blocks: blocks}, nil
+}
+
+// parseOptionalRichText parses data with ParseRichText, returning nil if data is absent.
+func parseOptionalRichText(data json.RawMessage) (*RichTextDocument, error) {
+	data = trimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	return ParseRichText(data)
+}
+
+func convertRichTextNodes(raw []rawRichTextNode) ([]*RichTextNode, error) {
+	nodes := make([]*RichTextNode, 0, len(raw))
+	for i := range raw {
+		node, err := convertRichTextNode(&raw[i])
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func convertRichTextNode(raw *rawRichTextNode) (*RichTextNode, error) {
+	node := &RichTextNode{
+		Element: RichTextElement(raw.E),
+		Text:    raw.T,
+		URL:     raw.U,
+		Ordered: raw.O,
+		MediaID: raw.ID,
+	}
+	for _, f := range raw.F {
+		// Each format is a [format, start, length] triple.
+		if len(f) == 3 {
+			node.Styles = append(node.Styles, RichTextStyle{Format: RichTextFormat(f[0]), Start: f[1], Length: f[2]})
+		}
+	}
+	// "l" is the level of a heading but the leading slash flag of a mention.
+	if len(raw.L) > 0 {
+		if node.Element == RichTextHeading {
+			_ = json.Unmarshal(raw.L, &node.Level)
+		} else {
+			_ = json.Unmarshal(raw.L, &node.LeadingSlash)
+		}
+	}
+
+	if len(raw.C) == 0 || bytes.Equal(raw.C, []byte("null")) {
+		return node, nil
+	}
+	switch node.Element {
+	case RichTextImage, RichTextGIF:
+		if err := json.Unmarshal(raw.C, &node.Text); err != nil {
+			return nil, fmt.Errorf("invalid rich text %s caption: %w", raw.E, err)
+		}
+	case RichTextTable:
+		var rows [][]rawRichTextNode
+		if err := json.Unmarshal(raw.C, &rows); err != nil {
+			return nil, fmt.Errorf("invalid rich text table: %w", err)
+		}
+		var err error
+		if node.Header, err = convertRichTextCells(raw.H); err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			cells, err := convertRichTextCells(row)
+			if err != nil {
+				return nil, err
+			}
+			node.Rows = append(node.Rows, cells)
+		}
+	default:
+		var children []rawRichTextNode
+		if err := json.Unmarshal(raw.C, &children); err != nil {
+			return nil, fmt.Errorf("invalid rich text %s content: %w", raw.E, err)
+		}
+		var err error
+		if node.Children, err = convertRichTextNodes(children); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func convertRichTextCells(raw []rawRichTextNode) ([]RichTextCell, error) {
+	cells := make([]RichTextCell, 0, len(raw))
+	for _, cell := range raw {
+		var content []rawRichTextNode
+		if len(cell.C) > 0 {
+			if err := json.Unmarshal(cell.C, &content); err != nil {
+				return nil, fmt.Errorf("invalid rich text table cell: %w", err)
+			}
+		}
+		nodes, err := convertRichTextNodes(content)
+		if err != nil {
+			return nil, err
+		}
+		cells = append(cells, RichTextCell{Align: cell.A, Content: nodes})
+	}
+	return cells, nil
+}
+
+// Walk calls fn for each node of the document in reading order, parents before their
+// children and table cells. If fn returns false, the node's children are skipped.
+func (d *RichTextDocument) Walk(fn func(node *RichTextNode) bool) {
+	if d == nil {
+		return
+	}
+	walkRichText(d.Blocks, fn)
+}
+
+func walkRichText(nodes []*RichTextNode, fn func(node *RichTextNode) bool) {
+	for _, node := range nodes {
+		if !fn(node) {
+			continue
+		}
+		walkRichText(node.Children, fn)
+		for _, cell := range node.Header {
+			walkRichText(cell.Content, fn)
+		}
+		for _, row := range node.Rows {
+			for _, cell := range row {
+				walkRichText(cell.Content, fn)
+			}
+		}
+	}
+}
+
+// Nodes returns the document's nodes of the given element in reading order, e.g. all
+// RichTextLink or RichTextUserMention nodes.
+func (d *RichTextDocument) Nodes(element RichTextElement) []*RichTextNode {
+	var nodes []*RichTextNode
+	d.Walk(func(node *RichTextNode) bool {
+		if node.Element == element {
+			nodes = append(nodes, node)
+		}
+		return true
+	})
+	return nodes
+}
+
+// PlainText renders the document as unformatted text: blocks are separated by blank lines,
+// mentions are written as "u/name" and "r/name", and spoilers are included.
+func (d *RichTextDocument) PlainText() string {
+	if d == nil {
+		return ""
+	}
+	var b strings.Builder
+	for i, block := range d.Blocks {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+		writeRichTextBlock(&b, block)
+	}
+	return b.String()
+}
+
+func writeRichTextBlock(b *strings.Builder, node *RichTextNode) {
+	switch node.Element {
+	case RichTextList:
+		for i, item := range node.Children {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			writeRichTextBlock(b, item)
+		}
+	case RichTextCodeBlock:
+		for i, line := range node.Children {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			b.WriteString(line.Text)
+		}
+	case RichTextBlockquote, RichTextListItem:
+		for i, child := range node.Children {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			writeRichTextBlock(b, child)
+		}
+	case RichTextTable:
+		rows := node.Rows
+		if len(node.Header) > 0 {
+			rows = append([][]RichTextCell{node.Header}, rows...)
+		}
+		for i, row := range rows {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			for j, cell := range row {
+				if j > 0 {
+					b.WriteByte('\t')
+				}
+				writeRichTextInline(b, cell.Content)
+			}
+		}
+	case RichTextImage, RichTextGIF:
+		b.WriteString(node.Text)
+	case RichTextRule:
+	default:
+		writeRichTextInline(b, []*RichTextNode{node})
+	}
+}
+
+func writeRichTextInline(b *strings.Builder, nodes []*RichTextNode) {
+	for _, node := range nodes {
+		switch node.Element {
+		case RichTextLink:
+			if node.Text != "" {
+				b.WriteString(node.Text)
+			} else {
+				b.WriteString(node.URL)
+			}
+		case RichTextUserMention, RichTextSubredditMention:
+			if node.LeadingSlash {
+				b.WriteByte('/')
+			}
+			b.WriteString(string(node.Element))
+			b.WriteString(node.Text)
+		case RichTextLineBreak:
+			b.WriteByte('\n')
+		default:
+			b.WriteString(node.Text)
+			writeRichTextInline(b, node.Children)
+		}
+	}
+}
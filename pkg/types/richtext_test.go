@@ -0,0 +1,103 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestComment_RichText(t *testing.T) {
+	var comment Comment
+	err := json.Unmarshal([]byte(`{"id": "c1", "body": "ignored", "rtjson": {"document": [
+		{"e": "par", "c": [
+			{"e": "text", "t": "Thanks ", "f": [[1, 0, 6]]},
+			{"e": "u/", "t": "gopher", "l": false},
+			{"e": "text", "t": ", see "},
+			{"e": "link", "t": "the docs", "u": "https://go.dev/doc"},
+			{"e": "text", "t": " and "},
+			{"e": "r/", "t": "golang", "l": true}
+		]},
+		{"e": "h", "l": 2, "c": [{"e": "text", "t": "Spoilers"}]},
+		{"e": "par", "c": [{"e": "spoilertext", "c": [{"e": "text", "t": "it was nil"}]}]},
+		{"e": "code", "c": [{"e": "raw", "t": "x := 1"}, {"e": "raw", "t": "_ = x"}]},
+		{"e": "list", "o": true, "c": [
+			{"e": "li", "c": [{"e": "par", "c": [{"e": "text", "t": "one"}]}]},
+			{"e": "li", "c": [{"e": "par", "c": [{"e": "text", "t": "two"}]}]}
+		]},
+		{"e": "table", "h": [{"a": "L", "c": [{"e": "text", "t": "k"}]}], "c": [[{"c": [{"e": "u/", "t": "spez"}]}]]},
+		{"e": "img", "id": "abc123", "c": "a caption"}
+	]}}`), &comment)
+	if err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	doc, err := comment.RichText()
+	if err != nil {
+		t.Fatalf("RichText returned error: %v", err)
+	}
+	if len(doc.Blocks) != 7 {
+		t.Fatalf("RichText() has %d blocks, want 7", len(doc.Blocks))
+	}
+
+	first := doc.Blocks[0].Children[0]
+	if len(first.Styles) != 1 || first.Styles[0] != (RichTextStyle{Format: RichTextBold, Start: 0, Length: 6}) {
+		t.Errorf("text styles = %+v, want bold 0..6", first.Styles)
+	}
+	if heading := doc.Blocks[1]; heading.Element != RichTextHeading || heading.Level != 2 {
+		t.Errorf("heading = %+v, want level 2", heading)
+	}
+	if !doc.Blocks[4].Ordered {
+		t.Error("list Ordered = false, want true")
+	}
+	if img := doc.Blocks[6]; img.MediaID != "abc123" || img.Text != "a caption" {
+		t.Errorf("image = %+v, want abc123 with caption", img)
+	}
+
+	links := doc.Nodes(RichTextLink)
+	if len(links) != 1 || links[0].URL != "https://go.dev/doc" || links[0].Text != "the docs" {
+		t.Errorf("Nodes(link) = %+v, want the docs link", links)
+	}
+	var users []string
+	for _, node := range doc.Nodes(RichTextUserMention) {
+		users = append(users, node.Text)
+	}
+	if len(users) != 2 || users[0] != "gopher" || users[1] != "spez" {
+		t.Errorf("Nodes(u/) = %v, want [gopher spez] including the table cell", users)
+	}
+	subs := doc.Nodes(RichTextSubredditMention)
+	if len(subs) != 1 || subs[0].Text != "golang" || !subs[0].LeadingSlash {
+		t.Errorf("Nodes(r/) = %+v, want /r/golang", subs)
+	}
+
+	// Skipping a spoiler's children hides its text from the walk.
+	var visible []string
+	doc.Walk(func(node *RichTextNode) bool {
+		if node.Element == RichTextText {
+			visible = append(visible, node.Text)
+		}
+		return node.Element != RichTextSpoiler
+	})
+	for _, text := range visible {
+		if text == "it was nil" {
+			t.Error("Walk visited the children of a skipped spoiler")
+		}
+	}
+
+	want := "Thanks u/gopher, see the docs and /r/golang\n\nSpoilers\n\nit was nil\n\nx := 1\n_ = x\n\none\ntwo\n\nk\nu/spez\n\na caption"
+	if got := doc.PlainText(); got != want {
+		t.Errorf("PlainText() = %q, want %q", got, want)
+	}
+}
+
+func TestParseRichText_Absent(t *testing.T) {
+	var post Post
+	if err := json.Unmarshal([]byte(`{"id": "p1", "selftext": "plain"}`), &post); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if doc, err := post.RichText(); doc != nil || err != nil {
+		t.Errorf("RichText() without rtjson = %v, %v; want nil, nil", doc, err)
+	}
+
+	post.RTJSON = json.RawMessage(`{"document": [{"e": "par", "c": "not a list"}]}`)
+	if _, err := post.RichText(); err == nil {
+		t.Error("RichText() with malformed content returned no error")
+	}
+}
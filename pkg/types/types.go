@@ -2,8 +2,16 @@ package types
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"encoding/json"
 	"fmt"
+	"html"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
 )
 
 const PREFIX_LENGTH = 3  // Length of kind prefixes like "t1_"
@@ -12,6 +20,7 @@ const ID_MAX_LENGTH = 13 // Max length of Reddit IDs (without prefix)
 
 const MAX_POST_TITLE_LENGTH = 300     // Reddit enforces a maximum title length of 300 characters
 const MAX_COMMENT_BODY_LENGTH = 10000 // Reddit enforces a maximum comment body length of 10,000 characters
+const MAX_SELF_TEXT_LENGTH = 40000    // Reddit enforces a maximum self post body length of 40,000 characters
 const MIN_USERNAME_LENGTH = 3         // Reddit enforces a minimum username length of 3 characters
 const MAX_USERNAME_LENGTH = 20        // Reddit enforces a maximum username length of 20 characters
 
@@ -91,6 +100,9 @@ type Created struct {
 // If IsEdited is true and Timestamp is 0, it was an old edit marked as `true`.
 // If IsEdited is true and Timestamp is non-zero, it's a modern edit with a timestamp.
 // If IsEdited is false, the item was not edited.
+//
+// Prefer the Time, Compare, and EditedAt helpers to reading or setting the fields directly;
+// they keep working if the representation changes.
 type Edited struct {
 	IsEdited  bool
 	Timestamp float64
@@ -152,6 +164,69 @@ func (e *Edited) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("invalid value for 'edited' field: %s", string(data))
 }
 
+// MarshalJSON implements json.Marshaler, encoding the value the way Reddit does and
+// UnmarshalJSON accepts: false, true for a legacy edit without a timestamp, or the edit
+// time in Unix seconds.
+func (e Edited) MarshalJSON() ([]byte, error) {
+	switch {
+	case !e.IsEdited:
+		return []byte("false"), nil
+	case e.Timestamp == 0:
+		return []byte("true"), nil
+	default:
+		return json.Marshal(e.Timestamp)
+	}
+}
+
+// EditedAt returns an Edited recording an edit at t, or an unedited value if t is zero.
+func EditedAt(t time.Time) Edited {
+	if t.IsZero() {
+		return Edited{}
+	}
+	return Edited{IsEdited: true, Timestamp: float64(t.UnixNano()) / 1e9}
+}
+
+// Time returns the edit time. ok is false if the item was not edited or Reddit only
+// reported a legacy boolean edit marker without a timestamp.
+func (e Edited) Time() (t time.Time, ok bool) {
+	if !e.IsEdited || e.Timestamp == 0 {
+		return time.Time{}, false
+	}
+	sec, frac := math.Modf(e.Timestamp)
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))).UTC(), true
+}
+
+// Compare orders edits: -1 if e is earlier than other, +1 if later, and 0 if they are the
+// same. An unedited value is earlier than any edit, and a legacy edit without a timestamp
+// is earlier than any timestamped edit.
+func (e Edited) Compare(other Edited) int {
+	rank := func(x Edited) int {
+		switch {
+		case !x.IsEdited:
+			return 0
+		case x.Timestamp == 0:
+			return 1
+		default:
+			return 2
+		}
+	}
+	if r, o := rank(e), rank(other); r != o {
+		return cmp.Compare(r, o)
+	}
+	return cmp.Compare(e.Timestamp, other.Timestamp)
+}
+
+// After reports whether e records a later edit than other, e.g. a newer revision of the
+// same comment.
+func (e Edited) After(other Edited) bool {
+	return e.Compare(other) > 0
+}
+
+// Equal reports whether e and other record the same edit.
+func (e Edited) Equal(other Edited) bool {
+	return e.Compare(other) == 0
+}
+
 // trimSpace safely trims whitespace from JSON data
 func trimSpace(data []byte) []byte {
 	start := 0
@@ -229,6 +304,50 @@ type Pagination struct {
 type PostsRequest struct {
 	Subreddit string
 	Pagination
+
+	// Params holds extra query parameters for options the wrapper does not model yet, such as
+	// include_categories or sr_detail. They cannot replace parameters the wrapper sets itself
+	// (limit, after, before, t, sort).
+	Params url.Values
+}
+
+// SearchType selects what a search returns.
+type SearchType string
+
+const (
+	// SearchTypePosts searches posts. It is the default.
+	SearchTypePosts SearchType = "link"
+	// SearchTypeSubreddits searches subreddit names and descriptions.
+	SearchTypeSubreddits SearchType = "sr"
+	// SearchTypeUsers searches user names and profiles.
+	SearchTypeUsers SearchType = "user"
+)
+
+// SearchRequest describes a search of Reddit or of one subreddit.
+type SearchRequest struct {
+	// Query is the search text, in Reddit's search syntax (e.g. `title:"go 1.23"`). Required.
+	Query string
+
+	// Subreddit restricts a post search to one subreddit. Leave blank to search all of
+	// Reddit. Subreddit searches cannot be restricted.
+	Subreddit string
+
+	// Sort is the result order: "relevance" (the default), "hot", "top", "new", or
+	// "comments" for posts, and "relevance" or "activity" for subreddits and users.
+	Sort string
+
+	// TimeRange limits post results to the last "hour", "day", "week", "month", or "year",
+	// or "all". Reddit uses "all" when empty.
+	TimeRange string
+
+	// Type selects what to search. Defaults to SearchTypePosts when empty.
+	Type SearchType
+
+	// Category is an optional tag, at most 5 characters, that Reddit records with the
+	// search for its own analytics. It does not change the results.
+	Category string
+
+	Pagination
 }
 
 // CommentsRequest describes a request to retrieve comments for a specific post.
@@ -236,6 +355,31 @@ type CommentsRequest struct {
 	Subreddit string
 	PostID    string
 	Pagination
+
+	// Sort is the comment order: "confidence" (Reddit's "best", the default), "top", "new",
+	// "controversial", "old", "random", or "qa", which puts comments the post's author
+	// replied to first.
+	Sort string
+
+	// ExcludeCollapsed drops comments Reddit collapses by default (low score, crowd control, etc.)
+	// and their replies from the response. Collapsed comments are included when false.
+	ExcludeCollapsed bool
+	// RequirePost fetches the post from /api/info when Reddit's comments response omits it,
+	// so that Post is never nil in a successful response. GetCommentsMultiple fetches all such
+	// posts in one request.
+	RequirePost bool
+	// LazyReplies keeps each top-level comment's replies as raw JSON in RawReplies instead of
+	// parsing the whole tree, which saves memory when only top-level comments are needed from
+	// a large thread. Call Comment.LoadReplies to parse a comment's replies on demand. With
+	// LazyReplies, MoreIDs and ContinueThreadLinks cover only the top level.
+	LazyReplies bool
+	// RichText asks Reddit to send each comment's body as rich text JSON (the "rtj"
+	// parameter), filling Comment.RTJSON for Comment.RichText to parse.
+	RichText bool
+	// Params holds extra query parameters for options the wrapper does not model yet, such as
+	// depth, context, or showedits. They cannot replace parameters the wrapper sets itself
+	// (limit, after, before, t, sort).
+	Params url.Values
 }
 
 // MoreCommentsRequest describes a request to expand previously truncated comment trees.
@@ -256,6 +400,166 @@ type MoreCommentsRequest struct {
 	// When true, Reddit will limit the response size (typically to 20 children).
 	// When false (default), Reddit will return all requested children.
 	LimitChildren bool
+
+	// Timeout bounds the whole call when non-zero. GetAllMoreComments returns the comments
+	// fetched before it expired along with a PartialResultError.
+	Timeout time.Duration
+}
+
+// MoreCommentsResult holds the comments loaded for a MoreCommentsRequest together with a
+// record of each morechildren request that was sent.
+type MoreCommentsResult struct {
+	// Comments are all comments returned, in Reddit's order. Reddit may include replies to
+	// the requested comments that were not themselves requested.
+	Comments []*Comment
+	// Batches describes each request, in the order they were sent.
+	Batches []MoreCommentsBatch
+}
+
+// MoreCommentsBatch records one morechildren request: the IDs it asked for, those Reddit
+// returned, and those it silently left out, usually because the comment was deleted or removed.
+type MoreCommentsBatch struct {
+	Requested []string
+	Returned  []string
+	Missing   []string
+}
+
+// Missing returns the requested IDs that no batch returned, in request order.
+func (r *MoreCommentsResult) Missing() []string {
+	var missing []string
+	for _, b := range r.Batches {
+		missing = append(missing, b.Missing...)
+	}
+	return missing
+}
+
+// SubmitKind identifies the kind of post being submitted.
+type SubmitKind string
+
+const (
+	SubmitKindSelf  SubmitKind = "self"  // Text post with an optional markdown body
+	SubmitKindLink  SubmitKind = "link"  // Post pointing at an external URL
+	SubmitKindImage SubmitKind = "image" // Post pointing at an image uploaded to Reddit's media host
+)
+
+// SubmitRequest describes a new post to submit to a subreddit.
+// Set Text for self posts and URL for link or image posts.
+type SubmitRequest struct {
+	Subreddit string
+	Title     string
+	Kind      SubmitKind
+
+	// Text is the markdown body for self posts. Ignored for link and image posts.
+	Text string
+
+	// URL is the target of a link post, or the uploaded media URL for an image post.
+	URL string
+
+	// FlairID and FlairText select a link flair template for the post.
+	// Required by subreddits whose post requirements set IsFlairRequired.
+	FlairID   string
+	FlairText string
+
+	// CheckFlair looks up the subreddit's post requirements before submitting. When flair
+	// is required and FlairID is empty, the template whose text matches FlairText is
+	// attached; if there is none, SubmitPost returns a *errors.FlairRequiredError listing
+	// the available templates without posting.
+	CheckFlair bool
+
+	NSFW        bool
+	Spoiler     bool
+	SendReplies bool
+
+	// IdempotencyKey makes retries of this submission safe. When a submission with the same
+	// key has already succeeded, or an earlier attempt failed ambiguously (e.g. timed out) and
+	// the post turns out to exist, the existing post is returned instead of posting again.
+	// Keys are remembered by the client for a limited time. Optional.
+	IdempotencyKey string
+}
+
+// FlairTemplate is a link flair a subreddit offers for posts.
+type FlairTemplate struct {
+	// ID is passed as SubmitRequest.FlairID to use the template.
+	ID   string `json:"id"`
+	Text string `json:"text"`
+	// TextEditable reports whether the poster may replace Text with SubmitRequest.FlairText.
+	TextEditable bool `json:"text_editable"`
+	// ModOnly templates can only be chosen by the subreddit's moderators.
+	ModOnly         bool   `json:"mod_only"`
+	CSSClass        string `json:"css_class"`
+	BackgroundColor string `json:"background_color"`
+	TextColor       string `json:"text_color"`
+}
+
+// SubmitResponse identifies a post created by a submission.
+type SubmitResponse struct {
+	// ID is the post ID without prefix, e.g. "abc123".
+	ID string `json:"id"`
+	// Name is the post fullname, e.g. "t3_abc123".
+	Name string `json:"name"`
+	// URL is the post's permalink URL.
+	URL string `json:"url"`
+	// WebsocketURL is set for image posts, which Reddit creates once it has processed the
+	// upload. ID, Name, and URL are empty for them; the post is announced on this websocket.
+	WebsocketURL string `json:"websocket_url,omitempty"`
+	// Reused is true when an existing post was returned for a repeated IdempotencyKey
+	// instead of submitting a new one.
+	Reused bool `json:"-"`
+
+	// RateLimit is the rate-limit state reported with the response, or nil if Reddit sent none.
+	RateLimit *RateLimitInfo `json:"-"`
+	// Meta holds the response's diagnostic headers when Config.CaptureHeaders is enabled.
+	Meta *ResponseMeta `json:"-"`
+}
+
+// AwardResponse is Reddit's reply to giving an award: the awarded thing's updated
+// awards and the giver's remaining coin balance.
+type AwardResponse struct {
+	// Coins is the giver's coin balance after paying for the award.
+	Coins int `json:"coins"`
+	// Gildings counts the thing's legacy silver, gold, and platinum awards by award ID,
+	// e.g. "gid_2".
+	Gildings map[string]int `json:"gildings"`
+	// AllAwardings lists every award the thing has received.
+	AllAwardings []Awarding `json:"all_awardings"`
+	// TreatmentTags are experiment tags Reddit attaches to the response.
+	TreatmentTags []string `json:"treatment_tags"`
+}
+
+// Awarding is one kind of award given to a post or comment, with how many times it was given.
+type Awarding struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	AwardType   string `json:"award_type"`
+	CoinPrice   int    `json:"coin_price"`
+	Count       int    `json:"count"`
+	IconURL     string `json:"icon_url"`
+}
+
+// PostRequirements describes a subreddit's submission rules as returned by
+// /api/v1/{subreddit}/post_requirements. Nil length fields mean "no limit".
+type PostRequirements struct {
+	TitleRequiredStrings    []string `json:"title_required_strings"`
+	TitleBlacklistedStrings []string `json:"title_blacklisted_strings"`
+	TitleTextMinLength      *int     `json:"title_text_min_length"`
+	TitleTextMaxLength      *int     `json:"title_text_max_length"`
+	TitleRegexes            []string `json:"title_regexes"`
+
+	// BodyRestrictionPolicy is "required", "notAllowed", or "none".
+	BodyRestrictionPolicy  string   `json:"body_restriction_policy"`
+	BodyRequiredStrings    []string `json:"body_required_strings"`
+	BodyBlacklistedStrings []string `json:"body_blacklisted_strings"`
+	BodyTextMinLength      *int     `json:"body_text_min_length"`
+	BodyTextMaxLength      *int     `json:"body_text_max_length"`
+	BodyRegexes            []string `json:"body_regexes"`
+
+	DomainBlacklist []string `json:"domain_blacklist"`
+	DomainWhitelist []string `json:"domain_whitelist"`
+
+	IsFlairRequired bool    `json:"is_flair_required"`
+	LinkRepostAge   *int    `json:"link_repost_age"`
+	GuidelinesText  *string `json:"guidelines_text"`
 }
 
 // SubredditData contains the data for a Subreddit.
@@ -269,9 +573,11 @@ type SubredditData struct {
 	HeaderImg            *string `json:"header_img"`
 	HeaderSize           []int   `json:"header_size"`
 	HeaderTitle          *string `json:"header_title"`
+	Lang                 string  `json:"lang"` // The community's primary language, e.g. "en"
 	Over18               bool    `json:"over18"`
 	PublicDescription    string  `json:"public_description"`
 	PublicTraffic        bool    `json:"public_traffic"`
+	Quarantine           bool    `json:"quarantine"`
 	Subscribers          int64   `json:"subscribers"`
 	SubmissionType       string  `json:"submission_type"`
 	SubmitLinkLabel      *string `json:"submit_link_label"`
@@ -283,6 +589,333 @@ type SubredditData struct {
 	UserIsContributor    *bool   `json:"user_is_contributor"`
 	UserIsModerator      *bool   `json:"user_is_moderator"`
 	UserIsSubscriber     *bool   `json:"user_is_subscriber"`
+
+	// NotificationLevel is the authenticated user's notification setting for the subreddit,
+	// e.g. NotificationLevelOff. It is nil without user authentication.
+	NotificationLevel *NotificationLevel `json:"notification_level"`
+	// UserHasFavorited reports whether the authenticated user has favorited the subreddit. It
+	// is nil without user authentication.
+	UserHasFavorited *bool `json:"user_has_favorited"`
+}
+
+// NotificationLevel is how often Reddit notifies a user about a subreddit's posts.
+type NotificationLevel string
+
+const (
+	NotificationLevelOff      NotificationLevel = "off"      // No post notifications
+	NotificationLevelLow      NotificationLevel = "low"      // Occasional notifications about popular posts
+	NotificationLevelFrequent NotificationLevel = "frequent" // Notifications about most posts
+)
+
+// NotificationsEnabled reports whether the authenticated user gets post notifications from
+// the subreddit, and whether that is known: it is unknown without user authentication.
+func (s *SubredditData) NotificationsEnabled() (enabled, known bool) {
+	if s == nil || s.NotificationLevel == nil {
+		return false, false
+	}
+	return *s.NotificationLevel != NotificationLevelOff, true
+}
+
+// ContentStatus describes whether a post or subreddit can be viewed, as reported by
+// ExistsPost and ExistsSubreddit.
+type ContentStatus string
+
+const (
+	ContentActive      ContentStatus = "active"      // Exists and is visible
+	ContentNotFound    ContentStatus = "not_found"   // Does not exist, or is not visible to the client
+	ContentRemoved     ContentStatus = "removed"     // Post removed by moderators or Reddit
+	ContentDeleted     ContentStatus = "deleted"     // Post deleted by its author
+	ContentPrivate     ContentStatus = "private"     // Subreddit is private
+	ContentQuarantined ContentStatus = "quarantined" // Subreddit is quarantined
+	ContentGated       ContentStatus = "gated"       // Subreddit requires opting in to view
+	ContentBanned      ContentStatus = "banned"      // Subreddit has been banned
+)
+
+// RemovalSource says where a RemovalInfo's explanation came from.
+type RemovalSource string
+
+const (
+	RemovalSourceNone       RemovalSource = ""            // The post was not removed
+	RemovalSourceModLog     RemovalSource = "modlog"      // The subreddit's moderation log; requires moderator access
+	RemovalSourceModComment RemovalSource = "mod_comment" // A distinguished moderator comment on the post
+	RemovalSourceCategory   RemovalSource = "category"    // Only the post's removed_by_category
+)
+
+// ModAction is an entry of a subreddit's moderation log.
+type ModAction struct {
+	ID             string  `json:"id"`
+	Action         string  `json:"action"` // e.g. "removelink", "spamlink", "approvelink"
+	Mod            string  `json:"mod"`
+	TargetFullname string  `json:"target_fullname"`
+	Details        string  `json:"details"`
+	Description    string  `json:"description"`
+	CreatedUTC     float64 `json:"created_utc"`
+}
+
+// RemovalInfo explains, as far as the client can tell, why a post was removed. It is
+// returned by GetRemovalInfo.
+type RemovalInfo struct {
+	// Fullname is the post's fullname.
+	Fullname string
+	// Status is ContentActive, ContentRemoved, ContentDeleted, or ContentNotFound.
+	Status ContentStatus
+	// Category is the post's removed_by_category, e.g. "moderator", "automod_filtered",
+	// "reddit", or "deleted". It is empty if the post was not removed.
+	Category string
+	// Reason is the best available explanation: the moderation log details, the moderator
+	// comment, or a description of Category, depending on Source.
+	Reason string
+	// Source says where Reason came from.
+	Source RemovalSource
+	// Moderator is the moderator who removed the post, if known.
+	Moderator string
+	// Action is the moderation log entry for the removal, when Source is RemovalSourceModLog.
+	Action *ModAction
+	// ModComment is the moderator's comment, when Source is RemovalSourceModComment.
+	ModComment *Comment
+}
+
+// BanOptions describes a ban for BanUser. The zero value is a permanent ban with no reason,
+// note, or message.
+type BanOptions struct {
+	// Duration is the length of the ban in days, from 1 to 999. Zero bans permanently.
+	Duration int
+	// Reason is the rule broken, shown to moderators in the ban list; at most 100 characters.
+	Reason string
+	// Note is a private note for other moderators; at most 300 characters.
+	Note string
+	// Message is sent to the user with the ban notice.
+	Message string
+}
+
+// BannedUser is an entry of a subreddit's ban list.
+type BannedUser struct {
+	// Name is the banned user's username.
+	Name string `json:"name"`
+	// ID is the user's account fullname, e.g. "t2_abc123".
+	ID string `json:"id"`
+	// RelID identifies the ban, e.g. "rb_abc123"; it is the cursor for paging the list.
+	RelID string `json:"rel_id"`
+	// Note is the ban's reason and moderator note as Reddit joined them.
+	Note string `json:"note"`
+	// DaysLeft is the number of days until a temporary ban ends, or nil for a permanent ban.
+	DaysLeft *int `json:"days_left"`
+	// Date is when the ban was made, in Unix seconds.
+	Date float64 `json:"date"`
+}
+
+// WikiPage is a page of a subreddit's wiki, at its current or a past revision.
+type WikiPage struct {
+	// Subreddit and Name identify the page, e.g. "golang" and "config/automoderator".
+	Subreddit string `json:"-"`
+	Name      string `json:"-"`
+	// Content is the page's markdown, and ContentHTML Reddit's rendering of it.
+	Content     string `json:"content_md"`
+	ContentHTML string `json:"content_html"`
+	// RevisionID identifies the revision shown; pass it as WikiEditRequest.PreviousRevision
+	// to detect conflicting edits.
+	RevisionID string `json:"revision_id"`
+	// RevisionDate is when the revision was made, in Unix seconds.
+	RevisionDate float64 `json:"revision_date"`
+	// RevisionBy is the username of the revision's author.
+	RevisionBy string `json:"-"`
+	// RevisionReason is the edit reason the author gave, or nil if none.
+	RevisionReason *string `json:"reason"`
+	// MayRevise reports whether the authenticated user may edit the page.
+	MayRevise bool `json:"may_revise"`
+}
+
+// WikiRevision is an entry of a wiki page's revision history.
+type WikiRevision struct {
+	ID string `json:"id"`
+	// Page is the name of the revised page.
+	Page string `json:"page"`
+	// Author is the username of the revision's author.
+	Author string `json:"-"`
+	// Reason is the edit reason the author gave, or nil if none.
+	Reason *string `json:"reason"`
+	// Timestamp is when the revision was made, in Unix seconds.
+	Timestamp float64 `json:"timestamp"`
+	// Hidden reports whether moderators hid the revision from the page's history.
+	Hidden bool `json:"revision_hidden"`
+}
+
+// WikiEditRequest describes an edit of a wiki page for EditWikiPage.
+type WikiEditRequest struct {
+	Subreddit string
+	// Page is the page to edit or create, e.g. "index" or "config/bot".
+	Page string
+	// Content is the new markdown of the whole page.
+	Content string
+	// Reason is an optional edit reason shown in the page's history; at most 256 characters.
+	Reason string
+	// PreviousRevision, if set, is the revision the edit is based on. Reddit rejects the edit
+	// with a conflict if the page has been revised since.
+	PreviousRevision string
+}
+
+// SubredditRule is one of a subreddit's rules, from its about/rules endpoint.
+type SubredditRule struct {
+	// Kind is what the rule applies to: "link", "comment", or "all".
+	Kind            string  `json:"kind"`
+	ShortName       string  `json:"short_name"`
+	Description     string  `json:"description"`
+	DescriptionHTML string  `json:"description_html"`
+	ViolationReason string  `json:"violation_reason"`
+	CreatedUTC      float64 `json:"created_utc"`
+	Priority        int     `json:"priority"`
+}
+
+// Sidebar is a subreddit's sidebar text split into sections, together with its rules.
+type Sidebar struct {
+	// Subreddit is the subreddit's display name.
+	Subreddit string
+	// Markdown is the sidebar as the moderators wrote it (the subreddit's description).
+	Markdown string
+	// HTML is Reddit's rendering of Markdown.
+	HTML string
+	// Sections splits Markdown at its headings, in order. Text before the first heading
+	// forms a section with Level 0 and no Heading.
+	Sections []SidebarSection
+	// Links lists every link in Markdown, in order.
+	Links []SidebarLink
+	// Rules lists the subreddit's rules in priority order.
+	Rules []SubredditRule
+	// SiteRules lists the site-wide reasons Reddit offers when reporting content there.
+	SiteRules []string
+}
+
+// SidebarSection is a heading of a sidebar and the markdown below it.
+type SidebarSection struct {
+	// Heading is the heading text without its "#" marks.
+	Heading string
+	// Level is the heading level, 1 for "#" through 6, or 0 for text before any heading.
+	Level int
+	// Markdown is the section's text, without the heading line.
+	Markdown string
+	// Links lists the links in Markdown, in order.
+	Links []SidebarLink
+}
+
+// SidebarLink is a markdown link in a sidebar. Links to Reddit paths such as /r/golang are
+// made absolute.
+type SidebarLink struct {
+	Text string
+	URL  string
+}
+
+// Widget kinds returned by a subreddit's widgets endpoint.
+const (
+	WidgetKindTextArea      = "textarea"
+	WidgetKindRules         = "subreddit-rules"
+	WidgetKindCommunityList = "community-list"
+	WidgetKindCalendar      = "calendar"
+	WidgetKindIDCard        = "id-card"
+	WidgetKindModerators    = "moderators"
+	WidgetKindButton        = "button"
+	WidgetKindImage         = "image"
+	WidgetKindMenu          = "menu"
+	WidgetKindPostFlair     = "post-flair"
+	WidgetKindCustom        = "custom"
+)
+
+// SubredditWidgets contains a subreddit's sidebar and topbar widgets in display order.
+type SubredditWidgets struct {
+	// Sidebar lists the sidebar widgets in the order Reddit displays them.
+	Sidebar []*Widget
+	// Topbar lists the topbar (menu) widgets in display order.
+	Topbar []*Widget
+	// IDCard is the community details widget, if present.
+	IDCard *Widget
+	// Moderators is the moderator list widget, if present.
+	Moderators *Widget
+	// Items holds every widget keyed by widget ID, including any not referenced by the layout.
+	Items map[string]*Widget
+}
+
+// Widget is a single subreddit widget. The kind-specific fields are populated according to Kind;
+// Data keeps the raw "data" payload for kinds without a typed representation.
+type Widget struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	ShortName string `json:"shortName"`
+
+	// Text and TextHTML hold the content of textarea widgets.
+	Text     string `json:"text"`
+	TextHTML string `json:"textHtml"`
+
+	// Display is "full" or "compact" for rules widgets.
+	Display string `json:"display"`
+
+	// Rules is populated for subreddit-rules widgets.
+	Rules []WidgetRule `json:"-"`
+	// Communities is populated for community-list widgets.
+	Communities []WidgetCommunity `json:"-"`
+	// Events is populated for calendar widgets.
+	Events []CalendarEvent `json:"-"`
+
+	// Data is the raw "data" field as returned by Reddit.
+	Data json.RawMessage `json:"data"`
+}
+
+// WidgetRule is one rule in a subreddit-rules widget.
+type WidgetRule struct {
+	ShortName       string  `json:"shortName"`
+	Description     string  `json:"description"`
+	DescriptionHTML string  `json:"descriptionHtml"`
+	ViolationReason string  `json:"violationReason"`
+	CreatedUTC      float64 `json:"createdUtc"`
+	Priority        int     `json:"priority"`
+}
+
+// WidgetCommunity is one subreddit in a community-list widget.
+type WidgetCommunity struct {
+	Name          string `json:"name"`
+	PrefixedName  string `json:"prefixedName"`
+	Subscribers   int64  `json:"subscribers"`
+	IconURL       string `json:"iconUrl"`
+	CommunityIcon string `json:"communityIcon"`
+	IsSubscribed  bool   `json:"isSubscribed"`
+	IsNSFW        bool   `json:"isNSFW"`
+	Type          string `json:"type"`
+}
+
+// CalendarEvent is one event in a calendar widget. Times are Unix timestamps.
+type CalendarEvent struct {
+	Title           string  `json:"title"`
+	TitleHTML       string  `json:"titleHtml"`
+	Description     string  `json:"description"`
+	DescriptionHTML string  `json:"descriptionHtml"`
+	Location        string  `json:"location"`
+	LocationHTML    string  `json:"locationHtml"`
+	StartTime       float64 `json:"startTime"`
+	EndTime         float64 `json:"endTime"`
+	AllDay          bool    `json:"allDay"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the "data" field according to the widget kind.
+func (w *Widget) UnmarshalJSON(data []byte) error {
+	type plain Widget
+	if err := json.Unmarshal(data, (*plain)(w)); err != nil {
+		return err
+	}
+	if len(w.Data) == 0 || bytes.Equal(w.Data, []byte("null")) {
+		return nil
+	}
+
+	var err error
+	switch w.Kind {
+	case WidgetKindRules:
+		err = json.Unmarshal(w.Data, &w.Rules)
+	case WidgetKindCommunityList:
+		err = json.Unmarshal(w.Data, &w.Communities)
+	case WidgetKindCalendar:
+		err = json.Unmarshal(w.Data, &w.Events)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid data for %s widget %s: %w", w.Kind, w.ID, err)
+	}
+	return nil
 }
 
 // MessageData contains the data for a private Message.
@@ -320,12 +953,102 @@ type AccountData struct {
 	LinkKarma        int    `json:"link_karma"`
 	Modhash          string `json:"modhash,omitempty"`
 	Over18           bool   `json:"over_18"`
+
+	// IsSuspended is set for suspended accounts. Reddit then omits most other fields,
+	// including ID and creation time.
+	IsSuspended bool `json:"is_suspended"`
+	// IsBlocked reports whether the authenticated user has blocked this account.
+	IsBlocked bool `json:"is_blocked"`
+}
+
+// Scope is an OAuth scope an application can request, as listed by /api/v1/scopes.
+type Scope struct {
+	// ID is the scope's name in authorization requests, e.g. "identity" or "read".
+	ID string `json:"id"`
+	// Name is the short title Reddit shows users, e.g. "My Identity".
+	Name string `json:"name"`
+	// Description explains what the scope allows.
+	Description string `json:"description"`
+}
+
+// AccountStatus describes whether an account can still be used.
+type AccountStatus string
+
+const (
+	AccountActive    AccountStatus = "active"
+	AccountSuspended AccountStatus = "suspended"
+	AccountDeleted   AccountStatus = "deleted"
+)
+
+// DeletedAuthor is the author name Reddit shows for deleted accounts and content.
+const DeletedAuthor = "[deleted]"
+
+// Status reports whether the account is active, suspended, or deleted.
+func (a *AccountData) Status() AccountStatus {
+	switch {
+	case a.IsSuspended:
+		return AccountSuspended
+	case a.Name == DeletedAuthor, a.Name == "" && a.ID == "":
+		return AccountDeleted
+	}
+	return AccountActive
+}
+
+// UserSummary is the short public profile of an account returned by
+// /api/user_data_by_account_ids.
+type UserSummary struct {
+	// Fullname is the account's fullname, e.g. "t2_abc123".
+	Fullname string `json:"-"`
+	// Name is the account's username.
+	Name          string  `json:"name"`
+	CreatedUTC    float64 `json:"created_utc"`
+	LinkKarma     int     `json:"link_karma"`
+	CommentKarma  int     `json:"comment_karma"`
+	ProfileImage  string  `json:"profile_img"`
+	ProfileOver18 bool    `json:"profile_over_18"`
+}
+
+// UsersResponse contains the accounts found by GetUsersByIDs.
+type UsersResponse struct {
+	// Users maps each account fullname found to its summary. Accounts that do not exist,
+	// or are suspended or deleted, are missing.
+	Users map[string]*UserSummary
+
+	// RateLimit is the rate-limit state reported with the response, or nil if Reddit sent none.
+	RateLimit *RateLimitInfo
+	// Meta holds the response's diagnostic headers when Config.CaptureHeaders is enabled.
+	Meta *ResponseMeta
 }
 
 // MoreData represents a "more" object, used for comment pagination.
 type MoreData struct {
 	ThingData
 	Children []string `json:"children"`
+	Count    int      `json:"count"`
+	ParentID string   `json:"parent_id"`
+	Depth    int      `json:"depth"`
+}
+
+// ContinueThreadID is the ID of a "more" object that stands for replies Reddit cut off
+// for depth. Reddit renders it as a "continue this thread" link; it cannot be expanded
+// with morechildren.
+const ContinueThreadID = "_"
+
+// IsContinueThread reports whether m is a "continue this thread" marker rather than a
+// list of comments that can be loaded with morechildren.
+func (m *MoreData) IsContinueThread() bool {
+	return m.ID == ContinueThreadID || (m.Count == 0 && len(m.Children) == 0)
+}
+
+// Permalink is a "continue this thread" link: the replies of ParentID were cut off for
+// depth and are only available by opening URL (or fetching that comment's thread).
+type Permalink struct {
+	// ParentID is the fullname of the comment whose replies were cut off, e.g. "t1_abc123".
+	ParentID string
+	// Depth is the nesting depth of the first hidden reply; top-level comments are depth 0.
+	Depth int
+	// URL is the https://www.reddit.com link that opens the thread at ParentID.
+	URL string
 }
 
 // Post represents a Reddit post with all its fields
@@ -360,6 +1083,115 @@ type Post struct {
 	Distinguished       *string         `json:"distinguished"`
 	Stickied            bool            `json:"stickied"`
 	UpvoteRatio         float64         `json:"upvote_ratio"` // Percentage of upvotes (0.0 to 1.0, e.g. 0.95 = 95% upvoted)
+
+	// RemovedByCategory says who removed the post, e.g. "moderator", "reddit", "deleted"
+	// (by its author), or "automod_filtered". It is nil for posts that were not removed.
+	RemovedByCategory *string `json:"removed_by_category"`
+	// AuthorIsBlocked reports whether the authenticated user has blocked the post's author.
+	AuthorIsBlocked bool `json:"author_is_blocked"`
+	// SrDetail is the partial subreddit data Reddit embeds when a listing is requested with
+	// sr_detail=true. It is nil otherwise; use SubredditInfo instead.
+	SrDetail *SubredditData `json:"sr_detail,omitempty"`
+	// Preview holds the images Reddit generated for the post's link, or nil if there are none.
+	// Use BestPreview to pick one for display.
+	Preview *Preview `json:"preview,omitempty"`
+	// RTJSON is the post's self text as Reddit rich text JSON, when Reddit sends it (e.g.
+	// for posts made in the rich text editor). Use RichText to parse it.
+	RTJSON json.RawMessage `json:"rtjson,omitempty"`
+
+	// subredditInfo is the subreddit data the client attached, if any.
+	subredditInfo *SubredditData
+}
+
+// SubredditInfo returns what is known about the post's subreddit: the data the client
+// attached from its subreddit cache, or else SrDetail. It returns nil if neither is available.
+func (p *Post) SubredditInfo() *SubredditData {
+	if p.subredditInfo != nil {
+		return p.subredditInfo
+	}
+	return p.SrDetail
+}
+
+// RichText parses the post's RTJSON into a document. It returns nil and no error if Reddit
+// sent no rich text for the post.
+func (p *Post) RichText() (*RichTextDocument, error) {
+	return parseOptionalRichText(p.RTJSON)
+}
+
+// SetSubredditInfo attaches subreddit data to the post, to be returned by SubredditInfo.
+func (p *Post) SetSubredditInfo(info *SubredditData) {
+	p.subredditInfo = info
+}
+
+// Preview is the preview data of a post: the images Reddit generated from its link.
+type Preview struct {
+	Images  []PreviewImage `json:"images"`
+	Enabled bool           `json:"enabled"`
+}
+
+// PreviewImage is one preview image, at its full size and in the smaller sizes Reddit
+// resized it to.
+type PreviewImage struct {
+	ID          string          `json:"id"`
+	Source      PreviewSource   `json:"source"`
+	Resolutions []PreviewSource `json:"resolutions"`
+}
+
+// PreviewSource is a preview image at one size. Reddit HTML-escapes URL, e.g. "&" as
+// "&amp;"; BestPreview returns it unescaped.
+type PreviewSource struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// BestPreview returns the size of the post's first preview image that best fits a
+// width×height box: the smallest size that fills the box, or the largest if none does. A
+// zero width or height leaves that dimension unconstrained, and BestPreview(0, 0) returns
+// the full-size image. The returned URL is unescaped and ready to fetch.
+//
+// It returns nil if the post has no preview.
+func (p *Post) BestPreview(width, height int) *PreviewSource {
+	if p == nil || p.Preview == nil || len(p.Preview.Images) == 0 {
+		return nil
+	}
+	image := p.Preview.Images[0]
+	candidates := append(slices.Clone(image.Resolutions), image.Source)
+	if width <= 0 && height <= 0 {
+		candidates = []PreviewSource{image.Source}
+	}
+
+	var best *PreviewSource
+	for i := range candidates {
+		c := &candidates[i]
+		if c.URL == "" {
+			continue
+		}
+		fits := c.fitsBox(width, height)
+		switch {
+		case best == nil:
+			best = c
+		case fits && (!best.fitsBox(width, height) || c.area() < best.area()):
+			best = c
+		case !fits && !best.fitsBox(width, height) && c.area() > best.area():
+			best = c
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	result := *best
+	result.URL = html.UnescapeString(result.URL)
+	return &result
+}
+
+// fitsBox reports whether the image is at least width×height.
+func (s *PreviewSource) fitsBox(width, height int) bool {
+	return s.Width >= width && s.Height >= height
+}
+
+func (s *PreviewSource) area() int {
+	return s.Width * s.Height
 }
 
 // Comment represents a Reddit comment with all its fields
@@ -388,7 +1220,489 @@ type Comment struct {
 	Subreddit           string     `json:"subreddit"`
 	SubredditID         string     `json:"subreddit_id"`
 	Distinguished       *string    `json:"distinguished"`
+	Stickied            bool       `json:"stickied"`
 	MoreChildrenIDs     []string   `json:"-"` // Aggregated IDs for deferred comment loading
+	Permalink           string     `json:"permalink"`
+
+	// ContinueThread reports whether Reddit cut this comment's replies off for depth.
+	// The hidden replies are listed in CommentsResponse.ContinueThreadLinks.
+	ContinueThread bool `json:"-"`
+
+	// Truncated reports whether the client stopped parsing at ParserOptions.MaxDepth. The
+	// IDs of the comment's direct replies are in MoreChildrenIDs, to be loaded with
+	// GetMoreComments.
+	Truncated bool `json:"-"`
+
+	// RawReplies holds the unparsed replies of a comment loaded with CommentsRequest.LazyReplies.
+	// LoadReplies parses it into Replies; it is nil once parsed or if there are no replies.
+	RawReplies json.RawMessage `json:"-"`
+
+	// Collapsed reports whether Reddit collapses the comment by default in its UI.
+	Collapsed bool `json:"collapsed"`
+	// CollapsedReason is the human-readable explanation shown for a collapsed comment.
+	CollapsedReason *string `json:"collapsed_reason"`
+	// CollapsedReasonCode is the machine-readable collapse reason, e.g. CollapsedReasonLowScore.
+	CollapsedReasonCode *string `json:"collapsed_reason_code"`
+	// CollapsedBecauseCrowdControl reports whether the subreddit's crowd control setting collapsed the comment.
+	CollapsedBecauseCrowdControl *bool `json:"collapsed_because_crowd_control"`
+	// AuthorIsBlocked reports whether the authenticated user has blocked the comment's author.
+	AuthorIsBlocked bool `json:"author_is_blocked"`
+	// RTJSON is the comment's body as Reddit rich text JSON, when Reddit sends it (for
+	// comments requested with CommentsRequest.RichText). Use RichText to parse it.
+	RTJSON json.RawMessage `json:"rtjson,omitempty"`
+
+	// Annotations holds values attached by annotators (see graw.Annotator), such as a
+	// sentiment score or matched keywords. It is not part of Reddit's response.
+	Annotations map[string]any `json:"-"`
+}
+
+// RichText parses the comment's RTJSON into a document. It returns nil and no error if
+// Reddit sent no rich text for the comment.
+func (c *Comment) RichText() (*RichTextDocument, error) {
+	return parseOptionalRichText(c.RTJSON)
+}
+
+// SetAnnotation attaches value to the comment under key, replacing any previous value.
+func (c *Comment) SetAnnotation(key string, value any) {
+	if c.Annotations == nil {
+		c.Annotations = make(map[string]any)
+	}
+	c.Annotations[key] = value
+}
+
+// Annotation returns the value attached under key, if any.
+func (c *Comment) Annotation(key string) (any, bool) {
+	v, ok := c.Annotations[key]
+	return v, ok
+}
+
+// RepliesParser parses the raw replies of a lazily loaded comment. The client implements it
+// with graw.Reddit.LoadReplies.
+type RepliesParser interface {
+	// ParseReplies parses comment.RawReplies into Replies and MoreChildrenIDs, leaving each
+	// reply's own replies unparsed.
+	ParseReplies(ctx context.Context, comment *Comment) error
+}
+
+// RepliesLoaded reports whether the comment's replies have been parsed into Replies.
+// It is false only for comments loaded with CommentsRequest.LazyReplies that have replies.
+func (c *Comment) RepliesLoaded() bool {
+	return len(c.RawReplies) == 0
+}
+
+// LoadReplies parses the comment's RawReplies with p and releases them. Each reply keeps
+// its own replies unparsed, so a thread can be walked one level at a time. It does nothing
+// if the replies are already loaded. It is not safe to call concurrently on one comment.
+func (c *Comment) LoadReplies(ctx context.Context, p RepliesParser) error {
+	if c.RepliesLoaded() {
+		return nil
+	}
+	if err := p.ParseReplies(ctx, c); err != nil {
+		return err
+	}
+	c.RawReplies = nil
+	return nil
+}
+
+// ScoreKnown reports whether the comment's score is meaningful. While a subreddit hides
+// scores on new comments (see SubredditData.CommentScoreHideMins), Reddit reports a
+// placeholder score of 1 and sets ScoreHidden.
+func (c *Comment) ScoreKnown() bool {
+	return !c.ScoreHidden
+}
+
+// ScoreVisibleAt returns when the comment's score becomes visible, given the subreddit's
+// CommentScoreHideMins. It returns the creation time when scores are not hidden.
+func (c *Comment) ScoreVisibleAt(hideMins int) time.Time {
+	created := time.Unix(int64(c.CreatedUTC), 0).UTC()
+	if hideMins <= 0 {
+		return created
+	}
+	return created.Add(time.Duration(hideMins) * time.Minute)
+}
+
+// Known values of Comment.CollapsedReasonCode.
+const (
+	CollapsedReasonLowScore      = "LOW_SCORE"
+	CollapsedReasonDeleted       = "DELETED"
+	CollapsedReasonBlockedAuthor = "BLOCKED_AUTHOR"
+	CollapsedReasonCrowdControl  = "CROWD_CONTROL"
+)
+
+// IsCrowdControlled reports whether the comment was collapsed by the subreddit's crowd control filter.
+func (c *Comment) IsCrowdControlled() bool {
+	if c.CollapsedBecauseCrowdControl != nil && *c.CollapsedBecauseCrowdControl {
+		return true
+	}
+	return c.CollapsedReasonCode != nil && *c.CollapsedReasonCode == CollapsedReasonCrowdControl
+}
+
+// CollapsedComments returns every collapsed comment in the tree, in depth-first order.
+// Replies of collapsed comments are searched as well.
+func CollapsedComments(comments []*Comment) []*Comment {
+	var collapsed []*Comment
+	var walk func([]*Comment)
+	walk = func(list []*Comment) {
+		for _, c := range list {
+			if c == nil {
+				continue
+			}
+			if c.Collapsed {
+				collapsed = append(collapsed, c)
+			}
+			walk(c.Replies)
+		}
+	}
+	walk(comments)
+	return collapsed
+}
+
+// WithoutCollapsed returns a copy of the comment tree with collapsed comments and their
+// replies removed. The input comments are not modified.
+func WithoutCollapsed(comments []*Comment) []*Comment {
+	visible := make([]*Comment, 0, len(comments))
+	for _, c := range comments {
+		if c == nil || c.Collapsed {
+			continue
+		}
+		clone := *c
+		if len(c.Replies) > 0 {
+			clone.Replies = WithoutCollapsed(c.Replies)
+		}
+		visible = append(visible, &clone)
+	}
+	return visible
+}
+
+// InfoResponse contains the posts and comments returned when looking items up by fullname.
+type InfoResponse struct {
+	Posts    []*Post
+	Comments []*Comment
+
+	// RateLimit is the rate-limit state reported with the response, or nil if Reddit sent none.
+	RateLimit *RateLimitInfo
+	// Meta holds the response's diagnostic headers when Config.CaptureHeaders is enabled.
+	Meta *ResponseMeta
+}
+
+// ShareLink is a resolved Reddit share or short link.
+type ShareLink struct {
+	// URL is the link as given by the caller.
+	URL string
+	// Permalink is the canonical https://www.reddit.com URL the link points to.
+	Permalink string
+	// Subreddit is the post's subreddit. It is empty for redd.it short links,
+	// which Reddit resolves without naming the subreddit.
+	Subreddit string
+	// PostID is the ID of the linked post, without the "t3_" prefix.
+	PostID string
+	// CommentID is the ID of the linked comment, if the link points at one.
+	CommentID string
+}
+
+// StreamMode selects how a post stream asks Reddit for new posts.
+type StreamMode string
+
+const (
+	// StreamModeBefore requests only posts newer than the newest one already seen, using
+	// before=<fullname>. This is Reddit's recommended approach: responses are small and
+	// posts arrive in order even on busy subreddits.
+	StreamModeBefore StreamMode = "before"
+	// StreamModeRefetch re-fetches the newest page on every poll and skips posts already seen.
+	StreamModeRefetch StreamMode = "refetch"
+)
+
+// StreamRequest describes a subreddit to stream new posts from.
+type StreamRequest struct {
+	Subreddit string
+
+	// Interval is the time between polls. Defaults to 30 seconds when zero.
+	Interval time.Duration
+
+	// Mode selects the polling strategy. Defaults to StreamModeBefore when empty.
+	Mode StreamMode
+
+	// Limit is the number of posts requested per poll (max 100). Defaults to 100 when zero.
+	Limit int
+
+	// MaxInterval enables adaptive polling: the interval follows the subreddit's post
+	// arrival rate, between MinInterval and MaxInterval, starting from Interval. Quiet
+	// subreddits are polled less often and busy ones more often. Zero keeps the interval
+	// fixed.
+	MaxInterval time.Duration
+	// MinInterval is the shortest adaptive interval. Defaults to 5 seconds when zero.
+	MinInterval time.Duration
+}
+
+// SyncState is the position of an incremental listing sync, as updated by SyncListing.
+// Persist it between runs; the zero value starts a new sync.
+type SyncState struct {
+	// Watermark is the fullname of the newest post synced so far.
+	Watermark string `json:"watermark,omitempty"`
+	// LastSync is when the state was last updated.
+	LastSync time.Time `json:"last_sync,omitempty"`
+}
+
+// SyncResult reports the posts a SyncListing call found.
+type SyncResult struct {
+	// Added are the posts newer than the previous watermark, oldest first.
+	Added []*Post
+	// Truncated is set when the gap since the previous sync was too large to page through
+	// completely, so some new posts may have been missed.
+	Truncated bool
+}
+
+// EditWatchRequest describes a set of comments to monitor for edits.
+type EditWatchRequest struct {
+	// CommentIDs are the comments to watch, as fullnames (e.g. "t1_abc123").
+	CommentIDs []string
+
+	// Interval is the time between re-fetches. Defaults to one minute when zero.
+	Interval time.Duration
+}
+
+// EditEvent reports that a watched comment changed between two fetches.
+type EditEvent struct {
+	// Comment is the comment as most recently fetched.
+	Comment *Comment
+	// Before is the body from the previous fetch. It equals After when Reddit reported
+	// a newer edit time but the body is unchanged (e.g. an edit that was reverted).
+	Before string
+	// After is the body from the most recent fetch.
+	After string
+	// EditedAt is the edit time Reddit reported, or the zero time if unknown.
+	EditedAt time.Time
+}
+
+// ScoreSample is one observation of a post's or comment's score, recorded by SampleScores.
+type ScoreSample struct {
+	Fullname string
+	// Time is when the item was fetched.
+	Time  time.Time
+	Score int
+	// NumComments is the post's comment count; it is always zero for comments.
+	NumComments int
+	// UpvoteRatio is the post's upvote ratio; it is always zero for comments.
+	UpvoteRatio float64
+}
+
+// SubredditStats is one observation of a subreddit's size, recorded by TrackSubscriberCount.
+type SubredditStats struct {
+	Subreddit string
+	// Time is when the subreddit was fetched.
+	Time        time.Time
+	Subscribers int64
+	// ActiveUsers is Reddit's (fuzzed) count of users currently viewing the subreddit.
+	ActiveUsers int
+}
+
+// OverviewKind identifies which field of an OverviewItem is set.
+type OverviewKind string
+
+const (
+	OverviewPost    OverviewKind = "t3"
+	OverviewComment OverviewKind = "t1"
+)
+
+// OverviewItem is one entry of a user's overview, which mixes posts and comments. Exactly
+// one of Post and Comment is set, as indicated by Kind.
+type OverviewItem struct {
+	Kind    OverviewKind
+	Post    *Post
+	Comment *Comment
+}
+
+// Fullname returns the item's fullname, e.g. "t3_abc123".
+func (i OverviewItem) Fullname() string {
+	switch {
+	case i.Post != nil:
+		return i.Post.Name
+	case i.Comment != nil:
+		return i.Comment.Name
+	}
+	return ""
+}
+
+// CreatedAt returns when the item was created.
+func (i OverviewItem) CreatedAt() time.Time {
+	switch {
+	case i.Post != nil:
+		return time.Unix(int64(i.Post.CreatedUTC), 0).UTC()
+	case i.Comment != nil:
+		return time.Unix(int64(i.Comment.CreatedUTC), 0).UTC()
+	}
+	return time.Time{}
+}
+
+// UserActivity summarizes a user's posts and comments over a period, as computed by
+// AggregateUserActivity. Times are in UTC.
+type UserActivity struct {
+	Username string
+	// Since is the start of the period; items created before it are not counted.
+	Since time.Time
+	// Newest and Oldest are the creation times of the newest and oldest items counted.
+	// Both are zero if nothing was counted.
+	Newest time.Time
+	Oldest time.Time
+
+	Posts    int
+	Comments int
+	// PostKarma and CommentKarma sum the scores of the counted posts and comments.
+	// Comments whose score Reddit still hides are counted but add nothing.
+	PostKarma    int
+	CommentKarma int
+
+	// Subreddits holds per-subreddit totals, most active first.
+	Subreddits []SubredditActivity
+	// ByHour counts items by hour of day (0-23) and ByWeekday by day of week (time.Sunday = 0).
+	ByHour    [24]int
+	ByWeekday [7]int
+
+	// Truncated reports that Reddit's listing ended before reaching Since. Reddit only lists
+	// about the newest 1000 items of a user, so older activity is missing from the summary.
+	Truncated bool
+}
+
+// SubredditActivity is a user's activity in one subreddit.
+type SubredditActivity struct {
+	Subreddit    string
+	Posts        int
+	Comments     int
+	PostKarma    int
+	CommentKarma int
+}
+
+// Total returns the number of posts and comments.
+func (a SubredditActivity) Total() int {
+	return a.Posts + a.Comments
+}
+
+// Listing is one page of a Reddit listing with its pagination cursors. It gives every
+// listing the same shape, whatever the item type: Listing[*Post], Listing[*Comment],
+// Listing[BannedUser], and so on. Every listing method returns one, the post and comment
+// methods through their ...Listing forms (GetHotListing, GetCommentsListing, ...).
+type Listing[T any] struct {
+	Items  []T
+	After  string // Reddit fullname of the last item, for the next page; empty on the last page
+	Before string // Reddit fullname of the first item, for the previous page
+
+	// RateLimit is the rate-limit state reported with the page, or nil if Reddit sent none.
+	RateLimit *RateLimitInfo
+	// Meta holds the page's diagnostic headers when Config.CaptureHeaders is enabled.
+	Meta *ResponseMeta
+}
+
+// Len returns the number of items on the page.
+func (l *Listing[T]) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.Items)
+}
+
+// HasNext reports whether there is a next page.
+func (l *Listing[T]) HasNext() bool {
+	return l != nil && l.After != ""
+}
+
+// NextPage returns p positioned at the page after this one.
+func (l *Listing[T]) NextPage(p Pagination) Pagination {
+	p.Before = ""
+	if l != nil {
+		p.After = l.After
+	}
+	return p
+}
+
+// PreviousPage returns p positioned at the page before this one.
+func (l *Listing[T]) PreviousPage(p Pagination) Pagination {
+	p.After = ""
+	if l != nil {
+		p.Before = l.Before
+	}
+	return p
+}
+
+// RateLimitInfo is Reddit's rate-limit state as reported in the X-Ratelimit-* headers of a
+// response. When a call makes several requests, it describes the last one.
+type RateLimitInfo struct {
+	// Used is the number of requests made in the current period.
+	Used int
+	// Remaining is the number of requests left in the current period.
+	Remaining float64
+	// ResetAt is when the current period ends.
+	ResetAt time.Time
+}
+
+// ResponseMeta holds diagnostic headers of the response a result came from, for support
+// requests to Reddit and for correlating calls with traces. It is only set when
+// Config.CaptureHeaders is enabled.
+type ResponseMeta struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Loid is Reddit's logged-out visitor ID (x-reddit-loid).
+	Loid string
+	// RequestID identifies the request in Reddit's logs (x-request-id).
+	RequestID string
+	// CacheStatus is the CDN cache result (x-cache), e.g. "MISS" or "HIT".
+	CacheStatus string
+	// ServedBy names the CDN nodes that handled the request (x-served-by).
+	ServedBy string
+	// Header holds every captured header, including the ones above.
+	Header http.Header
+}
+
+// NewResponseMeta builds a ResponseMeta from the named headers of a response. Headers the
+// response does not carry are left out.
+func NewResponseMeta(statusCode int, h http.Header, names []string) *ResponseMeta {
+	meta := &ResponseMeta{StatusCode: statusCode, Header: http.Header{}}
+	for _, name := range names {
+		if values := h.Values(name); len(values) > 0 {
+			meta.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+		}
+	}
+	meta.Loid = meta.Header.Get("X-Reddit-Loid")
+	meta.RequestID = meta.Header.Get("X-Request-Id")
+	meta.CacheStatus = meta.Header.Get("X-Cache")
+	meta.ServedBy = meta.Header.Get("X-Served-By")
+	return meta
+}
+
+// RequestTiming breaks down the time one HTTP request took, as observed by net/http/httptrace.
+// Phases that did not happen are zero; DNS, Connect, and TLS are zero on a reused connection.
+type RequestTiming struct {
+	// DNS is the time spent resolving the host.
+	DNS time.Duration
+	// Connect is the time spent opening the TCP connection.
+	Connect time.Duration
+	// TLS is the time spent on the TLS handshake.
+	TLS time.Duration
+	// ConnReused reports whether the request was sent on a previously opened connection.
+	ConnReused bool
+	// Wait is the time from the request being written to the first response byte: Reddit's
+	// processing time plus one network round trip.
+	Wait time.Duration
+	// TimeToFirstByte is the time from the start of the request to the first response byte.
+	TimeToFirstByte time.Duration
+	// Total is the time from the start of the request until the response body was read or
+	// the request failed.
+	Total time.Duration
+}
+
+// Network returns the time spent setting up the connection: DNS, Connect, and TLS.
+func (t *RequestTiming) Network() time.Duration {
+	return t.DNS + t.Connect + t.TLS
+}
+
+// RequestTimingTotals sums the RequestTiming of many requests. Its fields only grow, so
+// export them as counters and compare their rates.
+type RequestTimingTotals struct {
+	// Requests is the number of requests timed.
+	Requests int64
+	// ReusedConns is the number of those requests sent on a previously opened connection.
+	ReusedConns int64
+	// DNS, Connect, TLS, Wait, and Total sum the fields of the same name of each request.
+	DNS, Connect, TLS, Wait, Total time.Duration
 }
 
 // PostsResponse represents a collection of posts from a subreddit with pagination info.
@@ -396,6 +1710,42 @@ type PostsResponse struct {
 	Posts          []*Post
 	AfterFullname  string // Reddit fullname (e.g. "t3_abc123") of last item for next page
 	BeforeFullname string // Reddit fullname (e.g. "t3_abc123") of first item for prev page
+
+	// RateLimit is the rate-limit state reported with the response, or nil if Reddit sent none.
+	RateLimit *RateLimitInfo
+	// Meta holds the response's diagnostic headers when Config.CaptureHeaders is enabled.
+	Meta *ResponseMeta
+}
+
+// Listing returns the posts as a generic Listing.
+func (r *PostsResponse) Listing() *Listing[*Post] {
+	if r == nil {
+		return &Listing[*Post]{}
+	}
+	return &Listing[*Post]{Items: r.Posts, After: r.AfterFullname, Before: r.BeforeFullname, RateLimit: r.RateLimit, Meta: r.Meta}
+}
+
+// SearchResponse is a page of search results. Only the slice for the request's Type is set.
+type SearchResponse struct {
+	Posts          []*Post
+	Subreddits     []*SubredditData
+	Users          []*AccountData
+	AfterFullname  string // Reddit fullname of the last result, for the next page
+	BeforeFullname string // Reddit fullname of the first result, for the previous page
+
+	// Query is the query sent to Reddit, after trimming.
+	Query string
+	// Type is what was searched; SearchTypePosts when the request left it empty.
+	Type SearchType
+	// Sort is the order the results are in; "relevance" when the request left it empty.
+	Sort string
+	// NumFound is the total number of matches, or nil when Reddit does not report it.
+	NumFound *int
+
+	// RateLimit is the rate-limit state reported with the response, or nil if Reddit sent none.
+	RateLimit *RateLimitInfo
+	// Meta holds the response's diagnostic headers when Config.CaptureHeaders is enabled.
+	Meta *ResponseMeta
 }
 
 // CommentsResponse represents a post with its comments and more IDs for loading truncated comments.
@@ -405,4 +1755,32 @@ type CommentsResponse struct {
 	MoreIDs        []string // IDs of additional comments that can be loaded
 	AfterFullname  string   // Reddit fullname (e.g. "t1_abc123") of last comment for next page
 	BeforeFullname string   // Reddit fullname (e.g. "t1_abc123") of first comment for prev page
+
+	// ContinueThreadLinks lists the threads Reddit cut off for depth, in tree order.
+	// Their replies are not in MoreIDs: morechildren cannot expand them.
+	ContinueThreadLinks []Permalink
+
+	// Partial is set when one half of Reddit's response, the post listing or the comments
+	// listing, could not be parsed and the other half is returned alone. PostErr or
+	// CommentsErr says what failed.
+	Partial bool
+	// PostErr is why the post listing could not be parsed, with Partial set; Post is nil.
+	PostErr error
+	// CommentsErr is why the comments listing could not be parsed, with Partial set; Comments
+	// and MoreIDs are empty.
+	CommentsErr error
+
+	// RateLimit is the rate-limit state reported with the response, or nil if Reddit sent none.
+	RateLimit *RateLimitInfo
+	// Meta holds the response's diagnostic headers when Config.CaptureHeaders is enabled.
+	Meta *ResponseMeta
+}
+
+// Listing returns the top-level comments as a generic Listing. Replies stay attached to
+// their parents.
+func (r *CommentsResponse) Listing() *Listing[*Comment] {
+	if r == nil {
+		return &Listing[*Comment]{}
+	}
+	return &Listing[*Comment]{Items: r.Comments, After: r.AfterFullname, Before: r.BeforeFullname, RateLimit: r.RateLimit, Meta: r.Meta}
 }
@@ -1,8 +1,10 @@
 package types
 
 import (
+	"cmp"
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestEdited_UnmarshalJSON(t *testing.T) {
@@ -196,3 +198,288 @@ func TestMoreCommentsRequest(t *testing.T) {
 		t.Errorf("MoreCommentsRequest.Sort = %v, want %v", mcr.Sort, "confidence")
 	}
 }
+
+func TestComment_CollapsedFields(t *testing.T) {
+	data := []byte(`{"id":"c1","collapsed":true,"collapsed_reason":"comment score below threshold","collapsed_reason_code":"LOW_SCORE","collapsed_because_crowd_control":null}`)
+	var c Comment
+	if err := json.Unmarshal(data, &c); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if !c.Collapsed {
+		t.Error("Comment.Collapsed = false, want true")
+	}
+	if c.CollapsedReasonCode == nil || *c.CollapsedReasonCode != CollapsedReasonLowScore {
+		t.Errorf("Comment.CollapsedReasonCode = %v, want %v", c.CollapsedReasonCode, CollapsedReasonLowScore)
+	}
+	if c.IsCrowdControlled() {
+		t.Error("IsCrowdControlled() = true, want false")
+	}
+
+	crowd := true
+	c.CollapsedBecauseCrowdControl = &crowd
+	if !c.IsCrowdControlled() {
+		t.Error("IsCrowdControlled() = false, want true")
+	}
+}
+
+func TestCollapsedCommentHelpers(t *testing.T) {
+	hiddenReply := &Comment{ThingData: ThingData{ID: "r2"}, Collapsed: true}
+	visibleReply := &Comment{ThingData: ThingData{ID: "r1"}}
+	collapsedTop := &Comment{ThingData: ThingData{ID: "c2"}, Collapsed: true, Replies: []*Comment{{ThingData: ThingData{ID: "r3"}}}}
+	top := &Comment{ThingData: ThingData{ID: "c1"}, Replies: []*Comment{visibleReply, hiddenReply}}
+	comments := []*Comment{top, collapsedTop}
+
+	collapsed := CollapsedComments(comments)
+	if len(collapsed) != 2 || collapsed[0].ID != "r2" || collapsed[1].ID != "c2" {
+		t.Errorf("CollapsedComments() returned %d comments, want r2 and c2", len(collapsed))
+	}
+
+	visible := WithoutCollapsed(comments)
+	if len(visible) != 1 || visible[0].ID != "c1" {
+		t.Fatalf("WithoutCollapsed() = %d comments, want only c1", len(visible))
+	}
+	if len(visible[0].Replies) != 1 || visible[0].Replies[0].ID != "r1" {
+		t.Errorf("WithoutCollapsed() replies = %d, want only r1", len(visible[0].Replies))
+	}
+	if len(top.Replies) != 2 {
+		t.Errorf("WithoutCollapsed() modified input replies, got %d want 2", len(top.Replies))
+	}
+}
+
+func TestEdited_Time(t *testing.T) {
+	tests := []struct {
+		name   string
+		edited Edited
+		want   time.Time
+		wantOK bool
+	}{
+		{name: "not edited", edited: Edited{}, want: time.Time{}},
+		{name: "legacy boolean", edited: Edited{IsEdited: true}, want: time.Time{}},
+		{name: "timestamp", edited: Edited{IsEdited: true, Timestamp: 1700000000.5}, want: time.Unix(1700000000, 500000000), wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := tt.edited.Time(); !got.Equal(tt.want) || ok != tt.wantOK {
+				t.Errorf("Edited.Time() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestEdited_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		edited Edited
+		want   string
+	}{
+		{edited: Edited{}, want: "false"},
+		{edited: Edited{IsEdited: true}, want: "true"},
+		{edited: Edited{IsEdited: true, Timestamp: 1700000000.25}, want: "1700000000.25"},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.edited)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("Marshal(%+v) = %s, %v, want %s", tt.edited, data, err, tt.want)
+			continue
+		}
+		var back Edited
+		if err := json.Unmarshal(data, &back); err != nil || back != tt.edited {
+			t.Errorf("round trip of %+v = %+v, %v", tt.edited, back, err)
+		}
+	}
+}
+
+func TestEdited_Compare(t *testing.T) {
+	unedited := Edited{}
+	legacy := Edited{IsEdited: true}
+	earlier := EditedAt(time.Unix(1700000000, 0))
+	later := EditedAt(time.Unix(1700000600, 0))
+
+	ordered := []Edited{unedited, legacy, earlier, later}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			if got, want := a.Compare(b), cmp.Compare(i, j); got != want {
+				t.Errorf("%+v.Compare(%+v) = %d, want %d", a, b, got, want)
+			}
+			if a.After(b) != (i > j) || a.Equal(b) != (i == j) {
+				t.Errorf("%+v.After/Equal(%+v) disagree with Compare", a, b)
+			}
+		}
+	}
+
+	if got := EditedAt(time.Time{}); got != unedited {
+		t.Errorf("EditedAt(zero) = %+v, want unedited", got)
+	}
+	if at, ok := later.Time(); !ok || !at.Equal(time.Unix(1700000600, 0)) {
+		t.Errorf("EditedAt round trip = %v, %v", at, ok)
+	}
+}
+
+func TestWidget_UnmarshalJSON(t *testing.T) {
+	var w Widget
+	err := json.Unmarshal([]byte(`{"id":"w1","kind":"subreddit-rules","data":{"not":"a list"}}`), &w)
+	if err == nil {
+		t.Error("expected error for malformed rules data")
+	}
+
+	w = Widget{}
+	if err := json.Unmarshal([]byte(`{"id":"w2","kind":"custom","data":{"css":"x"}}`), &w); err != nil {
+		t.Fatalf("Unmarshal returned error: %v", err)
+	}
+	if w.Rules != nil || w.Communities != nil || w.Events != nil {
+		t.Error("untyped widget kinds should only populate Data")
+	}
+	if string(w.Data) != `{"css":"x"}` {
+		t.Errorf("Widget.Data = %s, want raw data", w.Data)
+	}
+}
+
+func TestComment_ScoreKnown(t *testing.T) {
+	created := Created{Created: 1700000000, CreatedUTC: 1700000000}
+	hidden := &Comment{Created: created, ScoreHidden: true, Votable: Votable{Score: 1, Ups: 1}}
+	visible := &Comment{Created: created, Votable: Votable{Score: 42, Ups: 42}}
+
+	if hidden.ScoreKnown() {
+		t.Error("ScoreKnown() = true for hidden score, want false")
+	}
+	if !visible.ScoreKnown() {
+		t.Error("ScoreKnown() = false for visible score, want true")
+	}
+
+	want := time.Unix(1700000000, 0).Add(90 * time.Minute)
+	if got := hidden.ScoreVisibleAt(90); !got.Equal(want) {
+		t.Errorf("ScoreVisibleAt(90) = %v, want %v", got, want)
+	}
+	if got := visible.ScoreVisibleAt(0); !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("ScoreVisibleAt(0) = %v, want creation time", got)
+	}
+}
+
+func TestListing(t *testing.T) {
+	page := &Listing[*Post]{Items: []*Post{{ThingData: ThingData{ID: "a"}}, {ThingData: ThingData{ID: "b"}}}, After: "t3_b", Before: "t3_a"}
+	if page.Len() != 2 || !page.HasNext() {
+		t.Errorf("Len = %d, HasNext = %v", page.Len(), page.HasNext())
+	}
+
+	next := page.NextPage(Pagination{Limit: 10, Before: "t3_z"})
+	if next != (Pagination{Limit: 10, After: "t3_b"}) {
+		t.Errorf("NextPage = %+v", next)
+	}
+	prev := page.PreviousPage(Pagination{Limit: 10, After: "t3_z"})
+	if prev != (Pagination{Limit: 10, Before: "t3_a"}) {
+		t.Errorf("PreviousPage = %+v", prev)
+	}
+
+	var empty *Listing[*Comment]
+	if empty.Len() != 0 || empty.HasNext() {
+		t.Error("nil listing should be empty with no next page")
+	}
+
+	resp := &PostsResponse{Posts: page.Items, AfterFullname: "t3_b", BeforeFullname: "t3_a"}
+	if got := resp.Listing(); got.Len() != 2 || got.After != "t3_b" || got.Before != "t3_a" {
+		t.Errorf("PostsResponse.Listing() = %+v", got)
+	}
+}
+
+func TestAccountData_Status(t *testing.T) {
+	tests := []struct {
+		name    string
+		account AccountData
+		want    AccountStatus
+	}{
+		{"active", AccountData{ThingData: ThingData{ID: "1w72", Name: "spez"}}, AccountActive},
+		{"blocked is still active", AccountData{ThingData: ThingData{ID: "1w72", Name: "spez"}, IsBlocked: true}, AccountActive},
+		{"suspended", AccountData{ThingData: ThingData{Name: "someone"}, IsSuspended: true}, AccountSuspended},
+		{"deleted name", AccountData{ThingData: ThingData{Name: DeletedAuthor}}, AccountDeleted},
+		{"empty", AccountData{}, AccountDeleted},
+	}
+	for _, tt := range tests {
+		if got := tt.account.Status(); got != tt.want {
+			t.Errorf("%s: Status() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestComment_AuthorIsBlocked(t *testing.T) {
+	var c Comment
+	if err := json.Unmarshal([]byte(`{"author":"someone","author_is_blocked":true}`), &c); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !c.AuthorIsBlocked {
+		t.Error("AuthorIsBlocked = false, want true")
+	}
+}
+
+func TestPost_BestPreview(t *testing.T) {
+	var p Post
+	err := json.Unmarshal([]byte(`{"preview": {"enabled": true, "images": [{
+		"id": "abc",
+		"source": {"url": "https://preview.redd.it/abc.jpg?width=1920&amp;s=src", "width": 1920, "height": 1080},
+		"resolutions": [
+			{"url": "https://preview.redd.it/abc.jpg?width=108&amp;s=a", "width": 108, "height": 60},
+			{"url": "https://preview.redd.it/abc.jpg?width=320&amp;s=b", "width": 320, "height": 180},
+			{"url": "https://preview.redd.it/abc.jpg?width=640&amp;s=c", "width": 640, "height": 360}
+		]
+	}]}}`), &p)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		width, height int
+		wantWidth     int
+	}{
+		{name: "exact", width: 320, height: 180, wantWidth: 320},
+		{name: "between sizes", width: 300, height: 0, wantWidth: 320},
+		{name: "height only", width: 0, height: 200, wantWidth: 640},
+		{name: "tiny", width: 50, height: 50, wantWidth: 108},
+		{name: "larger than source", width: 4000, height: 3000, wantWidth: 1920},
+		{name: "full size", width: 0, height: 0, wantWidth: 1920},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.BestPreview(tt.width, tt.height)
+			if got == nil || got.Width != tt.wantWidth {
+				t.Fatalf("BestPreview(%d, %d) = %+v, want width %d", tt.width, tt.height, got, tt.wantWidth)
+			}
+		})
+	}
+
+	got := p.BestPreview(640, 360)
+	if want := "https://preview.redd.it/abc.jpg?width=640&s=c"; got.URL != want {
+		t.Errorf("URL = %q, want %q", got.URL, want)
+	}
+	if p.Preview.Images[0].Resolutions[2].URL != "https://preview.redd.it/abc.jpg?width=640&amp;s=c" {
+		t.Error("BestPreview modified the post's preview data")
+	}
+
+	if (&Post{}).BestPreview(320, 180) != nil {
+		t.Error("BestPreview() of a post without preview is not nil")
+	}
+}
+
+func TestSubredditData_NotificationsEnabled(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		wantEnabled bool
+		wantKnown   bool
+	}{
+		{name: "frequent", json: `{"notification_level":"frequent"}`, wantEnabled: true, wantKnown: true},
+		{name: "low", json: `{"notification_level":"low"}`, wantEnabled: true, wantKnown: true},
+		{name: "off", json: `{"notification_level":"off"}`, wantEnabled: false, wantKnown: true},
+		{name: "unauthenticated", json: `{"notification_level":null}`, wantEnabled: false, wantKnown: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sub SubredditData
+			if err := json.Unmarshal([]byte(tt.json), &sub); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			enabled, known := sub.NotificationsEnabled()
+			if enabled != tt.wantEnabled || known != tt.wantKnown {
+				t.Errorf("NotificationsEnabled() = %v, %v, want %v, %v", enabled, known, tt.wantEnabled, tt.wantKnown)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// Body restriction policies reported by the post requirements endpoint.
+const (
+	BodyPolicyRequired   = "required"
+	BodyPolicyNotAllowed = "notAllowed"
+	BodyPolicyNone       = "none"
+)
+
+// ValidateSubmission checks a post draft before it is sent to Reddit.
+// It applies Reddit's global limits (title and body length, URL format) and, when
+// requirements is non-nil, the subreddit-specific rules from the post requirements endpoint.
+// Title and body regexes from the requirements are not evaluated.
+//
+// All problems are collected and returned together as a *errors.ValidationError.
+func ValidateSubmission(req *types.SubmitRequest, requirements *types.PostRequirements) error {
+	verr := &pkgerrs.ValidationError{Operation: "submit"}
+	if req == nil {
+		verr.Add("request", "submit request cannot be nil")
+		return verr
+	}
+
+	// Subreddit
+	if req.Subreddit == "" {
+		verr.Add("Subreddit", "subreddit is required")
+	} else if !IsValidSubreddit(NormalizeSubreddit(req.Subreddit)) {
+		verr.Add("Subreddit", fmt.Sprintf("subreddit has invalid format: %s", req.Subreddit))
+	}
+
+	// Title
+	titleLen := utf8.RuneCountInString(req.Title)
+	if strings.TrimSpace(req.Title) == "" {
+		verr.Add("Title", "title is required")
+	} else if titleLen > types.MAX_POST_TITLE_LENGTH {
+		verr.Add("Title", fmt.Sprintf("title exceeds %d character limit (%d chars)", types.MAX_POST_TITLE_LENGTH, titleLen))
+	}
+
+	// Kind-specific content
+	switch req.Kind {
+	case types.SubmitKindSelf:
+		if bodyLen := utf8.RuneCountInString(req.Text); bodyLen > types.MAX_SELF_TEXT_LENGTH {
+			verr.Add("Text", fmt.Sprintf("text exceeds %d character limit (%d chars)", types.MAX_SELF_TEXT_LENGTH, bodyLen))
+		}
+		if req.URL != "" {
+			verr.Add("URL", "url must be empty for self posts")
+		}
+	case types.SubmitKindLink, types.SubmitKindImage:
+		if req.URL == "" {
+			verr.Add("URL", fmt.Sprintf("url is required for %s posts", req.Kind))
+		} else if err := validateSubmitURL(req.URL); err != nil {
+			verr.Add("URL", err.Error())
+		}
+		if req.Text != "" {
+			verr.Add("Text", fmt.Sprintf("text must be empty for %s posts", req.Kind))
+		}
+	case "":
+		verr.Add("Kind", "kind is required")
+	default:
+		verr.Add("Kind", fmt.Sprintf("unsupported kind: %s", req.Kind))
+	}
+
+	if requirements != nil {
+		applyPostRequirements(verr, req, requirements)
+	}
+
+	return verr.ErrOrNil()
+}
+
+// ValidateCommentDraft checks a comment before it is sent to Reddit.
+// parentFullname must be a post (t3_) or comment (t1_) fullname.
+func ValidateCommentDraft(parentFullname, body string) error {
+	verr := &pkgerrs.ValidationError{Operation: "comment"}
+
+	if parentFullname == "" {
+		verr.Add("Parent", "parent fullname is required")
+	} else if !IsValidFullname(parentFullname) {
+		verr.Add("Parent", fmt.Sprintf("parent has invalid fullname format: %s", parentFullname))
+	} else if !strings.HasPrefix(parentFullname, string(types.KIND_POST)) && !strings.HasPrefix(parentFullname, string(types.KIND_COMMENT)) {
+		verr.Add("Parent", fmt.Sprintf("parent must be a post (t3_) or comment (t1_), got %s", parentFullname[:types.PREFIX_LENGTH]))
+	}
+
+	bodyLen := utf8.RuneCountInString(body)
+	if strings.TrimSpace(body) == "" {
+		verr.Add("Body", "body is required")
+	} else if bodyLen > types.MAX_COMMENT_BODY_LENGTH {
+		verr.Add("Body", fmt.Sprintf("body exceeds %d character limit (%d chars)", types.MAX_COMMENT_BODY_LENGTH, bodyLen))
+	}
+
+	return verr.ErrOrNil()
+}
+
+// applyPostRequirements checks a draft against subreddit-specific submission rules.
+func applyPostRequirements(verr *pkgerrs.ValidationError, req *types.SubmitRequest, r *types.PostRequirements) {
+	titleLen := utf8.RuneCountInString(req.Title)
+	if r.TitleTextMinLength != nil && titleLen < *r.TitleTextMinLength {
+		verr.Add("Title", fmt.Sprintf("subreddit requires titles of at least %d characters (%d chars)", *r.TitleTextMinLength, titleLen))
+	}
+	if r.TitleTextMaxLength != nil && titleLen > *r.TitleTextMaxLength {
+		verr.Add("Title", fmt.Sprintf("subreddit limits titles to %d characters (%d chars)", *r.TitleTextMaxLength, titleLen))
+	}
+	if len(r.TitleRequiredStrings) > 0 && !containsAnyFold(req.Title, r.TitleRequiredStrings) {
+		verr.Add("Title", fmt.Sprintf("title must contain one of: %s", strings.Join(r.TitleRequiredStrings, ", ")))
+	}
+	if s, ok := firstContainedFold(req.Title, r.TitleBlacklistedStrings); ok {
+		verr.Add("Title", fmt.Sprintf("title contains disallowed text: %q", s))
+	}
+
+	if req.Kind == types.SubmitKindSelf {
+		bodyLen := utf8.RuneCountInString(req.Text)
+		switch r.BodyRestrictionPolicy {
+		case BodyPolicyRequired:
+			if strings.TrimSpace(req.Text) == "" {
+				verr.Add("Text", "subreddit requires a post body")
+			}
+		case BodyPolicyNotAllowed:
+			if req.Text != "" {
+				verr.Add("Text", "subreddit does not allow a post body")
+			}
+		}
+		if r.BodyTextMinLength != nil && req.Text != "" && bodyLen < *r.BodyTextMinLength {
+			verr.Add("Text", fmt.Sprintf("subreddit requires bodies of at least %d characters (%d chars)", *r.BodyTextMinLength, bodyLen))
+		}
+		if r.BodyTextMaxLength != nil && bodyLen > *r.BodyTextMaxLength {
+			verr.Add("Text", fmt.Sprintf("subreddit limits bodies to %d characters (%d chars)", *r.BodyTextMaxLength, bodyLen))
+		}
+		if len(r.BodyRequiredStrings) > 0 && !containsAnyFold(req.Text, r.BodyRequiredStrings) {
+			verr.Add("Text", fmt.Sprintf("body must contain one of: %s", strings.Join(r.BodyRequiredStrings, ", ")))
+		}
+		if s, ok := firstContainedFold(req.Text, r.BodyBlacklistedStrings); ok {
+			verr.Add("Text", fmt.Sprintf("body contains disallowed text: %q", s))
+		}
+	}
+
+	if req.Kind == types.SubmitKindLink && req.URL != "" {
+		if u, err := url.Parse(req.URL); err == nil && u.Hostname() != "" {
+			host := strings.ToLower(u.Hostname())
+			if matchesDomain(host, r.DomainBlacklist) {
+				verr.Add("URL", fmt.Sprintf("subreddit does not allow links to %s", host))
+			}
+			if len(r.DomainWhitelist) > 0 && !matchesDomain(host, r.DomainWhitelist) {
+				verr.Add("URL", fmt.Sprintf("subreddit only allows links to: %s", strings.Join(r.DomainWhitelist, ", ")))
+			}
+		}
+	}
+
+	if r.IsFlairRequired && req.FlairID == "" {
+		verr.Add("FlairID", "subreddit requires post flair")
+	}
+}
+
+// validateSubmitURL checks that a submission URL is an absolute http(s) URL.
+func validateSubmitURL(raw string) error {
+	if strings.ContainsAny(raw, "\r\n") {
+		return fmt.Errorf("url cannot contain newline characters")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("url is malformed: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("url must use http or https scheme, got: %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("url must have a host")
+	}
+	return nil
+}
+
+// matchesDomain reports whether host equals, or is a subdomain of, any listed domain.
+func matchesDomain(host string, domains []string) bool {
+	for _, d := range domains {
+		d = strings.ToLower(strings.TrimPrefix(d, "www."))
+		h := strings.TrimPrefix(host, "www.")
+		if h == d || strings.HasSuffix(h, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyFold(s string, subs []string) bool {
+	_, ok := firstContainedFold(s, subs)
+	return ok
+}
+
+// firstContainedFold returns the first entry of subs that appears in s, ignoring case.
+func firstContainedFold(s string, subs []string) (string, bool) {
+	lower := strings.ToLower(s)
+	for _, sub := range subs {
+		if sub != "" && strings.Contains(lower, strings.ToLower(sub)) {
+			return sub, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,166 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestValidateSubmission(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *types.SubmitRequest
+		requirements *types.PostRequirements
+		wantFields   []string
+	}{
+		{
+			name: "valid self post",
+			req:  &types.SubmitRequest{Subreddit: "golang", Title: "Hello", Kind: types.SubmitKindSelf, Text: "body"},
+		},
+		{
+			name: "valid link post",
+			req:  &types.SubmitRequest{Subreddit: "golang", Title: "Hello", Kind: types.SubmitKindLink, URL: "https://go.dev"},
+		},
+		{
+			name:       "nil request",
+			req:        nil,
+			wantFields: []string{"request"},
+		},
+		{
+			name:       "missing everything",
+			req:        &types.SubmitRequest{},
+			wantFields: []string{"Subreddit", "Title", "Kind"},
+		},
+		{
+			name:       "title too long",
+			req:        &types.SubmitRequest{Subreddit: "golang", Title: strings.Repeat("a", types.MAX_POST_TITLE_LENGTH+1), Kind: types.SubmitKindSelf},
+			wantFields: []string{"Title"},
+		},
+		{
+			name:       "self text too long",
+			req:        &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindSelf, Text: strings.Repeat("a", types.MAX_SELF_TEXT_LENGTH+1)},
+			wantFields: []string{"Text"},
+		},
+		{
+			name:       "link without url",
+			req:        &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindLink},
+			wantFields: []string{"URL"},
+		},
+		{
+			name:       "link with bad scheme",
+			req:        &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindLink, URL: "javascript:alert(1)"},
+			wantFields: []string{"URL"},
+		},
+		{
+			name:       "unsupported kind",
+			req:        &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: "video"},
+			wantFields: []string{"Kind"},
+		},
+		{
+			name:         "flair required",
+			req:          &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindSelf},
+			requirements: &types.PostRequirements{IsFlairRequired: true},
+			wantFields:   []string{"FlairID"},
+		},
+		{
+			name:         "flair provided",
+			req:          &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindSelf, FlairID: "abc"},
+			requirements: &types.PostRequirements{IsFlairRequired: true},
+		},
+		{
+			name: "subreddit title rules",
+			req:  &types.SubmitRequest{Subreddit: "golang", Title: "buy now", Kind: types.SubmitKindSelf},
+			requirements: &types.PostRequirements{
+				TitleTextMinLength:      intPtr(10),
+				TitleRequiredStrings:    []string{"[Question]"},
+				TitleBlacklistedStrings: []string{"BUY"},
+			},
+			wantFields: []string{"Title"},
+		},
+		{
+			name:         "body required",
+			req:          &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindSelf},
+			requirements: &types.PostRequirements{BodyRestrictionPolicy: BodyPolicyRequired},
+			wantFields:   []string{"Text"},
+		},
+		{
+			name:         "body not allowed",
+			req:          &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindSelf, Text: "x"},
+			requirements: &types.PostRequirements{BodyRestrictionPolicy: BodyPolicyNotAllowed},
+			wantFields:   []string{"Text"},
+		},
+		{
+			name:         "blacklisted domain",
+			req:          &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindLink, URL: "https://www.spam.example/x"},
+			requirements: &types.PostRequirements{DomainBlacklist: []string{"spam.example"}},
+			wantFields:   []string{"URL"},
+		},
+		{
+			name:         "domain not whitelisted",
+			req:          &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindLink, URL: "https://example.com"},
+			requirements: &types.PostRequirements{DomainWhitelist: []string{"github.com"}},
+			wantFields:   []string{"URL"},
+		},
+		{
+			name:         "whitelisted subdomain",
+			req:          &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindLink, URL: "https://gist.github.com/x"},
+			requirements: &types.PostRequirements{DomainWhitelist: []string{"github.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSubmission(tt.req, tt.requirements)
+			assertFieldErrors(t, err, tt.wantFields)
+		})
+	}
+}
+
+func TestValidateCommentDraft(t *testing.T) {
+	tests := []struct {
+		name       string
+		parent     string
+		body       string
+		wantFields []string
+	}{
+		{"reply to post", "t3_abc123", "hello", nil},
+		{"reply to comment", "t1_def456", "hello", nil},
+		{"missing parent", "", "hello", []string{"Parent"}},
+		{"invalid parent", "abc123", "hello", []string{"Parent"}},
+		{"wrong parent kind", "t5_abc123", "hello", []string{"Parent"}},
+		{"empty body", "t3_abc123", "   ", []string{"Body"}},
+		{"body too long", "t3_abc123", strings.Repeat("a", types.MAX_COMMENT_BODY_LENGTH+1), []string{"Body"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCommentDraft(tt.parent, tt.body)
+			assertFieldErrors(t, err, tt.wantFields)
+		})
+	}
+}
+
+func assertFieldErrors(t *testing.T, err error, wantFields []string) {
+	t.Helper()
+	if len(wantFields) == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+
+	var verr *pkgerrs.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+	}
+	for _, field := range wantFields {
+		if !verr.HasField(field) {
+			t.Errorf("expected error for field %q, got %v", field, verr)
+		}
+	}
+}
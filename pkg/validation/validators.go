@@ -39,6 +39,30 @@ func IsValidSubreddit(s string) bool {
 	return subredditRegex.MatchString(s)
 }
 
+// NormalizeSubreddit strips surrounding whitespace and slashes and an optional "r/" prefix
+// from a subreddit name, so "r/golang", "/r/golang/", and "golang" all become "golang".
+// The result is not validated; use IsValidSubreddit to check it.
+func NormalizeSubreddit(name string) string {
+	name = strings.Trim(strings.TrimSpace(name), "/")
+	if len(name) > 2 && strings.EqualFold(name[:2], "r/") {
+		name = strings.TrimLeft(name[2:], "/")
+	}
+	return name
+}
+
+// NormalizeUsername strips surrounding whitespace and slashes and an optional "u/" or
+// "user/" prefix from a username, so "u/spez", "/user/spez/", and "spez" all become "spez".
+// The result is not validated; use IsValidUsername to check it.
+func NormalizeUsername(name string) string {
+	name = strings.Trim(strings.TrimSpace(name), "/")
+	for _, prefix := range []string{"u/", "user/"} {
+		if len(name) > len(prefix) && strings.EqualFold(name[:len(prefix)], prefix) {
+			return strings.TrimLeft(name[len(prefix):], "/")
+		}
+	}
+	return name
+}
+
 // IsValidUsername checks if a string is a valid Reddit username
 func IsValidUsername(s string) bool {
 	return usernameRegex.MatchString(s)
@@ -197,7 +221,7 @@ func ValidatePost(p *types.Post) error {
 	// Validate author
 	if p.Author == "" {
 		errs = append(errs, fmt.Errorf("Author is required"))
-	} else if p.Author != "[deleted]" && !IsValidUsername(p.Author) {
+	} else if p.Author != types.DeletedAuthor && !IsValidUsername(p.Author) {
 		errs = append(errs, fmt.Errorf("Author has invalid username format: %s", p.Author))
 	}
 
@@ -273,7 +297,7 @@ func ValidateComment(c *types.Comment) error {
 	// Validate author
 	if c.Author == "" {
 		errs = append(errs, fmt.Errorf("Author is required"))
-	} else if c.Author != "[deleted]" && !IsValidUsername(c.Author) {
+	} else if c.Author != types.DeletedAuthor && !IsValidUsername(c.Author) {
 		errs = append(errs, fmt.Errorf("Author has invalid username format: %s", c.Author))
 	}
 
@@ -355,7 +379,7 @@ func ValidateMessageData(m *types.MessageData) error {
 	// Validate author
 	if m.Author == "" {
 		errs = append(errs, fmt.Errorf("Author is required"))
-	} else if m.Author != "[deleted]" && !IsValidUsername(m.Author) {
+	} else if m.Author != types.DeletedAuthor && !IsValidUsername(m.Author) {
 		errs = append(errs, fmt.Errorf("Author has invalid username format: %s", m.Author))
 	}
 
@@ -384,9 +408,23 @@ func ValidateAccountData(a *types.AccountData) error {
 
 	var errs []error
 
-	// Validate embedded structs
-	if err := ValidateThingData(&a.ThingData); err != nil {
-		errs = append(errs, err)
+	// Suspended and deleted accounts come back without an ID, creation time, or karma.
+	if a.Status() != types.AccountActive {
+		if a.Name != "" && a.Name != types.DeletedAuthor && !IsValidUsername(a.Name) {
+			return fmt.Errorf("account validation failed: Name has invalid username format: %s", a.Name)
+		}
+		return nil
+	}
+
+	// Validate embedded structs. An account's name is its username, though some
+	// responses use the fullname instead.
+	if a.ID == "" {
+		errs = append(errs, fmt.Errorf("ID is required"))
+	} else if !IsValidBase36(a.ID) {
+		errs = append(errs, fmt.Errorf("ID has invalid format: %s", a.ID))
+	}
+	if a.Name != "" && !IsValidUsername(a.Name) && !IsValidFullname(a.Name) {
+		errs = append(errs, fmt.Errorf("Name has invalid username format: %s", a.Name))
 	}
 
 	if err := ValidateCreated(&a.Created); err != nil {
@@ -417,9 +455,14 @@ func ValidateMoreData(m *types.MoreData) error {
 
 	var errs []error
 
-	// Validate embedded ThingData
-	if err := ValidateThingData(&m.ThingData); err != nil {
-		errs = append(errs, err)
+	// Validate embedded ThingData. Continue-thread markers have the placeholder ID "_".
+	if m.ID != types.ContinueThreadID {
+		if err := ValidateThingData(&m.ThingData); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if m.ParentID != "" && !IsValidFullname(m.ParentID) {
+		errs = append(errs, fmt.Errorf("ParentID has invalid fullname format: %s", m.ParentID))
 	}
 
 	// Validate children IDs
@@ -59,6 +59,55 @@ func TestIsValidSubreddit(t *testing.T) {
 	}
 }
 
+func TestNormalizeSubreddit(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"golang", "golang"},
+		{"r/golang", "golang"},
+		{"/r/golang", "golang"},
+		{"/r/golang/", "golang"},
+		{"R/golang", "golang"},
+		{"  r/golang  ", "golang"},
+		{"r/", "r"},
+		{"", ""},
+		{"rust", "rust"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeSubreddit(tt.input); got != tt.want {
+				t.Errorf("NormalizeSubreddit(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeUsername(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"spez", "spez"},
+		{"u/spez", "spez"},
+		{"/u/spez/", "spez"},
+		{"U/spez", "spez"},
+		{"/user/spez", "spez"},
+		{"  u/spez  ", "spez"},
+		{"u/", "u"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeUsername(tt.input); got != tt.want {
+				t.Errorf("NormalizeUsername(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsValidUsername(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -0,0 +1,113 @@
+package graw
+
+import (
+	"context"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// CommentSortQA is the comment sort Reddit uses for Q&A threads such as AMAs: comments the
+// post's author replied to come first.
+const CommentSortQA = "qa"
+
+// QAPair is a comment together with the post author's replies to it.
+type QAPair struct {
+	// Question is the comment the author replied to.
+	Question *types.Comment
+	// Answers are the author's direct replies to Question, in thread order.
+	Answers []*types.Comment
+}
+
+// QAThread is the question-and-answer view of an interview-style thread.
+type QAThread struct {
+	// Post is the thread's post. It is nil if the comments were extracted without one.
+	Post *types.Post
+	// OP is the username of the post's author, whose replies count as answers.
+	OP string
+	// Pairs lists every comment the OP replied to, with the replies, in thread order.
+	Pairs []QAPair
+	// Answers lists every comment by the OP, in thread order, including replies to their own
+	// comments and top-level comments, which belong to no pair.
+	Answers []*types.Comment
+	// MoreIDs are IDs of comments the thread did not load. Questions or answers among them
+	// are missing until they are fetched with GetMoreComments.
+	MoreIDs []string
+}
+
+// QAExtract pairs the questions in a thread with the answers of the post's author, for
+// analysing AMAs and other interview-style threads. comments may be a comment tree, as
+// returned by GetComments, or a flat slice such as one from GetMoreComments; replies not yet
+// loaded with LoadReplies are not searched.
+//
+// A comment is an answer if the post's author wrote it; when its parent is someone else's
+// comment, the parent is the question. Usernames are compared case-insensitively. If post is
+// nil, or its author is deleted, the thread has no OP and no pairs.
+func QAExtract(post *types.Post, comments []*types.Comment) *QAThread {
+	thread := &QAThread{Post: post}
+	if post == nil || post.Author == "" || post.Author == types.DeletedAuthor {
+		return thread
+	}
+	thread.OP = post.Author
+
+	// Index every comment once, so a flattened tree passed in whole is not counted twice.
+	var ordered []*types.Comment
+	byName := make(map[string]*types.Comment)
+	for _, c := range flattenComments(comments) {
+		if _, seen := byName[c.Name]; seen && c.Name != "" {
+			continue
+		}
+		byName[c.Name] = c
+		ordered = append(ordered, c)
+		thread.MoreIDs = append(thread.MoreIDs, c.MoreChildrenIDs...)
+	}
+
+	pairs := make(map[string]int) // question name -> index in thread.Pairs
+	for _, c := range ordered {
+		if !strings.EqualFold(c.Author, thread.OP) {
+			continue
+		}
+		thread.Answers = append(thread.Answers, c)
+		question, ok := byName[c.ParentID]
+		if !ok || strings.EqualFold(question.Author, thread.OP) {
+			continue
+		}
+		if i, ok := pairs[question.Name]; ok {
+			thread.Pairs[i].Answers = append(thread.Pairs[i].Answers, c)
+			continue
+		}
+		pairs[question.Name] = len(thread.Pairs)
+		thread.Pairs = append(thread.Pairs, QAPair{Question: question, Answers: []*types.Comment{c}})
+	}
+	return thread
+}
+
+// GetQA fetches a Q&A thread such as an AMA and pairs its questions with the answers of the
+// post's author. The comments are fetched in CommentSortQA order and the post is always
+// fetched, since its author identifies the answers; request's Sort, RequirePost, and
+// LazyReplies are ignored. request is not modified.
+//
+// Answers in comment trees Reddit truncated are only found once the comments listed in
+// QAThread.MoreIDs are loaded; pass them to GetMoreComments and QAExtract the combined
+// comments.
+//
+// Returns an error if request is invalid or the request fails.
+func (r *Reddit) GetQA(ctx context.Context, request *types.CommentsRequest) (*QAThread, error) {
+	if request == nil {
+		return nil, &pkgerrs.ConfigError{Message: "comments request cannot be nil"}
+	}
+	qaRequest := *request
+	qaRequest.Sort = CommentSortQA
+	qaRequest.RequirePost = true
+	qaRequest.LazyReplies = false
+
+	resp, err := r.GetComments(ctx, &qaRequest)
+	if err != nil {
+		return nil, err
+	}
+	thread := QAExtract(resp.Post, resp.Comments)
+	// The response's MoreIDs also cover truncated top-level comments.
+	thread.MoreIDs = resp.MoreIDs
+	return thread, nil
+}
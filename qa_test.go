@@ -0,0 +1,107 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// qaComment returns a comment by author with the given replies.
+func qaComment(id, author, parent string, replies ...*types.Comment) *types.Comment {
+	return &types.Comment{ThingData: types.ThingData{ID: id, Name: "t1_" + id}, Author: author, ParentID: parent, Replies: replies}
+}
+
+// commentIDs returns the IDs of comments.
+func commentIDs(comments []*types.Comment) []string {
+	ids := make([]string, len(comments))
+	for i, c := range comments {
+		ids[i] = c.ID
+	}
+	return ids
+}
+
+func TestQAExtract(t *testing.T) {
+	post := &types.Post{ThingData: types.ThingData{ID: "p1", Name: "t3_p1"}, Author: "AmaHost"}
+	selfReply := qaComment("a4", "AmaHost", "t1_c3")
+	selfReply.MoreChildrenIDs = []string{"m1"}
+	tree := []*types.Comment{
+		qaComment("c1", "alice", "t3_p1",
+			qaComment("a1", "amahost", "t1_c1",
+				qaComment("f1", "bob", "t1_a1",
+					qaComment("a3", "AmaHost", "t1_f1"))),
+			qaComment("a2", "AmaHost", "t1_c1")),
+		qaComment("c2", "carol", "t3_p1"),
+		qaComment("c3", "AmaHost", "t3_p1", selfReply),
+	}
+
+	for name, comments := range map[string][]*types.Comment{"tree": tree, "flattened": flattenComments(tree)} {
+		t.Run(name, func(t *testing.T) {
+			thread := QAExtract(post, comments)
+			if thread.OP != "AmaHost" || thread.Post != post {
+				t.Errorf("OP = %q, Post = %v", thread.OP, thread.Post)
+			}
+			if len(thread.Pairs) != 2 {
+				t.Fatalf("got %d pairs, want 2", len(thread.Pairs))
+			}
+			if q, a := thread.Pairs[0].Question.ID, commentIDs(thread.Pairs[0].Answers); q != "c1" || !slices.Equal(a, []string{"a1", "a2"}) {
+				t.Errorf("pair 0 = %s: %v, want c1: [a1 a2]", q, a)
+			}
+			if q, a := thread.Pairs[1].Question.ID, commentIDs(thread.Pairs[1].Answers); q != "f1" || !slices.Equal(a, []string{"a3"}) {
+				t.Errorf("pair 1 = %s: %v, want f1: [a3]", q, a)
+			}
+			if got, want := commentIDs(thread.Answers), []string{"a1", "a3", "a2", "c3", "a4"}; !slices.Equal(got, want) {
+				t.Errorf("Answers = %v, want %v", got, want)
+			}
+			if !slices.Equal(thread.MoreIDs, []string{"m1"}) {
+				t.Errorf("MoreIDs = %v, want [m1]", thread.MoreIDs)
+			}
+		})
+	}
+
+	for _, post := range []*types.Post{nil, {Author: types.DeletedAuthor}} {
+		if thread := QAExtract(post, tree); thread.OP != "" || len(thread.Pairs) != 0 || len(thread.Answers) != 0 {
+			t.Errorf("QAExtract with post %+v = %+v, want no OP", post, thread)
+		}
+	}
+}
+
+func TestGetQA(t *testing.T) {
+	post := submitPostThing(t, "abc123", "I build compilers, AMA", "host", time.Unix(1700000000, 0))
+	answer := withFields(t, commentThing(t, "a1", "Mostly Go.", false), map[string]any{"author": "host", "parent_id": "t1_q1"})
+	question := withFields(t, commentThing(t, "q1", "What language?", false), map[string]any{"parent_id": "t3_abc123", "replies": listingThing(t, answer)})
+	var query string
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			query = req.URL.RawQuery
+			return []*types.Thing{listingThing(t, post), listingThing(t, question)}, nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	request := &types.CommentsRequest{Subreddit: "golang", PostID: "abc123", Sort: "new", LazyReplies: true}
+	thread, err := client.GetQA(context.Background(), request)
+	if err != nil {
+		t.Fatalf("GetQA returned error: %v", err)
+	}
+	if query != "sort=qa" {
+		t.Errorf("query = %q, want sort=qa", query)
+	}
+	if request.Sort != "new" || !request.LazyReplies {
+		t.Errorf("GetQA modified the request: %+v", request)
+	}
+	if thread.OP != "host" || len(thread.Pairs) != 1 || thread.Pairs[0].Question.ID != "q1" || thread.Pairs[0].Answers[0].ID != "a1" {
+		t.Errorf("thread = %+v, want q1 answered by a1", thread)
+	}
+
+	_, err = client.GetComments(context.Background(), &types.CommentsRequest{Subreddit: "golang", PostID: "abc123", Sort: "best"})
+	var configErr *pkgerrs.ConfigError
+	if !errors.As(err, &configErr) || configErr.Field != "Sort" {
+		t.Errorf("GetComments with sort best error = %v, want Sort ConfigError", err)
+	}
+}
@@ -0,0 +1,28 @@
+package graw
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// safeCall runs fn, converting a panic into a *errors.PanicError. Background workers use it
+// so that a panic while handling one response is reported like any other error instead of
+// crashing the host process. The panic and its stack trace are logged.
+func (r *Reddit) safeCall(ctx context.Context, operation string, fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			perr := &pkgerrs.PanicError{Operation: operation, Value: v, Stack: debug.Stack()}
+			if r.config != nil && r.config.Logger != nil {
+				r.config.Logger.LogAttrs(ctx, slog.LevelError, "recovered panic in worker",
+					slog.String("operation", operation),
+					slog.Any("panic", v),
+					slog.String("stack", string(perr.Stack)))
+			}
+			err = perr
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,51 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestSafeCall(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	ctx := context.Background()
+
+	sentinel := errors.New("plain failure")
+	if err := client.safeCall(ctx, "op", func() error { return sentinel }); err != sentinel {
+		t.Errorf("safeCall returned %v, want the function's error", err)
+	}
+
+	err := client.safeCall(ctx, "op", func() error { panic("boom") })
+	var perr *pkgerrs.PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected *PanicError, got %T: %v", err, err)
+	}
+	if perr.Operation != "op" || perr.Value != "boom" || len(perr.Stack) == 0 {
+		t.Errorf("PanicError = %+v", perr)
+	}
+}
+
+func TestGetCommentsMultiple_RecoversWorkerPanic(t *testing.T) {
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			panic("malformed response")
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	_, err := client.GetCommentsMultiple(context.Background(), []*types.CommentsRequest{
+		{Subreddit: "golang", PostID: "abc123"},
+		{Subreddit: "golang", PostID: "def456"},
+	})
+	var perr *pkgerrs.PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected *PanicError, got %T: %v", err, err)
+	}
+	if perr.Operation != "get comments" {
+		t.Errorf("Operation = %q, want %q", perr.Operation, "get comments")
+	}
+}
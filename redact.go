@@ -0,0 +1,155 @@
+package graw
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// RedactedAuthor replaces author names when a Redactor is configured to strip them.
+const RedactedAuthor = "[redacted]"
+
+// pseudonymLength is the number of hex digits kept from a name's HMAC.
+const pseudonymLength = 16
+
+// Patterns for PII-looking strings in post and comment text.
+var (
+	redactEmailPattern   = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	redactPhonePattern   = regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?(?:\(\d{3}\)|\b\d{3})[ .-]?\d{3}[ .-]?\d{4}\b`)
+	redactIPv4Pattern    = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	redactMentionPattern = regexp.MustCompile(`(^|[^A-Za-z0-9_/])(/?u/)([A-Za-z0-9_-]{3,20})`)
+)
+
+// RedactorConfig configures a Redactor.
+type RedactorConfig struct {
+	// Salt keys the hash that turns author names into pseudonyms. Use a secret, random salt
+	// per dataset: anyone who knows it can confirm whether a given user appears in the data.
+	// The same salt gives the same pseudonyms, so threads can be linked across exports.
+	Salt string
+	// StripAuthors replaces author names with RedactedAuthor instead of pseudonyms, which
+	// removes the ability to link a user's posts.
+	StripAuthors bool
+	// KeepText leaves titles and bodies unchanged; only author fields are redacted.
+	KeepText bool
+}
+
+// Redactor removes author names and PII-looking strings from posts and comments before
+// they are exported. Author names become stable pseudonyms (or are removed), and e-mail
+// addresses, phone numbers, IPv4 addresses, and u/ mentions in text are replaced. HTML
+// renderings of the text, and author flair, are dropped rather than rewritten.
+//
+// Pattern matching cannot find every kind of personal data; review datasets before
+// publishing them. A Redactor is safe for concurrent use.
+type Redactor struct {
+	config RedactorConfig
+}
+
+// NewRedactor returns a Redactor using config, or the defaults (hashing with an empty
+// salt) if config is nil.
+func NewRedactor(config *RedactorConfig) *Redactor {
+	r := &Redactor{}
+	if config != nil {
+		r.config = *config
+	}
+	return r
+}
+
+// Pseudonym returns the replacement for an author name. Names are compared
+// case-insensitively, as on Reddit. "[deleted]" and empty names are returned unchanged.
+func (r *Redactor) Pseudonym(name string) string {
+	if name == "" || name == types.DeletedAuthor {
+		return name
+	}
+	if r.config.StripAuthors {
+		return RedactedAuthor
+	}
+	mac := hmac.New(sha256.New, []byte(r.config.Salt))
+	mac.Write([]byte(strings.ToLower(name)))
+	return "user_" + hex.EncodeToString(mac.Sum(nil))[:pseudonymLength]
+}
+
+// RedactText replaces e-mail addresses, phone numbers, and IPv4 addresses in s with
+// placeholders, and u/ mentions with the mentioned user's pseudonym.
+func (r *Redactor) RedactText(s string) string {
+	s = redactEmailPattern.ReplaceAllString(s, "[email]")
+	s = redactIPv4Pattern.ReplaceAllString(s, "[ip]")
+	s = redactPhonePattern.ReplaceAllString(s, "[phone]")
+	return redactMentionPattern.ReplaceAllStringFunc(s, func(m string) string {
+		parts := redactMentionPattern.FindStringSubmatch(m)
+		return parts[1] + parts[2] + r.Pseudonym(parts[3])
+	})
+}
+
+// RedactPost returns a redacted copy of post; the original is not modified.
+func (r *Redactor) RedactPost(post *types.Post) *types.Post {
+	if post == nil {
+		return nil
+	}
+	out := *post
+	out.Author = r.Pseudonym(post.Author)
+	out.AuthorFlairText = nil
+	out.AuthorFlairCSSClass = nil
+	if !r.config.KeepText {
+		out.Title = r.RedactText(post.Title)
+		out.SelfText = r.RedactText(post.SelfText)
+		out.SelfTextHTML = nil
+	}
+	return &out
+}
+
+// RedactPosts returns redacted copies of posts.
+func (r *Redactor) RedactPosts(posts []*types.Post) []*types.Post {
+	out := make([]*types.Post, len(posts))
+	for i, p := range posts {
+		out[i] = r.RedactPost(p)
+	}
+	return out
+}
+
+// RedactComment returns a redacted copy of comment and its replies; the originals are not
+// modified. Annotations are not copied, since they may be derived from the original text.
+func (r *Redactor) RedactComment(comment *types.Comment) *types.Comment {
+	if comment == nil {
+		return nil
+	}
+	out := *comment
+	out.Author = r.Pseudonym(comment.Author)
+	out.LinkAuthor = r.Pseudonym(comment.LinkAuthor)
+	out.AuthorFlairText = nil
+	out.AuthorFlairCSSClass = nil
+	out.ApprovedBy = r.redactNamePtr(comment.ApprovedBy)
+	out.BannedBy = r.redactNamePtr(comment.BannedBy)
+	out.Annotations = nil
+	if !r.config.KeepText {
+		out.Body = r.RedactText(comment.Body)
+		out.BodyHTML = ""
+		out.LinkTitle = r.RedactText(comment.LinkTitle)
+	}
+	out.Replies = r.RedactComments(comment.Replies)
+	return &out
+}
+
+// RedactComments returns redacted copies of comments and their replies.
+func (r *Redactor) RedactComments(comments []*types.Comment) []*types.Comment {
+	if comments == nil {
+		return nil
+	}
+	out := make([]*types.Comment, len(comments))
+	for i, c := range comments {
+		out[i] = r.RedactComment(c)
+	}
+	return out
+}
+
+// redactNamePtr returns a pointer to the pseudonym for *name, or nil if name is nil.
+func (r *Redactor) redactNamePtr(name *string) *string {
+	if name == nil {
+		return nil
+	}
+	p := r.Pseudonym(*name)
+	return &p
+}
@@ -0,0 +1,112 @@
+package graw
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestRedactor_RedactText(t *testing.T) {
+	r := NewRedactor(&RedactorConfig{Salt: "s3cret"})
+	bob := r.Pseudonym("Bob_123")
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"mail me at jane.doe+x@example.co.uk please", "mail me at [email] please"},
+		{"call 555-123-4567 or (555) 123 4567", "call [phone] or [phone]"},
+		{"call +1 555.123.4567", "call [phone]"},
+		{"server at 192.168.0.12 is down", "server at [ip] is down"},
+		{"thanks u/Bob_123 and /u/bob_123!", "thanks u/" + bob + " and /u/" + bob + "!"},
+		{"see r/golang/u/notamention and 2024 results", "see r/golang/u/notamention and 2024 results"},
+	}
+	for _, tt := range tests {
+		if got := r.RedactText(tt.in); got != tt.want {
+			t.Errorf("RedactText(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactor_Pseudonym(t *testing.T) {
+	a := NewRedactor(&RedactorConfig{Salt: "one"})
+	b := NewRedactor(&RedactorConfig{Salt: "two"})
+
+	if got := a.Pseudonym("Alice"); got != a.Pseudonym("alice") {
+		t.Errorf("pseudonyms differ by case: %q vs %q", got, a.Pseudonym("alice"))
+	}
+	if a.Pseudonym("alice") == b.Pseudonym("alice") {
+		t.Error("different salts produced the same pseudonym")
+	}
+	if got := a.Pseudonym("alice"); !strings.HasPrefix(got, "user_") || strings.Contains(got, "alice") {
+		t.Errorf("Pseudonym(alice) = %q", got)
+	}
+	if got := a.Pseudonym("[deleted]"); got != "[deleted]" {
+		t.Errorf("Pseudonym([deleted]) = %q, want unchanged", got)
+	}
+	if got := NewRedactor(&RedactorConfig{StripAuthors: true}).Pseudonym("alice"); got != RedactedAuthor {
+		t.Errorf("StripAuthors Pseudonym = %q, want %q", got, RedactedAuthor)
+	}
+}
+
+func TestRedactor_RedactComment(t *testing.T) {
+	flair := "Gopher since 2012"
+	mod := "modname"
+	original := &types.Comment{
+		Author:          "alice",
+		AuthorFlairText: &flair,
+		ApprovedBy:      &mod,
+		Body:            "email alice@example.com",
+		BodyHTML:        "<p>email alice@example.com</p>",
+		Replies: []*types.Comment{
+			{Author: "bob", Body: "hi u/alice"},
+		},
+	}
+	original.SetAnnotation("sentiment", 0.9)
+
+	r := NewRedactor(&RedactorConfig{Salt: "salt"})
+	got := r.RedactComment(original)
+
+	if got.Author != r.Pseudonym("alice") || got.AuthorFlairText != nil || *got.ApprovedBy != r.Pseudonym("modname") {
+		t.Errorf("author fields not redacted: %+v", got)
+	}
+	if got.Body != "email [email]" || got.BodyHTML != "" {
+		t.Errorf("body = %q, html = %q", got.Body, got.BodyHTML)
+	}
+	if _, ok := got.Annotation("sentiment"); ok {
+		t.Error("annotations were copied")
+	}
+	if len(got.Replies) != 1 || got.Replies[0].Body != "hi u/"+r.Pseudonym("alice") {
+		t.Errorf("replies = %+v", got.Replies)
+	}
+
+	// The original tree is untouched.
+	if original.Author != "alice" || original.Body != "email alice@example.com" || original.Replies[0].Author != "bob" {
+		t.Errorf("original modified: %+v", original)
+	}
+
+	keep := NewRedactor(&RedactorConfig{KeepText: true}).RedactComment(original)
+	if keep.Body != original.Body || keep.Author == "alice" {
+		t.Errorf("KeepText: body = %q, author = %q", keep.Body, keep.Author)
+	}
+}
+
+func TestRedactor_RedactPosts(t *testing.T) {
+	html := "<p>call 555-123-4567</p>"
+	posts := []*types.Post{
+		{Author: "alice", Title: "Contact bob@example.com", SelfText: "call 555-123-4567", SelfTextHTML: &html},
+		nil,
+	}
+	got := NewRedactor(nil).RedactPosts(posts)
+	if len(got) != 2 || got[1] != nil {
+		t.Fatalf("RedactPosts() = %+v", got)
+	}
+	p := got[0]
+	if p.Author == "alice" || p.Title != "Contact [email]" || p.SelfText != "call [phone]" || p.SelfTextHTML != nil {
+		t.Errorf("RedactPosts()[0] = %+v", p)
+	}
+	if posts[0].Title != "Contact bob@example.com" {
+		t.Error("original post modified")
+	}
+}
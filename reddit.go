@@ -25,15 +25,21 @@
 package graw
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode"
 
 	"github.com/jamesprial/go-reddit-api-wrapper/internal"
 	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
@@ -45,14 +51,72 @@ const (
 	DefaultBaseURL = "https://oauth.reddit.com/"
 	// DefaultAuthURL is the default Reddit OAuth base URL
 	DefaultAuthURL = "https://www.reddit.com/"
+	// AuthorizeURL is the page, relative to the auth URL, where users authorize an app
+	AuthorizeURL = "api/v1/authorize"
+	// DefaultWebURL is the default base URL of endpoints served by Reddit's website
+	DefaultWebURL = "https://www.reddit.com/"
 	// DefaultUserAgent is the default user agent string
 	DefaultUserAgent = "go-reddit-api-wrapper/0.11.2 (by /u/yourusername)"
 	// MoreChildrenURL is the endpoint for loading more comments
 	MoreChildrenURL = "api/morechildren"
 	// MeURL is the endpoint for fetching the authenticated user's info
 	MeURL = "api/v1/me"
+	// PostRequirementsURLFormat is the endpoint for a subreddit's submission rules
+	PostRequirementsURLFormat = "api/v1/%s/post_requirements"
+	// InfoURL is the endpoint for looking up things by fullname
+	InfoURL = "api/info"
+	// UserDataURL is the endpoint for looking up account summaries by fullname
+	UserDataURL = "api/user_data_by_account_ids"
+	// RulesURLFormat is the endpoint for a subreddit's rules
+	RulesURLFormat = "r/%s/about/rules"
+	// WidgetsURLFormat is the endpoint for a subreddit's sidebar widgets
+	WidgetsURLFormat = "r/%s/api/widgets"
+	// SubmitURL is the endpoint for submitting a new post
+	SubmitURL = "api/submit"
+	// MediaAssetURL is the endpoint for leasing an upload slot on Reddit's media host
+	MediaAssetURL = "api/media/asset.json"
+	// CommentURL is the endpoint for replying to a post or comment
+	CommentURL = "api/comment"
+	// SaveURL is the endpoint for saving a post or comment
+	SaveURL = "api/save"
+	// UnsaveURL is the endpoint for removing a post or comment from the saved items
+	UnsaveURL = "api/unsave"
+	// HideURL is the endpoint for hiding posts from the user's listings
+	HideURL = "api/hide"
+	// UnhideURL is the endpoint for unhiding posts
+	UnhideURL = "api/unhide"
+	// SavedCategoriesURL is the endpoint for listing the user's saved categories (Reddit Premium)
+	SavedCategoriesURL = "api/saved_categories"
+	// ScopesURL is the endpoint for listing the available OAuth scopes
+	ScopesURL = "api/v1/scopes"
+	// GildURL is the endpoint for giving an award to a post or comment
+	GildURL = "api/v2/gold/gild"
+	// LinkFlairURLFormat is the endpoint for a subreddit's link flair templates
+	LinkFlairURLFormat = "r/%s/api/link_flair_v2"
+	// FriendURLFormat is the endpoint for adding a user to one of a subreddit's user lists, e.g. banned
+	FriendURLFormat = "r/%s/api/friend"
+	// UnfriendURLFormat is the endpoint for removing a user from one of a subreddit's user lists
+	UnfriendURLFormat = "r/%s/api/unfriend"
+	// BannedURLFormat is the endpoint for a subreddit's ban list
+	BannedURLFormat = "r/%s/about/banned"
+	// WikiPageURLFormat is the endpoint for a page of a subreddit's wiki
+	WikiPageURLFormat = "r/%s/wiki/%s"
+	// WikiPagesURLFormat is the endpoint listing the pages of a subreddit's wiki
+	WikiPagesURLFormat = "r/%s/wiki/pages"
+	// WikiRevisionsURLFormat is the endpoint for the revision history of a wiki page
+	WikiRevisionsURLFormat = "r/%s/wiki/revisions/%s"
+	// WikiEditURLFormat is the endpoint for editing a wiki page
+	WikiEditURLFormat = "r/%s/api/wiki/edit"
+	// ModLogURLFormat is the endpoint for a subreddit's moderation log
+	ModLogURLFormat = "r/%s/about/log"
+	// SubredditNotificationsURL is the endpoint for the user's post notification level for a subreddit
+	SubredditNotificationsURL = "api/subreddit_notification_level"
+	// SearchURL is the endpoint for searching all of Reddit
+	SearchURL = "search"
 
 	SubPrefixURL = "r/"
+	// UserPrefixURL is the path prefix for a user's listings
+	UserPrefixURL = "user/"
 
 	// HTTP timeout constants
 	// DefaultTimeout is the default HTTP client timeout
@@ -63,6 +127,30 @@ const (
 	MaxConcurrentCommentRequests = 10
 	// MaxTotalCommentRequests limits total requests in GetCommentsMultiple to prevent DoS
 	MaxTotalCommentRequests = 100
+
+	// PostRequirementsCacheTTL controls how long subreddit submission rules are reused
+	PostRequirementsCacheTTL = 15 * time.Minute
+	// SubredditInfoCacheTTL controls how long subreddit about data is reused
+	SubredditInfoCacheTTL = 15 * time.Minute
+
+	// MaxInfoFullnames is the maximum number of fullnames Reddit accepts in one info request
+	MaxInfoFullnames = 100
+	// MaxUserDataFullnames is the maximum number of account fullnames GetUsersByIDs accepts
+	MaxUserDataFullnames = 100
+	// MaxMoreChildrenIDs is the maximum number of comment IDs Reddit accepts in one morechildren request
+	MaxMoreChildrenIDs = 100
+	// DefaultEditWatchInterval is the re-fetch interval WatchForEdits uses when none is given
+	DefaultEditWatchInterval = time.Minute
+	// DefaultStreamInterval is the poll interval StreamNewPosts and StreamPostComments use when none is given
+	DefaultStreamInterval = 30 * time.Second
+	// DefaultMinStreamInterval is the lower bound of an adaptive StreamNewPosts interval when no MinInterval is given
+	DefaultMinStreamInterval = 5 * time.Second
+	// DefaultTrackInterval is the sampling interval TrackSubscriberCount uses when none is given
+	DefaultTrackInterval = 15 * time.Minute
+	// MaxShareRedirects limits how many redirects ResolveShareURL follows
+	MaxShareRedirects = 5
+	// SubmissionIdempotencyWindow is how long SubmitPost remembers idempotency keys
+	SubmissionIdempotencyWindow = 24 * time.Hour
 )
 
 // RateLimitConfig configures the client's local rate limiting behavior.
@@ -84,6 +172,33 @@ type RateLimitConfig struct {
 	ProactiveThreshold float64
 }
 
+// NetworkConfig tunes how the built-in transport resolves and connects to Reddit.
+// It helps on hosts where IPv6 routes to Reddit's edge fail intermittently or the
+// system resolver is unreliable.
+type NetworkConfig struct {
+	// Resolver replaces the system DNS resolver. Takes precedence over DNSServers.
+	Resolver *net.Resolver
+
+	// DNSServers are queried in order instead of the system resolver.
+	// Entries are "ip:port" or a bare IP address, which uses port 53.
+	DNSServers []string
+
+	// FallbackDelay is the Happy Eyeballs delay before an IPv4 connection is raced
+	// against a slow IPv6 one. Zero uses Go's default of 300ms; negative disables
+	// Happy Eyeballs so addresses are tried one at a time.
+	FallbackDelay time.Duration
+
+	// IPv4Only connects over IPv4 only, skipping IPv6 addresses entirely.
+	IPv4Only bool
+
+	// IPv4Fallback retries a failed connection over IPv4 only.
+	IPv4Fallback bool
+
+	// SystemDNSFallback retries a connection with the system resolver when the lookup
+	// through Resolver or DNSServers fails.
+	SystemDNSFallback bool
+}
+
 // Config holds the configuration for the Reddit client.
 // It provides all necessary authentication credentials and optional customization settings.
 //
@@ -113,11 +228,33 @@ type Config struct {
 	Username string
 	Password string
 
+	// RefreshToken authenticates as a user who authorized the app through the
+	// authorization-code flow (see AuthCodeConfig). Optional; it cannot be combined with
+	// Username and Password. ClientSecret may be empty for installed apps.
+	RefreshToken string
+
+	// OnRefreshToken is called with the new refresh token when Reddit rotates the one in
+	// RefreshToken. Optional. Persist the new token: the old one stops working. It is
+	// called while the client holds its token lock, so it must not make API calls.
+	OnRefreshToken func(refreshToken string)
+
 	// ClientID and ClientSecret for OAuth2 authentication.
 	// Required for all authentication types. Obtain these from Reddit's app preferences.
 	ClientID     string
 	ClientSecret string
 
+	// TokenProvider supplies access tokens instead of the built-in authenticator, e.g. one
+	// backed by a secrets service or a shared refresh-token store. Optional. When set, the
+	// client never contacts Reddit's token endpoint, and ClientID, ClientSecret, Username,
+	// Password, and RefreshToken must be empty. GetToken is called before every request, so
+	// it should cache tokens and renew them itself.
+	TokenProvider TokenProvider
+
+	// TokenStore persists access and refresh tokens, so a restarted program reuses them
+	// instead of authenticating again. Optional. NewFileTokenStore keeps them in a file.
+	// Cannot be combined with TokenProvider.
+	TokenStore TokenStore
+
 	// UserAgent string to identify your application to Reddit.
 	// Should follow format: "platform:app-name:version by /u/username"
 	// Example: "web:myapp:1.0 by /u/myusername"
@@ -131,6 +268,17 @@ type Config struct {
 	// Defaults to DefaultAuthURL if not specified. Usually doesn't need to be changed.
 	AuthURL string
 
+	// WebURL for endpoints served by Reddit's website rather than the OAuth API, such as
+	// the list of OAuth scopes. Requests to it carry no OAuth token.
+	// Defaults to DefaultWebURL if not specified. Usually doesn't need to be changed.
+	WebURL string
+
+	// Locale is a BCP 47 language tag, such as "de" or "pt-BR", sent as Accept-Language
+	// with every request. Optional. Reddit uses it to localize the content it translates,
+	// such as community titles and descriptions; SubredditData.Lang reports a community's
+	// own language.
+	Locale string
+
 	// HTTPClient to use for requests.
 	// Defaults to a client with DefaultTimeout if not specified.
 	// Customize this to set custom timeouts, proxies, or other HTTP behavior.
@@ -143,6 +291,10 @@ type Config struct {
 	// See package documentation for secure HTTP client configuration examples.
 	HTTPClient *http.Client
 
+	// NetworkConfig customizes DNS resolution and IPv4/IPv6 dialing of the built-in transport.
+	// Optional. Ignored when HTTPClient is set; configure that client's Transport instead.
+	NetworkConfig *NetworkConfig
+
 	// Logger for structured diagnostics.
 	// Optional. If provided, debug information will be logged during API calls.
 	Logger *slog.Logger
@@ -151,6 +303,135 @@ type Config struct {
 	// Optional. If not specified, defaults to 100 requests/minute with burst of 10.
 	// Set RequestsPerMinute to a very high value (e.g., 100000) to effectively disable rate limiting for tests.
 	RateLimitConfig *RateLimitConfig
+
+	// RetryConfig enables automatic retries of read requests that fail transiently.
+	// Optional. If not specified, failed requests are returned without retrying.
+	RetryConfig *RetryConfig
+
+	// BatchRetry makes GetCommentsMultiple finish the whole batch despite failures and then
+	// retry only the posts that failed transiently, in rounds, up to MaxRetries times with
+	// backoff between rounds. Optional. If not specified, the first failure cancels the batch.
+	BatchRetry *RetryConfig
+
+	// NotFoundCache remembers GET requests that returned 404, so repeated lookups of
+	// deleted or banned content fail locally with a *errors.NotFoundError instead of using
+	// rate limit budget. Optional. If not specified, every lookup is sent to Reddit.
+	NotFoundCache *NotFoundCacheConfig
+
+	// PostRequirementsCacheTTL controls how long subreddit submission rules are cached.
+	// Defaults to PostRequirementsCacheTTL if zero. At most 1000 subreddits are cached.
+	PostRequirementsCacheTTL time.Duration
+
+	// SubredditInfoCacheTTL controls how long subreddit data from GetSubreddit and sr_detail
+	// is cached for GetSubreddit and Post.SubredditInfo.
+	// Defaults to SubredditInfoCacheTTL if zero; a negative value disables the cache. At
+	// most 5000 subreddits are cached, the least recently used dropped first.
+	SubredditInfoCacheTTL time.Duration
+
+	// WriteAuditor is called after every mutating API call (save, submit, etc.)
+	// with a record of what was attempted and whether it succeeded.
+	// Optional. Use it to keep an audit trail of actions taken by the client.
+	WriteAuditor WriteAuditor
+
+	// ExtraHeaders are added to every API request, after the Authorization header.
+	// Optional. Use them for API gateways that need their own credentials. They cannot
+	// replace the headers the client sets itself (Authorization, User-Agent, Content-Type,
+	// Content-Length, Host).
+	ExtraHeaders map[string]string
+
+	// HeaderProvider is called for every API request after ExtraHeaders are applied, and
+	// the headers it returns are added to the request. Optional. Use it for headers that
+	// change per request, such as request signatures.
+	HeaderProvider HeaderProvider
+
+	// JSONCodec decodes API responses in place of encoding/json. Optional. Use it to plug in
+	// a faster decoder when processing large volumes of listings.
+	JSONCodec JSONCodec
+
+	// CaptureHeaders records diagnostic response headers (x-reddit-loid, x-request-id,
+	// x-cache, and CDN timing headers) in the Meta field of PostsResponse, CommentsResponse,
+	// InfoResponse, SubmitResponse, SearchResponse, and Listing. Optional. Include them in
+	// support requests to Reddit or attach them to traces.
+	CaptureHeaders bool
+
+	// Parser bounds how much of a comment tree GetComments, GetMoreComments, and
+	// StreamPostComments parse. Optional. By default whole threads are parsed.
+	Parser *ParserOptions
+
+	// AllowNSFW opts in to quarantined and age-gated subreddits. When a listing fails because
+	// the subreddit is quarantined or gated, it is retried once with the over-18 and opt-in
+	// cookies the Reddit site sets. Optional. Without it such listings fail with a
+	// *errors.ForbiddenError whose IsQuarantined or IsGated method reports why.
+	AllowNSFW bool
+
+	// Annotator enriches comments returned by GetComments, GetMoreComments, and
+	// StreamPostComments before they are handed to the caller. Optional.
+	// Use an AnnotatorChain to combine several annotators.
+	Annotator Annotator
+
+	// StreamBuffer sizes the channels of StreamNewPosts, StreamPostComments, and
+	// WatchForEdits and sets what happens when a slow consumer lets them fill. Optional.
+	// By default the channels are unbuffered and the stream pauses until the consumer
+	// catches up.
+	StreamBuffer *StreamBufferConfig
+
+	// Sink receives every post, comment, and subreddit the client parses, for pipelines
+	// that persist fetched entities. Optional.
+	Sink Sink
+}
+
+// RetryConfig configures automatic retries. Only idempotent requests (GET) are retried,
+// and only after a retryable *errors.TransportError or a 429, 500, 502, 503, or 504 response.
+// An unknown host or a certificate that fails verification is not retried.
+// Delays grow exponentially from InitialBackoff up to MaxBackoff, and Retry-After headers
+// from Reddit are honored by the rate limiter.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+
+	// InitialBackoff is the delay before the first retry.
+	// Defaults to 500ms if zero or negative.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between retries.
+	// Defaults to 30s if zero or negative.
+	MaxBackoff time.Duration
+}
+
+// ParserOptions bounds the work done parsing comment trees, for analysis that only needs
+// the upper levels of giant threads.
+type ParserOptions struct {
+	// MaxDepth is the number of comment levels parsed: 1 keeps only top-level comments,
+	// 2 adds their direct replies, and so on. The replies of the deepest kept comments are
+	// not parsed; their IDs are recorded in the comment's MoreChildrenIDs, and in
+	// CommentsResponse.MoreIDs, and the comment is marked Truncated. Zero means no limit.
+	// Comments loaded with CommentsRequest.LazyReplies are parsed one level at a time and
+	// are not limited.
+	MaxDepth int
+
+	// LenientCursors accepts listing after and before cursors that are not fullnames,
+	// logging a warning, instead of failing the listing. Reddit occasionally sends valid
+	// cursors in other formats. Accepted cursors can be passed back in Pagination. Cursors
+	// longer than 100 bytes or containing spaces or control characters are still rejected.
+	LenientCursors bool
+}
+
+// NotFoundCacheConfig configures the negative cache of 404 responses. A URL that returns 404
+// is answered locally for TTL; if it still returns 404 when checked again, the TTL doubles,
+// up to MaxTTL, so content that stays gone is checked less and less often. A later success
+// forgets the URL. Only GET requests are cached, keyed by their full URL.
+type NotFoundCacheConfig struct {
+	// TTL is how long a URL's first 404 is remembered.
+	// Defaults to 10 minutes if zero or negative.
+	TTL time.Duration
+
+	// MaxTTL caps the growing TTL.
+	// Defaults to 24 hours if zero or negative.
+	MaxTTL time.Duration
+
+	// MaxEntries bounds the number of URLs remembered; the entries closest to expiry are
+	// dropped first. Defaults to 10000 if zero or negative.
+	MaxEntries int
 }
 
 // TokenProvider defines the interface for retrieving an access token.
@@ -170,6 +451,10 @@ type HTTPClient interface {
 	// Optional query parameters can be provided as url.Values.
 	NewRequest(ctx context.Context, method, path string, body io.Reader, params ...url.Values) (*http.Request, error)
 
+	// NewEndpointRequest is like NewRequest, but resolves the path against the base URL of the
+	// endpoint's host, so methods can reach endpoints outside the OAuth API host.
+	NewEndpointRequest(ctx context.Context, method string, endpoint internal.Endpoint, body io.Reader, params ...url.Values) (*http.Request, error)
+
 	// Do executes an HTTP request and unmarshals the response into a Reddit Thing object.
 	// This is used for most Reddit API endpoints that return structured data.
 	Do(req *http.Request, v *types.Thing) error
@@ -181,6 +466,10 @@ type HTTPClient interface {
 	// DoMoreChildren executes an HTTP request for the morechildren endpoint.
 	// Returns the Things array from the nested json.data structure.
 	DoMoreChildren(req *http.Request) ([]*types.Thing, error)
+
+	// DoJSON executes an HTTP request and unmarshals the response body into v.
+	// This is used for endpoints that return plain JSON objects rather than Things.
+	DoJSON(req *http.Request, v any) error
 }
 
 // Validator defines validation operations for Reddit API parameters.
@@ -189,6 +478,10 @@ type Validator interface {
 	// ValidateSubredditName checks if a subreddit name is valid according to Reddit's naming rules.
 	ValidateSubredditName(name string) error
 
+	// NormalizeSubredditName validates a subreddit name given as "golang", "r/golang", or
+	// "/r/golang" and returns the bare name.
+	NormalizeSubredditName(name string) (string, error)
+
 	// ValidatePagination checks if pagination parameters are valid.
 	ValidatePagination(pagination *types.Pagination) error
 
@@ -214,7 +507,7 @@ type Validator interface {
 	ValidateURL(url string) error
 
 	// ValidateConfig validates the configuration fields and returns the validated/defaulted httpClient.
-	// Returns an error if validation fails.
+	// clientSecret may be empty, for installed apps. Returns an error if validation fails.
 	ValidateConfig(clientID, clientSecret, userAgent string, httpClient *http.Client, logger *slog.Logger, defaultTimeout time.Duration) (*http.Client, error)
 }
 
@@ -223,6 +516,8 @@ type Parser interface {
 	ParseThing(ctx context.Context, thing *types.Thing) (any, error)
 	ExtractPosts(ctx context.Context, thing *types.Thing) ([]*types.Post, error)
 	ExtractPostAndComments(ctx context.Context, things []*types.Thing) (*types.CommentsResponse, error)
+	// ParseReplies parses the RawReplies of a comment loaded with CommentsRequest.LazyReplies.
+	ParseReplies(ctx context.Context, comment *types.Comment) error
 }
 
 // Reddit is the main Reddit API client.
@@ -240,10 +535,35 @@ type Parser interface {
 //	posts, err := client.GetHot(ctx, &types.PostsRequest{Subreddit: "golang", Limit: 25})
 type Reddit struct {
 	httpClient HTTPClient
-	auth       TokenProvider
 	config     *Config
 	parser     Parser
 	validator  Validator
+
+	// auth supplies access tokens. authMu guards it so UpdateCredentials can swap it
+	// while requests are in flight.
+	auth   TokenProvider
+	authMu sync.RWMutex
+
+	// postRequirements caches subreddit submission rules, keyed by lowercase subreddit name.
+	postRequirements boundedCache[*cachedPostRequirements]
+
+	// subreddits caches subreddit data, keyed by lowercase subreddit name.
+	subreddits boundedCache[*cachedSubreddit]
+
+	// submissions tracks SubmitRequest idempotency keys.
+	submissions submissionLedger
+
+	// listingCache holds listings fetched with WithCacheTTL or WithSWR, keyed by path and query.
+	listingCache boundedCache[*cachedListing]
+
+	// workers counts batch tasks for Stats.
+	workers workerPoolStats
+
+	// streamDropped counts stream items discarded under a dropping BackpressurePolicy.
+	streamDropped atomic.Int64
+
+	// streams holds the *pollTuner of each running StreamNewPosts stream, for StreamStats.
+	streams sync.Map
 }
 
 // NewClient creates a new Reddit client with the provided configuration.
@@ -285,6 +605,9 @@ func NewClientWithContext(ctx context.Context, config *Config) (*Reddit, error)
 	if config.AuthURL == "" {
 		config.AuthURL = DefaultAuthURL
 	}
+	if config.WebURL == "" {
+		config.WebURL = DefaultWebURL
+	}
 
 	// Validate config and set HTTP client defaults
 	validator := internal.NewValidator()
@@ -296,38 +619,60 @@ func NewClientWithContext(ctx context.Context, config *Config) (*Reddit, error)
 	if err := validator.ValidateURL(config.AuthURL); err != nil {
 		return nil, &pkgerrs.ConfigError{Field: "AuthURL", Message: fmt.Sprintf("invalid auth URL: %v", err)}
 	}
-	var err error
-	config.HTTPClient, err = validator.ValidateConfig(
-		config.ClientID,
-		config.ClientSecret,
-		config.UserAgent,
-		config.HTTPClient,
-		config.Logger,
-		DefaultTimeout,
-	)
-	if err != nil {
+	if err := validator.ValidateURL(config.WebURL); err != nil {
+		return nil, &pkgerrs.ConfigError{Field: "WebURL", Message: fmt.Sprintf("invalid web URL: %v", err)}
+	}
+	extraHeaders := make(http.Header, len(config.ExtraHeaders))
+	for name, value := range config.ExtraHeaders {
+		extraHeaders.Set(name, value)
+	}
+	if err := validateExtraHeaders("ExtraHeaders", extraHeaders); err != nil {
+		return nil, err
+	}
+	if err := validateStreamBuffer(config.StreamBuffer); err != nil {
+		return nil, err
+	}
+	if config.Parser != nil && config.Parser.MaxDepth < 0 {
+		return nil, &pkgerrs.ConfigError{Field: "Parser.MaxDepth", Message: "max depth cannot be negative"}
+	}
+	if err := validateLocale(config.Locale); err != nil {
 		return nil, err
 	}
 
-	// Create authenticator
-	grantType := "client_credentials" // Default to app-only auth
-	if config.Username != "" && config.Password != "" {
-		grantType = "password" // Use password grant if credentials provided
+	if config.NetworkConfig != nil && config.HTTPClient == nil {
+		transport, err := internal.NewTransport(internal.NetworkConfig{
+			Resolver:          config.NetworkConfig.Resolver,
+			DNSServers:        config.NetworkConfig.DNSServers,
+			FallbackDelay:     config.NetworkConfig.FallbackDelay,
+			IPv4Only:          config.NetworkConfig.IPv4Only,
+			IPv4Fallback:      config.NetworkConfig.IPv4Fallback,
+			SystemDNSFallback: config.NetworkConfig.SystemDNSFallback,
+		})
+		if err != nil {
+			return nil, &pkgerrs.ConfigError{Field: "NetworkConfig.DNSServers", Message: err.Error()}
+		}
+		config.HTTPClient = &http.Client{Timeout: DefaultTimeout, Transport: transport}
 	}
 
-	auth, err := internal.NewAuthenticator(
-		config.HTTPClient,
-		config.Username,
-		config.Password,
-		config.ClientID,
-		config.ClientSecret,
-		config.UserAgent,
-		config.AuthURL,
-		grantType,
-		config.Logger,
-	)
-	if err != nil {
-		return nil, &pkgerrs.AuthError{Message: "failed to create authenticator", Err: err}
+	var auth TokenProvider
+	var err error
+	if config.TokenProvider != nil {
+		if config.ClientID != "" || config.ClientSecret != "" || config.Username != "" || config.Password != "" || config.RefreshToken != "" {
+			return nil, &pkgerrs.ConfigError{Field: "TokenProvider", Message: "a token provider cannot be combined with ClientID, ClientSecret, Username, Password, or RefreshToken"}
+		}
+		if config.TokenStore != nil {
+			return nil, &pkgerrs.ConfigError{Field: "TokenStore", Message: "a token store cannot be combined with a token provider"}
+		}
+		config.HTTPClient, err = validator.ValidateHTTPClient(config.UserAgent, config.HTTPClient, config.Logger, DefaultTimeout)
+		if err != nil {
+			return nil, err
+		}
+		auth = config.TokenProvider
+	} else {
+		auth, err = newAuthenticator(config, validator)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Validate that we can get a token before creating the client
@@ -337,7 +682,7 @@ func NewClientWithContext(ctx context.Context, config *Config) (*Reddit, error)
 	}
 
 	// Create internal HTTP client
-	var httpClient HTTPClient
+	var httpClient *internal.Client
 	if config.RateLimitConfig != nil {
 		// Convert public config to internal config
 		internalRateLimitCfg := internal.RateLimitConfig{
@@ -367,14 +712,104 @@ func NewClientWithContext(ctx context.Context, config *Config) (*Reddit, error)
 			Err:       err,
 		}
 	}
+	if err := httpClient.SetWebURL(config.WebURL); err != nil {
+		return nil, &pkgerrs.ConfigError{Field: "WebURL", Message: fmt.Sprintf("invalid web URL: %v", err)}
+	}
+	if config.RetryConfig != nil {
+		httpClient.SetRetryConfig(internal.RetryConfig{
+			MaxRetries:     config.RetryConfig.MaxRetries,
+			InitialBackoff: config.RetryConfig.InitialBackoff,
+			MaxBackoff:     config.RetryConfig.MaxBackoff,
+		})
+	}
+	if config.NotFoundCache != nil {
+		httpClient.SetNotFoundCache(internal.NotFoundCacheConfig{
+			TTL:        config.NotFoundCache.TTL,
+			MaxTTL:     config.NotFoundCache.MaxTTL,
+			MaxEntries: config.NotFoundCache.MaxEntries,
+		})
+	}
+
+	parser := internal.NewParser(config.Logger)
+	if config.JSONCodec != nil {
+		httpClient.SetCodec(config.JSONCodec)
+		parser.SetCodec(config.JSONCodec)
+	}
+	if config.Parser != nil {
+		parser.SetMaxDepth(config.Parser.MaxDepth)
+		if config.Parser.LenientCursors {
+			parser.SetCursorPolicy(internal.CursorLenient)
+			validator.SetCursorPolicy(internal.CursorLenient)
+		}
+	}
 
-	return &Reddit{
+	client := &Reddit{
 		httpClient: httpClient,
 		auth:       auth,
 		config:     config,
-		parser:     internal.NewParser(config.Logger),
-		validator:  internal.NewValidator(),
-	}, nil
+		parser:     parser,
+		validator:  validator,
+	}
+	if config.Sink != nil {
+		client.parser = &sinkParser{Parser: parser, r: client}
+	}
+	return client, nil
+}
+
+// newAuthenticator validates config's credentials and returns the built-in authenticator
+// for them.
+func newAuthenticator(config *Config, validator *internal.Validator) (*internal.Authenticator, error) {
+	if config.RefreshToken != "" && (config.Username != "" || config.Password != "") {
+		return nil, &pkgerrs.ConfigError{Field: "RefreshToken", Message: "a refresh token cannot be combined with a username and password"}
+	}
+	// Installed apps have no secret and authenticate with a refresh token alone.
+	if config.ClientSecret == "" && config.RefreshToken == "" {
+		return nil, &pkgerrs.ConfigError{Message: "ClientID and ClientSecret are required"}
+	}
+
+	var err error
+	config.HTTPClient, err = validator.ValidateConfig(
+		config.ClientID,
+		config.ClientSecret,
+		config.UserAgent,
+		config.HTTPClient,
+		config.Logger,
+		DefaultTimeout,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create authenticator
+	grantType := "client_credentials" // Default to app-only auth
+	if config.Username != "" && config.Password != "" {
+		grantType = "password" // Use password grant if credentials provided
+	}
+	if config.RefreshToken != "" {
+		grantType = "refresh_token"
+	}
+
+	auth, err := internal.NewAuthenticator(
+		config.HTTPClient,
+		config.Username,
+		config.Password,
+		config.ClientID,
+		config.ClientSecret,
+		config.UserAgent,
+		config.AuthURL,
+		grantType,
+		config.Logger,
+	)
+	if err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to create authenticator", Err: err}
+	}
+	if config.RefreshToken != "" {
+		auth.SetRefreshToken(config.RefreshToken, config.OnRefreshToken)
+	}
+	if config.TokenStore != nil {
+		auth.SetTokenStore(authTokenStore{store: config.TokenStore}, true)
+	}
+	return auth, nil
 }
 
 // Me returns information about the authenticated user.
@@ -422,6 +857,11 @@ func (r *Reddit) Me(ctx context.Context) (*types.AccountData, error) {
 // GetSubreddit retrieves information about a specific subreddit.
 // This includes subscriber count, description, rules, and other metadata.
 //
+// Results are cached per subreddit for Config.SubredditInfoCacheTTL and attached to posts
+// fetched later, so Post.SubredditInfo can be read without calling GetSubreddit again. The
+// returned data is shared with the cache and must not be modified. Counts such as
+// Subscribers may therefore be up to the TTL old; set a negative TTL to always fetch.
+//
 // Parameters:
 //   - name: The subreddit name without the "r/" prefix (e.g., "golang", "programming")
 //
@@ -439,10 +879,19 @@ func (r *Reddit) Me(ctx context.Context) (*types.AccountData, error) {
 // This method works with both application-only and user authentication.
 func (r *Reddit) GetSubreddit(ctx context.Context, name string) (*types.SubredditData, error) {
 	// Validate subreddit name
-	if err := r.validator.ValidateSubredditName(name); err != nil {
+	name, err := r.validator.NormalizeSubredditName(name)
+	if err != nil {
 		return nil, err
 	}
+	if entry, ok := r.cachedSubreddit(strings.ToLower(name)); ok && entry.about {
+		return entry.data, nil
+	}
+	return r.fetchSubreddit(ctx, name)
+}
 
+// fetchSubreddit requests the about data of the normalized subreddit name, bypassing the
+// cache, and caches the result. Callers that need current counts use it directly.
+func (r *Reddit) fetchSubreddit(ctx context.Context, name string) (*types.SubredditData, error) {
 	path := SubPrefixURL + name + "/about"
 	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -471,6 +920,7 @@ func (r *Reddit) GetSubreddit(ctx context.Context, name string) (*types.Subreddi
 		return nil, &pkgerrs.ParseError{Operation: "subreddit response", Err: fmt.Errorf("unexpected response type")}
 	}
 
+	r.rememberSubreddit(subreddit, true)
 	return subreddit, nil
 }
 
@@ -487,8 +937,10 @@ func (r *Reddit) GetSubreddit(ctx context.Context, name string) (*types.Subreddi
 //
 // The returned PostsResponse includes AfterFullname and BeforeFullname fields
 // that can be used in subsequent calls for pagination.
-func (r *Reddit) GetHot(ctx context.Context, request *types.PostsRequest) (*types.PostsResponse, error) {
-	return r.getPosts(ctx, request, "hot")
+//
+// Options such as WithLimit and WithAfter override the request's fields for this call.
+func (r *Reddit) GetHot(ctx context.Context, request *types.PostsRequest, opts ...ListingOption) (*types.PostsResponse, error) {
+	return r.getPosts(ctx, request, SortHot, opts...)
 }
 
 // GetNew retrieves new posts from a subreddit or the Reddit front page.
@@ -501,12 +953,74 @@ func (r *Reddit) GetHot(ctx context.Context, request *types.PostsRequest) (*type
 // Returns:
 //   - PostsResponse containing the posts and pagination information
 //   - Error if the request fails
-func (r *Reddit) GetNew(ctx context.Context, request *types.PostsRequest) (*types.PostsResponse, error) {
-	return r.getPosts(ctx, request, "new")
+//
+// Options such as WithLimit and WithAfter override the request's fields for this call.
+func (r *Reddit) GetNew(ctx context.Context, request *types.PostsRequest, opts ...ListingOption) (*types.PostsResponse, error) {
+	return r.getPosts(ctx, request, SortNew, opts...)
+}
+
+// GetPosts retrieves posts from a subreddit, or the front page when subreddit is empty,
+// configured entirely by options. The order defaults to hot; use WithSort to fetch, e.g.,
+// the top posts of the week:
+//
+//	resp, err := client.GetPosts(ctx, "golang", graw.WithSort(graw.TopWeek), graw.WithLimit(50))
+//
+// Returns an error if an option is invalid or the request fails.
+func (r *Reddit) GetPosts(ctx context.Context, subreddit string, opts ...ListingOption) (*types.PostsResponse, error) {
+	return r.getPosts(ctx, &types.PostsRequest{Subreddit: subreddit}, ListingSort{}, opts...)
+}
+
+// GetHotListing is GetHot returning the page as a generic types.Listing, like the other
+// listing methods.
+func (r *Reddit) GetHotListing(ctx context.Context, request *types.PostsRequest, opts ...ListingOption) (*types.Listing[*types.Post], error) {
+	return postsListing(r.getPosts(ctx, request, SortHot, opts...))
+}
+
+// GetNewListing is GetNew returning the page as a generic types.Listing.
+func (r *Reddit) GetNewListing(ctx context.Context, request *types.PostsRequest, opts ...ListingOption) (*types.Listing[*types.Post], error) {
+	return postsListing(r.getPosts(ctx, request, SortNew, opts...))
+}
+
+// GetPostsListing is GetPosts returning the page as a generic types.Listing.
+func (r *Reddit) GetPostsListing(ctx context.Context, subreddit string, opts ...ListingOption) (*types.Listing[*types.Post], error) {
+	return postsListing(r.GetPosts(ctx, subreddit, opts...))
+}
+
+// postsListing converts the result of a posts call to a Listing.
+func postsListing(resp *types.PostsResponse, err error) (*types.Listing[*types.Post], error) {
+	if err != nil {
+		return nil, err
+	}
+	return resp.Listing(), nil
 }
 
 // getPosts is the common implementation for fetching posts from different sort endpoints.
-func (r *Reddit) getPosts(ctx context.Context, request *types.PostsRequest, sort string) (*types.PostsResponse, error) {
+// sort is the endpoint's fixed order, or the zero value to take it from a WithSort option
+// (hot by default).
+func (r *Reddit) getPosts(ctx context.Context, request *types.PostsRequest, sort ListingSort, opts ...ListingOption) (*types.PostsResponse, error) {
+	fixed := sort != ListingSort{}
+	if !fixed {
+		sort = SortHot
+	}
+	var cache cachePolicy
+	if len(opts) > 0 {
+		call := newListingCall(request, opts)
+		cache = call.cache
+		if call.sortSet {
+			if fixed {
+				return nil, &pkgerrs.ConfigError{Field: "Sort", Message: "WithSort is only supported by GetPosts"}
+			}
+			sort = call.sort
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = call.context(ctx)
+		defer cancel()
+		request = &call.request
+	}
+	if err := sort.validate(); err != nil {
+		return nil, err
+	}
+
 	subreddit := ""
 	var pagination *types.Pagination
 	if request != nil {
@@ -515,7 +1029,8 @@ func (r *Reddit) getPosts(ctx context.Context, request *types.PostsRequest, sort
 
 		// Validate subreddit name if provided
 		if subreddit != "" {
-			if err := r.validator.ValidateSubredditName(subreddit); err != nil {
+			var err error
+			if subreddit, err = r.validator.NormalizeSubredditName(subreddit); err != nil {
 				return nil, err
 			}
 		}
@@ -526,49 +1041,53 @@ func (r *Reddit) getPosts(ctx context.Context, request *types.PostsRequest, sort
 		}
 	}
 
-	path := sort
+	path := sort.Order
 	if subreddit != "" {
-		path = SubPrefixURL + subreddit + "/" + sort
+		path = SubPrefixURL + subreddit + "/" + sort.Order
 	}
 
 	// Build query parameters
 	params := buildPaginationParams(pagination)
-
-	httpReq, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil, params)
-	if err != nil {
-		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	if sort.Time != "" {
+		params.Set("t", sort.Time)
 	}
-
-	// Add authentication headers
-	if err := r.addAuthHeaders(ctx, httpReq); err != nil {
-		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	if request != nil {
+		if err := addExtraParams(params, request.Params); err != nil {
+			return nil, err
+		}
 	}
 
-	var result types.Thing
-	err = r.httpClient.Do(httpReq, &result)
-	if err != nil {
-		return nil, wrapDoError(err, "get "+sort+" posts", path)
+	fetch := func(ctx context.Context) (*types.PostsResponse, error) {
+		listing, err := fetchListing[*types.Post](ctx, r, path, params, "get "+sort.Order+" posts", "parse posts")
+		if err != nil {
+			return nil, err
+		}
+		return &types.PostsResponse{
+			Posts:          listing.Items,
+			AfterFullname:  listing.After,
+			BeforeFullname: listing.Before,
+			RateLimit:      listing.RateLimit,
+			Meta:           listing.Meta,
+		}, nil
+	}
+	if !cache.enabled() {
+		return fetch(ctx)
 	}
 
-	posts, err := r.parser.ExtractPosts(ctx, &result)
+	resp, err := cachedFetch(ctx, r, path+"?"+params.Encode(), cache, fetch)
 	if err != nil {
-		return nil, &pkgerrs.ParseError{Operation: "parse posts", Err: err}
+		return nil, err
 	}
-
-	var after, before string
-	listing, err := r.parser.ParseThing(ctx, &result)
-	if err == nil {
-		if listingData, ok := listing.(*types.ListingData); ok {
-			after = listingData.AfterFullname
-			before = listingData.BeforeFullname
+	// Copy the response and its posts so callers cannot modify the cached ones.
+	copied := *resp
+	copied.Posts = make([]*types.Post, len(resp.Posts))
+	for i, post := range resp.Posts {
+		if post != nil {
+			p := *post
+			copied.Posts[i] = &p
 		}
 	}
-
-	return &types.PostsResponse{
-		Posts:          posts,
-		AfterFullname:  after,
-		BeforeFullname: before,
-	}, nil
+	return &copied, nil
 }
 
 // GetComments retrieves comments for a specific post.
@@ -587,10 +1106,16 @@ func (r *Reddit) getPosts(ctx context.Context, request *types.PostsRequest, sort
 // The comments are returned in a flat slice, but each comment contains information
 // about its parent and can be organized into a tree structure if needed.
 //
+// If Reddit's post listing or comments listing cannot be parsed, the other half is still
+// returned, with Partial set and the failure in PostErr or CommentsErr, together with a
+// *errors.PartialResultError. Callers that only want complete pages can treat any error as
+// a failure.
+//
 // Returns an error if:
 //   - The client is not connected
 //   - The post doesn't exist or is in a private subreddit
 //   - The API request fails
+//   - Neither the post nor the comments can be parsed
 func (r *Reddit) GetComments(ctx context.Context, request *types.CommentsRequest) (*types.CommentsResponse, error) {
 	if request == nil {
 		return nil, &pkgerrs.ConfigError{Message: "comments request cannot be nil"}
@@ -600,7 +1125,8 @@ func (r *Reddit) GetComments(ctx context.Context, request *types.CommentsRequest
 	}
 
 	// Validate subreddit name
-	if err := r.validator.ValidateSubredditName(request.Subreddit); err != nil {
+	subreddit, err := r.validator.NormalizeSubredditName(request.Subreddit)
+	if err != nil {
 		return nil, err
 	}
 
@@ -613,11 +1139,105 @@ func (r *Reddit) GetComments(ctx context.Context, request *types.CommentsRequest
 	if err := r.validator.ValidatePagination(&request.Pagination); err != nil {
 		return nil, err
 	}
+	if !validCommentSorts[request.Sort] {
+		return nil, &pkgerrs.ConfigError{Field: "Sort", Message: "invalid comment sort: " + request.Sort}
+	}
 
-	path := SubPrefixURL + request.Subreddit + "/comments/" + request.PostID
+	path := SubPrefixURL + subreddit + "/comments/" + request.PostID
 
 	// Build query parameters
 	params := buildPaginationParams(&request.Pagination)
+	if request.Sort != "" {
+		params.Set("sort", request.Sort)
+	}
+	if err := addExtraParams(params, request.Params); err != nil {
+		return nil, err
+	}
+	if request.RichText {
+		params.Set("rtj", "yes")
+	}
+	if request.LazyReplies {
+		ctx = internal.WithLazyReplies(ctx)
+	}
+	extractResult, partialErr := r.fetchComments(ctx, path, params)
+	if extractResult == nil {
+		return nil, partialErr
+	}
+
+	if request.ExcludeCollapsed {
+		extractResult.Comments = types.WithoutCollapsed(extractResult.Comments)
+	}
+	r.annotateComments(ctx, "get comments", flattenComments(extractResult.Comments))
+
+	// Note: post may be nil if Reddit only returned comments without the post
+	if request.RequirePost {
+		results := []*types.CommentsResponse{extractResult}
+		if err := r.fillMissingPosts(ctx, []*types.CommentsRequest{request}, results); err != nil {
+			return nil, err
+		}
+	}
+	return extractResult, partialErr
+}
+
+// GetCommentsListing is GetComments returning the top-level comments as a generic
+// types.Listing, with replies attached to their parents. Use GetComments for the post,
+// MoreIDs, and ContinueThreadLinks, which a Listing does not hold.
+//
+// Like GetComments, it returns a partly parsed page together with a *errors.PartialResultError.
+func (r *Reddit) GetCommentsListing(ctx context.Context, request *types.CommentsRequest) (*types.Listing[*types.Comment], error) {
+	resp, err := r.GetComments(ctx, request)
+	if resp == nil {
+		return nil, err
+	}
+	return resp.Listing(), err
+}
+
+// validCommentSorts are the comment orders Reddit accepts; empty uses Reddit's default.
+var validCommentSorts = map[string]bool{
+	"": true, "confidence": true, "top": true, "new": true, "controversial": true, "old": true, "random": true, CommentSortQA: true,
+}
+
+// fillMissingPosts sets Post on each response that lacks one and whose request has
+// RequirePost set, fetching all missing posts with a single /api/info request. results[i] is
+// the response for requests[i]; nil results are skipped.
+func (r *Reddit) fillMissingPosts(ctx context.Context, requests []*types.CommentsRequest, results []*types.CommentsResponse) error {
+	var fullnames []string
+	for i, resp := range results {
+		if resp != nil && resp.Post == nil && requests[i].RequirePost {
+			fullnames = append(fullnames, string(types.KIND_POST)+requests[i].PostID)
+		}
+	}
+	if len(fullnames) == 0 {
+		return nil
+	}
+
+	info, err := r.GetInfo(ctx, fullnames)
+	if err != nil {
+		return err
+	}
+	posts := make(map[string]*types.Post, len(info.Posts))
+	for _, p := range info.Posts {
+		posts[p.ID] = p
+	}
+	for i, resp := range results {
+		if resp == nil || resp.Post != nil || !requests[i].RequirePost {
+			continue
+		}
+		post, ok := posts[requests[i].PostID]
+		if !ok {
+			return &pkgerrs.ParseError{
+				Operation: "get comments",
+				Err:       fmt.Errorf("post %s missing from comments and info responses", requests[i].PostID),
+			}
+		}
+		resp.Post = post
+	}
+	return nil
+}
+
+// fetchComments requests a post's comment page at path and parses the post and comment tree.
+func (r *Reddit) fetchComments(ctx context.Context, path string, params url.Values) (*types.CommentsResponse, error) {
+	ctx, rateLimit := r.recordResponse(ctx)
 	httpReq, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil, params)
 	if err != nil {
 		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
@@ -635,11 +1255,20 @@ func (r *Reddit) GetComments(ctx context.Context, request *types.CommentsRequest
 
 	// Parse the post and comments
 	extractResult, err := r.parser.ExtractPostAndComments(ctx, result)
-	if err != nil {
+	if err != nil && (extractResult == nil || !extractResult.Partial) {
 		return nil, &pkgerrs.ParseError{Operation: "parse comments", Err: err}
 	}
-
-	// Note: post may be nil if Reddit only returned comments without the post
+	extractResult.RateLimit = rateLimit.Info()
+	extractResult.Meta = rateLimit.Meta()
+	r.attachSubredditInfo(extractResult.Post)
+	if extractResult.Partial {
+		return extractResult, &pkgerrs.PartialResultError{
+			Operation: "get comments",
+			Completed: 1,
+			Total:     2,
+			Err:       &pkgerrs.ParseError{Operation: "parse comments", Err: cmp.Or(extractResult.PostErr, extractResult.CommentsErr)},
+		}
+	}
 	return extractResult, nil
 }
 
@@ -656,7 +1285,20 @@ func (r *Reddit) GetComments(ctx context.Context, request *types.CommentsRequest
 //
 // The function uses a worker pool to limit concurrent goroutines (max MaxConcurrentCommentRequests),
 // preventing resource exhaustion when processing many requests. Results are collected in the original order.
-// If any request fails, the error is returned but successful responses are still included in the result slice.
+// If any request fails, the remaining requests are cancelled and the first error is returned; responses that
+// completed are still included in the result slice, with nil for the others. If ctx is cancelled, ctx.Err()
+// is returned.
+// A panic in a worker is recovered and reported as that request's *errors.PanicError.
+//
+// For requests with RequirePost set, posts that Reddit left out of the comments payload are
+// fetched together in one /api/info request after the batch, rather than one per request.
+//
+// With Config.BatchRetry, a failure does not cancel the batch. Once every request has been
+// tried, those that failed with a retryable transport error or a 429 or 5xx response are
+// tried again, and only those, until they succeed or BatchRetry.MaxRetries rounds have run.
+// If requests still fail, the other responses are returned with a *errors.PartialResultError
+// wrapping the error of the first failed request; the failed requests' slots are nil, even
+// for a request whose comments page was only partly parsed.
 //
 // Returns an error if any individual request fails or if too many requests are provided.
 func (r *Reddit) GetCommentsMultiple(ctx context.Context, requests []*types.CommentsRequest) ([]*types.CommentsResponse, error) {
@@ -692,7 +1334,7 @@ func (r *Reddit) GetCommentsMultiple(ctx context.Context, requests []*types.Comm
 			}
 		}
 		// Validate subreddit name format
-		if err := r.validator.ValidateSubredditName(req.Subreddit); err != nil {
+		if _, err := r.validator.NormalizeSubredditName(req.Subreddit); err != nil {
 			return nil, &pkgerrs.ConfigError{
 				Field:   fmt.Sprintf("requests[%d].Subreddit", i),
 				Message: err.Error(),
@@ -707,70 +1349,48 @@ func (r *Reddit) GetCommentsMultiple(ctx context.Context, requests []*types.Comm
 		}
 	}
 
-	// Create channels for results
-	type result struct {
-		index    int
-		response *types.CommentsResponse
-		err      error
-	}
-	resultChan := make(chan result, len(requests))
-
-	// Create semaphore channel to limit concurrent goroutines
-	semaphore := make(chan struct{}, MaxConcurrentCommentRequests)
-
-	// Launch goroutines for parallel fetching with worker pool
-	for i, req := range requests {
-		go func(index int, req *types.CommentsRequest) {
-			// Acquire semaphore slot (blocks if pool is full)
-			select {
-			case semaphore <- struct{}{}:
-				defer func() { <-semaphore }() // Release slot when done
-			case <-ctx.Done():
-				resultChan <- result{index: index, response: nil, err: ctx.Err()}
-				return
-			}
-
-			// Check if context is already cancelled before starting
-			select {
-			case <-ctx.Done():
-				resultChan <- result{index: index, response: nil, err: ctx.Err()}
-				return
-			default:
+	// Each worker writes only its own slot, so no further synchronization is needed.
+	results := make([]*types.CommentsResponse, len(requests))
+	fetch := func(ctx context.Context, i int) error {
+		// A panic while handling one post is reported as that request's error.
+		return r.safeCall(ctx, "get comments", func() (err error) {
+			// Missing posts are fetched for the whole batch below.
+			request := *requests[i]
+			request.RequirePost = false
+			// A failed request's slot stays nil, even when GetComments returned a partial page.
+			resp, err := r.GetComments(ctx, &request)
+			if err != nil {
+				return err
 			}
-
-			resp, err := r.GetComments(ctx, req)
-			resultChan <- result{index: index, response: resp, err: err}
-		}(i, req)
+			results[i] = resp
+			return nil
+		})
 	}
 
-	// Collect results
-	results := make([]*types.CommentsResponse, len(requests))
-	var firstError error
-	collected := 0
-	for collected < len(requests) {
-		select {
-		case res := <-resultChan:
-			if res.err != nil && firstError == nil {
-				firstError = res.err
-			}
-			results[res.index] = res.response
-			collected++
-		case <-ctx.Done():
-			// Context cancelled, collect remaining results but set error
-			if firstError == nil {
-				firstError = ctx.Err()
-			}
-			// Drain remaining results to prevent goroutine leaks
-			remaining := len(requests) - collected
-			for j := 0; j < remaining; j++ {
-				<-resultChan
-			}
-			return results, firstError
+	if r.config == nil || r.config.BatchRetry == nil {
+		if err := r.runWorkers(ctx, len(requests), MaxConcurrentCommentRequests, fetch); err != nil {
+			return results, err
 		}
+		return results, r.fillMissingPosts(ctx, requests, results)
 	}
 
-	if firstError != nil {
-		return results, firstError
+	errs, err := r.retryBatch(ctx, len(requests), MaxConcurrentCommentRequests, *r.config.BatchRetry, fetch)
+	if err != nil {
+		return results, err
+	}
+	if err := r.fillMissingPosts(ctx, requests, results); err != nil {
+		return results, err
+	}
+	partial := &pkgerrs.PartialResultError{Operation: "get comments", Total: len(requests)}
+	for _, err := range errs {
+		if err == nil {
+			partial.Completed++
+		} else if partial.Err == nil {
+			partial.Err = err
+		}
+	}
+	if partial.Err != nil {
+		return results, partial
 	}
 	return results, nil
 }
@@ -792,8 +1412,8 @@ func (r *Reddit) GetCommentsMultiple(ctx context.Context, requests []*types.Comm
 // The function automatically adds the "t3_" prefix to LinkID if not present. The returned
 // comments are in Reddit's API order, not necessarily the order of the input IDs.
 //
-// Note: Reddit has limits on how many comment IDs can be requested at once.
-// If you have many IDs, consider splitting them into multiple requests.
+// Note: Reddit accepts at most MaxMoreChildrenIDs comment IDs per request. Use
+// GetAllMoreComments to load more than that.
 //
 // Returns an error if:
 //   - The client is not connected
@@ -801,6 +1421,111 @@ func (r *Reddit) GetCommentsMultiple(ctx context.Context, requests []*types.Comm
 //   - The comment IDs are invalid
 //   - The API request fails
 func (r *Reddit) GetMoreComments(ctx context.Context, request *types.MoreCommentsRequest) ([]*types.Comment, error) {
+	if request != nil && request.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, request.Timeout)
+		defer cancel()
+	}
+	comments, err := r.getMoreComments(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	r.annotateComments(ctx, "get more comments", comments)
+	return comments, nil
+}
+
+// GetAllMoreComments loads any number of truncated comments, splitting CommentIDs into
+// requests of at most MaxMoreChildrenIDs and sending them one after another. Use
+// LoadMoreComments to also learn which requested comments Reddit did not return.
+//
+// If a request fails after others have succeeded, for example because ctx or
+// request.Timeout expired, the comments loaded so far are returned together with a
+// *errors.PartialResultError wrapping the cause; errors.Is(err, context.DeadlineExceeded)
+// reports a timeout. Partial results are not passed to Config.Annotator, since the
+// deadline has usually passed. If the first request fails, only the error is returned.
+//
+// Returns an error if the request is invalid or a request fails.
+func (r *Reddit) GetAllMoreComments(ctx context.Context, request *types.MoreCommentsRequest) ([]*types.Comment, error) {
+	result, err := r.LoadMoreComments(ctx, request)
+	if result == nil {
+		return nil, err
+	}
+	return result.Comments, err
+}
+
+// LoadMoreComments loads comments like GetAllMoreComments and also reports, per request,
+// which IDs were requested, which Reddit returned, and which it silently dropped, so callers
+// can tell deleted or removed comments apart from complete coverage.
+//
+// Partial results and errors are handled as in GetAllMoreComments: Batches lists only the
+// requests that succeeded.
+//
+// Returns an error if the request is invalid or a request fails.
+func (r *Reddit) LoadMoreComments(ctx context.Context, request *types.MoreCommentsRequest) (*types.MoreCommentsResult, error) {
+	if request == nil {
+		return nil, &pkgerrs.ConfigError{Message: "more comments request cannot be nil"}
+	}
+	chunks := slices.Collect(slices.Chunk(request.CommentIDs, MaxMoreChildrenIDs))
+	// Validate every chunk before sending any request.
+	for _, chunk := range chunks {
+		if err := r.validator.ValidateCommentIDs(chunk); err != nil {
+			return nil, err
+		}
+	}
+	if request.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, request.Timeout)
+		defer cancel()
+	}
+
+	result := &types.MoreCommentsResult{}
+	for i, chunk := range chunks {
+		chunkRequest := *request
+		chunkRequest.CommentIDs = chunk
+		err := ctx.Err()
+		if err == nil {
+			var loaded []*types.Comment
+			loaded, err = r.getMoreComments(ctx, &chunkRequest)
+			if err == nil {
+				result.Comments = append(result.Comments, loaded...)
+				result.Batches = append(result.Batches, newMoreCommentsBatch(chunk, loaded))
+			}
+		}
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+			return result, &pkgerrs.PartialResultError{
+				Operation: "get more comments",
+				Completed: i,
+				Total:     len(chunks),
+				Err:       err,
+			}
+		}
+	}
+	r.annotateComments(ctx, "get more comments", result.Comments)
+	return result, nil
+}
+
+// newMoreCommentsBatch records which of the requested IDs appear in loaded.
+func newMoreCommentsBatch(requested []string, loaded []*types.Comment) types.MoreCommentsBatch {
+	found := make(map[string]bool, len(loaded))
+	for _, c := range loaded {
+		found[c.ID] = true
+	}
+	batch := types.MoreCommentsBatch{Requested: slices.Clone(requested)}
+	for _, id := range requested {
+		if found[id] {
+			batch.Returned = append(batch.Returned, id)
+		} else {
+			batch.Missing = append(batch.Missing, id)
+		}
+	}
+	return batch
+}
+
+// getMoreComments implements GetMoreComments without running the configured Annotator.
+func (r *Reddit) getMoreComments(ctx context.Context, request *types.MoreCommentsRequest) ([]*types.Comment, error) {
 	if request == nil {
 		return nil, &pkgerrs.ConfigError{Message: "more comments request cannot be nil"}
 	}
@@ -903,15 +1628,46 @@ func buildPaginationParams(pagination *types.Pagination) url.Values {
 	return params
 }
 
+// reservedQueryParams are set by the wrapper and cannot be supplied through request Params.
+var reservedQueryParams = map[string]bool{
+	"limit":    true,
+	"after":    true,
+	"before":   true,
+	"t":        true,
+	"sort":     true,
+	"api_type": true,
+}
+
+// addExtraParams copies caller-supplied query parameters into params.
+// Returns a *errors.ConfigError if a key is empty, reserved, or a key or value contains
+// control characters.
+func addExtraParams(params, extra url.Values) error {
+	for key, values := range extra {
+		if key == "" || strings.ContainsFunc(key, unicode.IsControl) {
+			return &pkgerrs.ConfigError{Field: "Params", Message: fmt.Sprintf("invalid parameter name %q", key)}
+		}
+		if reservedQueryParams[strings.ToLower(key)] {
+			return &pkgerrs.ConfigError{Field: "Params", Message: fmt.Sprintf("parameter %q is set by the client and cannot be overridden", key)}
+		}
+		for _, value := range values {
+			if strings.ContainsFunc(value, unicode.IsControl) {
+				return &pkgerrs.ConfigError{Field: "Params", Message: fmt.Sprintf("parameter %q contains control characters", key)}
+			}
+			params.Add(key, value)
+		}
+	}
+	return nil
+}
+
 // addAuthHeaders adds authentication headers to a request.
 // This is called internally before each API request.
 func (r *Reddit) addAuthHeaders(ctx context.Context, req *http.Request) error {
-	token, err := r.auth.GetToken(ctx)
+	token, err := r.tokenProvider().GetToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get auth token: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+token)
-	return nil
+	return r.addExtraHeaders(ctx, req)
 }
 
 func mapAPIError(err error) (*pkgerrs.APIError, bool) {
@@ -922,14 +1678,43 @@ func mapAPIError(err error) (*pkgerrs.APIError, bool) {
 	return nil, false
 }
 
-// wrapDoError wraps errors from HTTP client Do operations, preserving APIErrors
-// and wrapping other errors as RequestErrors with context.
+// wrapDoError wraps errors from HTTP client Do operations, classifying APIErrors into
+// typed status errors with the targeted resource and wrapping other errors as RequestErrors.
+// Both keep the request's timing: the typed errors through the APIError they wrap.
 func wrapDoError(err error, operation, url string) error {
 	if err == nil {
 		return nil
 	}
 	if apiErr, ok := mapAPIError(err); ok {
-		return apiErr
+		return pkgerrs.ClassifyAPIError(apiErr, resourceContext(operation, url))
+	}
+	reqErr := &pkgerrs.RequestError{Operation: operation, URL: url, Err: err}
+	var clientErr *pkgerrs.ClientError
+	if errors.As(err, &clientErr) {
+		reqErr.Timing = clientErr.Timing
+	}
+	return reqErr
+}
+
+// resourceContext derives the subreddit and post targeted by a request path such as
+// "r/golang/comments/abc123" or "comments/abc123".
+func resourceContext(operation, path string) pkgerrs.ResourceContext {
+	ctx := pkgerrs.ResourceContext{Operation: operation, URL: path}
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		switch segments[i] {
+		case "r":
+			if ctx.Subreddit == "" {
+				ctx.Subreddit = segments[i+1]
+			}
+		case "comments":
+			if ctx.PostID == "" {
+				ctx.PostID = segments[i+1]
+			}
+		}
 	}
-	return &pkgerrs.RequestError{Operation: operation, URL: url, Err: err}
+	return ctx
 }
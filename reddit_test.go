@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -22,10 +24,12 @@ import (
 
 // mockHTTPClient implements the HTTPClient interface for testing
 type mockHTTPClient struct {
-	newRequestFunc     func(ctx context.Context, method, path string, body io.Reader, params ...url.Values) (*http.Request, error)
-	doFunc             func(req *http.Request, v *types.Thing) error
-	doThingArrayFunc   func(req *http.Request) ([]*types.Thing, error)
-	doMoreChildrenFunc func(req *http.Request) ([]*types.Thing, error)
+	newRequestFunc         func(ctx context.Context, method, path string, body io.Reader, params ...url.Values) (*http.Request, error)
+	newEndpointRequestFunc func(ctx context.Context, method string, endpoint internal.Endpoint, body io.Reader, params ...url.Values) (*http.Request, error)
+	doFunc                 func(req *http.Request, v *types.Thing) error
+	doThingArrayFunc       func(req *http.Request) ([]*types.Thing, error)
+	doMoreChildrenFunc     func(req *http.Request) ([]*types.Thing, error)
+	doJSONFunc             func(req *http.Request, v any) error
 }
 
 func (m *mockHTTPClient) NewRequest(ctx context.Context, method, path string, body io.Reader, params ...url.Values) (*http.Request, error) {
@@ -39,6 +43,20 @@ func (m *mockHTTPClient) NewRequest(ctx context.Context, method, path string, bo
 	return req, nil
 }
 
+func (m *mockHTTPClient) NewEndpointRequest(ctx context.Context, method string, endpoint internal.Endpoint, body io.Reader, params ...url.Values) (*http.Request, error) {
+	if m.newEndpointRequestFunc != nil {
+		return m.newEndpointRequestFunc(ctx, method, endpoint, body, params...)
+	}
+	if endpoint.Host == internal.HostAPI {
+		return m.NewRequest(ctx, method, endpoint.Path, body, params...)
+	}
+	req, _ := http.NewRequestWithContext(ctx, method, "https://www.reddit.com/"+endpoint.Path, body)
+	if len(params) > 0 && params[0] != nil {
+		req.URL.RawQuery = params[0].Encode()
+	}
+	return req, nil
+}
+
 func (m *mockHTTPClient) Do(req *http.Request, v *types.Thing) error {
 	if m.doFunc != nil {
 		return m.doFunc(req, v)
@@ -60,6 +78,13 @@ func (m *mockHTTPClient) DoMoreChildren(req *http.Request) ([]*types.Thing, erro
 	return nil, nil
 }
 
+func (m *mockHTTPClient) DoJSON(req *http.Request, v any) error {
+	if m.doJSONFunc != nil {
+		return m.doJSONFunc(req, v)
+	}
+	return nil
+}
+
 // mockTokenProvider implements the TokenProvider interface for testing
 type mockTokenProvider struct {
 	token string
@@ -338,6 +363,55 @@ func TestNewClientWithContext_AuthenticationFailure(t *testing.T) {
 	}
 }
 
+func TestNewClientWithContext_TokenProvider(t *testing.T) {
+	t.Parallel()
+
+	var authHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/access_token" {
+			t.Error("client with a TokenProvider requested a token")
+		}
+		authHeader = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"t2","data":{"name":"gopher","id":"abc","created":1700000000,"created_utc":1700000000}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	config := &Config{
+		TokenProvider: &mockTokenProvider{token: "external"},
+		UserAgent:     "tester",
+		AuthURL:       server.URL + "/",
+		BaseURL:       server.URL + "/",
+		HTTPClient:    server.Client(),
+	}
+	client, err := NewClientWithContext(context.Background(), config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Me(context.Background()); err != nil {
+		t.Fatalf("Me returned error: %v", err)
+	}
+	if authHeader != "Bearer external" {
+		t.Errorf("Authorization = %q, want the provider's token", authHeader)
+	}
+	var stateErr *pkgerrs.StateError
+	if err := client.UpdateCredentials(context.Background(), "id", "secret", ""); !errors.As(err, &stateErr) {
+		t.Errorf("UpdateCredentials error = %v, want StateError", err)
+	}
+
+	_, err = NewClientWithContext(context.Background(), &Config{TokenProvider: &mockTokenProvider{token: "t"}, ClientID: "id", ClientSecret: "secret"})
+	var configErr *pkgerrs.ConfigError
+	if !errors.As(err, &configErr) || configErr.Field != "TokenProvider" {
+		t.Errorf("TokenProvider with credentials error = %v, want TokenProvider ConfigError", err)
+	}
+
+	_, err = NewClientWithContext(context.Background(), &Config{TokenProvider: &mockTokenProvider{err: errors.New("vault sealed")}})
+	var authErr *pkgerrs.AuthError
+	if !errors.As(err, &authErr) {
+		t.Errorf("failing TokenProvider error = %v, want AuthError", err)
+	}
+}
+
 func TestNewClientWithContext_RateLimitConfig(t *testing.T) {
 	t.Parallel()
 
@@ -374,6 +448,45 @@ func TestNewClientWithContext_RateLimitConfig(t *testing.T) {
 	}
 }
 
+func TestNewClientWithContext_NetworkConfig(t *testing.T) {
+	t.Parallel()
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"stub","token_type":"bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	config := &Config{
+		ClientID:      "id",
+		ClientSecret:  "secret",
+		UserAgent:     "tester",
+		AuthURL:       tokenServer.URL + "/",
+		BaseURL:       tokenServer.URL + "/",
+		NetworkConfig: &NetworkConfig{IPv4Only: true, FallbackDelay: -1},
+	}
+	if _, err := NewClientWithContext(context.Background(), config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.HTTPClient == nil || config.HTTPClient.Transport == nil {
+		t.Fatal("expected the built-in transport to be configured")
+	}
+
+	config = &Config{
+		ClientID:      "id",
+		ClientSecret:  "secret",
+		UserAgent:     "tester",
+		AuthURL:       tokenServer.URL + "/",
+		BaseURL:       tokenServer.URL + "/",
+		NetworkConfig: &NetworkConfig{DNSServers: []string{"resolver.example"}},
+	}
+	_, err := NewClientWithContext(context.Background(), config)
+	var configErr *pkgerrs.ConfigError
+	if !errors.As(err, &configErr) || configErr.Field != "NetworkConfig.DNSServers" {
+		t.Fatalf("expected NetworkConfig.DNSServers ConfigError, got %v", err)
+	}
+}
+
 func TestClient_Me(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -464,7 +577,8 @@ func TestClient_Me(t *testing.T) {
 							t.Errorf("expected RequestError, got %T: %v", err, err)
 						}
 					case "APIError":
-						if _, ok := err.(*pkgerrs.APIError); !ok {
+						var apiErr *pkgerrs.APIError
+						if !errors.As(err, &apiErr) {
 							t.Errorf("expected APIError, got %T: %v", err, err)
 						}
 					}
@@ -801,7 +915,7 @@ func TestClient_getPostsErrors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			client := newTestClient(tt.httpClient, tt.auth)
-			_, err := client.getPosts(context.Background(), tt.request, "hot")
+			_, err := client.getPosts(context.Background(), tt.request, SortHot)
 			if err == nil {
 				t.Fatal("expected error but got none")
 			}
@@ -852,6 +966,29 @@ func TestClient_GetNew(t *testing.T) {
 	}
 }
 
+func TestClient_AcceptsPrefixedSubredditNames(t *testing.T) {
+	for _, name := range []string{"golang", "r/golang", "/r/golang", "/r/golang/"} {
+		t.Run(name, func(t *testing.T) {
+			var gotPath string
+			mock := &mockHTTPClient{
+				doFunc: func(req *http.Request, v *types.Thing) error {
+					gotPath = req.URL.Path
+					*v = types.Thing{Kind: "Listing", Data: json.RawMessage(`{"children":[]}`)}
+					return nil
+				},
+			}
+
+			client := newTestClient(mock, nil)
+			if _, err := client.GetNew(context.Background(), &types.PostsRequest{Subreddit: name}); err != nil {
+				t.Fatalf("GetNew(%q) returned error: %v", name, err)
+			}
+			if gotPath != "/r/golang/new" {
+				t.Errorf("GetNew(%q) requested %q, want /r/golang/new", name, gotPath)
+			}
+		})
+	}
+}
+
 func TestClient_GetComments(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -1115,7 +1252,8 @@ func TestClient_GetComments(t *testing.T) {
 							t.Errorf("expected RequestError, got %T: %v", err, err)
 						}
 					case "APIError":
-						if _, ok := err.(*pkgerrs.APIError); !ok {
+						var apiErr *pkgerrs.APIError
+						if !errors.As(err, &apiErr) {
 							t.Errorf("expected APIError, got %T: %v", err, err)
 						}
 					case "AuthError":
@@ -1734,3 +1872,332 @@ func TestClient_addAuthHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapDoError_ClassifiesStatus(t *testing.T) {
+	apiErr := &pkgerrs.APIError{StatusCode: http.StatusForbidden, Message: "request failed", Reason: pkgerrs.ReasonQuarantined}
+	err := wrapDoError(apiErr, "get comments", "r/golang/comments/abc123?limit=10")
+
+	var forbidden *pkgerrs.ForbiddenError
+	if !errors.As(err, &forbidden) {
+		t.Fatalf("wrapDoError() = %T, want *ForbiddenError", err)
+	}
+	if forbidden.Subreddit != "golang" || forbidden.PostID != "abc123" {
+		t.Errorf("context = %+v, want subreddit golang and post abc123", forbidden.ResourceContext)
+	}
+	if !forbidden.IsQuarantined() {
+		t.Error("expected IsQuarantined to be true")
+	}
+
+	var notFound *pkgerrs.NotFoundError
+	if !errors.As(wrapDoError(&pkgerrs.APIError{StatusCode: http.StatusNotFound}, "get comments", "comments/xyz"), &notFound) || notFound.PostID != "xyz" {
+		t.Errorf("expected NotFoundError for post xyz, got %v", notFound)
+	}
+}
+
+func TestWrapDoError_KeepsTiming(t *testing.T) {
+	timing := &types.RequestTiming{DNS: time.Second, Total: 2 * time.Second}
+	err := wrapDoError(&pkgerrs.ClientError{Err: io.ErrUnexpectedEOF, Timing: timing}, "get hot posts", "r/golang/hot")
+
+	var reqErr *pkgerrs.RequestError
+	if !errors.As(err, &reqErr) || reqErr.Timing != timing {
+		t.Fatalf("wrapDoError() = %#v, want RequestError with the client's timing", err)
+	}
+	if reqErr.Timing.Network() != time.Second {
+		t.Errorf("Network() = %v, want 1s", reqErr.Timing.Network())
+	}
+
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusServiceUnavailable} {
+		err := wrapDoError(&pkgerrs.APIError{StatusCode: status, Timing: timing}, "get hot posts", "r/golang/hot")
+		var apiErr *pkgerrs.APIError
+		if !errors.As(err, &apiErr) || apiErr.Timing != timing {
+			t.Errorf("wrapDoError(%d) = %#v, want an error carrying the request's timing", status, err)
+		}
+	}
+}
+
+func TestClient_GetComments_RequirePost(t *testing.T) {
+	var infoCalls []string
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			// Comments payload without the post.
+			return []*types.Thing{listingThing(t), listingThing(t, commentThing(t, "c1", "hi", false))}, nil
+		},
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			ids := req.URL.Query().Get("id")
+			infoCalls = append(infoCalls, ids)
+			var posts []*types.Thing
+			for _, id := range strings.Split(ids, ",") {
+				if id != "t3_gone" {
+					posts = append(posts, submitPostThing(t, strings.TrimPrefix(id, "t3_"), "title", "gopher", time.Unix(1700000000, 0)))
+				}
+			}
+			*v = *listingThing(t, posts...)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	resp, err := client.GetComments(ctx, &types.CommentsRequest{Subreddit: "golang", PostID: "abc123"})
+	if err != nil || resp.Post != nil || len(infoCalls) != 0 {
+		t.Fatalf("without RequirePost: post = %v, err = %v, info calls = %v", resp.Post, err, infoCalls)
+	}
+
+	resp, err = client.GetComments(ctx, &types.CommentsRequest{Subreddit: "golang", PostID: "abc123", RequirePost: true})
+	if err != nil {
+		t.Fatalf("GetComments returned error: %v", err)
+	}
+	if resp.Post == nil || resp.Post.ID != "abc123" || len(resp.Comments) != 1 {
+		t.Errorf("GetComments() post = %+v, comments = %d", resp.Post, len(resp.Comments))
+	}
+
+	infoCalls = nil
+	results, err := client.GetCommentsMultiple(ctx, []*types.CommentsRequest{
+		{Subreddit: "golang", PostID: "p1", RequirePost: true},
+		{Subreddit: "golang", PostID: "p2"},
+		{Subreddit: "golang", PostID: "p3", RequirePost: true},
+	})
+	if err != nil {
+		t.Fatalf("GetCommentsMultiple returned error: %v", err)
+	}
+	if len(infoCalls) != 1 || !strings.Contains(infoCalls[0], "t3_p1") || !strings.Contains(infoCalls[0], "t3_p3") || strings.Contains(infoCalls[0], "t3_p2") {
+		t.Errorf("info calls = %v, want one call for t3_p1 and t3_p3", infoCalls)
+	}
+	if results[0].Post == nil || results[0].Post.ID != "p1" || results[1].Post != nil || results[2].Post == nil || results[2].Post.ID != "p3" {
+		t.Errorf("posts = %v, %v, %v", results[0].Post, results[1].Post, results[2].Post)
+	}
+
+	_, err = client.GetComments(ctx, &types.CommentsRequest{Subreddit: "golang", PostID: "gone", RequirePost: true})
+	var parseErr *pkgerrs.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("missing post error = %v, want *errors.ParseError", err)
+	}
+}
+
+func TestClient_GetAllMoreComments(t *testing.T) {
+	ids := make([]string, 250)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("c%d", i)
+	}
+
+	t.Run("chunks requests", func(t *testing.T) {
+		var sizes []int
+		mock := &mockHTTPClient{
+			doMoreChildrenFunc: func(req *http.Request) ([]*types.Thing, error) {
+				if err := req.ParseForm(); err != nil {
+					t.Fatalf("parse form: %v", err)
+				}
+				children := strings.Split(req.PostForm.Get("children"), ",")
+				sizes = append(sizes, len(children))
+				return []*types.Thing{commentThing(t, children[0], "body", false)}, nil
+			},
+		}
+		client := newTestClient(mock, nil)
+
+		comments, err := client.GetAllMoreComments(context.Background(), &types.MoreCommentsRequest{LinkID: "post1", CommentIDs: ids})
+		if err != nil {
+			t.Fatalf("GetAllMoreComments returned error: %v", err)
+		}
+		if !reflect.DeepEqual(sizes, []int{100, 100, 50}) {
+			t.Errorf("chunk sizes = %v, want [100 100 50]", sizes)
+		}
+		if len(comments) != 3 || comments[2].ID != "c200" {
+			t.Errorf("comments = %d, want one per chunk", len(comments))
+		}
+	})
+
+	t.Run("partial results on cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls int
+		mock := &mockHTTPClient{
+			doMoreChildrenFunc: func(req *http.Request) ([]*types.Thing, error) {
+				calls++
+				if calls == 2 {
+					cancel()
+					return nil, ctx.Err()
+				}
+				return []*types.Thing{commentThing(t, fmt.Sprintf("c%d", calls), "body", false)}, nil
+			},
+		}
+		client := newTestClient(mock, nil)
+
+		comments, err := client.GetAllMoreComments(ctx, &types.MoreCommentsRequest{LinkID: "post1", CommentIDs: ids})
+		var partial *pkgerrs.PartialResultError
+		if !errors.As(err, &partial) {
+			t.Fatalf("error = %v, want *errors.PartialResultError", err)
+		}
+		if partial.Completed != 1 || partial.Total != 3 || !errors.Is(err, context.Canceled) {
+			t.Errorf("partial = %+v", partial)
+		}
+		if len(comments) != 1 || comments[0].ID != "c1" {
+			t.Errorf("comments = %+v, want the first chunk's comment", comments)
+		}
+		if calls != 2 {
+			t.Errorf("calls = %d, want 2 (no request after cancellation)", calls)
+		}
+	})
+
+	t.Run("first request fails", func(t *testing.T) {
+		mock := &mockHTTPClient{
+			doMoreChildrenFunc: func(req *http.Request) ([]*types.Thing, error) {
+				return nil, context.DeadlineExceeded
+			},
+		}
+		client := newTestClient(mock, nil)
+		comments, err := client.GetAllMoreComments(context.Background(), &types.MoreCommentsRequest{LinkID: "post1", CommentIDs: ids})
+		var partial *pkgerrs.PartialResultError
+		if comments != nil || err == nil || errors.As(err, &partial) {
+			t.Errorf("got %v, %v; want nil comments and a non-partial error", comments, err)
+		}
+	})
+
+	t.Run("invalid ID in later chunk", func(t *testing.T) {
+		client := newTestClient(&mockHTTPClient{}, nil)
+		bad := append(slices.Clone(ids), "bad id!")
+		if _, err := client.GetAllMoreComments(context.Background(), &types.MoreCommentsRequest{LinkID: "post1", CommentIDs: bad}); err == nil {
+			t.Error("expected validation error")
+		}
+	})
+}
+
+func TestClient_LoadMoreComments(t *testing.T) {
+	ids := make([]string, 150)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("c%d", i)
+	}
+	mock := &mockHTTPClient{
+		doMoreChildrenFunc: func(req *http.Request) ([]*types.Thing, error) {
+			if err := req.ParseForm(); err != nil {
+				t.Fatalf("parse form: %v", err)
+			}
+			// Reddit drops deleted comments (every third ID here) and includes unrequested replies.
+			var things []*types.Thing
+			for _, id := range strings.Split(req.PostForm.Get("children"), ",") {
+				var n int
+				fmt.Sscanf(id, "c%d", &n)
+				if n%3 != 0 {
+					things = append(things, commentThing(t, id, "body", false))
+				}
+			}
+			things = append(things, commentThing(t, fmt.Sprintf("reply%d", len(things)), "body", false))
+			return things, nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	result, err := client.LoadMoreComments(context.Background(), &types.MoreCommentsRequest{LinkID: "post1", CommentIDs: ids})
+	if err != nil {
+		t.Fatalf("LoadMoreComments returned error: %v", err)
+	}
+	if len(result.Batches) != 2 {
+		t.Fatalf("batches = %d, want 2", len(result.Batches))
+	}
+	first, second := result.Batches[0], result.Batches[1]
+	if len(first.Requested) != 100 || len(first.Returned) != 66 || len(first.Missing) != 34 || first.Missing[1] != "c3" {
+		t.Errorf("first batch: requested %d, returned %d, missing %d (%v)",
+			len(first.Requested), len(first.Returned), len(first.Missing), first.Missing[:2])
+	}
+	if len(second.Requested) != 50 || second.Requested[0] != "c100" || len(second.Missing) != 16 {
+		t.Errorf("second batch: requested %d from %s, missing %d", len(second.Requested), second.Requested[0], len(second.Missing))
+	}
+	if got := result.Missing(); len(got) != 50 || got[0] != "c0" || got[49] != "c147" {
+		t.Errorf("Missing() = %d IDs, want 50 from c0 to c147", len(got))
+	}
+	if len(result.Comments) != 66+1+34+1 {
+		t.Errorf("comments = %d, want returned IDs plus one extra reply per batch", len(result.Comments))
+	}
+}
+
+func TestClient_GetComments_RichText(t *testing.T) {
+	var rtj []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rtj = append(rtj, req.URL.Query().Get("rtj"))
+		var comment map[string]any
+		_ = json.Unmarshal(commentThing(t, "c1", "**hi** u/spez", false).Data, &comment)
+		if req.URL.Query().Get("rtj") == "yes" {
+			comment["rtjson"] = json.RawMessage(`{"document": [{"e": "par", "c": [
+				{"e": "text", "t": "hi ", "f": [[1, 0, 2]]}, {"e": "u/", "t": "spez"}
+			]}]}`)
+		}
+		json.NewEncoder(w).Encode([]any{
+			listingThing(t, submitPostThing(t, "post1", "Title", "gopher", time.Unix(1700000000, 0))),
+			map[string]any{"kind": "Listing", "data": map[string]any{"children": []any{
+				map[string]any{"kind": "t1", "data": comment},
+			}}},
+		})
+	}))
+	defer server.Close()
+
+	httpClient, err := internal.NewClient(server.Client(), server.URL, "test/1.0", nil)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	client := newTestClient(httpClient, nil)
+	ctx := context.Background()
+
+	resp, err := client.GetComments(ctx, &types.CommentsRequest{Subreddit: "golang", PostID: "post1", RichText: true})
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	doc, err := resp.Comments[0].RichText()
+	if err != nil || doc == nil {
+		t.Fatalf("RichText() = %v, %v; want a document", doc, err)
+	}
+	if got := doc.PlainText(); got != "hi u/spez" {
+		t.Errorf("PlainText() = %q, want %q", got, "hi u/spez")
+	}
+	if mentions := doc.Nodes(types.RichTextUserMention); len(mentions) != 1 || mentions[0].Text != "spez" {
+		t.Errorf("user mentions = %+v, want spez", mentions)
+	}
+
+	resp, err = client.GetComments(ctx, &types.CommentsRequest{Subreddit: "golang", PostID: "post1"})
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if doc, err := resp.Comments[0].RichText(); doc != nil || err != nil {
+		t.Errorf("RichText() without the option = %v, %v; want nil, nil", doc, err)
+	}
+	if !slices.Equal(rtj, []string{"yes", ""}) {
+		t.Errorf("rtj parameters = %q, want [yes, none]", rtj)
+	}
+}
+
+func TestClient_GetComments_PartialResponse(t *testing.T) {
+	var calls int
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			calls++
+			comments := &types.Thing{Kind: "t3", Data: json.RawMessage(`{}`)} // not a Listing
+			if calls > 1 {
+				comments = listingThing(t, commentThing(t, "c1", "hello", false))
+			}
+			return []*types.Thing{
+				listingThing(t, submitPostThing(t, "post1", "Title", "gopher", time.Unix(1700000000, 0))),
+				comments,
+			}, nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	request := &types.CommentsRequest{Subreddit: "golang", PostID: "post1"}
+
+	resp, err := client.GetComments(context.Background(), request)
+	var partial *pkgerrs.PartialResultError
+	if !errors.As(err, &partial) || partial.Completed != 1 || partial.Total != 2 {
+		t.Fatalf("GetComments() error = %v, want PartialResultError for 1 of 2 listings", err)
+	}
+	if resp == nil || resp.Post == nil || !resp.Partial || resp.CommentsErr == nil {
+		t.Fatalf("GetComments() = %+v, want the post with CommentsErr", resp)
+	}
+
+	// GetCommentsMultiple counts a partial page as a failure, and BatchRetry retries it.
+	calls = 0
+	client.config.BatchRetry = &RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond}
+	results, err := client.GetCommentsMultiple(context.Background(), []*types.CommentsRequest{request})
+	if err != nil {
+		t.Fatalf("GetCommentsMultiple() error = %v", err)
+	}
+	if calls != 2 || len(results[0].Comments) != 1 || results[0].Partial {
+		t.Errorf("GetCommentsMultiple() made %d calls, got %+v; want a retried complete page", calls, results[0])
+	}
+}
@@ -0,0 +1,183 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// removalModLogLimit is how many moderation log entries of each removal action
+// GetRemovalInfo searches for a post.
+const removalModLogLimit = 500
+
+// removalActions are the moderation log actions that remove a post.
+var removalActions = []string{"removelink", "spamlink"}
+
+// removalCategories describes the values Reddit reports in removed_by_category.
+var removalCategories = map[string]string{
+	"moderator":          "removed by a moderator",
+	"automod_filtered":   "filtered by AutoModerator",
+	"reddit":             "removed by Reddit",
+	"anti_evil_ops":      "removed by Reddit's Anti-Evil Operations team",
+	"community_ops":      "removed by Reddit's Community team",
+	"legal_operations":   "removed by Reddit's legal operations team",
+	"copyright_takedown": "removed in response to a copyright notice",
+	"content_takedown":   "removed in response to a legal takedown request",
+	"deleted":            "deleted by its author",
+	"author":             "deleted by its author",
+}
+
+// GetRemovalInfo explains, as far as it can, why a post was removed. The ID may be given
+// with or without the "t3_" prefix.
+//
+// It is best effort. With user authentication the subreddit's moderation log is searched
+// first; it is only readable by the subreddit's moderators, and other accounts skip it. Then
+// the post's top-level comments are searched for a moderator's removal comment, preferring a
+// stickied one. Failing both, the explanation is derived from the post's
+// removed_by_category. RemovalInfo.Source says which of these applied.
+//
+// Posts that were not removed are reported with Status ContentActive, and posts that do not
+// exist or cannot be seen with Status ContentNotFound.
+//
+// Returns an error if the ID is invalid or a request fails for a reason other than missing
+// moderator access.
+func (r *Reddit) GetRemovalInfo(ctx context.Context, fullname string) (*types.RemovalInfo, error) {
+	postID := strings.TrimPrefix(fullname, string(types.KIND_POST))
+	if err := r.validator.ValidatePostID(postID); err != nil {
+		return nil, err
+	}
+	fullname = string(types.KIND_POST) + postID
+
+	posts, err := r.GetInfo(ctx, []string{fullname})
+	if err != nil {
+		return nil, err
+	}
+	info := &types.RemovalInfo{Fullname: fullname, Status: types.ContentNotFound}
+	if len(posts.Posts) == 0 {
+		return info, nil
+	}
+	post := posts.Posts[0]
+	info.Status = postStatus(post)
+	if post.RemovedByCategory == nil {
+		return info, nil
+	}
+	info.Category = *post.RemovedByCategory
+	info.Source = types.RemovalSourceCategory
+	info.Reason = describeRemovalCategory(info.Category)
+	if info.Status == types.ContentDeleted {
+		return info, nil
+	}
+
+	if r.config != nil && r.config.Username != "" {
+		action, err := r.findRemovalAction(ctx, post.Subreddit, fullname)
+		if err != nil {
+			return nil, err
+		}
+		if action != nil {
+			info.Source = types.RemovalSourceModLog
+			info.Action = action
+			info.Moderator = action.Mod
+			if reason := strings.TrimSpace(action.Details + " " + action.Description); reason != "" {
+				info.Reason = reason
+			}
+			return info, nil
+		}
+	}
+
+	comments, err := r.GetComments(ctx, &types.CommentsRequest{Subreddit: post.Subreddit, PostID: postID})
+	if err != nil {
+		return nil, err
+	}
+	if comment := findModComment(comments.Comments); comment != nil {
+		info.Source = types.RemovalSourceModComment
+		info.ModComment = comment
+		info.Moderator = comment.Author
+		info.Reason = comment.Body
+	}
+	return info, nil
+}
+
+// findRemovalAction searches a subreddit's moderation log for the removal of fullname. It
+// returns nil if the log has no such entry or the client cannot read it.
+func (r *Reddit) findRemovalAction(ctx context.Context, subreddit, fullname string) (*types.ModAction, error) {
+	for _, action := range removalActions {
+		entries, err := r.modLog(ctx, subreddit, action)
+		if err != nil {
+			var forbidden *pkgerrs.ForbiddenError
+			var notFound *pkgerrs.NotFoundError
+			if errors.As(err, &forbidden) || errors.As(err, &notFound) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		for i := range entries {
+			if entries[i].TargetFullname == fullname {
+				return &entries[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// modLog fetches the newest moderation log entries of a subreddit for one action.
+func (r *Reddit) modLog(ctx context.Context, subreddit, action string) ([]types.ModAction, error) {
+	path := fmt.Sprintf(ModLogURLFormat, subreddit)
+	params := url.Values{"type": {action}, "limit": {fmt.Sprint(removalModLogLimit)}}
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil, params)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var listing struct {
+		Data struct {
+			Children []struct {
+				Data types.ModAction `json:"data"`
+			} `json:"children"`
+		} `json:"data"`
+	}
+	if err := r.httpClient.DoJSON(req, &listing); err != nil {
+		return nil, wrapDoError(err, "get moderation log", path)
+	}
+	entries := make([]types.ModAction, 0, len(listing.Data.Children))
+	for _, child := range listing.Data.Children {
+		entries = append(entries, child.Data)
+	}
+	return entries, nil
+}
+
+// findModComment returns the top-level comment a moderator left in that capacity,
+// preferring a stickied one, or nil.
+func findModComment(comments []*types.Comment) *types.Comment {
+	var found *types.Comment
+	for _, c := range comments {
+		if c == nil || c.Distinguished == nil || *c.Distinguished != "moderator" {
+			continue
+		}
+		if c.Stickied {
+			return c
+		}
+		if found == nil {
+			found = c
+		}
+	}
+	return found
+}
+
+// describeRemovalCategory returns a short explanation of a removed_by_category value.
+func describeRemovalCategory(category string) string {
+	if description, ok := removalCategories[category]; ok {
+		return description
+	}
+	return fmt.Sprintf("removed (%s)", category)
+}
@@ -0,0 +1,131 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// withFields returns thing with fields added to its data.
+func withFields(t *testing.T, thing *types.Thing, fields map[string]any) *types.Thing {
+	t.Helper()
+	var data map[string]any
+	if err := json.Unmarshal(thing.Data, &data); err != nil {
+		t.Fatalf("unmarshal thing: %v", err)
+	}
+	for k, v := range fields {
+		data[k] = v
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatalf("marshal thing: %v", err)
+	}
+	return &types.Thing{Kind: thing.Kind, Data: raw}
+}
+
+// removalMock serves a post with the given removed_by_category from api/info, its comments,
+// and the subreddit's moderation log, which fails with modLogErr if set.
+func removalMock(t *testing.T, category any, comments []*types.Thing, modLog []types.ModAction, modLogErr error) *mockHTTPClient {
+	post := withFields(t, submitPostThing(t, "abc123", "title", "gopher", time.Unix(1700000000, 0)), map[string]any{"removed_by_category": category})
+	return &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = *listingThing(t, post)
+			return nil
+		},
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			return []*types.Thing{listingThing(t, post), listingThing(t, comments...)}, nil
+		},
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.URL.Path != "/r/golang/about/log" {
+				t.Errorf("unexpected request %s", req.URL.Path)
+			}
+			if modLogErr != nil {
+				return modLogErr
+			}
+			children := []map[string]any{}
+			for _, a := range modLog {
+				if a.Action == req.URL.Query().Get("type") {
+					children = append(children, map[string]any{"kind": "modaction", "data": a})
+				}
+			}
+			data, _ := json.Marshal(map[string]any{"kind": "Listing", "data": map[string]any{"children": children}})
+			return json.Unmarshal(data, v)
+		},
+	}
+}
+
+func TestGetRemovalInfo(t *testing.T) {
+	modComment := withFields(t, commentThing(t, "m1", "Removed: rule 3, no memes.", false), map[string]any{"distinguished": "moderator", "author": "gomod", "stickied": true})
+	plainComment := commentThing(t, "c1", "why was this removed?", false)
+	removal := types.ModAction{ID: "ModAction_1", Action: "spamlink", Mod: "gomod", TargetFullname: "t3_abc123", Details: "spam"}
+
+	tests := []struct {
+		name          string
+		category      any
+		userAuth      bool
+		comments      []*types.Thing
+		modLog        []types.ModAction
+		modLogErr     error
+		wantStatus    types.ContentStatus
+		wantSource    types.RemovalSource
+		wantReason    string
+		wantModerator string
+	}{
+		{name: "not removed", category: nil, wantStatus: types.ContentActive},
+		{name: "deleted by author", category: "deleted", wantStatus: types.ContentDeleted, wantSource: types.RemovalSourceCategory, wantReason: "deleted by its author"},
+		{name: "category only", category: "automod_filtered", comments: []*types.Thing{plainComment}, wantStatus: types.ContentRemoved, wantSource: types.RemovalSourceCategory, wantReason: "filtered by AutoModerator"},
+		{name: "mod comment", category: "moderator", comments: []*types.Thing{plainComment, modComment}, wantStatus: types.ContentRemoved, wantSource: types.RemovalSourceModComment, wantReason: "Removed: rule 3, no memes.", wantModerator: "gomod"},
+		{name: "mod log", category: "moderator", userAuth: true, modLog: []types.ModAction{removal}, wantStatus: types.ContentRemoved, wantSource: types.RemovalSourceModLog, wantReason: "spam", wantModerator: "gomod"},
+		{
+			name: "mod log forbidden", category: "moderator", userAuth: true, comments: []*types.Thing{modComment},
+			modLogErr:  &pkgerrs.APIError{StatusCode: http.StatusForbidden, Message: "Forbidden"},
+			wantStatus: types.ContentRemoved, wantSource: types.RemovalSourceModComment, wantReason: "Removed: rule 3, no memes.", wantModerator: "gomod",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(removalMock(t, tt.category, tt.comments, tt.modLog, tt.modLogErr), nil)
+			if tt.userAuth {
+				client.config.Username = "gomod"
+			}
+
+			info, err := client.GetRemovalInfo(context.Background(), "t3_abc123")
+			if err != nil {
+				t.Fatalf("GetRemovalInfo returned error: %v", err)
+			}
+			if info.Fullname != "t3_abc123" || info.Status != tt.wantStatus || info.Source != tt.wantSource {
+				t.Errorf("info = %+v, want status %s from %q", info, tt.wantStatus, tt.wantSource)
+			}
+			if info.Reason != tt.wantReason || info.Moderator != tt.wantModerator {
+				t.Errorf("reason = %q by %q, want %q by %q", info.Reason, info.Moderator, tt.wantReason, tt.wantModerator)
+			}
+			if (tt.wantSource == types.RemovalSourceModLog) != (info.Action != nil) {
+				t.Errorf("Action = %+v", info.Action)
+			}
+			if (tt.wantSource == types.RemovalSourceModComment) != (info.ModComment != nil) {
+				t.Errorf("ModComment = %+v", info.ModComment)
+			}
+		})
+	}
+}
+
+func TestGetRemovalInfo_NotFound(t *testing.T) {
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = *listingThing(t)
+			return nil
+		},
+	}
+	info, err := newTestClient(mock, nil).GetRemovalInfo(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("GetRemovalInfo returned error: %v", err)
+	}
+	if info.Status != types.ContentNotFound || info.Fullname != "t3_abc123" {
+		t.Errorf("info = %+v, want not found", info)
+	}
+}
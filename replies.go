@@ -0,0 +1,28 @@
+package graw
+
+import (
+	"context"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// LoadReplies parses the replies of a comment fetched with CommentsRequest.LazyReplies and
+// runs the configured Annotator on them. Each reply keeps its own replies unparsed; call
+// LoadReplies on it to go a level deeper. No request is made, and it does nothing if the
+// comment's replies are already loaded.
+//
+// Returns an error if comment is nil or its raw replies cannot be parsed.
+func (r *Reddit) LoadReplies(ctx context.Context, comment *types.Comment) error {
+	if comment == nil {
+		return &pkgerrs.ConfigError{Field: "comment", Message: "comment cannot be nil"}
+	}
+	if comment.RepliesLoaded() {
+		return nil
+	}
+	if err := comment.LoadReplies(ctx, r.parser); err != nil {
+		return &pkgerrs.ParseError{Operation: "parse replies", Err: err}
+	}
+	r.annotateComments(ctx, "load replies", comment.Replies)
+	return nil
+}
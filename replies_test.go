@@ -0,0 +1,123 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestLoadReplies(t *testing.T) {
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			var things []*types.Thing
+			if err := json.Unmarshal(grawtest.NewCommentTree(2, 3), &things); err != nil {
+				t.Fatal(err)
+			}
+			return things, nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	resp, err := client.GetComments(ctx, &types.CommentsRequest{
+		Subreddit:   grawtest.FixtureSubreddit,
+		PostID:      grawtest.FixturePostID,
+		LazyReplies: true,
+	})
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if len(resp.Comments) != 3 {
+		t.Fatalf("got %d top-level comments, want 3", len(resp.Comments))
+	}
+	top := resp.Comments[0]
+	if top.RepliesLoaded() || len(top.Replies) != 0 {
+		t.Fatalf("replies were parsed eagerly: %d replies", len(top.Replies))
+	}
+
+	if err := client.LoadReplies(ctx, top); err != nil {
+		t.Fatalf("LoadReplies() error = %v", err)
+	}
+	if !top.RepliesLoaded() || len(top.Replies) != 3 {
+		t.Fatalf("after LoadReplies got %d replies, loaded = %v", len(top.Replies), top.RepliesLoaded())
+	}
+	if err := client.LoadReplies(ctx, top); err != nil || len(top.Replies) != 3 {
+		t.Errorf("second LoadReplies() = %v with %d replies, want a no-op", err, len(top.Replies))
+	}
+
+	eager, err := client.GetComments(ctx, &types.CommentsRequest{Subreddit: grawtest.FixtureSubreddit, PostID: grawtest.FixturePostID})
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if !eager.Comments[0].RepliesLoaded() || len(eager.Comments[0].Replies) != 3 {
+		t.Errorf("eager comment has %d replies", len(eager.Comments[0].Replies))
+	}
+}
+
+func TestLoadReplies_Errors(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+
+	var cfgErr *pkgerrs.ConfigError
+	if err := client.LoadReplies(context.Background(), nil); !errors.As(err, &cfgErr) {
+		t.Errorf("LoadReplies(nil) error = %v, want ConfigError", err)
+	}
+
+	comment := &types.Comment{RawReplies: json.RawMessage(`{"kind":"t1","data":{}}`)}
+	var parseErr *pkgerrs.ParseError
+	if err := client.LoadReplies(context.Background(), comment); !errors.As(err, &parseErr) {
+		t.Errorf("LoadReplies() error = %v, want ParseError", err)
+	}
+	if comment.RepliesLoaded() {
+		t.Error("RawReplies were released after a failed parse")
+	}
+}
+
+func TestParserMaxDepth(t *testing.T) {
+	mux := http.NewServeMux()
+	grawtest.HandleToken(mux, grawtest.StaticTokenHandler("test-token"))
+	mux.HandleFunc("/r/"+grawtest.FixtureSubreddit+"/comments/"+grawtest.FixturePostID, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(grawtest.NewCommentTree(3, 2))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	config := &Config{
+		ClientID: "id", ClientSecret: "secret", UserAgent: "test/1.0",
+		BaseURL: server.URL + "/", AuthURL: server.URL + "/", WebURL: server.URL + "/",
+		HTTPClient: server.Client(),
+		Parser:     &ParserOptions{MaxDepth: 1},
+	}
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	resp, err := client.GetComments(context.Background(), &types.CommentsRequest{Subreddit: grawtest.FixtureSubreddit, PostID: grawtest.FixturePostID})
+	if err != nil {
+		t.Fatalf("GetComments returned error: %v", err)
+	}
+	if len(resp.Comments) != 2 {
+		t.Fatalf("got %d top-level comments, want 2", len(resp.Comments))
+	}
+	for _, c := range resp.Comments {
+		if !c.Truncated || len(c.Replies) != 0 || len(c.MoreChildrenIDs) != 2 {
+			t.Errorf("comment %s: truncated=%v, %d replies, more=%v; want its replies recorded, not parsed", c.ID, c.Truncated, len(c.Replies), c.MoreChildrenIDs)
+		}
+	}
+	if len(resp.MoreIDs) != 4 {
+		t.Errorf("MoreIDs = %v, want the 4 second-level comments", resp.MoreIDs)
+	}
+
+	config.Parser.MaxDepth = -1
+	_, err = NewClient(config)
+	var cfgErr *pkgerrs.ConfigError
+	if !errors.As(err, &cfgErr) || cfgErr.Field != "Parser.MaxDepth" {
+		t.Errorf("NewClient error = %v, want Parser.MaxDepth ConfigError", err)
+	}
+}
@@ -0,0 +1,28 @@
+package graw
+
+import (
+	"context"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+)
+
+// capturedHeaders are the response headers kept in types.ResponseMeta when
+// Config.CaptureHeaders is enabled.
+var capturedHeaders = []string{
+	"X-Reddit-Loid",
+	"X-Request-Id",
+	"X-Cache",
+	"X-Cache-Hits",
+	"X-Served-By",
+	"X-Timer",
+}
+
+// recordResponse returns a context whose requests report their rate-limit headers, and with
+// Config.CaptureHeaders their diagnostic headers, to a new recorder.
+func (r *Reddit) recordResponse(ctx context.Context) (context.Context, *internal.RateLimitRecorder) {
+	ctx, rec := internal.WithRateLimitRecorder(ctx)
+	if r.config != nil && r.config.CaptureHeaders {
+		rec.CaptureHeaders(capturedHeaders...)
+	}
+	return ctx, rec
+}
@@ -0,0 +1,62 @@
+package graw
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestCaptureHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	grawtest.HandleToken(mux, grawtest.StaticTokenHandler("test-token"))
+	mux.HandleFunc("/r/golang/new", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Reddit-Loid", "000000000abc.2.1700000000000.Z0FBQUFB")
+		w.Header().Set("X-Request-Id", "req-42")
+		w.Header().Set("X-Cache", "MISS")
+		w.Header().Set("X-Served-By", "cache-fra1")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.Write(grawtest.NewListing(1))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for _, capture := range []bool{false, true} {
+		client, err := NewClient(&Config{
+			ClientID: "id", ClientSecret: "secret", UserAgent: "test/1.0",
+			BaseURL: server.URL + "/", AuthURL: server.URL + "/", WebURL: server.URL + "/",
+			HTTPClient:     server.Client(),
+			CaptureHeaders: capture,
+		})
+		if err != nil {
+			t.Fatalf("NewClient returned error: %v", err)
+		}
+		resp, err := client.GetNew(context.Background(), &types.PostsRequest{Subreddit: "golang"})
+		if err != nil {
+			t.Fatalf("GetNew returned error: %v", err)
+		}
+
+		meta := resp.Meta
+		if !capture {
+			if meta != nil {
+				t.Errorf("Meta = %+v without CaptureHeaders, want nil", meta)
+			}
+			continue
+		}
+		if meta == nil {
+			t.Fatal("Meta is nil with CaptureHeaders")
+		}
+		if meta.StatusCode != http.StatusOK || meta.Loid != "000000000abc.2.1700000000000.Z0FBQUFB" || meta.RequestID != "req-42" || meta.CacheStatus != "MISS" || meta.ServedBy != "cache-fra1" {
+			t.Errorf("Meta = %+v", meta)
+		}
+		if meta.Header.Get("Set-Cookie") != "" {
+			t.Error("Meta captured a header outside the diagnostic set")
+		}
+		if resp.Listing().Meta != meta {
+			t.Error("Listing() dropped Meta")
+		}
+	}
+}
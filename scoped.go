@@ -0,0 +1,108 @@
+package graw
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// PostsDefaults are the settings a ScopedClient applies to every listing call.
+type PostsDefaults struct {
+	// Subreddit is the subreddit to list; empty means the front page.
+	Subreddit string
+	// Limit is the number of posts per page; zero uses Reddit's default.
+	Limit int
+	// Sort is the order GetPosts uses; the zero value means hot.
+	Sort ListingSort
+	// Timeout bounds each call, as with WithTimeout.
+	Timeout time.Duration
+	// Params are extra query parameters sent with each call, as with WithParam.
+	Params url.Values
+}
+
+// ScopedClient is a lightweight view of a Reddit client whose listing calls start from
+// preset defaults, for tools that work with one subreddit. It shares the parent client's
+// connection, authentication, rate limit, and cache, and is safe for concurrent use.
+type ScopedClient struct {
+	r        *Reddit
+	defaults PostsDefaults
+}
+
+// WithDefaults returns a ScopedClient whose listing calls use defaults. Options passed to
+// each call are applied on top, so they override the defaults for that call:
+//
+//	golang := client.WithDefaults(graw.PostsDefaults{Subreddit: "golang", Limit: 100})
+//	hot, err := golang.GetHot(ctx)
+//	next, err := golang.GetHot(ctx, graw.WithAfter(hot.AfterFullname))
+//
+// The defaults are checked when a call is made, not here.
+func (r *Reddit) WithDefaults(defaults PostsDefaults) *ScopedClient {
+	defaults.Params = maps.Clone(defaults.Params)
+	return &ScopedClient{r: r, defaults: defaults}
+}
+
+// Client returns the client s was created from.
+func (s *ScopedClient) Client() *Reddit {
+	return s.r
+}
+
+// Defaults returns the defaults s applies.
+func (s *ScopedClient) Defaults() PostsDefaults {
+	d := s.defaults
+	d.Params = maps.Clone(d.Params)
+	return d
+}
+
+// GetHot retrieves hot posts, as Reddit.GetHot does, starting from the defaults.
+func (s *ScopedClient) GetHot(ctx context.Context, opts ...ListingOption) (*types.PostsResponse, error) {
+	return s.r.getPosts(ctx, s.request(), SortHot, s.options(opts)...)
+}
+
+// GetNew retrieves new posts, as Reddit.GetNew does, starting from the defaults.
+func (s *ScopedClient) GetNew(ctx context.Context, opts ...ListingOption) (*types.PostsResponse, error) {
+	return s.r.getPosts(ctx, s.request(), SortNew, s.options(opts)...)
+}
+
+// GetPosts retrieves posts in the default order, or the order given with WithSort, as
+// Reddit.GetPosts does, starting from the defaults.
+func (s *ScopedClient) GetPosts(ctx context.Context, opts ...ListingOption) (*types.PostsResponse, error) {
+	if s.defaults.Sort != (ListingSort{}) {
+		opts = append([]ListingOption{WithSort(s.defaults.Sort)}, opts...)
+	}
+	return s.r.getPosts(ctx, s.request(), ListingSort{}, s.options(opts)...)
+}
+
+// GetHotListing is GetHot returning the page as a generic types.Listing.
+func (s *ScopedClient) GetHotListing(ctx context.Context, opts ...ListingOption) (*types.Listing[*types.Post], error) {
+	return postsListing(s.GetHot(ctx, opts...))
+}
+
+// GetNewListing is GetNew returning the page as a generic types.Listing.
+func (s *ScopedClient) GetNewListing(ctx context.Context, opts ...ListingOption) (*types.Listing[*types.Post], error) {
+	return postsListing(s.GetNew(ctx, opts...))
+}
+
+// GetPostsListing is GetPosts returning the page as a generic types.Listing.
+func (s *ScopedClient) GetPostsListing(ctx context.Context, opts ...ListingOption) (*types.Listing[*types.Post], error) {
+	return postsListing(s.GetPosts(ctx, opts...))
+}
+
+// request returns a new request holding the default subreddit, limit, and parameters.
+func (s *ScopedClient) request() *types.PostsRequest {
+	return &types.PostsRequest{
+		Subreddit:  s.defaults.Subreddit,
+		Pagination: types.Pagination{Limit: s.defaults.Limit},
+		Params:     maps.Clone(s.defaults.Params),
+	}
+}
+
+// options returns opts preceded by the default timeout, if any.
+func (s *ScopedClient) options(opts []ListingOption) []ListingOption {
+	if s.defaults.Timeout > 0 {
+		return append([]ListingOption{WithTimeout(s.defaults.Timeout)}, opts...)
+	}
+	return opts
+}
@@ -0,0 +1,78 @@
+package graw
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestScopedClient(t *testing.T) {
+	var requests []*http.Request
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			requests = append(requests, req)
+			*v = *listingThing(t)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	params := url.Values{"sr_detail": {"true"}}
+	scoped := client.WithDefaults(PostsDefaults{
+		Subreddit: "golang",
+		Limit:     100,
+		Sort:      TopWeek,
+		Timeout:   time.Minute,
+		Params:    params,
+	})
+	params.Set("sr_detail", "changed") // Defaults are copied.
+	ctx := context.Background()
+
+	if _, err := scoped.GetHot(ctx); err != nil {
+		t.Fatalf("GetHot returned error: %v", err)
+	}
+	if _, err := scoped.GetNew(ctx, WithLimit(5), WithAfter("t3_abc")); err != nil {
+		t.Fatalf("GetNew returned error: %v", err)
+	}
+	if _, err := scoped.GetPosts(ctx); err != nil {
+		t.Fatalf("GetPosts returned error: %v", err)
+	}
+	if _, err := scoped.GetPosts(ctx, WithSort(SortRising)); err != nil {
+		t.Fatalf("GetPosts with sort returned error: %v", err)
+	}
+	if _, err := scoped.GetHot(ctx, WithSort(TopDay)); err == nil {
+		t.Error("GetHot with WithSort should fail, as on Reddit")
+	}
+
+	tests := []struct {
+		path, limit, after, t string
+	}{
+		{"/r/golang/hot", "100", "", ""},
+		{"/r/golang/new", "5", "t3_abc", ""},
+		{"/r/golang/top", "100", "", "week"},
+		{"/r/golang/rising", "100", "", ""},
+	}
+	if len(requests) != len(tests) {
+		t.Fatalf("made %d requests, want %d", len(requests), len(tests))
+	}
+	for i, tt := range tests {
+		req := requests[i]
+		q := req.URL.Query()
+		if req.URL.Path != tt.path || q.Get("limit") != tt.limit || q.Get("after") != tt.after || q.Get("t") != tt.t {
+			t.Errorf("request %d = %s, want %s limit=%s after=%s t=%s", i, req.URL, tt.path, tt.limit, tt.after, tt.t)
+		}
+		if q.Get("sr_detail") != "true" {
+			t.Errorf("request %d sr_detail = %q, want true", i, q.Get("sr_detail"))
+		}
+		if _, ok := req.Context().Deadline(); !ok {
+			t.Errorf("request %d has no deadline from the default timeout", i)
+		}
+	}
+
+	if scoped.Client() != client || scoped.Defaults().Subreddit != "golang" {
+		t.Errorf("Client() or Defaults() do not match the parent client")
+	}
+}
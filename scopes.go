@@ -0,0 +1,44 @@
+package graw
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// GetAvailableScopes lists the OAuth scopes Reddit offers, with the names and descriptions
+// it shows users on the authorization page, sorted by ID. Use it to build permission
+// prompts or to check scope names before requesting them.
+//
+// Returns an error if the API request fails or the response cannot be decoded.
+func (r *Reddit) GetAvailableScopes(ctx context.Context) ([]types.Scope, error) {
+	req, err := r.httpClient.NewEndpointRequest(ctx, http.MethodGet, internal.WebEndpoint(ScopesURL), nil)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: ScopesURL, Err: err}
+	}
+
+	// The scope list is public and served by the website, which takes no OAuth token.
+	if err := r.addExtraHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "add headers", URL: ScopesURL, Err: err}
+	}
+
+	// The response is an object keyed by scope ID.
+	var resp map[string]types.Scope
+	if err := r.httpClient.DoJSON(req, &resp); err != nil {
+		return nil, wrapDoError(err, "get available scopes", ScopesURL)
+	}
+
+	scopes := make([]types.Scope, 0, len(resp))
+	for id, scope := range resp {
+		if scope.ID == "" {
+			scope.ID = id
+		}
+		scopes = append(scopes, scope)
+	}
+	sort.Slice(scopes, func(i, j int) bool { return scopes[i].ID < scopes[j].ID })
+	return scopes, nil
+}
@@ -0,0 +1,58 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestClient_GetAvailableScopes(t *testing.T) {
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.Method != http.MethodGet || req.URL.Path != "/"+ScopesURL {
+				t.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+			}
+			if req.URL.Host != "www.reddit.com" || req.Header.Get("Authorization") != "" {
+				t.Errorf("scopes requested from %s with Authorization %q, want www.reddit.com without a token", req.URL.Host, req.Header.Get("Authorization"))
+			}
+			return json.Unmarshal([]byte(`{
+				"read": {"description": "Access posts and comments through my account.", "id": "read", "name": "Read Content"},
+				"identity": {"description": "Access my reddit username and signup date.", "id": "identity", "name": "My Identity"},
+				"edit": {"description": "Edit and delete my comments and submissions.", "name": "Edit Posts"}
+			}`), v)
+		},
+	}
+	scopes, err := newTestClient(mock, nil).GetAvailableScopes(context.Background())
+	if err != nil {
+		t.Fatalf("GetAvailableScopes returned error: %v", err)
+	}
+	want := []types.Scope{
+		{ID: "edit", Name: "Edit Posts", Description: "Edit and delete my comments and submissions."},
+		{ID: "identity", Name: "My Identity", Description: "Access my reddit username and signup date."},
+		{ID: "read", Name: "Read Content", Description: "Access posts and comments through my account."},
+	}
+	if len(scopes) != len(want) {
+		t.Fatalf("GetAvailableScopes() = %+v, want %+v", scopes, want)
+	}
+	for i := range want {
+		if scopes[i] != want[i] {
+			t.Errorf("scopes[%d] = %+v, want %+v", i, scopes[i], want[i])
+		}
+	}
+
+	failing := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			return &pkgerrs.APIError{StatusCode: http.StatusServiceUnavailable, Message: "request failed"}
+		},
+	}
+	_, err = newTestClient(failing, nil).GetAvailableScopes(context.Background())
+	var degraded *pkgerrs.ServiceDegradedError
+	if !errors.As(err, &degraded) {
+		t.Errorf("expected ServiceDegradedError, got %T: %v", err, err)
+	}
+}
@@ -0,0 +1,157 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// SampleSchedule lists when SampleScores fetches an item, as offsets from the start of
+// sampling. Offsets must be non-negative and in increasing order.
+type SampleSchedule []time.Duration
+
+// EverySchedule samples count times, interval apart, starting immediately.
+func EverySchedule(interval time.Duration, count int) SampleSchedule {
+	schedule := make(SampleSchedule, 0, max(count, 0))
+	for i := range count {
+		schedule = append(schedule, time.Duration(i)*interval)
+	}
+	return schedule
+}
+
+// GeometricSchedule samples count times, starting immediately, with gaps that begin at
+// first and grow by factor each time: 0, first, first+first*factor, and so on. It suits
+// virality curves, where scores change fastest soon after posting.
+func GeometricSchedule(first time.Duration, factor float64, count int) SampleSchedule {
+	schedule := make(SampleSchedule, 0, max(count, 0))
+	var offset time.Duration
+	gap := float64(first)
+	for range count {
+		schedule = append(schedule, offset)
+		offset += time.Duration(gap)
+		gap *= factor
+	}
+	return schedule
+}
+
+// validate checks that offsets are non-negative and increasing.
+func (s SampleSchedule) validate() error {
+	if len(s) == 0 {
+		return &pkgerrs.ConfigError{Field: "schedule", Message: "schedule cannot be empty"}
+	}
+	for i, offset := range s {
+		if offset < 0 {
+			return &pkgerrs.ConfigError{Field: "schedule", Message: fmt.Sprintf("offset %d is negative", i)}
+		}
+		if i > 0 && offset < s[i-1] {
+			return &pkgerrs.ConfigError{Field: "schedule", Message: fmt.Sprintf("offset %d is before offset %d", i, i-1)}
+		}
+	}
+	return nil
+}
+
+// ScoreSink receives the samples recorded by SampleScores.
+type ScoreSink interface {
+	RecordScore(ctx context.Context, sample types.ScoreSample) error
+}
+
+// ScoreSinkFunc adapts a function to the ScoreSink interface.
+type ScoreSinkFunc func(ctx context.Context, sample types.ScoreSample) error
+
+// RecordScore calls f.
+func (f ScoreSinkFunc) RecordScore(ctx context.Context, sample types.ScoreSample) error {
+	return f(ctx, sample)
+}
+
+// SampleScores fetches a post or comment at each point of schedule and records its score
+// (and, for posts, comment count and upvote ratio) in sink. It blocks until the schedule is
+// complete, so run it in a goroutine to sample several items at once.
+//
+// A fetch that fails, or that finds the item missing, is logged and skipped; the sample is
+// simply absent from the curve. When a fetch takes longer than the gap to the next offset,
+// the next sample is taken immediately.
+//
+// Returns an error if:
+//   - fullname is not a post or comment fullname, or schedule is empty or out of order
+//   - sink is nil, or returns an error
+//   - ctx is cancelled before the schedule completes
+func (r *Reddit) SampleScores(ctx context.Context, fullname string, schedule SampleSchedule, sink ScoreSink) error {
+	if !strings.HasPrefix(fullname, string(types.KIND_POST)) && !strings.HasPrefix(fullname, string(types.KIND_COMMENT)) {
+		return &pkgerrs.ConfigError{Field: "fullname", Message: fmt.Sprintf("not a post or comment fullname: %q", fullname)}
+	}
+	if err := schedule.validate(); err != nil {
+		return err
+	}
+	if sink == nil {
+		return &pkgerrs.ConfigError{Field: "sink", Message: "score sink cannot be nil"}
+	}
+
+	start := time.Now()
+	for _, offset := range schedule {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Until(start.Add(offset))):
+		}
+
+		var sample *types.ScoreSample
+		err := r.safeCall(ctx, "sample scores", func() (err error) {
+			sample, err = r.fetchScoreSample(ctx, fullname)
+			return err
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if r.config != nil && r.config.Logger != nil {
+				r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "score sample fetch failed",
+					slog.String("fullname", fullname),
+					slog.String("error", err.Error()))
+			}
+			continue
+		}
+		if sample == nil {
+			if r.config != nil && r.config.Logger != nil {
+				r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "score sample item not found",
+					slog.String("fullname", fullname))
+			}
+			continue
+		}
+		if err := sink.RecordScore(ctx, *sample); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchScoreSample fetches fullname and returns its current scores, or nil if Reddit did
+// not return the item.
+func (r *Reddit) fetchScoreSample(ctx context.Context, fullname string) (*types.ScoreSample, error) {
+	info, err := r.GetInfo(ctx, []string{fullname})
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, p := range info.Posts {
+		if p.Name == fullname {
+			return &types.ScoreSample{
+				Fullname:    fullname,
+				Time:        now,
+				Score:       p.Score,
+				NumComments: p.NumComments,
+				UpvoteRatio: p.UpvoteRatio,
+			}, nil
+		}
+	}
+	for _, c := range info.Comments {
+		if c.Name == fullname {
+			return &types.ScoreSample{Fullname: fullname, Time: now, Score: c.Score}, nil
+		}
+	}
+	return nil, nil
+}
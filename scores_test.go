@@ -0,0 +1,128 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestSchedules(t *testing.T) {
+	every := EverySchedule(time.Minute, 3)
+	if len(every) != 3 || every[0] != 0 || every[2] != 2*time.Minute {
+		t.Errorf("EverySchedule() = %v", every)
+	}
+	geo := GeometricSchedule(time.Minute, 2, 4)
+	want := SampleSchedule{0, time.Minute, 3 * time.Minute, 7 * time.Minute}
+	if len(geo) != len(want) {
+		t.Fatalf("GeometricSchedule() = %v, want %v", geo, want)
+	}
+	for i := range want {
+		if geo[i] != want[i] {
+			t.Errorf("GeometricSchedule()[%d] = %v, want %v", i, geo[i], want[i])
+		}
+	}
+	if got := EverySchedule(time.Minute, -1); len(got) != 0 {
+		t.Errorf("EverySchedule(count -1) = %v, want empty", got)
+	}
+}
+
+func TestSampleScores(t *testing.T) {
+	var fetches int
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			fetches++
+			switch fetches {
+			case 2:
+				return errors.New("temporary failure")
+			case 3:
+				*v = *listingThing(t) // item missing
+				return nil
+			}
+			*v = *listingThing(t, submitPostThing(t, "abc123", "title", "gopher", time.Unix(1700000000, 0)))
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	var samples []types.ScoreSample
+	sink := ScoreSinkFunc(func(_ context.Context, s types.ScoreSample) error {
+		samples = append(samples, s)
+		return nil
+	})
+	schedule := EverySchedule(5*time.Millisecond, 4)
+	if err := client.SampleScores(context.Background(), "t3_abc123", schedule, sink); err != nil {
+		t.Fatalf("SampleScores returned error: %v", err)
+	}
+
+	if fetches != 4 {
+		t.Errorf("fetches = %d, want 4", fetches)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("recorded %d samples, want 2 (failed and missing fetches skipped)", len(samples))
+	}
+	for _, s := range samples {
+		if s.Fullname != "t3_abc123" || s.Score != 1 || s.Time.IsZero() {
+			t.Errorf("sample = %+v", s)
+		}
+	}
+	if !samples[1].Time.After(samples[0].Time) {
+		t.Errorf("samples not in time order: %v, %v", samples[0].Time, samples[1].Time)
+	}
+}
+
+func TestSampleScores_Errors(t *testing.T) {
+	okMock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = *listingThing(t, commentThing(t, "c1", "body", false))
+			return nil
+		},
+	}
+	client := newTestClient(okMock, nil)
+	nopSink := ScoreSinkFunc(func(context.Context, types.ScoreSample) error { return nil })
+	sinkErr := errors.New("disk full")
+
+	tests := []struct {
+		name     string
+		fullname string
+		schedule SampleSchedule
+		sink     ScoreSink
+		wantErr  error
+	}{
+		{name: "subreddit fullname", fullname: "t5_abc", schedule: SampleSchedule{0}, sink: nopSink},
+		{name: "empty schedule", fullname: "t1_c1", sink: nopSink},
+		{name: "negative offset", fullname: "t1_c1", schedule: SampleSchedule{-time.Second}, sink: nopSink},
+		{name: "out of order", fullname: "t1_c1", schedule: SampleSchedule{time.Second, 0}, sink: nopSink},
+		{name: "nil sink", fullname: "t1_c1", schedule: SampleSchedule{0}},
+		{
+			name:     "sink error",
+			fullname: "t1_c1",
+			schedule: SampleSchedule{0, time.Hour},
+			sink:     ScoreSinkFunc(func(context.Context, types.ScoreSample) error { return sinkErr }),
+			wantErr:  sinkErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.SampleScores(context.Background(), tt.fullname, tt.schedule, tt.sink)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := client.SampleScores(ctx, "t1_c1", SampleSchedule{0, time.Hour}, nopSink)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("error = %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// Search searches posts, subreddits, or users. Post searches cover all of Reddit, or one
+// subreddit when request.Subreddit is set; subreddit and user searches always cover all of
+// Reddit. Pass the response's AfterFullname as request.After to fetch the next page.
+//
+// Besides the results, the response echoes the query, type, and sort the results are for,
+// and the total number of matches when Reddit reports it.
+//
+// Returns an error if:
+//   - request is nil or its Query is empty
+//   - the subreddit name, Sort, TimeRange, Type, Category, or pagination is invalid
+//   - The request fails
+func (r *Reddit) Search(ctx context.Context, request *types.SearchRequest) (*types.SearchResponse, error) {
+	if request == nil {
+		return nil, &pkgerrs.ConfigError{Message: "search request cannot be nil"}
+	}
+	query := strings.TrimSpace(request.Query)
+	if query == "" {
+		return nil, &pkgerrs.ConfigError{Field: "Query", Message: "search query is required"}
+	}
+	if err := validateSearch(request); err != nil {
+		return nil, err
+	}
+	if err := r.validator.ValidatePagination(&request.Pagination); err != nil {
+		return nil, err
+	}
+
+	path := SearchURL
+	params := buildPaginationParams(&request.Pagination)
+	params.Set("q", query)
+	if request.Subreddit != "" {
+		subreddit, err := r.validator.NormalizeSubredditName(request.Subreddit)
+		if err != nil {
+			return nil, err
+		}
+		path = SubPrefixURL + subreddit + "/search"
+		params.Set("restrict_sr", "1")
+	}
+	if request.Type != "" {
+		params.Set("type", string(request.Type))
+	}
+	if request.Sort != "" {
+		params.Set("sort", request.Sort)
+	}
+	if request.TimeRange != "" {
+		params.Set("t", request.TimeRange)
+	}
+	if request.Category != "" {
+		params.Set("category", request.Category)
+	}
+
+	ctx, rateLimit := r.recordResponse(ctx)
+	thing, err := r.getListingThing(ctx, path, params, "search")
+	if err != nil {
+		return nil, err
+	}
+	listing, err := parseListing[any](ctx, r, thing, "parse search results")
+	if err != nil {
+		return nil, err
+	}
+	// Reddit reports the total for some searches next to the listing's cursors.
+	var stats struct {
+		NumFound *int `json:"num_found"`
+	}
+	if err := json.Unmarshal(thing.Data, &stats); err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "parse search results", Err: err}
+	}
+
+	resp := &types.SearchResponse{
+		AfterFullname:  listing.After,
+		BeforeFullname: listing.Before,
+		Query:          query,
+		Type:           request.Type,
+		Sort:           request.Sort,
+		NumFound:       stats.NumFound,
+		RateLimit:      rateLimit.Info(),
+		Meta:           rateLimit.Meta(),
+	}
+	if resp.Type == "" {
+		resp.Type = types.SearchTypePosts
+	}
+	if resp.Sort == "" {
+		resp.Sort = "relevance"
+	}
+	for _, item := range listing.Items {
+		switch v := item.(type) {
+		case *types.Post:
+			resp.Posts = append(resp.Posts, v)
+		case *types.SubredditData:
+			resp.Subreddits = append(resp.Subreddits, v)
+		case *types.AccountData:
+			resp.Users = append(resp.Users, v)
+		}
+	}
+	return resp, nil
+}
+
+// validateSearch checks a search's Type, Sort, TimeRange, and Category against the values
+// Reddit accepts.
+func validateSearch(request *types.SearchRequest) error {
+	if utf8.RuneCountInString(request.Category) > 5 {
+		return &pkgerrs.ConfigError{Field: "Category", Message: "category cannot be longer than 5 characters"}
+	}
+	switch request.Type {
+	case "", types.SearchTypePosts:
+		switch request.Sort {
+		case "", "relevance", "hot", "top", "new", "comments":
+		default:
+			return &pkgerrs.ConfigError{Field: "Sort", Message: "invalid post search sort: " + request.Sort}
+		}
+		switch request.TimeRange {
+		case "", "hour", "day", "week", "month", "year", "all":
+		default:
+			return &pkgerrs.ConfigError{Field: "TimeRange", Message: "invalid time range: " + request.TimeRange}
+		}
+	case types.SearchTypeSubreddits, types.SearchTypeUsers:
+		switch request.Sort {
+		case "", "relevance", "activity":
+		default:
+			return &pkgerrs.ConfigError{Field: "Sort", Message: fmt.Sprintf("invalid %s search sort: %s", searchTypeName(request.Type), request.Sort)}
+		}
+		if request.TimeRange != "" {
+			return &pkgerrs.ConfigError{Field: "TimeRange", Message: "a time range is only supported for post searches"}
+		}
+		if request.Subreddit != "" {
+			return &pkgerrs.ConfigError{Field: "Subreddit", Message: searchTypeName(request.Type) + " searches cannot be restricted to a subreddit"}
+		}
+	default:
+		return &pkgerrs.ConfigError{Field: "Type", Message: fmt.Sprintf("unknown search type %q", request.Type)}
+	}
+	return nil
+}
+
+// searchTypeName names a non-post search type in error messages.
+func searchTypeName(t types.SearchType) string {
+	if t == types.SearchTypeUsers {
+		return "user"
+	}
+	return "subreddit"
+}
+
+// SearchByFlair retrieves a page of a subreddit's posts whose link flair matches flairText,
+// newest first. It builds the flair:"..." search query itself, quoting the text and escaping
+// quotes and backslashes in it, and restricts the search to the subreddit.
+//
+// The subreddit may be given as "golang", "r/golang", or "/r/golang". pagination may be nil.
+//
+// Returns an error if the subreddit, flair text, or pagination is invalid, or the request fails.
+func (r *Reddit) SearchByFlair(ctx context.Context, subreddit, flairText string, pagination *types.Pagination) (*types.PostsResponse, error) {
+	if subreddit == "" {
+		return nil, &pkgerrs.ConfigError{Field: "Subreddit", Message: "subreddit is required"}
+	}
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return nil, err
+	}
+	flairText = strings.TrimSpace(flairText)
+	if flairText == "" {
+		return nil, &pkgerrs.ConfigError{Field: "flairText", Message: "flair text is required"}
+	}
+	if strings.IndexFunc(flairText, unicode.IsControl) >= 0 {
+		return nil, &pkgerrs.ConfigError{Field: "flairText", Message: "flair text cannot contain control characters"}
+	}
+	if pagination != nil {
+		if err := r.validator.ValidatePagination(pagination); err != nil {
+			return nil, err
+		}
+	}
+
+	path := SubPrefixURL + subreddit + "/search"
+	params := buildPaginationParams(pagination)
+	params.Set("q", flairQuery(flairText))
+	params.Set("restrict_sr", "1")
+	params.Set("sort", "new")
+
+	listing, err := fetchListing[*types.Post](ctx, r, path, params, "search by flair", "parse posts")
+	if err != nil {
+		return nil, err
+	}
+	return &types.PostsResponse{
+		Posts:          listing.Items,
+		AfterFullname:  listing.After,
+		BeforeFullname: listing.Before,
+		RateLimit:      listing.RateLimit,
+		Meta:           listing.Meta,
+	}, nil
+}
+
+// SearchByFlairListing is SearchByFlair returning the page as a generic types.Listing.
+func (r *Reddit) SearchByFlairListing(ctx context.Context, subreddit, flairText string, pagination *types.Pagination) (*types.Listing[*types.Post], error) {
+	return postsListing(r.SearchByFlair(ctx, subreddit, flairText, pagination))
+}
+
+// flairQuery returns the search query matching posts with the given flair text.
+func flairQuery(text string) string {
+	var b strings.Builder
+	b.WriteString(`flair:"`)
+	for _, c := range text {
+		if c == '"' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
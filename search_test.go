@@ -0,0 +1,213 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestSearch(t *testing.T) {
+	post := submitPostThing(t, "p1", "generics", "gopher", time.Unix(1700000100, 0))
+	subreddit := &types.Thing{Kind: "t5", Data: json.RawMessage(`{"id":"2rc7j","name":"t5_2rc7j","display_name":"golang"}`)}
+	user := &types.Thing{Kind: "t2", Data: json.RawMessage(`{"id":"abc12","name":"gopher","created":1700000000,"created_utc":1700000000,"link_karma":10,"comment_karma":20}`)}
+
+	tests := []struct {
+		name           string
+		request        *types.SearchRequest
+		wantPath       string
+		wantQuery      map[string]string
+		wantPosts      int
+		wantSubreddits int
+		wantUsers      int
+		wantType       types.SearchType
+		wantSort       string
+	}{
+		{
+			name:      "all of reddit",
+			request:   &types.SearchRequest{Query: " generics ", Sort: "top", TimeRange: "week", Pagination: types.Pagination{Limit: 10, After: "t3_prev"}},
+			wantPath:  "/search",
+			wantQuery: map[string]string{"q": "generics", "sort": "top", "t": "week", "limit": "10", "after": "t3_prev", "restrict_sr": ""},
+			wantPosts: 1,
+			wantType:  types.SearchTypePosts,
+			wantSort:  "top",
+		},
+		{
+			name:      "one subreddit",
+			request:   &types.SearchRequest{Query: "generics", Subreddit: "r/golang", Type: types.SearchTypePosts},
+			wantPath:  "/r/golang/search",
+			wantQuery: map[string]string{"q": "generics", "restrict_sr": "1", "type": "link", "category": ""},
+			wantPosts: 1,
+			wantType:  types.SearchTypePosts,
+			wantSort:  "relevance",
+		},
+		{
+			name:           "subreddits",
+			request:        &types.SearchRequest{Query: "go", Type: types.SearchTypeSubreddits, Sort: "activity"},
+			wantPath:       "/search",
+			wantQuery:      map[string]string{"q": "go", "type": "sr", "sort": "activity"},
+			wantSubreddits: 1,
+			wantType:       types.SearchTypeSubreddits,
+			wantSort:       "activity",
+		},
+		{
+			name:      "users",
+			request:   &types.SearchRequest{Query: "gopher", Type: types.SearchTypeUsers, Category: "hdr"},
+			wantPath:  "/search",
+			wantQuery: map[string]string{"q": "gopher", "type": "user", "category": "hdr"},
+			wantUsers: 1,
+			wantType:  types.SearchTypeUsers,
+			wantSort:  "relevance",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			children := []*types.Thing{post}
+			if tt.wantSubreddits > 0 {
+				children = []*types.Thing{subreddit}
+			}
+			if tt.wantUsers > 0 {
+				children = []*types.Thing{user}
+			}
+			client := newTestClient(userListingMock(t, &req, children...), nil)
+
+			resp, err := client.Search(context.Background(), tt.request)
+			if err != nil {
+				t.Fatalf("Search returned error: %v", err)
+			}
+			if req.URL.Path != tt.wantPath {
+				t.Errorf("path = %q, want %q", req.URL.Path, tt.wantPath)
+			}
+			for k, want := range tt.wantQuery {
+				if got := req.URL.Query().Get(k); got != want {
+					t.Errorf("query %s = %q, want %q", k, got, want)
+				}
+			}
+			if len(resp.Posts) != tt.wantPosts || len(resp.Subreddits) != tt.wantSubreddits || len(resp.Users) != tt.wantUsers {
+				t.Errorf("got %d posts, %d subreddits, and %d users, want %d, %d, and %d",
+					len(resp.Posts), len(resp.Subreddits), len(resp.Users), tt.wantPosts, tt.wantSubreddits, tt.wantUsers)
+			}
+			if resp.Query != tt.wantQuery["q"] || resp.Type != tt.wantType || resp.Sort != tt.wantSort || resp.NumFound != nil {
+				t.Errorf("metadata = query %q, type %q, sort %q, num_found %v; want %q, %q, %q, nil",
+					resp.Query, resp.Type, resp.Sort, resp.NumFound, tt.wantQuery["q"], tt.wantType, tt.wantSort)
+			}
+		})
+	}
+}
+
+func TestSearch_NumFound(t *testing.T) {
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*v = types.Thing{Kind: "Listing", Data: json.RawMessage(`{"after":null,"before":null,"num_found":1234,"children":[]}`)}
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	resp, err := client.Search(context.Background(), &types.SearchRequest{Query: "go"})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if resp.NumFound == nil || *resp.NumFound != 1234 {
+		t.Errorf("NumFound = %v, want 1234", resp.NumFound)
+	}
+}
+
+func TestSearch_InvalidInput(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+
+	tests := []struct {
+		name      string
+		request   *types.SearchRequest
+		wantField string
+	}{
+		{name: "nil request"},
+		{name: "blank query", request: &types.SearchRequest{Query: " "}, wantField: "Query"},
+		{name: "bad sort", request: &types.SearchRequest{Query: "go", Sort: "activity"}, wantField: "Sort"},
+		{name: "bad time range", request: &types.SearchRequest{Query: "go", TimeRange: "decade"}, wantField: "TimeRange"},
+		{name: "bad type", request: &types.SearchRequest{Query: "go", Type: "comment"}, wantField: "Type"},
+		{name: "bad user sort", request: &types.SearchRequest{Query: "go", Type: types.SearchTypeUsers, Sort: "new"}, wantField: "Sort"},
+		{name: "restricted user search", request: &types.SearchRequest{Query: "go", Type: types.SearchTypeUsers, Subreddit: "golang"}, wantField: "Subreddit"},
+		{name: "long category", request: &types.SearchRequest{Query: "go", Category: "toolong"}, wantField: "Category"},
+		{name: "restricted subreddit search", request: &types.SearchRequest{Query: "go", Type: types.SearchTypeSubreddits, Subreddit: "golang"}, wantField: "Subreddit"},
+		{name: "subreddit search time range", request: &types.SearchRequest{Query: "go", Type: types.SearchTypeSubreddits, TimeRange: "day"}, wantField: "TimeRange"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Search(context.Background(), tt.request)
+			var configErr *pkgerrs.ConfigError
+			if !errors.As(err, &configErr) || configErr.Field != tt.wantField {
+				t.Errorf("error = %v, want ConfigError for %q", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestSearchByFlair(t *testing.T) {
+	var req *http.Request
+	post := submitPostThing(t, "p1", "title", "gopher", time.Unix(1700000100, 0))
+	client := newTestClient(userListingMock(t, &req, post), nil)
+
+	resp, err := client.SearchByFlair(context.Background(), "r/golang", ` Help "urgent" `, &types.Pagination{Limit: 25, After: "t3_prev"})
+	if err != nil {
+		t.Fatalf("SearchByFlair returned error: %v", err)
+	}
+	if req.URL.Path != "/r/golang/search" {
+		t.Errorf("path = %q, want /r/golang/search", req.URL.Path)
+	}
+	q := req.URL.Query()
+	if got, want := q.Get("q"), `flair:"Help \"urgent\""`; got != want {
+		t.Errorf("q = %s, want %s", got, want)
+	}
+	if q.Get("restrict_sr") != "1" || q.Get("sort") != "new" || q.Get("limit") != "25" || q.Get("after") != "t3_prev" {
+		t.Errorf("query = %v", q)
+	}
+	if len(resp.Posts) != 1 || resp.Posts[0].ID != "p1" {
+		t.Errorf("posts = %+v, want [p1]", resp.Posts)
+	}
+}
+
+func TestSearchByFlair_InvalidInput(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		subreddit string
+		flair     string
+	}{
+		{name: "missing subreddit", flair: "Help"},
+		{name: "invalid subreddit", subreddit: "not a sub", flair: "Help"},
+		{name: "blank flair", subreddit: "golang", flair: "  "},
+		{name: "control characters", subreddit: "golang", flair: "Help\nme"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SearchByFlair(ctx, tt.subreddit, tt.flair, nil)
+			var configErr *pkgerrs.ConfigError
+			if !errors.As(err, &configErr) {
+				t.Errorf("expected ConfigError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFlairQuery(t *testing.T) {
+	tests := map[string]string{
+		"Discussion":    `flair:"Discussion"`,
+		`say "hi"`:      `flair:"say \"hi\""`,
+		`back\slash`:    `flair:"back\\slash"`,
+		"Meta: Rules 📌": `flair:"Meta: Rules 📌"`,
+	}
+	for in, want := range tests {
+		if got := flairQuery(in); got != want {
+			t.Errorf("flairQuery(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
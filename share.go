@@ -0,0 +1,192 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// shareHosts are the hosts ResolveShareURL accepts and will follow redirects to.
+var shareHosts = map[string]bool{
+	"reddit.com":     true,
+	"www.reddit.com": true,
+	"old.reddit.com": true,
+	"new.reddit.com": true,
+	"np.reddit.com":  true,
+	"m.reddit.com":   true,
+	"redd.it":        true,
+}
+
+// ResolveShareURL converts a Reddit share link into the post (and comment) it points to.
+// Accepted forms include redd.it short links, /r/{sub}/s/{code} and /s/{code} share links,
+// and ordinary permalinks on any reddit.com host.
+//
+// Share links are resolved by following Reddit's redirects with a plain, unauthenticated
+// request, so resolution does not spend the client's OAuth rate limit. Redirects are only
+// followed within reddit.com and redd.it, and at most MaxShareRedirects times.
+//
+// Returns an error if:
+//   - The URL is malformed or not a Reddit URL
+//   - A redirect leaves Reddit or exceeds MaxShareRedirects
+//   - The link does not resolve to a post
+func (r *Reddit) ResolveShareURL(ctx context.Context, rawURL string) (*types.ShareLink, error) {
+	current, err := parseShareURL(rawURL)
+	if err != nil {
+		return nil, &pkgerrs.ConfigError{Field: "url", Message: err.Error()}
+	}
+
+	for hops := 0; ; hops++ {
+		if link, ok := linkFromPermalink(current); ok {
+			link.URL = rawURL
+			return link, nil
+		}
+		if hops == MaxShareRedirects {
+			return nil, &pkgerrs.RequestError{
+				Operation: "resolve share url",
+				URL:       rawURL,
+				Err:       fmt.Errorf("exceeded %d redirects", MaxShareRedirects),
+			}
+		}
+
+		next, err := r.followShareRedirect(ctx, current)
+		if err != nil {
+			return nil, &pkgerrs.RequestError{Operation: "resolve share url", URL: rawURL, Err: err}
+		}
+		current = next
+	}
+}
+
+// GetPostFromURL resolves a share link or permalink with ResolveShareURL and fetches the post.
+//
+// Returns an error if the link cannot be resolved or the post is not visible to the client.
+func (r *Reddit) GetPostFromURL(ctx context.Context, rawURL string) (*types.Post, error) {
+	link, err := r.ResolveShareURL(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := r.GetInfo(ctx, []string{string(types.KIND_POST) + link.PostID})
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Posts) == 0 {
+		return nil, pkgerrs.ClassifyAPIError(
+			&pkgerrs.APIError{StatusCode: http.StatusNotFound, Message: "post not found"},
+			pkgerrs.ResourceContext{Operation: "get post from url", URL: rawURL, Subreddit: link.Subreddit, PostID: link.PostID},
+		)
+	}
+	return info.Posts[0], nil
+}
+
+// followShareRedirect requests u without authentication and returns the redirect target.
+func (r *Reddit) followShareRedirect(ctx context.Context, u *url.URL) (*url.URL, error) {
+	client := &http.Client{Timeout: DefaultTimeout}
+	if r.config != nil && r.config.HTTPClient != nil {
+		copied := *r.config.HTTPClient
+		client = &copied
+	}
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if r.config != nil && r.config.UserAgent != "" {
+		req.Header.Set("User-Agent", r.config.UserAgent)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	resp.Body.Close()
+
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("link did not redirect to a post (status %d)", resp.StatusCode)
+	}
+	header := resp.Header.Get("Location")
+	if header == "" {
+		return nil, fmt.Errorf("redirect without location (status %d)", resp.StatusCode)
+	}
+	location, err := u.Parse(header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect location: %w", err)
+	}
+	if !shareHosts[strings.ToLower(location.Hostname())] {
+		return nil, fmt.Errorf("redirect left reddit: %s", location.Host)
+	}
+	return location, nil
+}
+
+// parseShareURL parses rawURL and checks that it is an http(s) Reddit URL.
+func parseShareURL(rawURL string) (*url.URL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return nil, fmt.Errorf("URL cannot be empty")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("URL must use http or https scheme, got: %s", u.Scheme)
+	}
+	if !shareHosts[strings.ToLower(u.Hostname())] {
+		return nil, fmt.Errorf("not a Reddit URL: %s", u.Host)
+	}
+	return u, nil
+}
+
+// linkFromPermalink extracts the post and comment from a permalink or redd.it short link.
+// It reports false for share links that still need to be resolved.
+func linkFromPermalink(u *url.URL) (*types.ShareLink, bool) {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	if strings.EqualFold(u.Hostname(), "redd.it") {
+		if len(segments) != 1 || segments[0] == "" {
+			return nil, false
+		}
+		id := segments[0]
+		return &types.ShareLink{Permalink: "https://www.reddit.com/comments/" + id + "/", PostID: id}, true
+	}
+
+	link := &types.ShareLink{}
+	if len(segments) >= 2 && segments[0] == "r" {
+		link.Subreddit = segments[1]
+		segments = segments[2:]
+	}
+	if len(segments) < 2 || segments[0] != "comments" || segments[1] == "" {
+		return nil, false
+	}
+	link.PostID = segments[1]
+	// Permalinks to comments look like comments/{post}/{slug}/{comment}
+	if len(segments) >= 4 && segments[3] != "" {
+		link.CommentID = segments[3]
+	}
+
+	permalink := "https://www.reddit.com/"
+	if link.Subreddit != "" {
+		permalink += "r/" + link.Subreddit + "/"
+	}
+	permalink += "comments/" + link.PostID + "/"
+	if len(segments) >= 3 && segments[2] != "" {
+		permalink += segments[2] + "/"
+	}
+	if link.CommentID != "" {
+		permalink += link.CommentID + "/"
+	}
+	link.Permalink = permalink
+	return link, true
+}
@@ -0,0 +1,97 @@
+package graw
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// handlerTransport serves every request with a handler, regardless of host.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+func TestClient_ResolveShareURL(t *testing.T) {
+	var sawAuth bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "" {
+			sawAuth = true
+		}
+		switch req.Host + req.URL.Path {
+		case "www.reddit.com/r/golang/s/AbCdEf", "reddit.com/r/golang/s/AbCdEf":
+			http.Redirect(w, req, "https://www.reddit.com/r/golang/comments/abc123/generics_question/?share_id=x", http.StatusMovedPermanently)
+		case "reddit.com/s/hop":
+			http.Redirect(w, req, "/r/golang/s/AbCdEf", http.StatusFound)
+		case "www.reddit.com/s/evil":
+			http.Redirect(w, req, "https://example.com/r/golang/comments/abc123/", http.StatusFound)
+		default:
+			http.NotFound(w, req)
+		}
+	})
+	client := newTestClient(&mockHTTPClient{}, nil)
+	client.config.HTTPClient = &http.Client{Transport: handlerTransport{handler: handler}}
+
+	tests := []struct {
+		name    string
+		url     string
+		want    *types.ShareLink
+		wantErr string
+	}{
+		{
+			name: "share link",
+			url:  "https://www.reddit.com/r/golang/s/AbCdEf",
+			want: &types.ShareLink{Permalink: "https://www.reddit.com/r/golang/comments/abc123/generics_question/", Subreddit: "golang", PostID: "abc123"},
+		},
+		{
+			name: "relative redirect chain",
+			url:  "https://reddit.com/s/hop",
+			want: &types.ShareLink{Permalink: "https://www.reddit.com/r/golang/comments/abc123/generics_question/", Subreddit: "golang", PostID: "abc123"},
+		},
+		{
+			name: "short link",
+			url:  "https://redd.it/abc123",
+			want: &types.ShareLink{Permalink: "https://www.reddit.com/comments/abc123/", PostID: "abc123"},
+		},
+		{
+			name: "comment permalink without scheme",
+			url:  "old.reddit.com/r/golang/comments/abc123/slug/def456/",
+			want: &types.ShareLink{Permalink: "https://www.reddit.com/r/golang/comments/abc123/slug/def456/", Subreddit: "golang", PostID: "abc123", CommentID: "def456"},
+		},
+		{name: "redirect off reddit", url: "https://www.reddit.com/s/evil", wantErr: "redirect left reddit"},
+		{name: "not reddit", url: "https://example.com/r/golang/comments/abc123/", wantErr: "not a Reddit URL"},
+		{name: "dead link", url: "https://www.reddit.com/s/missing", wantErr: "status 404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.ResolveShareURL(context.Background(), tt.url)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveShareURL() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveShareURL() returned error: %v", err)
+			}
+			tt.want.URL = tt.url
+			if *got != *tt.want {
+				t.Errorf("ResolveShareURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if sawAuth {
+		t.Error("share link requests must not carry the OAuth token")
+	}
+}
@@ -0,0 +1,141 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// sidebarLinkBase is prefixed to links in sidebars that are Reddit paths, e.g. /r/golang.
+const sidebarLinkBase = "https://www.reddit.com"
+
+var (
+	// sidebarHeading matches an ATX heading line, e.g. "## Rules ##".
+	sidebarHeading = regexp.MustCompile(`^(#{1,6})\s*(.*?)\s*#*\s*$`)
+	// sidebarLink matches a markdown link, e.g. [Go](https://go.dev "title").
+	sidebarLink = regexp.MustCompile(`\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+)
+
+// rulesResponse is the raw shape of the about/rules endpoint.
+type rulesResponse struct {
+	Rules     []types.SubredditRule `json:"rules"`
+	SiteRules []string              `json:"site_rules"`
+}
+
+// GetSidebar retrieves a subreddit's sidebar: the description markdown and Reddit's HTML
+// rendering of it, the markdown split into sections at its headings, the links it contains,
+// and the subreddit's rules from about/rules. It suits apps that mirror a sidebar into a
+// chat server or wiki.
+//
+// The description comes from GetSubreddit and shares its cache. Sidebars built from new
+// Reddit widgets are available from GetSubredditWidgets instead.
+//
+// Returns an error if:
+//   - The subreddit name is invalid
+//   - The subreddit doesn't exist or is private
+//   - Either API request fails or its response cannot be decoded
+func (r *Reddit) GetSidebar(ctx context.Context, subreddit string) (*types.Sidebar, error) {
+	about, err := r.GetSubreddit(ctx, subreddit)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := r.getSubredditRules(ctx, subreddit)
+	if err != nil {
+		return nil, err
+	}
+
+	sidebar := &types.Sidebar{
+		Subreddit: about.DisplayName,
+		Markdown:  about.Description,
+		HTML:      html.UnescapeString(about.DescriptionHTML),
+		Sections:  sidebarSections(about.Description),
+		Rules:     rules.Rules,
+		SiteRules: rules.SiteRules,
+	}
+	for _, section := range sidebar.Sections {
+		sidebar.Links = append(sidebar.Links, section.Links...)
+	}
+	return sidebar, nil
+}
+
+// getSubredditRules fetches a subreddit's rules, sorted by priority.
+func (r *Reddit) getSubredditRules(ctx context.Context, subreddit string) (*rulesResponse, error) {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(RulesURLFormat, subreddit)
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var resp rulesResponse
+	if err := r.httpClient.DoJSON(req, &resp); err != nil {
+		return nil, wrapDoError(err, "get subreddit rules", path)
+	}
+	for i := range resp.Rules {
+		resp.Rules[i].DescriptionHTML = html.UnescapeString(resp.Rules[i].DescriptionHTML)
+	}
+	slices.SortStableFunc(resp.Rules, func(a, b types.SubredditRule) int {
+		return a.Priority - b.Priority
+	})
+	return &resp, nil
+}
+
+// sidebarSections splits sidebar markdown at its ATX headings. Lines inside fenced code
+// blocks are never headings. Blank sections before the first heading are dropped.
+func sidebarSections(markdown string) []types.SidebarSection {
+	var sections []types.SidebarSection
+	current := types.SidebarSection{}
+	var body []string
+	flush := func() {
+		current.Markdown = strings.TrimSpace(strings.Join(body, "\n"))
+		if current.Level > 0 || current.Markdown != "" {
+			current.Links = sidebarLinks(current.Markdown)
+			sections = append(sections, current)
+		}
+		body = nil
+	}
+
+	inCode := false
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+		if m := sidebarHeading.FindStringSubmatch(line); m != nil && !inCode {
+			flush()
+			current = types.SidebarSection{Heading: m[2], Level: len(m[1])}
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+	return sections
+}
+
+// sidebarLinks returns the markdown links in text, with Reddit paths made absolute.
+func sidebarLinks(text string) []types.SidebarLink {
+	var links []types.SidebarLink
+	for _, m := range sidebarLink.FindAllStringSubmatch(text, -1) {
+		url := m[2]
+		if strings.HasPrefix(url, "/") && !strings.HasPrefix(url, "//") {
+			url = sidebarLinkBase + url
+		}
+		links = append(links, types.SidebarLink{Text: strings.TrimSpace(m[1]), URL: url})
+	}
+	return links
+}
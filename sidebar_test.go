@@ -0,0 +1,83 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const sidebarMarkdown = "Welcome to [r/golang](/r/golang)!\r\n\r\n## Resources ##\r\n\r\n* [Tour](https://go.dev/tour \"A Tour of Go\")\r\n* [FAQ](/r/golang/wiki/faq)\r\n\r\n```\r\n# not a heading\r\n```\r\n\r\n### Related\r\n\r\n[Gophers](https://gophers.slack.com)"
+
+func TestClient_GetSidebar(t *testing.T) {
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			if req.URL.Path != "/r/golang/about" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			data, _ := json.Marshal(map[string]any{
+				"id": "2rc7j", "name": "t5_2rc7j", "display_name": "golang",
+				"description":      sidebarMarkdown,
+				"description_html": "&lt;div class=\"md\"&gt;&lt;p&gt;Welcome&lt;/p&gt;&lt;/div&gt;",
+			})
+			*v = types.Thing{Kind: "t5", Data: data}
+			return nil
+		},
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.URL.Path != "/r/golang/about/rules" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			return json.Unmarshal([]byte(`{
+				"rules": [
+					{"kind": "link", "short_name": "On topic", "description": "Posts must be about Go", "priority": 1},
+					{"kind": "all", "short_name": "Be kind", "description_html": "&lt;p&gt;No insults&lt;/p&gt;", "violation_reason": "Unkind", "priority": 0}
+				],
+				"site_rules": ["Spam", "Personal information"]
+			}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	sidebar, err := client.GetSidebar(context.Background(), "r/golang")
+	if err != nil {
+		t.Fatalf("GetSidebar returned error: %v", err)
+	}
+	if sidebar.Subreddit != "golang" || sidebar.Markdown != sidebarMarkdown || sidebar.HTML != `<div class="md"><p>Welcome</p></div>` {
+		t.Errorf("sidebar = %q, %q", sidebar.Subreddit, sidebar.HTML)
+	}
+
+	var headings []string
+	for _, s := range sidebar.Sections {
+		headings = append(headings, s.Heading)
+	}
+	if want := []string{"", "Resources", "Related"}; !slices.Equal(headings, want) {
+		t.Fatalf("headings = %q, want %q", headings, want)
+	}
+	if s := sidebar.Sections[1]; s.Level != 2 || len(s.Links) != 2 || s.Markdown[:1] != "*" {
+		t.Errorf("Resources section = %+v", s)
+	}
+
+	want := []types.SidebarLink{
+		{Text: "r/golang", URL: "https://www.reddit.com/r/golang"},
+		{Text: "Tour", URL: "https://go.dev/tour"},
+		{Text: "FAQ", URL: "https://www.reddit.com/r/golang/wiki/faq"},
+		{Text: "Gophers", URL: "https://gophers.slack.com"},
+	}
+	if !slices.Equal(sidebar.Links, want) {
+		t.Errorf("Links = %+v, want %+v", sidebar.Links, want)
+	}
+
+	if len(sidebar.Rules) != 2 || sidebar.Rules[0].ShortName != "Be kind" || sidebar.Rules[0].DescriptionHTML != "<p>No insults</p>" || sidebar.Rules[1].Kind != "link" {
+		t.Errorf("Rules = %+v, want Be kind first with unescaped HTML", sidebar.Rules)
+	}
+	if len(sidebar.SiteRules) != 2 {
+		t.Errorf("SiteRules = %v", sidebar.SiteRules)
+	}
+
+	if _, err := client.GetSidebar(context.Background(), "no spaces"); err == nil {
+		t.Error("GetSidebar accepted an invalid subreddit name")
+	}
+}
@@ -0,0 +1,124 @@
+package graw
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// Sink receives every post, comment, and subreddit the client parses, whichever method
+// fetched it. Set Config.Sink to feed a database or message queue without wrapping each
+// call site.
+//
+// Methods are called synchronously, before the fetching method returns, and may be called
+// concurrently by parallel fetches such as GetCommentsMultiple. Errors and panics are logged
+// and do not fail the fetch. The entities are the ones returned to the caller, so a Sink
+// must not modify them.
+type Sink interface {
+	OnPost(ctx context.Context, post *types.Post) error
+	// OnComment is called for each comment of a tree, replies included.
+	OnComment(ctx context.Context, comment *types.Comment) error
+	OnSubreddit(ctx context.Context, subreddit *types.SubredditData) error
+}
+
+// NopSink ignores every entity. Embed it in a struct to implement only some of Sink's methods.
+type NopSink struct{}
+
+// OnPost does nothing.
+func (NopSink) OnPost(context.Context, *types.Post) error { return nil }
+
+// OnComment does nothing.
+func (NopSink) OnComment(context.Context, *types.Comment) error { return nil }
+
+// OnSubreddit does nothing.
+func (NopSink) OnSubreddit(context.Context, *types.SubredditData) error { return nil }
+
+// sinkParser passes the entities its Parser produces to the client's Sink.
+type sinkParser struct {
+	Parser
+	r *Reddit
+}
+
+// ParseThing parses thing and sinks the post, comment tree, or subreddit it holds.
+func (p *sinkParser) ParseThing(ctx context.Context, thing *types.Thing) (any, error) {
+	parsed, err := p.Parser.ParseThing(ctx, thing)
+	if err != nil {
+		return nil, err
+	}
+	switch v := parsed.(type) {
+	case *types.Post:
+		p.sink(ctx, "sink post", func(s Sink) error { return s.OnPost(ctx, v) })
+	case *types.Comment:
+		p.sinkComments(ctx, []*types.Comment{v})
+	case *types.SubredditData:
+		p.sink(ctx, "sink subreddit", func(s Sink) error { return s.OnSubreddit(ctx, v) })
+	}
+	return parsed, nil
+}
+
+// ExtractPosts extracts the posts of a listing and sinks each.
+func (p *sinkParser) ExtractPosts(ctx context.Context, thing *types.Thing) ([]*types.Post, error) {
+	posts, err := p.Parser.ExtractPosts(ctx, thing)
+	if err != nil {
+		return nil, err
+	}
+	p.sinkPosts(ctx, posts)
+	return posts, nil
+}
+
+// ExtractPostAndComments extracts a post and its comment tree and sinks them. Of a partial
+// response, the half that parsed is sunk and the error returned with it.
+func (p *sinkParser) ExtractPostAndComments(ctx context.Context, things []*types.Thing) (*types.CommentsResponse, error) {
+	resp, err := p.Parser.ExtractPostAndComments(ctx, things)
+	if resp == nil || (err != nil && !resp.Partial) {
+		return nil, err
+	}
+	if resp.Post != nil {
+		p.sinkPosts(ctx, []*types.Post{resp.Post})
+	}
+	p.sinkComments(ctx, resp.Comments)
+	return resp, err
+}
+
+// ParseReplies parses a lazily loaded comment's replies and sinks them.
+func (p *sinkParser) ParseReplies(ctx context.Context, comment *types.Comment) error {
+	if err := p.Parser.ParseReplies(ctx, comment); err != nil {
+		return err
+	}
+	p.sinkComments(ctx, comment.Replies)
+	return nil
+}
+
+func (p *sinkParser) sinkPosts(ctx context.Context, posts []*types.Post) {
+	for _, post := range posts {
+		if post != nil {
+			p.sink(ctx, "sink post", func(s Sink) error { return s.OnPost(ctx, post) })
+		}
+	}
+}
+
+// sinkComments sinks comments and, depth first, their replies.
+func (p *sinkParser) sinkComments(ctx context.Context, comments []*types.Comment) {
+	for _, c := range comments {
+		if c == nil {
+			continue
+		}
+		p.sink(ctx, "sink comment", func(s Sink) error { return s.OnComment(ctx, c) })
+		p.sinkComments(ctx, c.Replies)
+	}
+}
+
+// sink calls fn with the configured Sink, logging failures.
+func (p *sinkParser) sink(ctx context.Context, operation string, fn func(Sink) error) {
+	config := p.r.config
+	if config == nil || config.Sink == nil {
+		return
+	}
+	err := p.r.safeCall(ctx, operation, func() error { return fn(config.Sink) })
+	if err != nil && config.Logger != nil {
+		config.Logger.LogAttrs(ctx, slog.LevelWarn, "sink failed",
+			slog.String("operation", operation),
+			slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,112 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// recordingSink records the IDs of the entities it receives.
+type recordingSink struct {
+	NopSink
+	mu       sync.Mutex
+	posts    []string
+	comments []string
+	err      error
+}
+
+func (s *recordingSink) OnPost(_ context.Context, post *types.Post) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.posts = append(s.posts, post.ID)
+	return s.err
+}
+
+func (s *recordingSink) OnComment(_ context.Context, comment *types.Comment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.comments = append(s.comments, comment.ID)
+	if comment.ID == "c1" {
+		panic("sink exploded")
+	}
+	return s.err
+}
+
+func newSinkTestClient(mock *mockHTTPClient, sink Sink) *Reddit {
+	client := newTestClient(mock, nil)
+	client.config.Sink = sink
+	client.parser = &sinkParser{Parser: client.parser, r: client}
+	return client
+}
+
+func TestSink(t *testing.T) {
+	mock := &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			return json.Unmarshal(grawtest.NewListing(3), v)
+		},
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			var things []*types.Thing
+			err := json.Unmarshal(grawtest.NewCommentTree(2, 2), &things)
+			return things, err
+		},
+	}
+	sink := &recordingSink{err: errors.New("database unavailable")}
+	client := newSinkTestClient(mock, sink)
+	ctx := context.Background()
+
+	posts, err := client.GetNew(ctx, &types.PostsRequest{Subreddit: grawtest.FixtureSubreddit})
+	if err != nil {
+		t.Fatalf("GetNew() error = %v, want sink failures ignored", err)
+	}
+	if len(posts.Posts) != 3 {
+		t.Fatalf("got %d posts, want 3", len(posts.Posts))
+	}
+	if _, err := client.GetComments(ctx, &types.CommentsRequest{Subreddit: grawtest.FixtureSubreddit, PostID: grawtest.FixturePostID}); err != nil {
+		t.Fatalf("GetComments() error = %v, want sink panics recovered", err)
+	}
+
+	if want := []string{"p1", "p2", "p3", grawtest.FixturePostID}; !slices.Equal(sink.posts, want) {
+		t.Errorf("sunk posts = %v, want %v", sink.posts, want)
+	}
+	if want := []string{"c1", "c2", "c3", "c4", "c5", "c6"}; !slices.Equal(sink.comments, want) {
+		t.Errorf("sunk comments = %v, want %v", sink.comments, want)
+	}
+}
+
+func TestSink_LazyReplies(t *testing.T) {
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			var things []*types.Thing
+			err := json.Unmarshal(grawtest.NewCommentTree(2, 2), &things)
+			return things, err
+		},
+	}
+	sink := &recordingSink{}
+	client := newSinkTestClient(mock, sink)
+	ctx := context.Background()
+
+	resp, err := client.GetComments(ctx, &types.CommentsRequest{
+		Subreddit:   grawtest.FixtureSubreddit,
+		PostID:      grawtest.FixturePostID,
+		LazyReplies: true,
+	})
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+	if want := []string{"c1", "c4"}; !slices.Equal(sink.comments, want) {
+		t.Fatalf("sunk comments = %v, want only the top level %v", sink.comments, want)
+	}
+	if err := client.LoadReplies(ctx, resp.Comments[1]); err != nil {
+		t.Fatalf("LoadReplies() error = %v", err)
+	}
+	if want := []string{"c1", "c4", "c5", "c6"}; !slices.Equal(sink.comments, want) {
+		t.Errorf("sunk comments = %v, want %v", sink.comments, want)
+	}
+}
@@ -0,0 +1,99 @@
+package graw
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// ClientStats is a snapshot of a client's local concurrency. Compare the counts to tell a
+// local bottleneck (requests queued on the rate limiter or worker pool) from throttling by
+// Reddit (ThrottledUntil set).
+type ClientStats struct {
+	// InFlightRequests is the number of requests sent to Reddit and awaiting a response.
+	InFlightRequests int
+	// RateLimitWaiters is the number of requests waiting for the local rate limiter or for
+	// a delay Reddit asked for.
+	RateLimitWaiters int
+	// ThrottledUntil is when the current delay requested by Reddit (via Retry-After or a
+	// nearly exhausted quota) ends. It is zero when Reddit is not throttling the client.
+	ThrottledUntil time.Time
+
+	// ActiveWorkers is the number of batch tasks running, such as the per-post fetches of
+	// GetCommentsMultiple.
+	ActiveWorkers int
+	// QueuedWorkers is the number of batch tasks waiting for a free worker.
+	QueuedWorkers int
+	// WorkerCapacity is the number of tasks the running batches may run at once.
+	WorkerCapacity int
+
+	// DroppedStreamItems is the number of posts, comments, and edit events streams have
+	// discarded because their consumer fell behind. It only grows under a dropping
+	// Config.StreamBuffer policy.
+	DroppedStreamItems int64
+
+	// NotFoundCacheHits is the number of lookups answered from Config.NotFoundCache instead
+	// of being sent to Reddit.
+	NotFoundCacheHits int64
+
+	// RequestTiming sums the phases of every request sent to Reddit, as observed by
+	// net/http/httptrace. Export its fields as counters: growth in DNS, Connect, and TLS
+	// points at the network, growth in Wait at Reddit's processing time.
+	RequestTiming types.RequestTimingTotals
+}
+
+// statsReporter is implemented by HTTP clients that can report request activity.
+type statsReporter interface {
+	Stats() internal.ClientStats
+}
+
+// workerPoolStats counts batch tasks across all running batches.
+type workerPoolStats struct {
+	active   atomic.Int64
+	queued   atomic.Int64
+	capacity atomic.Int64
+}
+
+// Stats returns a snapshot of the client's in-flight requests, rate-limiter waiters, worker
+// pool occupancy, and request timing. It is cheap and safe to call concurrently, e.g. from a
+// metrics exporter. Request counts and timing are zero if the client was built around a
+// custom HTTPClient that does not report them.
+func (r *Reddit) Stats() ClientStats {
+	stats := ClientStats{
+		ActiveWorkers:      int(r.workers.active.Load()),
+		QueuedWorkers:      int(r.workers.queued.Load()),
+		WorkerCapacity:     int(r.workers.capacity.Load()),
+		DroppedStreamItems: r.streamDropped.Load(),
+	}
+	if reporter, ok := r.httpClient.(statsReporter); ok {
+		s := reporter.Stats()
+		stats.InFlightRequests = s.InFlight
+		stats.RateLimitWaiters = s.RateLimitWaiters
+		stats.ThrottledUntil = s.ThrottledUntil
+		stats.NotFoundCacheHits = s.NotFoundCacheHits
+		stats.RequestTiming = s.Timing
+	}
+	return stats
+}
+
+// runWorkers is runBatch with the tasks counted in the client's worker pool stats.
+func (r *Reddit) runWorkers(ctx context.Context, n, limit int, fn func(ctx context.Context, i int) error) error {
+	r.workers.queued.Add(int64(n))
+	r.workers.capacity.Add(int64(min(n, limit)))
+	var started atomic.Int64
+	defer func() {
+		r.workers.queued.Add(started.Load() - int64(n))
+		r.workers.capacity.Add(-int64(min(n, limit)))
+	}()
+
+	return runBatch(ctx, n, limit, func(ctx context.Context, i int) error {
+		started.Add(1)
+		r.workers.queued.Add(-1)
+		r.workers.active.Add(1)
+		defer r.workers.active.Add(-1)
+		return fn(ctx, i)
+	})
+}
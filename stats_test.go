@@ -0,0 +1,50 @@
+package graw
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStats_WorkerPool(t *testing.T) {
+	r := newTestClient(&mockHTTPClient{}, nil)
+	release := make(chan struct{})
+	running := make(chan struct{}, 5)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- r.runWorkers(context.Background(), 5, 2, func(ctx context.Context, i int) error {
+			running <- struct{}{}
+			<-release
+			return nil
+		})
+	}()
+	<-running
+	<-running
+
+	stats := r.Stats()
+	if stats.ActiveWorkers != 2 || stats.QueuedWorkers != 3 || stats.WorkerCapacity != 2 {
+		t.Errorf("Stats() during batch = %+v, want 2 active, 3 queued, capacity 2", stats)
+	}
+	if stats.InFlightRequests != 0 || stats.RateLimitWaiters != 0 {
+		t.Errorf("request counts = %+v, want zero for a client without request stats", stats)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("runWorkers returned error: %v", err)
+	}
+	if stats := r.Stats(); stats != (ClientStats{}) {
+		t.Errorf("Stats() after batch = %+v, want zero", stats)
+	}
+}
+
+func TestStats_CancelledBatch(t *testing.T) {
+	r := newTestClient(&mockHTTPClient{}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_ = r.runWorkers(ctx, 10, 2, func(ctx context.Context, i int) error { return nil })
+	if stats := r.Stats(); stats != (ClientStats{}) {
+		t.Errorf("Stats() after cancelled batch = %+v, want zero", stats)
+	}
+}
@@ -0,0 +1,327 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const (
+	// streamSeenCapacity bounds how many post IDs a stream remembers for de-duplication.
+	streamSeenCapacity = 1000
+	// streamMaxBackfillPages bounds how many extra pages one poll fetches to close a gap.
+	streamMaxBackfillPages = 10
+	// streamReanchorPolls is how many consecutive empty before= polls a stream tolerates before
+	// re-reading the newest page. Reddit returns nothing for before=<fullname> once that post
+	// is deleted or removed, so a stream anchored on it would otherwise stall.
+	streamReanchorPolls = 5
+)
+
+// StreamNewPosts polls a subreddit's new listing and emits posts as they are created, oldest
+// first.
+//
+// The newest page is fetched once before StreamNewPosts returns to establish a starting point;
+// posts that already existed are not emitted. In StreamModeBefore (the default) each later poll
+// asks only for posts newer than the newest one seen, which keeps responses small. In
+// StreamModeRefetch the newest page is re-read on every poll and already-seen posts are skipped.
+//
+// If more posts arrive between polls than fit in one page, the stream pages through the gap
+// (forwards with before=, or backwards with after= until it reaches a post it has seen) and
+// emits the backfilled posts in order. Gaps are logged with their size.
+//
+// With MaxInterval set, the interval adapts to the subreddit's post arrival rate, aiming for
+// about a quarter of Limit new posts per poll. StreamStats reports each stream's current
+// interval and arrival rate.
+//
+// Later fetch failures are logged and retried on the next tick rather than ending the stream.
+// The returned channel is closed when ctx is cancelled. Consumers should keep draining it;
+// by default the stream blocks until each post is received. Config.StreamBuffer can buffer
+// the channel and drop posts instead.
+//
+// Returns an error if:
+//   - request is nil or the subreddit name is invalid
+//   - Mode, Limit, MinInterval, or MaxInterval is invalid
+//   - The initial fetch fails
+func (r *Reddit) StreamNewPosts(ctx context.Context, request *types.StreamRequest) (<-chan *types.Post, error) {
+	if request == nil {
+		return nil, &pkgerrs.ConfigError{Message: "stream request cannot be nil"}
+	}
+	subreddit, err := r.validator.NormalizeSubredditName(request.Subreddit)
+	if err != nil {
+		return nil, err
+	}
+	mode := request.Mode
+	switch mode {
+	case "":
+		mode = types.StreamModeBefore
+	case types.StreamModeBefore, types.StreamModeRefetch:
+	default:
+		return nil, &pkgerrs.ConfigError{Field: "Mode", Message: fmt.Sprintf("unknown stream mode %q", mode)}
+	}
+	limit := request.Limit
+	if limit == 0 {
+		limit = 100
+	}
+	if err := r.validator.ValidatePagination(&types.Pagination{Limit: limit}); err != nil {
+		return nil, err
+	}
+	interval := request.Interval
+	if interval <= 0 {
+		interval = DefaultStreamInterval
+	}
+	if request.MinInterval < 0 || request.MaxInterval < 0 {
+		return nil, &pkgerrs.ConfigError{Field: "MaxInterval", Message: "adaptive interval bounds cannot be negative"}
+	}
+	minInterval, maxInterval := interval, interval
+	if request.MaxInterval > 0 {
+		minInterval, maxInterval = request.MinInterval, request.MaxInterval
+		if minInterval == 0 {
+			minInterval = min(DefaultMinStreamInterval, maxInterval)
+		}
+		if minInterval > maxInterval {
+			return nil, &pkgerrs.ConfigError{Field: "MinInterval", Message: "MinInterval cannot exceed MaxInterval"}
+		}
+	} else if request.MinInterval > 0 {
+		return nil, &pkgerrs.ConfigError{Field: "MinInterval", Message: "MinInterval requires MaxInterval"}
+	}
+
+	stream := &postStream{
+		r:         r,
+		subreddit: subreddit,
+		mode:      mode,
+		limit:     limit,
+		seen:      newRecentIDs(streamSeenCapacity),
+	}
+	if _, err := stream.poll(ctx); err != nil {
+		return nil, err
+	}
+
+	// Aim for a quarter page per poll, leaving headroom before a burst needs backfill pages.
+	tuner := newPollTuner(subreddit, interval, minInterval, maxInterval, max(1, limit/4), time.Now())
+	r.streams.Store(tuner, struct{}{})
+
+	sender := newStreamSender[*types.Post](r)
+	go func() {
+		defer close(sender.ch)
+		defer r.streams.Delete(tuner)
+		current := tuner.stats().Interval
+		ticker := time.NewTicker(current)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			var fresh []*types.Post
+			err := r.safeCall(ctx, "stream new posts", func() (err error) {
+				fresh, err = stream.poll(ctx)
+				return err
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if r.pauseIfDegraded(ctx, "stream new posts", err) {
+					continue
+				}
+				if r.config != nil && r.config.Logger != nil {
+					r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "post stream fetch failed",
+						slog.String("subreddit", subreddit),
+						slog.String("error", err.Error()))
+				}
+				continue
+			}
+			if next := tuner.observe(len(fresh), time.Now()); next != current {
+				current = next
+				ticker.Reset(current)
+			}
+
+			for _, post := range fresh {
+				if !sender.send(ctx, post) {
+					return
+				}
+			}
+		}
+	}()
+
+	return sender.ch, nil
+}
+
+// postStream holds the polling state for StreamNewPosts.
+type postStream struct {
+	r         *Reddit
+	subreddit string
+	mode      types.StreamMode
+	limit     int
+
+	// anchor is the fullname of the newest post seen so far.
+	anchor string
+	// emptyPolls counts consecutive before= polls that returned nothing.
+	emptyPolls int
+	seen       *recentIDs
+}
+
+// poll fetches the posts created since the previous poll and returns them oldest first.
+func (s *postStream) poll(ctx context.Context) ([]*types.Post, error) {
+	primed := s.anchor != ""
+	pagination := types.Pagination{Limit: s.limit}
+	useBefore := s.mode == types.StreamModeBefore && primed && s.emptyPolls < streamReanchorPolls
+	if useBefore {
+		pagination.Before = s.anchor
+	}
+
+	listed, err := s.fetch(ctx, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	// A full page may mean more posts arrived than one request returns.
+	if primed && len(listed) == s.limit {
+		if useBefore {
+			listed, err = s.catchUp(ctx, listed)
+		} else if !s.overlaps(listed) {
+			listed, err = s.backfill(ctx, listed)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case !useBefore:
+		s.emptyPolls = 0
+	case len(listed) == 0:
+		s.emptyPolls++
+	default:
+		s.emptyPolls = 0
+	}
+
+	// Reddit lists newest first; collect unseen posts and reverse them.
+	var fresh []*types.Post
+	for _, post := range listed {
+		if post == nil || s.seen.contains(post.Name) {
+			continue
+		}
+		fresh = append(fresh, post)
+	}
+	for i := len(fresh) - 1; i >= 0; i-- {
+		s.seen.add(fresh[i].Name)
+	}
+	if len(listed) > 0 && listed[0] != nil {
+		s.anchor = listed[0].Name
+	}
+	for i, j := 0, len(fresh)-1; i < j; i, j = i+1, j-1 {
+		fresh[i], fresh[j] = fresh[j], fresh[i]
+	}
+	return fresh, nil
+}
+
+// fetch requests one page of the subreddit's new listing, newest first.
+func (s *postStream) fetch(ctx context.Context, pagination types.Pagination) ([]*types.Post, error) {
+	resp, err := s.r.GetNew(ctx, &types.PostsRequest{Subreddit: s.subreddit, Pagination: pagination})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Posts, nil
+}
+
+// catchUp pages forwards from a full before= page until Reddit has no newer posts, returning
+// the combined listing newest first.
+func (s *postStream) catchUp(ctx context.Context, listed []*types.Post) ([]*types.Post, error) {
+	page, pages := listed, 0
+	for len(page) == s.limit && pages < streamMaxBackfillPages && page[0] != nil {
+		next, err := s.fetch(ctx, types.Pagination{Limit: s.limit, Before: page[0].Name})
+		if err != nil {
+			return nil, err
+		}
+		pages++
+		listed = append(append([]*types.Post(nil), next...), listed...)
+		page = next
+	}
+	s.logGap(ctx, len(listed)-s.limit, pages, len(page) < s.limit)
+	return listed, nil
+}
+
+// backfill pages backwards from a page of entirely unseen posts until it reaches a post the
+// stream has already seen, returning the combined listing newest first.
+func (s *postStream) backfill(ctx context.Context, listed []*types.Post) ([]*types.Post, error) {
+	closed, pages := false, 0
+	for pages < streamMaxBackfillPages {
+		last := listed[len(listed)-1]
+		if last == nil {
+			break
+		}
+		next, err := s.fetch(ctx, types.Pagination{Limit: s.limit, After: last.Name})
+		if err != nil {
+			return nil, err
+		}
+		pages++
+		listed = append(listed, next...)
+		if s.overlaps(next) || len(next) < s.limit {
+			closed = true
+			break
+		}
+	}
+	s.logGap(ctx, len(listed)-s.limit, pages, closed)
+	return listed, nil
+}
+
+// overlaps reports whether any of posts has already been seen.
+func (s *postStream) overlaps(posts []*types.Post) bool {
+	for _, post := range posts {
+		if post != nil && s.seen.contains(post.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// logGap reports a gap the stream had to page through.
+func (s *postStream) logGap(ctx context.Context, backfilled, pages int, closed bool) {
+	if (backfilled == 0 && closed) || s.r.config == nil || s.r.config.Logger == nil {
+		return
+	}
+	level, msg := slog.LevelInfo, "post stream gap backfilled"
+	if !closed {
+		level, msg = slog.LevelWarn, "post stream gap exceeded backfill limit; some posts may be missed"
+	}
+	s.r.config.Logger.LogAttrs(ctx, level, msg,
+		slog.String("subreddit", s.subreddit),
+		slog.Int("backfilled", backfilled),
+		slog.Int("pages", pages))
+}
+
+// recentIDs is a set of IDs that forgets the oldest entries once it reaches capacity.
+type recentIDs struct {
+	ids   map[string]struct{}
+	order []string
+	max   int
+}
+
+func newRecentIDs(max int) *recentIDs {
+	return &recentIDs{ids: make(map[string]struct{}, max), max: max}
+}
+
+func (s *recentIDs) contains(id string) bool {
+	_, ok := s.ids[id]
+	return ok
+}
+
+func (s *recentIDs) add(id string) {
+	if s.contains(id) {
+		return
+	}
+	if len(s.order) >= s.max {
+		delete(s.ids, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.ids[id] = struct{}{}
+	s.order = append(s.order, id)
+}
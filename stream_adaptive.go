@@ -0,0 +1,114 @@
+package graw
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// adaptiveSmoothing is the weight of the latest poll in a stream's arrival rate average.
+	adaptiveSmoothing = 0.3
+	// adaptiveMaxGrowth bounds how much an adaptive interval grows after one poll, so a single
+	// quiet poll after a burst does not jump straight to MaxInterval.
+	adaptiveMaxGrowth = 2
+)
+
+// StreamStats describes a running StreamNewPosts stream.
+type StreamStats struct {
+	// Subreddit is the streamed subreddit.
+	Subreddit string
+	// Interval is the current time between polls. It only changes for adaptive streams.
+	Interval time.Duration
+	// PostsPerMinute is the stream's smoothed post arrival rate.
+	PostsPerMinute float64
+	// Adaptive reports whether the interval follows the arrival rate.
+	Adaptive bool
+}
+
+// pollTuner measures a stream's post arrival rate and, between its bounds, picks the poll
+// interval that should return about target posts per poll. With equal bounds the interval
+// stays fixed and only the rate is measured.
+type pollTuner struct {
+	subreddit string
+	min, max  time.Duration
+	target    float64
+
+	mu       sync.Mutex
+	interval time.Duration
+	rate     float64 // posts per second
+	measured bool
+	last     time.Time
+}
+
+// newPollTuner returns a tuner starting at interval, which is clamped to [lo, hi].
+func newPollTuner(subreddit string, interval, lo, hi time.Duration, target int, now time.Time) *pollTuner {
+	return &pollTuner{
+		subreddit: subreddit,
+		min:       lo,
+		max:       hi,
+		target:    float64(target),
+		interval:  clampDuration(interval, lo, hi),
+		last:      now,
+	}
+}
+
+// observe records that a poll at now returned n new posts and returns the interval to wait
+// before the next poll.
+func (t *pollTuner) observe(n int, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elapsed := now.Sub(t.last)
+	if elapsed <= 0 {
+		return t.interval
+	}
+	t.last = now
+	sample := float64(n) / elapsed.Seconds()
+	if t.measured {
+		t.rate = adaptiveSmoothing*sample + (1-adaptiveSmoothing)*t.rate
+	} else {
+		t.rate, t.measured = sample, true
+	}
+
+	if t.min == t.max {
+		return t.interval
+	}
+	next := t.interval * adaptiveMaxGrowth
+	if t.rate > 0 {
+		next = min(next, time.Duration(t.target/t.rate*float64(time.Second)))
+	}
+	t.interval = clampDuration(next, t.min, t.max)
+	return t.interval
+}
+
+// stats returns a snapshot of the tuner.
+func (t *pollTuner) stats() StreamStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return StreamStats{
+		Subreddit:      t.subreddit,
+		Interval:       t.interval,
+		PostsPerMinute: t.rate * 60,
+		Adaptive:       t.min != t.max,
+	}
+}
+
+// StreamStats returns a snapshot of the client's running StreamNewPosts streams, ordered by
+// subreddit, including the poll interval each adaptive stream has chosen. Like Stats, it is
+// cheap and safe to call concurrently.
+func (r *Reddit) StreamStats() []StreamStats {
+	var stats []StreamStats
+	r.streams.Range(func(key, _ any) bool {
+		stats = append(stats, key.(*pollTuner).stats())
+		return true
+	})
+	slices.SortFunc(stats, func(a, b StreamStats) int { return cmp.Compare(a.Subreddit, b.Subreddit) })
+	return stats
+}
+
+// clampDuration limits d to [lo, hi].
+func clampDuration(d, lo, hi time.Duration) time.Duration {
+	return max(lo, min(d, hi))
+}
@@ -0,0 +1,111 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestPollTuner(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	t.Run("quiet subreddit backs off to max", func(t *testing.T) {
+		tuner := newPollTuner("golang", 30*time.Second, 5*time.Second, 5*time.Minute, 25, start)
+		now, want := start, []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+		for i, w := range want {
+			now = now.Add(tuner.stats().Interval)
+			if got := tuner.observe(0, now); got != w {
+				t.Errorf("poll %d: interval = %v, want %v", i, got, w)
+			}
+		}
+	})
+
+	t.Run("busy subreddit polls faster", func(t *testing.T) {
+		tuner := newPollTuner("askreddit", 30*time.Second, 5*time.Second, 5*time.Minute, 25, start)
+		// 60 posts in 30s is 2 posts/s; 25 posts per poll needs a 12.5s interval.
+		if got := tuner.observe(60, start.Add(30*time.Second)); got != 12500*time.Millisecond {
+			t.Errorf("interval = %v, want 12.5s", got)
+		}
+		// 200 posts in 12.5s is 16 posts/s; the smoothed rate hits the 5s floor.
+		if got := tuner.observe(200, start.Add(42500*time.Millisecond)); got != 5*time.Second {
+			t.Errorf("interval = %v, want 5s", got)
+		}
+		stats := tuner.stats()
+		if !stats.Adaptive || stats.PostsPerMinute < 200 {
+			t.Errorf("stats = %+v, want adaptive with a high rate", stats)
+		}
+	})
+
+	t.Run("fixed interval only measures", func(t *testing.T) {
+		tuner := newPollTuner("golang", 30*time.Second, 30*time.Second, 30*time.Second, 25, start)
+		if got := tuner.observe(30, start.Add(30*time.Second)); got != 30*time.Second {
+			t.Errorf("interval = %v, want 30s", got)
+		}
+		if stats := tuner.stats(); stats.Adaptive || stats.PostsPerMinute != 60 {
+			t.Errorf("stats = %+v, want fixed at 60 posts/min", stats)
+		}
+	})
+}
+
+func TestStreamNewPosts_AdaptiveInterval(t *testing.T) {
+	listing := &fakeNewListing{}
+	listing.publish("a1")
+	client := newTestClient(listing.client(t), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	posts, err := client.StreamNewPosts(ctx, &types.StreamRequest{
+		Subreddit:   "golang",
+		Interval:    5 * time.Millisecond,
+		MinInterval: 5 * time.Millisecond,
+		MaxInterval: 40 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("StreamNewPosts returned error: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		stats := client.StreamStats()
+		if len(stats) != 1 || stats[0].Subreddit != "golang" || !stats[0].Adaptive {
+			t.Fatalf("StreamStats() = %+v, want one adaptive golang stream", stats)
+		}
+		if stats[0].Interval == 40*time.Millisecond {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("interval = %v, want it to back off to 40ms", stats[0].Interval)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	cancel()
+	for range posts {
+	}
+	if stats := client.StreamStats(); len(stats) != 0 {
+		t.Errorf("StreamStats() after cancel = %+v, want none", stats)
+	}
+}
+
+func TestStreamNewPosts_InvalidIntervalBounds(t *testing.T) {
+	client := newTestClient((&fakeNewListing{}).client(t), nil)
+	tests := []struct {
+		request   types.StreamRequest
+		wantField string
+	}{
+		{request: types.StreamRequest{MaxInterval: -time.Second}, wantField: "MaxInterval"},
+		{request: types.StreamRequest{MinInterval: time.Minute, MaxInterval: time.Second}, wantField: "MinInterval"},
+		{request: types.StreamRequest{MinInterval: time.Second}, wantField: "MinInterval"},
+	}
+	for _, tt := range tests {
+		tt.request.Subreddit = "golang"
+		_, err := client.StreamNewPosts(context.Background(), &tt.request)
+		var cfgErr *pkgerrs.ConfigError
+		if !errors.As(err, &cfgErr) || cfgErr.Field != tt.wantField {
+			t.Errorf("StreamNewPosts(%+v) error = %v, want %s ConfigError", tt.request, err, tt.wantField)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// DefaultStreamBufferSize is the channel buffer streams get when a dropping
+// BackpressurePolicy is configured without a Size.
+const DefaultStreamBufferSize = 100
+
+// BackpressurePolicy decides what a stream does when its consumer falls behind and the
+// channel buffer is full.
+type BackpressurePolicy string
+
+const (
+	// BackpressureBlock pauses the stream until the consumer makes room. Nothing is lost,
+	// but polling stops while the consumer is busy. This is the default.
+	BackpressureBlock BackpressurePolicy = "block"
+	// BackpressureDropOldest discards the oldest buffered item to make room for the new one.
+	BackpressureDropOldest BackpressurePolicy = "drop_oldest"
+	// BackpressureDropNewest discards the new item, keeping what is already buffered.
+	BackpressureDropNewest BackpressurePolicy = "drop_newest"
+)
+
+// StreamBufferConfig sizes the channels returned by StreamNewPosts, StreamPostComments, and
+// WatchForEdits and chooses what happens when they fill up. Dropped items are counted in
+// ClientStats.DroppedStreamItems.
+type StreamBufferConfig struct {
+	// Size is the channel buffer. Zero means unbuffered with BackpressureBlock and
+	// DefaultStreamBufferSize with the dropping policies.
+	Size int
+	// Policy defaults to BackpressureBlock.
+	Policy BackpressurePolicy
+}
+
+// validateStreamBuffer checks a StreamBufferConfig from Config.
+func validateStreamBuffer(cfg *StreamBufferConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.Size < 0 {
+		return &pkgerrs.ConfigError{Field: "StreamBuffer.Size", Message: "buffer size cannot be negative"}
+	}
+	switch cfg.Policy {
+	case "", BackpressureBlock, BackpressureDropOldest, BackpressureDropNewest:
+		return nil
+	default:
+		return &pkgerrs.ConfigError{Field: "StreamBuffer.Policy", Message: fmt.Sprintf("unknown backpressure policy %q", cfg.Policy)}
+	}
+}
+
+// streamSender delivers a stream's items to its channel under the configured policy.
+type streamSender[T any] struct {
+	r      *Reddit
+	ch     chan T
+	policy BackpressurePolicy
+}
+
+// newStreamSender creates the channel for a stream of r.
+func newStreamSender[T any](r *Reddit) *streamSender[T] {
+	size, policy := 0, BackpressureBlock
+	if r.config != nil && r.config.StreamBuffer != nil {
+		size = r.config.StreamBuffer.Size
+		if r.config.StreamBuffer.Policy != "" {
+			policy = r.config.StreamBuffer.Policy
+		}
+	}
+	if size == 0 && policy != BackpressureBlock {
+		size = DefaultStreamBufferSize
+	}
+	return &streamSender[T]{r: r, ch: make(chan T, size), policy: policy}
+}
+
+// send delivers v, dropping an item instead of waiting if the policy says so. It returns
+// false if ctx was cancelled first.
+func (s *streamSender[T]) send(ctx context.Context, v T) bool {
+	switch s.policy {
+	case BackpressureDropNewest:
+		select {
+		case s.ch <- v:
+		default:
+			s.r.streamDropped.Add(1)
+		}
+		return ctx.Err() == nil
+	case BackpressureDropOldest:
+		for {
+			select {
+			case s.ch <- v:
+				return ctx.Err() == nil
+			default:
+			}
+			// The buffer is full; discard its oldest item unless the consumer just took it.
+			select {
+			case <-s.ch:
+				s.r.streamDropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- v:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func drain(ch chan int) []int {
+	var got []int
+	for {
+		select {
+		case v := <-ch:
+			got = append(got, v)
+		default:
+			return got
+		}
+	}
+}
+
+func TestStreamSender(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *StreamBufferConfig
+		wantCap     int
+		wantItems   []int
+		wantDropped int64
+	}{
+		{name: "drop newest", cfg: &StreamBufferConfig{Size: 3, Policy: BackpressureDropNewest}, wantCap: 3, wantItems: []int{1, 2, 3}, wantDropped: 2},
+		{name: "drop oldest", cfg: &StreamBufferConfig{Size: 3, Policy: BackpressureDropOldest}, wantCap: 3, wantItems: []int{3, 4, 5}, wantDropped: 2},
+		{name: "default size", cfg: &StreamBufferConfig{Policy: BackpressureDropNewest}, wantCap: DefaultStreamBufferSize, wantItems: []int{1, 2, 3, 4, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(&mockHTTPClient{}, nil)
+			client.config.StreamBuffer = tt.cfg
+			sender := newStreamSender[int](client)
+			if cap(sender.ch) != tt.wantCap {
+				t.Fatalf("buffer = %d, want %d", cap(sender.ch), tt.wantCap)
+			}
+			for i := 1; i <= 5; i++ {
+				if !sender.send(context.Background(), i) {
+					t.Fatalf("send(%d) reported cancellation", i)
+				}
+			}
+			if got := drain(sender.ch); !slices.Equal(got, tt.wantItems) {
+				t.Errorf("received %v, want %v", got, tt.wantItems)
+			}
+			if got := client.Stats().DroppedStreamItems; got != tt.wantDropped {
+				t.Errorf("DroppedStreamItems = %d, want %d", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestStreamSender_BlockStopsOnCancel(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	client.config.StreamBuffer = &StreamBufferConfig{Size: 1}
+	sender := newStreamSender[int](client)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if !sender.send(ctx, 1) {
+		t.Fatal("send into an empty buffer reported cancellation")
+	}
+	done := make(chan bool)
+	go func() { done <- sender.send(ctx, 2) }()
+	select {
+	case <-done:
+		t.Fatal("send into a full buffer did not block")
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	if <-done {
+		t.Error("send after cancellation = true, want false")
+	}
+	if got := client.Stats().DroppedStreamItems; got != 0 {
+		t.Errorf("DroppedStreamItems = %d, want 0", got)
+	}
+}
+
+func TestStreamNewPosts_DropsForSlowConsumer(t *testing.T) {
+	listing := &fakeNewListing{}
+	listing.publish("a1")
+	client := newTestClient(listing.client(t), nil)
+	client.config.StreamBuffer = &StreamBufferConfig{Size: 2, Policy: BackpressureDropOldest}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	posts, err := client.StreamNewPosts(ctx, &types.StreamRequest{Subreddit: "golang", Interval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("StreamNewPosts returned error: %v", err)
+	}
+
+	listing.publish("b1", "b2", "b3", "b4")
+	deadline := time.Now().Add(2 * time.Second)
+	for client.Stats().DroppedStreamItems < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("DroppedStreamItems = %d, want 2", client.Stats().DroppedStreamItems)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := receivePosts(t, posts, 2); !slices.Equal(got, []string{"b3", "b4"}) {
+		t.Errorf("posts = %v, want the newest two", got)
+	}
+}
+
+func TestValidateStreamBuffer(t *testing.T) {
+	tests := []struct {
+		cfg       *StreamBufferConfig
+		wantField string
+	}{
+		{cfg: nil},
+		{cfg: &StreamBufferConfig{Size: 10}},
+		{cfg: &StreamBufferConfig{Policy: BackpressureDropOldest}},
+		{cfg: &StreamBufferConfig{Size: -1}, wantField: "StreamBuffer.Size"},
+		{cfg: &StreamBufferConfig{Policy: "drop_all"}, wantField: "StreamBuffer.Policy"},
+	}
+	for _, tt := range tests {
+		err := validateStreamBuffer(tt.cfg)
+		if tt.wantField == "" {
+			if err != nil {
+				t.Errorf("validateStreamBuffer(%+v) error = %v", tt.cfg, err)
+			}
+			continue
+		}
+		var cfgErr *pkgerrs.ConfigError
+		if !errors.As(err, &cfgErr) || cfgErr.Field != tt.wantField {
+			t.Errorf("validateStreamBuffer(%+v) error = %v, want %s ConfigError", tt.cfg, err, tt.wantField)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package graw
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const (
+	// commentStreamSeenCapacity bounds how many comment IDs a comment stream remembers.
+	commentStreamSeenCapacity = 10000
+	// commentStreamClockSkew allows for differences between local and Reddit clocks when
+	// deciding whether a comment first seen behind a "more" stub is new.
+	commentStreamClockSkew = time.Minute
+)
+
+// StreamPostComments polls a post's comments (sorted by new) and emits each comment once,
+// when it first appears, oldest first. It is the building block for bots that reply in live
+// threads.
+//
+// Reddit returns comments as a tree, so a new reply can appear anywhere in it; the stream walks
+// the whole tree rather than only the top level. Replies hidden behind "load more" stubs are
+// fetched with GetMoreComments, up to MaxInfoFullnames per poll.
+//
+// Comments that exist when StreamPostComments is called are not emitted. Later fetch failures
+// are logged and retried on the next tick. The returned channel is closed when ctx is
+// cancelled, and is buffered as Config.StreamBuffer sets. An interval of zero uses
+// DefaultStreamInterval.
+//
+// Returns an error if the post ID is invalid or the initial fetch fails.
+func (r *Reddit) StreamPostComments(ctx context.Context, postID string, interval time.Duration) (<-chan *types.Comment, error) {
+	postID = strings.TrimPrefix(postID, string(types.KIND_POST))
+	if err := r.validator.ValidatePostID(postID); err != nil {
+		return nil, err
+	}
+	if interval <= 0 {
+		interval = DefaultStreamInterval
+	}
+
+	stream := &commentStream{
+		r:       r,
+		postID:  postID,
+		seen:    newRecentIDs(commentStreamSeenCapacity),
+		started: time.Now(),
+	}
+	if _, err := stream.poll(ctx); err != nil {
+		return nil, err
+	}
+
+	sender := newStreamSender[*types.Comment](r)
+	go func() {
+		defer close(sender.ch)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			var fresh []*types.Comment
+			err := r.safeCall(ctx, "stream post comments", func() (err error) {
+				fresh, err = stream.poll(ctx)
+				return err
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if r.pauseIfDegraded(ctx, "stream post comments", err) {
+					continue
+				}
+				if r.config != nil && r.config.Logger != nil {
+					r.config.Logger.LogAttrs(ctx, slog.LevelWarn, "comment stream fetch failed",
+						slog.String("post_id", postID),
+						slog.String("error", err.Error()))
+				}
+				continue
+			}
+
+			for _, c := range fresh {
+				if !sender.send(ctx, c) {
+					return
+				}
+			}
+		}
+	}()
+
+	return sender.ch, nil
+}
+
+// commentStream holds the polling state for StreamPostComments.
+type commentStream struct {
+	r      *Reddit
+	postID string
+	seen   *recentIDs
+	// primed is set after the first poll, whose comments are recorded but not emitted.
+	primed bool
+	// started is when the stream began; comments older than this are never emitted.
+	started time.Time
+}
+
+// poll fetches the post's comments and returns those not seen before, oldest first.
+func (s *commentStream) poll(ctx context.Context) ([]*types.Comment, error) {
+	params := url.Values{}
+	params.Set("sort", "new")
+	params.Set("limit", strconv.Itoa(MaxInfoFullnames))
+	resp, err := s.r.fetchComments(ctx, "comments/"+s.postID, params)
+	if err != nil {
+		return nil, err
+	}
+	all := flattenComments(resp.Comments)
+
+	// Load replies hidden behind "more" stubs that have not been seen yet.
+	var moreIDs []string
+	for _, id := range resp.MoreIDs {
+		if len(moreIDs) == MaxInfoFullnames {
+			break
+		}
+		if !s.seen.contains(string(types.KIND_COMMENT) + id) {
+			moreIDs = append(moreIDs, id)
+		}
+	}
+	if s.primed && len(moreIDs) > 0 {
+		more, err := s.r.getMoreComments(ctx, &types.MoreCommentsRequest{
+			LinkID:     s.postID,
+			CommentIDs: moreIDs,
+			Sort:       "new",
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, flattenComments(more)...)
+	}
+
+	cutoff := float64(s.started.Add(-commentStreamClockSkew).Unix())
+	var fresh []*types.Comment
+	for _, c := range all {
+		if c == nil || s.seen.contains(c.Name) {
+			continue
+		}
+		s.seen.add(c.Name)
+		if s.primed && c.CreatedUTC >= cutoff {
+			fresh = append(fresh, c)
+		}
+	}
+	if !s.primed {
+		// Record the stubs too, so comments that already existed are not loaded later.
+		for _, id := range resp.MoreIDs {
+			s.seen.add(string(types.KIND_COMMENT) + id)
+		}
+		s.primed = true
+	}
+
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].CreatedUTC < fresh[j].CreatedUTC })
+	s.r.annotateComments(ctx, "stream post comments", fresh)
+	return fresh, nil
+}
+
+// flattenComments returns every comment in the trees rooted at comments, parents before replies.
+func flattenComments(comments []*types.Comment) []*types.Comment {
+	var flat []*types.Comment
+	var walk func([]*types.Comment)
+	walk = func(level []*types.Comment) {
+		for _, c := range level {
+			if c == nil {
+				continue
+			}
+			flat = append(flat, c)
+			walk(c.Replies)
+		}
+	}
+	walk(comments)
+	return flat
+}
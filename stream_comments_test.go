@@ -0,0 +1,153 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// replyThing builds a t1 Thing on post1 with the given replies nested under it.
+func replyThing(t *testing.T, id, parentID string, created time.Time, replies ...*types.Thing) *types.Thing {
+	t.Helper()
+	fields := map[string]any{
+		"id":           id,
+		"name":         "t1_" + id,
+		"body":         "comment " + id,
+		"author":       "gopher",
+		"subreddit":    "golang",
+		"subreddit_id": "t5_2qh1i",
+		"parent_id":    parentID,
+		"link_id":      "t3_post1",
+		"created":      float64(created.Unix()),
+		"created_utc":  float64(created.Unix()),
+		"score":        1,
+		"ups":          1,
+	}
+	if len(replies) > 0 {
+		fields["replies"] = listingThing(t, replies...)
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("marshal comment: %v", err)
+	}
+	return &types.Thing{Kind: "t1", Data: data}
+}
+
+// moreThing builds a "load more" stub for the given comment IDs.
+func moreThing(t *testing.T, parentID string, ids ...string) *types.Thing {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"id":        ids[0],
+		"name":      "t1_" + ids[0],
+		"parent_id": parentID,
+		"count":     len(ids),
+		"children":  ids,
+	})
+	if err != nil {
+		t.Fatalf("marshal more: %v", err)
+	}
+	return &types.Thing{Kind: "more", Data: data}
+}
+
+func TestStreamPostComments(t *testing.T) {
+	start := time.Now()
+	old := start.Add(-time.Hour)
+
+	var mu sync.Mutex
+	var gotQuery string
+	tree := []*types.Thing{
+		replyThing(t, "c1", "t3_post1", old, replyThing(t, "c2", "t1_c1", old)),
+		moreThing(t, "t3_post1", "c0"),
+	}
+	mock := &mockHTTPClient{
+		doThingArrayFunc: func(req *http.Request) ([]*types.Thing, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if req.URL.Path != "/comments/post1" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			gotQuery = req.URL.RawQuery
+			return []*types.Thing{
+				listingThing(t, submitPostThing(t, "post1", "Live thread", "gopher", old)),
+				listingThing(t, tree...),
+			}, nil
+		},
+		doMoreChildrenFunc: func(req *http.Request) ([]*types.Thing, error) {
+			if err := req.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			if children := req.PostForm.Get("children"); children != "c5" {
+				t.Errorf("morechildren requested %q, want only the new stub c5", children)
+			}
+			return []*types.Thing{replyThing(t, "c5", "t1_c1", start.Add(3*time.Second))}, nil
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	comments, err := client.StreamPostComments(ctx, "t3_post1", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("StreamPostComments returned error: %v", err)
+	}
+
+	// A new top-level comment, a new reply nested under c2, and a reply hidden behind a stub.
+	mu.Lock()
+	tree = []*types.Thing{
+		replyThing(t, "c3", "t3_post1", start.Add(2*time.Second)),
+		replyThing(t, "c1", "t3_post1", old,
+			replyThing(t, "c2", "t1_c1", old, replyThing(t, "c4", "t1_c2", start.Add(time.Second)))),
+		moreThing(t, "t3_post1", "c0"),
+		moreThing(t, "t1_c1", "c5"),
+	}
+	mu.Unlock()
+
+	var got []string
+	for len(got) < 3 {
+		select {
+		case c := <-comments:
+			got = append(got, c.ID)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out after receiving %v", got)
+		}
+	}
+	if want := []string{"c4", "c3", "c5"}; !slices.Equal(got, want) {
+		t.Errorf("comments = %v, want %v", got, want)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if gotQuery != "limit=100&sort=new" {
+		t.Errorf("query = %q, want limit=100&sort=new", gotQuery)
+	}
+}
+
+func TestStreamPostComments_InvalidPostID(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	if _, err := client.StreamPostComments(context.Background(), "not valid!", time.Second); err == nil {
+		t.Error("expected error for invalid post ID")
+	}
+}
+
+func TestFlattenComments(t *testing.T) {
+	tree := []*types.Comment{
+		{ThingData: types.ThingData{ID: "a"}, Replies: []*types.Comment{
+			{ThingData: types.ThingData{ID: "b"}, Replies: []*types.Comment{{ThingData: types.ThingData{ID: "c"}}}},
+		}},
+		nil,
+		{ThingData: types.ThingData{ID: "d"}},
+	}
+	var ids []string
+	for _, c := range flattenComments(tree) {
+		ids = append(ids, c.ID)
+	}
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(ids, want) {
+		t.Errorf("flattenComments = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,239 @@
+package graw
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// fakeNewListing serves r/golang/new from an in-memory list of posts, newest first,
+// honouring the limit, before, and after parameters the way Reddit does.
+type fakeNewListing struct {
+	mu      sync.Mutex
+	posts   []string // post IDs, newest first
+	befores []string // before= value of each request
+}
+
+func (f *fakeNewListing) publish(ids ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, id := range ids {
+		f.posts = append([]string{id}, f.posts...)
+	}
+}
+
+func (f *fakeNewListing) remove(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, p := range f.posts {
+		if p == id {
+			f.posts = append(f.posts[:i], f.posts[i+1:]...)
+			return
+		}
+	}
+}
+
+func (f *fakeNewListing) client(t *testing.T) *mockHTTPClient {
+	return &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+
+			query := req.URL.Query()
+			before, after := query.Get("before"), query.Get("after")
+			f.befores = append(f.befores, before)
+			limit := 100
+			if l := query.Get("limit"); l != "" {
+				n, err := strconv.Atoi(l)
+				if err != nil {
+					t.Fatalf("bad limit %q", l)
+				}
+				limit = n
+			}
+
+			var window []string
+			switch {
+			case after != "":
+				for i, id := range f.posts {
+					if "t3_"+id == after {
+						window = f.posts[i+1:]
+						break
+					}
+				}
+			case before == "":
+				window = f.posts
+			default:
+				for i, id := range f.posts {
+					if "t3_"+id == before {
+						window = f.posts[:i]
+						break
+					}
+				}
+				// Reddit returns the posts closest to the anchor.
+				if len(window) > limit {
+					window = window[len(window)-limit:]
+				}
+			}
+			if len(window) > limit {
+				window = window[:limit]
+			}
+
+			children := make([]*types.Thing, 0, len(window))
+			for _, id := range window {
+				children = append(children, submitPostThing(t, id, "post "+id, "gopher", time.Now()))
+			}
+			*v = *listingThing(t, children...)
+			return nil
+		},
+	}
+}
+
+func receivePosts(t *testing.T, posts <-chan *types.Post, n int) []string {
+	t.Helper()
+	var ids []string
+	for len(ids) < n {
+		select {
+		case post, ok := <-posts:
+			if !ok {
+				t.Fatalf("stream closed after %v", ids)
+			}
+			ids = append(ids, post.ID)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out after receiving %v", ids)
+		}
+	}
+	return ids
+}
+
+func TestStreamNewPosts(t *testing.T) {
+	for _, mode := range []types.StreamMode{types.StreamModeBefore, types.StreamModeRefetch} {
+		t.Run(string(mode), func(t *testing.T) {
+			listing := &fakeNewListing{}
+			listing.publish("a1", "a2")
+			client := newTestClient(listing.client(t), nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			posts, err := client.StreamNewPosts(ctx, &types.StreamRequest{
+				Subreddit: "r/golang",
+				Interval:  10 * time.Millisecond,
+				Mode:      mode,
+			})
+			if err != nil {
+				t.Fatalf("StreamNewPosts returned error: %v", err)
+			}
+
+			listing.publish("b1", "b2", "b3")
+			got := receivePosts(t, posts, 3)
+			if want := []string{"b1", "b2", "b3"}; !slices.Equal(got, want) {
+				t.Errorf("posts = %v, want %v", got, want)
+			}
+
+			listing.publish("c1")
+			if got := receivePosts(t, posts, 1); got[0] != "c1" {
+				t.Errorf("next post = %s, want c1", got[0])
+			}
+
+			listing.mu.Lock()
+			befores := append([]string(nil), listing.befores...)
+			listing.mu.Unlock()
+			if befores[0] != "" {
+				t.Errorf("initial fetch used before=%q", befores[0])
+			}
+			usedBefore := false
+			for _, b := range befores[1:] {
+				if b != "" {
+					usedBefore = true
+				}
+			}
+			if usedBefore != (mode == types.StreamModeBefore) {
+				t.Errorf("mode %s: before parameters %v", mode, befores)
+			}
+		})
+	}
+}
+
+func TestStreamNewPosts_ReanchorsAfterAnchorRemoved(t *testing.T) {
+	listing := &fakeNewListing{}
+	listing.publish("a1", "a2")
+	client := newTestClient(listing.client(t), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	posts, err := client.StreamNewPosts(ctx, &types.StreamRequest{Subreddit: "golang", Interval: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("StreamNewPosts returned error: %v", err)
+	}
+
+	// Removing the anchor makes before=t3_a2 return nothing, like a deleted post on Reddit.
+	listing.remove("a2")
+	listing.publish("b1")
+	if got := receivePosts(t, posts, 1); got[0] != "b1" {
+		t.Errorf("post = %s, want b1", got[0])
+	}
+}
+
+func TestStreamNewPosts_BackfillsGaps(t *testing.T) {
+	for _, mode := range []types.StreamMode{types.StreamModeBefore, types.StreamModeRefetch} {
+		t.Run(string(mode), func(t *testing.T) {
+			listing := &fakeNewListing{}
+			listing.publish("a1", "a2", "a3")
+			client := newTestClient(listing.client(t), nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			posts, err := client.StreamNewPosts(ctx, &types.StreamRequest{
+				Subreddit: "golang",
+				Interval:  10 * time.Millisecond,
+				Mode:      mode,
+				Limit:     2,
+			})
+			if err != nil {
+				t.Fatalf("StreamNewPosts returned error: %v", err)
+			}
+
+			// Five posts between polls do not fit in a page of two.
+			listing.publish("b1", "b2", "b3", "b4", "b5")
+			got := receivePosts(t, posts, 5)
+			if want := []string{"b1", "b2", "b3", "b4", "b5"}; !slices.Equal(got, want) {
+				t.Errorf("posts = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestStreamNewPosts_InvalidRequest(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	tests := []struct {
+		name    string
+		request *types.StreamRequest
+	}{
+		{"nil request", nil},
+		{"invalid subreddit", &types.StreamRequest{Subreddit: "no"}},
+		{"unknown mode", &types.StreamRequest{Subreddit: "golang", Mode: "sideways"}},
+		{"limit too large", &types.StreamRequest{Subreddit: "golang", Limit: 500}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.StreamNewPosts(context.Background(), tt.request); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestRecentIDs_EvictsOldest(t *testing.T) {
+	s := newRecentIDs(2)
+	s.add("a")
+	s.add("b")
+	s.add("c")
+	if s.contains("a") || !s.contains("b") || !s.contains("c") {
+		t.Errorf("unexpected contents %v", s.order)
+	}
+}
@@ -0,0 +1,161 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
+)
+
+// maxCachedPostRequirements bounds the number of subreddits whose submission rules are
+// cached. The least recently used subreddits are dropped first.
+const maxCachedPostRequirements = 1000
+
+// cachedPostRequirements is a cache entry for a subreddit's submission rules.
+type cachedPostRequirements struct {
+	requirements *types.PostRequirements
+	fetchedAt    time.Time
+}
+
+// GetPostRequirements retrieves a subreddit's submission rules, such as title length limits,
+// body restrictions, domain lists, and whether post flair is required.
+//
+// Results are cached per subreddit for Config.PostRequirementsCacheTTL, so repeated validation of
+// drafts for the same subreddit does not cost additional API calls. At most 1000 subreddits are
+// cached; the least recently used are dropped first.
+//
+// Returns an error if:
+//   - The subreddit name is invalid
+//   - The API request fails
+func (r *Reddit) GetPostRequirements(ctx context.Context, subreddit string) (*types.PostRequirements, error) {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower(subreddit)
+	ttl := r.postRequirementsTTL()
+	if entry, ok := r.postRequirements.get(key); ok {
+		if time.Since(entry.fetchedAt) < ttl {
+			return entry.requirements, nil
+		}
+		r.postRequirements.remove(key, entry)
+	}
+
+	path := fmt.Sprintf(PostRequirementsURLFormat, subreddit)
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var requirements types.PostRequirements
+	if err := r.httpClient.DoJSON(req, &requirements); err != nil {
+		return nil, wrapDoError(err, "get post requirements", path)
+	}
+
+	now := time.Now()
+	r.postRequirements.put(key, &cachedPostRequirements{requirements: &requirements, fetchedAt: now}, now.Add(ttl), maxCachedPostRequirements)
+	return &requirements, nil
+}
+
+// postRequirementsTTL returns the configured cache lifetime for post requirements.
+func (r *Reddit) postRequirementsTTL() time.Duration {
+	if r.config != nil && r.config.PostRequirementsCacheTTL > 0 {
+		return r.config.PostRequirementsCacheTTL
+	}
+	return PostRequirementsCacheTTL
+}
+
+// ValidateSubmission checks a post draft before it is submitted, so that obviously invalid
+// posts fail locally instead of wasting an API call.
+//
+// The draft is checked against Reddit's global limits (title and body length, URL format)
+// and the subreddit's post requirements (flair, title and body rules, domain lists), which
+// are fetched via GetPostRequirements and cached.
+//
+// Returns a *errors.ValidationError listing every invalid field, or an error from fetching
+// the subreddit's requirements. If the subreddit name itself is invalid, requirements are not
+// fetched and only the local checks are reported.
+func (r *Reddit) ValidateSubmission(ctx context.Context, request *types.SubmitRequest) error {
+	if request == nil || r.validator.ValidateSubredditName(validation.NormalizeSubreddit(request.Subreddit)) != nil {
+		return validation.ValidateSubmission(request, nil)
+	}
+
+	requirements, err := r.GetPostRequirements(ctx, request.Subreddit)
+	if err != nil {
+		return err
+	}
+
+	return validation.ValidateSubmission(request, requirements)
+}
+
+// SubmitPost submits a self, link, or image post to a subreddit. For image posts, upload
+// the image with UploadImage first and pass the returned URL as request.URL.
+//
+// The draft is checked with validation.ValidateSubmission before anything is sent; call
+// ValidateSubmission first to also check the subreddit's post requirements, or set
+// request.CheckFlair to have a required flair attached or reported before posting. Set
+// request.IdempotencyKey to make retries after timeouts safe: a repeated key returns the
+// post created by the earlier attempt (with Reused set) rather than posting a duplicate.
+//
+// Returns an error if:
+//   - The request is invalid
+//   - request.CheckFlair is set and the subreddit requires a flair that was not given
+//   - A submission with the same IdempotencyKey is already in progress
+//   - The API request fails or Reddit rejects the post
+func (r *Reddit) SubmitPost(ctx context.Context, request *types.SubmitRequest) (*types.SubmitResponse, error) {
+	if err := validation.ValidateSubmission(request, nil); err != nil {
+		return nil, err
+	}
+	subreddit := validation.NormalizeSubreddit(request.Subreddit)
+	flairID := request.FlairID
+	if request.CheckFlair {
+		var err error
+		if flairID, err = r.requiredFlair(ctx, subreddit, request); err != nil {
+			return nil, err
+		}
+	}
+
+	form := url.Values{}
+	form.Set("sr", subreddit)
+	form.Set("kind", string(request.Kind))
+	form.Set("title", request.Title)
+	switch request.Kind {
+	case types.SubmitKindSelf:
+		form.Set("text", request.Text)
+	case types.SubmitKindLink, types.SubmitKindImage:
+		form.Set("url", request.URL)
+	}
+	if flairID != "" {
+		form.Set("flair_id", flairID)
+	}
+	if request.FlairText != "" {
+		form.Set("flair_text", request.FlairText)
+	}
+	form.Set("nsfw", strconv.FormatBool(request.NSFW))
+	form.Set("spoiler", strconv.FormatBool(request.Spoiler))
+	form.Set("sendreplies", strconv.FormatBool(request.SendReplies))
+
+	return r.submitIdempotent(ctx, request, func() (*types.SubmitResponse, error) {
+		ctx, rateLimit := r.recordResponse(ctx)
+		var resp types.SubmitResponse
+		if err := r.postForm(ctx, "submit", SubmitURL, "r/"+subreddit, form, &resp); err != nil {
+			return nil, err
+		}
+		resp.RateLimit = rateLimit.Info()
+		resp.Meta = rateLimit.Meta()
+		return &resp, nil
+	})
+}
@@ -0,0 +1,114 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestClient_GetPostRequirements(t *testing.T) {
+	var calls atomic.Int32
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			calls.Add(1)
+			if req.URL.Path != "/api/v1/golang/post_requirements" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			return json.Unmarshal([]byte(`{"is_flair_required":true,"body_restriction_policy":"required"}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	for i := 0; i < 3; i++ {
+		reqs, err := client.GetPostRequirements(context.Background(), "golang")
+		if err != nil {
+			t.Fatalf("GetPostRequirements returned error: %v", err)
+		}
+		if !reqs.IsFlairRequired || reqs.BodyRestrictionPolicy != "required" {
+			t.Errorf("unexpected requirements: %+v", reqs)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected requirements to be cached after 1 call, got %d calls", got)
+	}
+
+	if _, err := client.GetPostRequirements(context.Background(), "a"); err == nil {
+		t.Error("expected error for invalid subreddit name")
+	}
+}
+
+func TestClient_GetPostRequirements_Bounded(t *testing.T) {
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			return json.Unmarshal([]byte(`{}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	for i := range maxCachedPostRequirements + 10 {
+		if _, err := client.GetPostRequirements(context.Background(), fmt.Sprintf("sub%d", i)); err != nil {
+			t.Fatalf("GetPostRequirements returned error: %v", err)
+		}
+	}
+	if n := client.postRequirements.len(); n != maxCachedPostRequirements {
+		t.Errorf("cached subreddits = %d, want the bound %d", n, maxCachedPostRequirements)
+	}
+}
+
+func TestClient_ValidateSubmission(t *testing.T) {
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			return json.Unmarshal([]byte(`{"is_flair_required":true}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	err := client.ValidateSubmission(ctx, &types.SubmitRequest{
+		Subreddit: "golang",
+		Title:     "Generics question",
+		Kind:      types.SubmitKindSelf,
+		Text:      "How do I...",
+	})
+	var verr *pkgerrs.ValidationError
+	if !errors.As(err, &verr) || !verr.HasField("FlairID") {
+		t.Fatalf("expected FlairID validation error, got %v", err)
+	}
+
+	err = client.ValidateSubmission(ctx, &types.SubmitRequest{
+		Subreddit: "golang",
+		Title:     "Generics question",
+		Kind:      types.SubmitKindSelf,
+		FlairID:   "flair-1",
+	})
+	if err != nil {
+		t.Errorf("expected valid submission, got %v", err)
+	}
+
+	t.Run("requirements fetch failure", func(t *testing.T) {
+		failing := newTestClient(&mockHTTPClient{
+			doJSONFunc: func(req *http.Request, v any) error {
+				return &pkgerrs.APIError{StatusCode: http.StatusForbidden, Message: "forbidden"}
+			},
+		}, nil)
+		err := failing.ValidateSubmission(ctx, &types.SubmitRequest{Subreddit: "golang", Title: "t", Kind: types.SubmitKindSelf})
+		var apiErr *pkgerrs.APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("expected APIError, got %T: %v", err, err)
+		}
+	})
+
+	t.Run("invalid subreddit skips fetch", func(t *testing.T) {
+		err := client.ValidateSubmission(ctx, &types.SubmitRequest{Subreddit: "x", Title: "t", Kind: types.SubmitKindSelf})
+		var verr *pkgerrs.ValidationError
+		if !errors.As(err, &verr) || !verr.HasField("Subreddit") {
+			t.Errorf("expected Subreddit validation error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package graw
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// maxCachedSubreddits bounds the number of subreddits cached for GetSubreddit and
+// Post.SubredditInfo. The least recently used subreddits are dropped first.
+const maxCachedSubreddits = 5000
+
+// cachedSubreddit is a subreddit cache entry.
+type cachedSubreddit struct {
+	data *types.SubredditData
+	// about is set for complete data from GetSubreddit, and unset for the partial sr_detail
+	// data embedded in listings.
+	about     bool
+	fetchedAt time.Time
+}
+
+// rememberSubreddit caches data for Post.SubredditInfo and, if it is complete about data,
+// GetSubreddit. Partial data does not replace a fresh complete entry.
+func (r *Reddit) rememberSubreddit(data *types.SubredditData, about bool) {
+	ttl := r.subredditInfoTTL()
+	if data == nil || data.DisplayName == "" || ttl < 0 {
+		return
+	}
+	key := strings.ToLower(data.DisplayName)
+	if !about {
+		if entry, ok := r.cachedSubreddit(key); ok && entry.about {
+			return
+		}
+	}
+	now := time.Now()
+	r.subreddits.put(key, &cachedSubreddit{data: data, about: about, fetchedAt: now}, now.Add(ttl), maxCachedSubreddits)
+}
+
+// cachedSubreddit returns the unexpired cache entry for the lowercase subreddit name key.
+func (r *Reddit) cachedSubreddit(key string) (*cachedSubreddit, bool) {
+	entry, ok := r.subreddits.get(key)
+	if !ok {
+		return nil, false
+	}
+	if ttl := r.subredditInfoTTL(); ttl < 0 || time.Since(entry.fetchedAt) >= ttl {
+		r.subreddits.remove(key, entry)
+		return nil, false
+	}
+	return entry, true
+}
+
+// forgetSubreddit drops the cached data of the subreddit, e.g. after a change to the
+// user's settings for it.
+func (r *Reddit) forgetSubreddit(name string) {
+	key := strings.ToLower(name)
+	if entry, ok := r.subreddits.get(key); ok {
+		r.subreddits.remove(key, entry)
+	}
+}
+
+// attachSubredditInfo caches the sr_detail data of posts and attaches the cached data of
+// each post's subreddit, for Post.SubredditInfo.
+func (r *Reddit) attachSubredditInfo(posts ...*types.Post) {
+	for _, post := range posts {
+		if post == nil {
+			continue
+		}
+		r.rememberSubreddit(post.SrDetail, false)
+		if entry, ok := r.cachedSubreddit(strings.ToLower(post.Subreddit)); ok {
+			post.SetSubredditInfo(entry.data)
+		}
+	}
+}
+
+// subredditInfoTTL returns the configured cache lifetime for subreddit data, negative if
+// the cache is disabled.
+func (r *Reddit) subredditInfoTTL() time.Duration {
+	if r.config != nil && r.config.SubredditInfoCacheTTL != 0 {
+		return r.config.SubredditInfoCacheTTL
+	}
+	return SubredditInfoCacheTTL
+}
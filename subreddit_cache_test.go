@@ -0,0 +1,159 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// srDetailPostThing is submitPostThing with sr_detail data embedded.
+func srDetailPostThing(t *testing.T, id string, subscribers int) *types.Thing {
+	t.Helper()
+	thing := submitPostThing(t, id, "post "+id, "gopher", time.Unix(1700000000, 0))
+	var data map[string]any
+	if err := json.Unmarshal(thing.Data, &data); err != nil {
+		t.Fatal(err)
+	}
+	data["sr_detail"] = map[string]any{"display_name": "golang", "title": "The Go Programming Language", "subscribers": subscribers}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &types.Thing{Kind: "t3", Data: raw}
+}
+
+// subredditCacheMock serves r/golang/about and an r/golang/new listing, counting about requests.
+func subredditCacheMock(t *testing.T, abouts *int, posts ...*types.Thing) *mockHTTPClient {
+	return &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			switch req.URL.Path {
+			case "/r/golang/about":
+				*abouts++
+				data, _ := json.Marshal(map[string]any{"id": "2qh1i", "name": "t5_2qh1i", "display_name": "golang", "subscribers": 250000, "accounts_active": 900})
+				*v = types.Thing{Kind: "t5", Data: data}
+			case "/r/golang/new":
+				*v = *listingThing(t, posts...)
+			default:
+				t.Errorf("unexpected request %s", req.URL.Path)
+			}
+			return nil
+		},
+	}
+}
+
+func TestSubredditInfo_FromSrDetail(t *testing.T) {
+	var abouts int
+	client := newTestClient(subredditCacheMock(t, &abouts,
+		srDetailPostThing(t, "a1", 249000),
+		submitPostThing(t, "a2", "post a2", "gopher", time.Unix(1700000000, 0)),
+	), nil)
+
+	resp, err := client.GetNew(context.Background(), &types.PostsRequest{Subreddit: "golang"})
+	if err != nil {
+		t.Fatalf("GetNew returned error: %v", err)
+	}
+	for _, post := range resp.Posts {
+		if info := post.SubredditInfo(); info == nil || info.Subscribers != 249000 {
+			t.Errorf("post %s SubredditInfo() = %+v, want the sr_detail data", post.ID, info)
+		}
+	}
+
+	// Partial sr_detail data does not stand in for GetSubreddit.
+	if _, err := client.GetSubreddit(context.Background(), "golang"); err != nil {
+		t.Fatalf("GetSubreddit returned error: %v", err)
+	}
+	if abouts != 1 {
+		t.Errorf("about requests = %d, want 1", abouts)
+	}
+}
+
+func TestSubredditInfo_FromGetSubreddit(t *testing.T) {
+	var abouts int
+	client := newTestClient(subredditCacheMock(t, &abouts, srDetailPostThing(t, "a1", 249000)), nil)
+	ctx := context.Background()
+
+	for _, name := range []string{"golang", "r/golang", "GoLang"} {
+		if _, err := client.GetSubreddit(ctx, name); err != nil {
+			t.Fatalf("GetSubreddit returned error: %v", err)
+		}
+	}
+	if abouts != 1 {
+		t.Errorf("about requests = %d, want 1", abouts)
+	}
+
+	resp, err := client.GetNew(ctx, &types.PostsRequest{Subreddit: "golang"})
+	if err != nil {
+		t.Fatalf("GetNew returned error: %v", err)
+	}
+	info := resp.Posts[0].SubredditInfo()
+	if info == nil || info.AccountsActive != 900 {
+		t.Errorf("SubredditInfo() = %+v, want the cached about data", info)
+	}
+	if resp.Posts[0].SrDetail == nil || resp.Posts[0].SrDetail.Subscribers != 249000 {
+		t.Errorf("SrDetail = %+v, want the embedded data", resp.Posts[0].SrDetail)
+	}
+
+	client.config.SubredditInfoCacheTTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	if _, err := client.GetSubreddit(ctx, "golang"); err != nil {
+		t.Fatalf("GetSubreddit returned error: %v", err)
+	}
+	if abouts != 2 {
+		t.Errorf("about requests after expiry = %d, want 2", abouts)
+	}
+}
+
+func TestSubredditInfo_CacheDisabled(t *testing.T) {
+	var abouts int
+	client := newTestClient(subredditCacheMock(t, &abouts, srDetailPostThing(t, "a1", 249000)), nil)
+	client.config.SubredditInfoCacheTTL = -1
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := client.GetSubreddit(ctx, "golang"); err != nil {
+			t.Fatalf("GetSubreddit returned error: %v", err)
+		}
+	}
+	if abouts != 2 {
+		t.Errorf("about requests = %d, want 2 with the cache disabled", abouts)
+	}
+	if n := client.subreddits.len(); n != 0 {
+		t.Errorf("cached subreddits = %d, want 0", n)
+	}
+}
+
+func TestBoundedCache(t *testing.T) {
+	var cache boundedCache[int]
+	now := time.Now()
+	cache.put("expired", 1, now.Add(-time.Second), 3)
+	cache.put("old", 2, now.Add(time.Hour), 3)
+	cache.put("recent", 3, now.Add(time.Hour), 3)
+
+	// The expired entry goes first.
+	cache.put("new", 4, now.Add(time.Hour), 3)
+	if _, ok := cache.get("expired"); ok || cache.len() != 3 {
+		t.Errorf("after first eviction: expired kept = %v, len = %d; want false, 3", ok, cache.len())
+	}
+
+	// Then the least recently used one.
+	cache.get("old")
+	cache.put("newer", 5, now.Add(time.Hour), 3)
+	if _, ok := cache.get("recent"); ok {
+		t.Error("least recently used entry was kept")
+	}
+	if _, ok := cache.get("old"); !ok || cache.len() != 3 {
+		t.Errorf("recently used entry kept = %v, len = %d; want true, 3", ok, cache.len())
+	}
+
+	if cache.replace("old", 9, 6, now.Add(time.Hour)) {
+		t.Error("replace with a stale old value succeeded")
+	}
+	cache.remove("old", 2)
+	if _, ok := cache.get("old"); ok {
+		t.Error("remove kept the entry")
+	}
+}
@@ -0,0 +1,113 @@
+package graw
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const (
+	// syncPageSize is the number of posts requested per page by SyncListing (Reddit's maximum).
+	syncPageSize = 100
+	// syncMaxPages bounds how many pages one SyncListing call reads to close a gap.
+	syncMaxPages = 10
+)
+
+// SyncListing fetches the posts in a subreddit listing that are newer than state.Watermark,
+// advances the watermark past them, and reports them oldest first. Run it periodically to
+// keep a copy of a subreddit up to date without refetching whole pages.
+//
+// Posts are compared by their base36 IDs, which Reddit assigns in creation order, so the sync
+// still works after the watermark post is deleted. For SortNew, SyncListing pages back until
+// it reaches the watermark, reading at most 1000 posts; Truncated is set if it gave up first.
+// Other sorts only read their first page and report the posts on it newer than the watermark.
+//
+// With a zero state, the posts on the listing's first page are reported as added. state is
+// only updated when the call succeeds.
+//
+// Returns an error if state is nil, the subreddit or sort is invalid, or a request fails.
+func (r *Reddit) SyncListing(ctx context.Context, subreddit string, sort ListingSort, state *types.SyncState) (*types.SyncResult, error) {
+	if state == nil {
+		return nil, &pkgerrs.ConfigError{Field: "state", Message: "sync state cannot be nil"}
+	}
+	if subreddit == "" {
+		return nil, &pkgerrs.ConfigError{Field: "Subreddit", Message: "subreddit is required"}
+	}
+	var watermark uint64
+	if state.Watermark != "" {
+		var ok bool
+		if watermark, ok = fullnameOrder(state.Watermark); !ok {
+			return nil, &pkgerrs.ConfigError{Field: "Watermark", Message: "invalid watermark fullname: " + state.Watermark}
+		}
+	}
+
+	maxPages := syncMaxPages
+	if sort != SortNew || state.Watermark == "" {
+		maxPages = 1
+	}
+
+	result := &types.SyncResult{}
+	orders := make(map[string]uint64)
+	after := ""
+	for page := 0; ; page++ {
+		if page == maxPages {
+			result.Truncated = sort == SortNew && state.Watermark != ""
+			break
+		}
+		resp, err := r.getPosts(ctx, &types.PostsRequest{
+			Subreddit:  subreddit,
+			Pagination: types.Pagination{Limit: syncPageSize, After: after},
+		}, sort)
+		if err != nil {
+			return nil, err
+		}
+
+		reached := false
+		for _, post := range resp.Posts {
+			order, ok := fullnameOrder(post.Name)
+			if !ok {
+				continue
+			}
+			if state.Watermark != "" && order <= watermark {
+				reached = true
+				continue
+			}
+			// Posts can shift onto the next page between requests.
+			if _, dup := orders[post.Name]; dup {
+				continue
+			}
+			result.Added = append(result.Added, post)
+			orders[post.Name] = order
+		}
+		if reached || resp.AfterFullname == "" || len(resp.Posts) == 0 {
+			break
+		}
+		after = resp.AfterFullname
+	}
+
+	slices.SortFunc(result.Added, func(a, b *types.Post) int {
+		return cmp.Compare(orders[a.Name], orders[b.Name])
+	})
+	if len(result.Added) > 0 {
+		state.Watermark = result.Added[len(result.Added)-1].Name
+	}
+	state.LastSync = time.Now()
+	return result, nil
+}
+
+// fullnameOrder returns the numeric value of a fullname's base36 ID, which increases with
+// creation time among things of the same kind.
+func fullnameOrder(fullname string) (uint64, bool) {
+	_, id, ok := strings.Cut(fullname, "_")
+	if !ok || id == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(id, 36, 64)
+	return n, err == nil
+}
@@ -0,0 +1,135 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// syncListingMock serves r/golang/new from *newest posts numbered down to 1, newest first,
+// leaving out deleted ones and paging with after=. It counts the requests in *requests.
+func syncListingMock(t *testing.T, newest *int, requests *int, deleted map[int]bool) *mockHTTPClient {
+	return &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*requests++
+			if req.URL.Path != "/r/golang/new" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			start := *newest
+			if after := req.URL.Query().Get("after"); after != "" {
+				n, _ := strconv.ParseUint(after[len("t3_"):], 36, 64)
+				start = int(n) - 1
+			}
+			var children []*types.Thing
+			n := start
+			for ; n > 0 && len(children) < syncPageSize; n-- {
+				if !deleted[n] {
+					created := time.Unix(1700000000+int64(n), 0)
+					children = append(children, submitPostThing(t, strconv.FormatInt(int64(n), 36), "post", "poster", created))
+				}
+			}
+			listing := types.ListingData{Children: children}
+			if n > 0 {
+				listing.AfterFullname = "t3_" + strconv.FormatInt(int64(n+1), 36)
+			}
+			data, err := json.Marshal(listing)
+			if err != nil {
+				t.Fatalf("marshal listing: %v", err)
+			}
+			*v = types.Thing{Kind: "Listing", Data: data}
+			return nil
+		},
+	}
+}
+
+func TestClient_SyncListing(t *testing.T) {
+	newest, requests := 5000, 0
+	client := newTestClient(syncListingMock(t, &newest, &requests, nil), nil)
+	ctx := context.Background()
+	var state types.SyncState
+
+	// The first sync reports the first page.
+	result, err := client.SyncListing(ctx, "golang", SortNew, &state)
+	if err != nil {
+		t.Fatalf("SyncListing returned error: %v", err)
+	}
+	if len(result.Added) != syncPageSize || result.Truncated || requests != 1 {
+		t.Fatalf("first sync: added %d, truncated %v, requests %d", len(result.Added), result.Truncated, requests)
+	}
+	if want := "t3_" + strconv.FormatInt(5000, 36); state.Watermark != want || state.LastSync.IsZero() {
+		t.Errorf("state = %+v, want watermark %s", state, want)
+	}
+	if result.Added[0].ID != strconv.FormatInt(4901, 36) {
+		t.Errorf("first added = %s, want the oldest post first", result.Added[0].ID)
+	}
+
+	// A later sync pages back only as far as the watermark.
+	newest, requests = 5150, 0
+	result, err = client.SyncListing(ctx, "golang", SortNew, &state)
+	if err != nil {
+		t.Fatalf("SyncListing returned error: %v", err)
+	}
+	if len(result.Added) != 150 || result.Truncated || requests != 2 {
+		t.Errorf("incremental sync: added %d, truncated %v, requests %d", len(result.Added), result.Truncated, requests)
+	}
+	if result.Added[0].ID != strconv.FormatInt(5001, 36) || result.Added[149].ID != strconv.FormatInt(5150, 36) {
+		t.Errorf("added range %s..%s", result.Added[0].ID, result.Added[149].ID)
+	}
+
+	// Nothing new.
+	requests = 0
+	if result, err = client.SyncListing(ctx, "golang", SortNew, &state); err != nil || len(result.Added) != 0 || requests != 1 {
+		t.Errorf("idle sync: %v, %+v, requests %d", err, result, requests)
+	}
+
+	// A gap larger than the sync reads is reported as truncated.
+	newest, requests = 7000, 0
+	result, err = client.SyncListing(ctx, "golang", SortNew, &state)
+	if err != nil {
+		t.Fatalf("SyncListing returned error: %v", err)
+	}
+	if !result.Truncated || len(result.Added) != syncPageSize*syncMaxPages || requests != syncMaxPages {
+		t.Errorf("large gap: added %d, truncated %v, requests %d", len(result.Added), result.Truncated, requests)
+	}
+	if want := "t3_" + strconv.FormatInt(7000, 36); state.Watermark != want {
+		t.Errorf("watermark = %s, want %s", state.Watermark, want)
+	}
+}
+
+func TestClient_SyncListing_DeletedWatermark(t *testing.T) {
+	newest, requests := 300, 0
+	// The watermark post has been deleted, so the listing never returns it.
+	client := newTestClient(syncListingMock(t, &newest, &requests, map[int]bool{250: true}), nil)
+	state := types.SyncState{Watermark: "t3_" + strconv.FormatInt(250, 36)}
+
+	result, err := client.SyncListing(context.Background(), "golang", SortNew, &state)
+	if err != nil {
+		t.Fatalf("SyncListing returned error: %v", err)
+	}
+	if len(result.Added) != 50 || result.Truncated || requests != 1 {
+		t.Errorf("added %d, truncated %v, requests %d; want 50 posts from one page", len(result.Added), result.Truncated, requests)
+	}
+}
+
+func TestClient_SyncListing_Errors(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	ctx := context.Background()
+	var configErr *pkgerrs.ConfigError
+
+	if _, err := client.SyncListing(ctx, "golang", SortNew, nil); !errors.As(err, &configErr) {
+		t.Errorf("nil state: got %v, want ConfigError", err)
+	}
+	if _, err := client.SyncListing(ctx, "golang", SortNew, &types.SyncState{Watermark: "bad"}); !errors.As(err, &configErr) {
+		t.Errorf("invalid watermark: got %v, want ConfigError", err)
+	}
+	if _, err := client.SyncListing(ctx, "golang", ListingSort{Order: "best"}, &types.SyncState{}); !errors.As(err, &configErr) {
+		t.Errorf("invalid sort: got %v, want ConfigError", err)
+	}
+}
@@ -181,7 +181,7 @@ func (cg *CommentGenerator) GenerateCommentWithOptions(opts CommentThreadOptions
 	}
 
 	if !opts.HasEdited {
-		comment.Edited = types.Edited{IsEdited: false, Timestamp: 0}
+		comment.Edited = types.Edited{}
 	}
 
 	if !opts.HasAwards {
@@ -402,13 +402,13 @@ func (cg *CommentGenerator) generatePostID() string {
 
 func (cg *CommentGenerator) generateEditedTime(created time.Time) types.Edited {
 	if cg.rand.Float32() < 0.85 { // 85% chance not edited
-		return types.Edited{IsEdited: false, Timestamp: 0}
+		return types.Edited{}
 	}
 
 	// Edited sometime after creation
 	editDelay := time.Duration(cg.rand.Intn(7200)) * time.Second // Within 2 hours
 	editedTime := created.Add(editDelay)
-	return types.Edited{IsEdited: true, Timestamp: float64(editedTime.Unix())}
+	return types.EditedAt(editedTime.Truncate(time.Second))
 }
 
 func (cg *CommentGenerator) randElement(slice []string) string {
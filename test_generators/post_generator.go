@@ -247,7 +247,7 @@ func (pg *PostGenerator) GenerateOldPost() types.Post {
 		Created:    oldUTC,
 		CreatedUTC: oldUTC,
 	}
-	post.Edited = types.Edited{IsEdited: false, Timestamp: 0} // Usually not edited
+	post.Edited = types.Edited{} // Usually not edited
 
 	return post
 }
@@ -364,13 +364,13 @@ func (pg *PostGenerator) generatePostID() string {
 
 func (pg *PostGenerator) generateEditedTime(created time.Time) types.Edited {
 	if pg.rand.Float32() < 0.8 { // 80% chance not edited
-		return types.Edited{IsEdited: false, Timestamp: 0}
+		return types.Edited{}
 	}
 
 	// Edited sometime after creation
 	editDelay := time.Duration(pg.rand.Intn(3600)) * time.Second // Within 1 hour
 	editedTime := created.Add(editDelay)
-	return types.Edited{IsEdited: true, Timestamp: float64(editedTime.Unix())}
+	return types.EditedAt(editedTime.Truncate(time.Second))
 }
 
 func (pg *PostGenerator) generatePostURL(subreddit, title string) string {
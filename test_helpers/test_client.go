@@ -39,6 +39,7 @@ func NewTestClient(config *MockClientConfig) *TestClient {
 		UserAgent:    config.UserAgent,
 		BaseURL:      config.BaseURL,
 		AuthURL:      config.BaseURL, // Use same URL for auth
+		WebURL:       config.BaseURL, // and for website endpoints
 		HTTPClient: &http.Client{
 			Timeout: config.Timeout,
 		},
@@ -0,0 +1,148 @@
+package graw
+
+import (
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// CalendarPeriod is a calendar unit for CalendarRange.
+type CalendarPeriod int
+
+const (
+	CalendarDay   CalendarPeriod = iota // Midnight to midnight
+	CalendarWeek                        // Monday to Monday (ISO 8601 weeks)
+	CalendarMonth                       // First of the month to first of the next
+	CalendarYear                        // January 1 to January 1
+)
+
+// TimeRange is the half-open interval [Since, Until).
+//
+// Reddit's listing time filters are relative to the current time ("the past month"), so a
+// calendar query such as "top posts of last month" needs both a time filter wide enough to
+// reach Since, from TopOf, and client-side filtering with Contains:
+//
+//	month := graw.LastCalendar(graw.CalendarMonth, time.Now().In(loc))
+//	resp, err := client.GetPosts(ctx, "golang", graw.WithSort(month.Top()), graw.WithLimit(100))
+//	posts := month.FilterPosts(resp.Posts)
+type TimeRange struct {
+	Since time.Time
+	Until time.Time
+}
+
+// CalendarRange returns the calendar period containing t. Boundaries are computed in t's
+// location, so day and month edges follow the caller's time zone and its daylight saving
+// changes; convert t with In first to use another zone.
+func CalendarRange(period CalendarPeriod, t time.Time) TimeRange {
+	y, m, d := t.Date()
+	loc := t.Location()
+	var since, until time.Time
+	switch period {
+	case CalendarWeek:
+		// time.Weekday counts from Sunday; shift so Monday is day 0.
+		offset := (int(t.Weekday()) + 6) % 7
+		since = time.Date(y, m, d-offset, 0, 0, 0, 0, loc)
+		until = time.Date(y, m, d-offset+7, 0, 0, 0, 0, loc)
+	case CalendarMonth:
+		since = time.Date(y, m, 1, 0, 0, 0, 0, loc)
+		until = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+	case CalendarYear:
+		since = time.Date(y, time.January, 1, 0, 0, 0, 0, loc)
+		until = time.Date(y+1, time.January, 1, 0, 0, 0, 0, loc)
+	default:
+		since = time.Date(y, m, d, 0, 0, 0, 0, loc)
+		until = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+	}
+	return TimeRange{Since: since, Until: until}
+}
+
+// LastCalendar returns the complete calendar period before the one containing now, e.g.
+// all of March when now is any time in April. Unlike now.AddDate(0, -1, 0), it does not
+// skip or repeat a month when now falls on the 29th to 31st.
+func LastCalendar(period CalendarPeriod, now time.Time) TimeRange {
+	current := CalendarRange(period, now)
+	return CalendarRange(period, current.Since.Add(-time.Nanosecond))
+}
+
+// Contains reports whether t falls within the range.
+func (tr TimeRange) Contains(t time.Time) bool {
+	return !t.Before(tr.Since) && t.Before(tr.Until)
+}
+
+// ContainsUTC reports whether a Reddit created_utc timestamp falls within the range.
+func (tr TimeRange) ContainsUTC(created float64) bool {
+	sec := int64(created)
+	nsec := int64((created - float64(sec)) * float64(time.Second))
+	return tr.Contains(time.Unix(sec, nsec))
+}
+
+// FilterPosts returns the posts created within the range, in their original order.
+func (tr TimeRange) FilterPosts(posts []*types.Post) []*types.Post {
+	var filtered []*types.Post
+	for _, p := range posts {
+		if p != nil && tr.ContainsUTC(p.CreatedUTC) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// FilterComments returns the comments created within the range, in their original order.
+// Replies are not searched.
+func (tr TimeRange) FilterComments(comments []*types.Comment) []*types.Comment {
+	var filtered []*types.Comment
+	for _, c := range comments {
+		if c != nil && tr.ContainsUTC(c.CreatedUTC) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// Top returns the narrowest top listing that reaches back to the start of the range.
+func (tr TimeRange) Top() ListingSort {
+	return TopOf(tr.Since)
+}
+
+// Controversial returns the narrowest controversial listing that reaches back to the
+// start of the range.
+func (tr TimeRange) Controversial() ListingSort {
+	return ControversialOf(tr.Since)
+}
+
+// TopOf returns the narrowest top listing whose time filter, counted back from now,
+// includes posts created at t. Results must still be filtered to the wanted range, since
+// the filter usually reaches further back than t.
+func TopOf(t time.Time) ListingSort {
+	return ListingSort{Order: "top", Time: timeFilterSince(t, time.Now())}
+}
+
+// ControversialOf is like TopOf for controversial listings.
+func ControversialOf(t time.Time) ListingSort {
+	return ListingSort{Order: "controversial", Time: timeFilterSince(t, time.Now())}
+}
+
+// timeFilterWindows are Reddit's time filters with the spans they are guaranteed to cover.
+// Month and year are rounded down so that a boundary case picks the wider filter rather
+// than missing posts.
+var timeFilterWindows = []struct {
+	filter string
+	span   time.Duration
+}{
+	{"hour", time.Hour},
+	{"day", 24 * time.Hour},
+	{"week", 7 * 24 * time.Hour},
+	{"month", 28 * 24 * time.Hour},
+	{"year", 365 * 24 * time.Hour},
+}
+
+// timeFilterSince returns the narrowest time filter that includes t, relative to now.
+func timeFilterSince(t, now time.Time) string {
+	age := now.Sub(t)
+	for _, w := range timeFilterWindows {
+		if age <= w.span {
+			return w.filter
+		}
+	}
+	return "all"
+}
@@ -0,0 +1,104 @@
+package graw
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+func TestCalendarRange(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	at := time.Date(2024, time.March, 31, 22, 30, 0, 0, ny) // a Sunday
+
+	tests := []struct {
+		name      string
+		period    CalendarPeriod
+		last      bool
+		wantSince time.Time
+		wantUntil time.Time
+	}{
+		{"day", CalendarDay, false, time.Date(2024, 3, 31, 0, 0, 0, 0, ny), time.Date(2024, 4, 1, 0, 0, 0, 0, ny)},
+		{"week", CalendarWeek, false, time.Date(2024, 3, 25, 0, 0, 0, 0, ny), time.Date(2024, 4, 1, 0, 0, 0, 0, ny)},
+		{"month", CalendarMonth, false, time.Date(2024, 3, 1, 0, 0, 0, 0, ny), time.Date(2024, 4, 1, 0, 0, 0, 0, ny)},
+		{"year", CalendarYear, false, time.Date(2024, 1, 1, 0, 0, 0, 0, ny), time.Date(2025, 1, 1, 0, 0, 0, 0, ny)},
+		{"last day", CalendarDay, true, time.Date(2024, 3, 30, 0, 0, 0, 0, ny), time.Date(2024, 3, 31, 0, 0, 0, 0, ny)},
+		{"last week", CalendarWeek, true, time.Date(2024, 3, 18, 0, 0, 0, 0, ny), time.Date(2024, 3, 25, 0, 0, 0, 0, ny)},
+		// AddDate(0, -1, 0) from March 31 lands on March 2; the previous month is February.
+		{"last month", CalendarMonth, true, time.Date(2024, 2, 1, 0, 0, 0, 0, ny), time.Date(2024, 3, 1, 0, 0, 0, 0, ny)},
+		{"last year", CalendarYear, true, time.Date(2023, 1, 1, 0, 0, 0, 0, ny), time.Date(2024, 1, 1, 0, 0, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TimeRange
+			if tt.last {
+				got = LastCalendar(tt.period, at)
+			} else {
+				got = CalendarRange(tt.period, at)
+			}
+			if !got.Since.Equal(tt.wantSince) || !got.Until.Equal(tt.wantUntil) {
+				t.Errorf("got [%v, %v), want [%v, %v)", got.Since, got.Until, tt.wantSince, tt.wantUntil)
+			}
+		})
+	}
+
+	// The day daylight saving starts is 23 hours long.
+	dst := CalendarRange(CalendarDay, time.Date(2024, 3, 10, 12, 0, 0, 0, ny))
+	if d := dst.Until.Sub(dst.Since); d != 23*time.Hour {
+		t.Errorf("DST day length = %v, want 23h", d)
+	}
+}
+
+func TestTimeRange_Filter(t *testing.T) {
+	tr := TimeRange{Since: time.Unix(1000, 0), Until: time.Unix(2000, 0)}
+	posts := []*types.Post{
+		{ThingData: types.ThingData{ID: "before"}, Created: types.Created{CreatedUTC: 999}},
+		{ThingData: types.ThingData{ID: "start"}, Created: types.Created{CreatedUTC: 1000}},
+		nil,
+		{ThingData: types.ThingData{ID: "inside"}, Created: types.Created{CreatedUTC: 1999.5}},
+		{ThingData: types.ThingData{ID: "end"}, Created: types.Created{CreatedUTC: 2000}},
+	}
+	got := tr.FilterPosts(posts)
+	if len(got) != 2 || got[0].ID != "start" || got[1].ID != "inside" {
+		t.Errorf("FilterPosts() = %+v, want start and inside", got)
+	}
+
+	comments := []*types.Comment{
+		{ThingData: types.ThingData{ID: "c1"}, Created: types.Created{CreatedUTC: 1500}},
+		{ThingData: types.ThingData{ID: "c2"}, Created: types.Created{CreatedUTC: 2500}},
+	}
+	if got := tr.FilterComments(comments); len(got) != 1 || got[0].ID != "c1" {
+		t.Errorf("FilterComments() = %+v, want c1", got)
+	}
+}
+
+func TestTimeFilterSince(t *testing.T) {
+	now := time.Date(2024, 4, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{30 * time.Minute, "hour"},
+		{time.Hour, "hour"},
+		{2 * time.Hour, "day"},
+		{3 * 24 * time.Hour, "week"},
+		{20 * 24 * time.Hour, "month"},
+		{29 * 24 * time.Hour, "year"},
+		{400 * 24 * time.Hour, "all"},
+	}
+	for _, tt := range tests {
+		if got := timeFilterSince(now.Add(-tt.age), now); got != tt.want {
+			t.Errorf("timeFilterSince(now-%v) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+
+	if got := TopOf(time.Now().Add(-10 * time.Minute)); got != TopHour {
+		t.Errorf("TopOf(10 minutes ago) = %+v, want TopHour", got)
+	}
+	if got := ControversialOf(time.Now().Add(-2 * 24 * time.Hour)); got != ControversialWeek {
+		t.Errorf("ControversialOf(2 days ago) = %+v, want ControversialWeek", got)
+	}
+}
@@ -0,0 +1,161 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/internal"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+// TokenStore persists the client's OAuth tokens, so a long-running program that restarts can
+// reuse its access token, and its latest refresh token, instead of authenticating on every
+// boot. Set it as Config.TokenStore.
+//
+// A store holds the tokens of one account and app; do not share it between clients with
+// different credentials. Store errors are logged and never fail a request.
+type TokenStore interface {
+	// Load returns the stored token, or nil and no error if none is stored.
+	Load(ctx context.Context) (*Token, error)
+	// Save replaces the stored token. It is called whenever the client obtains a new one,
+	// while the client holds its token lock, so it must not make API calls.
+	Save(ctx context.Context, token *Token) error
+}
+
+// FileTokenStore keeps the client's tokens in a JSON file readable only by its owner. Each
+// save rewrites the file via a temporary file and rename, so a crash leaves either the old
+// or the new token.
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore returns a store that keeps tokens in the file at path. The file is
+// created on the first save.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// storedToken is the file format of FileTokenStore.
+type storedToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Scopes       []string  `json:"scopes,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// Load reads the stored token. It returns nil if the file does not exist.
+//
+// Returns a *errors.StateError if the file cannot be read and a *errors.ParseError if it
+// cannot be decoded.
+func (s *FileTokenStore) Load(_ context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &pkgerrs.StateError{Operation: "read token file", Message: err.Error()}
+	}
+	var stored storedToken
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "decode token file", Err: err}
+	}
+	return &Token{
+		AccessToken:  stored.AccessToken,
+		RefreshToken: stored.RefreshToken,
+		Scopes:       stored.Scopes,
+		Expiry:       stored.Expiry,
+	}, nil
+}
+
+// Save writes token to the file atomically.
+func (s *FileTokenStore) Save(_ context.Context, token *Token) error {
+	if token == nil {
+		return &pkgerrs.ConfigError{Field: "token", Message: "token cannot be nil"}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(storedToken{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Scopes:       token.Scopes,
+		Expiry:       token.Expiry,
+	}, "", "  ")
+	if err != nil {
+		return &pkgerrs.ParseError{Operation: "encode token file", Err: err}
+	}
+	return writeFileAtomic(s.path, data, "write token file")
+}
+
+// writeFileAtomic replaces the file at path with data via a temporary file in the same
+// directory, synced and renamed over path, so a crash leaves either the old or the new
+// contents. The file is readable only by its owner.
+//
+// Returns a *errors.StateError naming operation if any step fails.
+func writeFileAtomic(path string, data []byte, operation string) error {
+	// CreateTemp makes the file readable only by its owner.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return &pkgerrs.StateError{Operation: operation, Message: err.Error()}
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return &pkgerrs.StateError{Operation: operation, Message: err.Error()}
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return &pkgerrs.StateError{Operation: operation, Message: err.Error()}
+	}
+	if err := tmp.Close(); err != nil {
+		return &pkgerrs.StateError{Operation: operation, Message: err.Error()}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return &pkgerrs.StateError{Operation: operation, Message: err.Error()}
+	}
+	return nil
+}
+
+// authTokenStore adapts a TokenStore to the internal authenticator.
+type authTokenStore struct {
+	store TokenStore
+}
+
+func (s authTokenStore) Load(ctx context.Context) (*internal.Grant, error) {
+	token, err := s.store.Load(ctx)
+	if err != nil || token == nil {
+		return nil, err
+	}
+	return &internal.Grant{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Scope:        strings.Join(token.Scopes, " "),
+		Expiry:       token.Expiry,
+	}, nil
+}
+
+func (s authTokenStore) Save(ctx context.Context, grant *internal.Grant) error {
+	err := s.store.Save(ctx, &Token{
+		AccessToken:  grant.AccessToken,
+		RefreshToken: grant.RefreshToken,
+		Scopes:       strings.Fields(grant.Scope),
+		Expiry:       grant.Expiry,
+	})
+	if err != nil {
+		return fmt.Errorf("token store: %w", err)
+	}
+	return nil
+}
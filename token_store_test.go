@@ -0,0 +1,79 @@
+package graw
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/grawtest"
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	var tokenRequests atomic.Int32
+	mux := http.NewServeMux()
+	grawtest.HandleToken(mux, grawtest.TokenHandler(func(req *grawtest.TokenRequest) *grawtest.TokenResponse {
+		tokenRequests.Add(1)
+		resp := grawtest.NewTokenResponse("issued")
+		return &resp
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "token.json")
+	newClient := func() {
+		t.Helper()
+		_, err := NewClient(&Config{
+			ClientID:     "id",
+			ClientSecret: "secret",
+			TokenStore:   NewFileTokenStore(path),
+			AuthURL:      server.URL + "/",
+			BaseURL:      server.URL + "/",
+			HTTPClient:   server.Client(),
+		})
+		if err != nil {
+			t.Fatalf("NewClient returned error: %v", err)
+		}
+	}
+
+	newClient()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("token file not written: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("token file mode = %v, want 0600", perm)
+	}
+	stored, err := NewFileTokenStore(path).Load(context.Background())
+	if err != nil || stored == nil || stored.AccessToken != "issued" || stored.Expiry.IsZero() {
+		t.Fatalf("Load = %+v, %v; want the issued token", stored, err)
+	}
+
+	// A restarted client reuses the stored token.
+	newClient()
+	if got := tokenRequests.Load(); got != 1 {
+		t.Errorf("token requests = %d, want 1", got)
+	}
+
+	if token, err := NewFileTokenStore(filepath.Join(t.TempDir(), "missing.json")).Load(context.Background()); token != nil || err != nil {
+		t.Errorf("Load of a missing file = %+v, %v; want nil, nil", token, err)
+	}
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var parseErr *pkgerrs.ParseError
+	if _, err := NewFileTokenStore(path).Load(context.Background()); !errors.As(err, &parseErr) {
+		t.Errorf("Load of a corrupt file error = %v, want ParseError", err)
+	}
+
+	_, err = NewClient(&Config{TokenProvider: &mockTokenProvider{token: "t"}, TokenStore: NewFileTokenStore(path)})
+	var configErr *pkgerrs.ConfigError
+	if !errors.As(err, &configErr) || configErr.Field != "TokenStore" {
+		t.Errorf("TokenStore with TokenProvider error = %v, want TokenStore ConfigError", err)
+	}
+}
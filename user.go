@@ -0,0 +1,214 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/validation"
+)
+
+// GetUser retrieves the public profile of an account: its karma, creation time, and
+// whether it is suspended. The username may be given with or without a "u/" prefix.
+//
+// Suspended accounts are returned with IsSuspended set and most other fields empty, so check
+// Status before relying on them. A deleted or never-registered account returns a
+// *errors.NotFoundError.
+//
+// Returns an error if the username is invalid or the request fails.
+func (r *Reddit) GetUser(ctx context.Context, username string) (*types.AccountData, error) {
+	username = validation.NormalizeUsername(username)
+	if !validation.IsValidUsername(username) {
+		return nil, &pkgerrs.ConfigError{Field: "username", Message: fmt.Sprintf("invalid username: %q", username)}
+	}
+
+	path := UserPrefixURL + username + "/about"
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var result types.Thing
+	if err := r.httpClient.Do(req, &result); err != nil {
+		return nil, wrapDoError(err, "get user", path)
+	}
+
+	parsed, err := r.parser.ParseThing(ctx, &result)
+	if err != nil {
+		return nil, &pkgerrs.ParseError{Operation: "parse user", Err: err}
+	}
+	account, ok := parsed.(*types.AccountData)
+	if !ok {
+		return nil, &pkgerrs.ParseError{Operation: "user response", Err: fmt.Errorf("unexpected response type")}
+	}
+	return account, nil
+}
+
+// GetUserOverview retrieves a page of a user's posts and comments, newest first unless
+// WithSort says otherwise. Each item is a types.OverviewItem holding either a post or a
+// comment, so callers can switch on Kind instead of re-parsing raw things. The page's After
+// cursor is a fullname of either kind and can be passed back with WithAfter as usual.
+//
+// The username may be given with or without a "u/" prefix. Options are applied as for
+// GetPosts; WithSort accepts SortHot, SortNew, and the Top and Controversial sorts.
+//
+// Returns an error if the username or options are invalid, or the request fails.
+func (r *Reddit) GetUserOverview(ctx context.Context, username string, opts ...ListingOption) (*types.Listing[types.OverviewItem], error) {
+	listing, err := getUserListing[any](ctx, r, username, "overview", opts)
+	if err != nil {
+		return nil, err
+	}
+	return overviewListing(listing), nil
+}
+
+// GetSaved retrieves a page of the posts and comments a user has saved, most recently saved
+// first, as types.OverviewItem values like GetUserOverview. Reddit only shows saved items to
+// their owner, so username must be the authenticated user; others receive a
+// *errors.ForbiddenError. Page through all of them with WithAfter and the listing's After
+// cursor to sync a local archive. Options are applied as for GetUserOverview.
+func (r *Reddit) GetSaved(ctx context.Context, username string, opts ...ListingOption) (*types.Listing[types.OverviewItem], error) {
+	listing, err := getUserListing[any](ctx, r, username, "saved", opts)
+	if err != nil {
+		return nil, err
+	}
+	return overviewListing(listing), nil
+}
+
+// overviewListing converts a listing of posts and comments into OverviewItems, dropping
+// anything else.
+func overviewListing(listing *types.Listing[any]) *types.Listing[types.OverviewItem] {
+	overview := &types.Listing[types.OverviewItem]{
+		Items:     make([]types.OverviewItem, 0, len(listing.Items)),
+		After:     listing.After,
+		Before:    listing.Before,
+		RateLimit: listing.RateLimit,
+		Meta:      listing.Meta,
+	}
+	for _, item := range listing.Items {
+		switch v := item.(type) {
+		case *types.Post:
+			overview.Items = append(overview.Items, types.OverviewItem{Kind: types.OverviewPost, Post: v})
+		case *types.Comment:
+			overview.Items = append(overview.Items, types.OverviewItem{Kind: types.OverviewComment, Comment: v})
+		}
+	}
+	return overview
+}
+
+// GetUserSubmitted retrieves a page of a user's posts. See GetUserOverview for the
+// accepted usernames and options.
+func (r *Reddit) GetUserSubmitted(ctx context.Context, username string, opts ...ListingOption) (*types.Listing[*types.Post], error) {
+	return getUserListing[*types.Post](ctx, r, username, "submitted", opts)
+}
+
+// GetUserPosts is GetUserSubmitted under the name used by other Reddit clients.
+func (r *Reddit) GetUserPosts(ctx context.Context, username string, opts ...ListingOption) (*types.Listing[*types.Post], error) {
+	return r.GetUserSubmitted(ctx, username, opts...)
+}
+
+// GetUserComments retrieves a page of a user's comments. See GetUserOverview for the
+// accepted usernames and options.
+func (r *Reddit) GetUserComments(ctx context.Context, username string, opts ...ListingOption) (*types.Listing[*types.Comment], error) {
+	return getUserListing[*types.Comment](ctx, r, username, "comments", opts)
+}
+
+// getUserListing fetches user/{username}/{where} and returns its children of type T.
+func getUserListing[T any](ctx context.Context, r *Reddit, username, where string, opts []ListingOption) (*types.Listing[T], error) {
+	username = validation.NormalizeUsername(username)
+	if !validation.IsValidUsername(username) {
+		return nil, &pkgerrs.ConfigError{Field: "username", Message: fmt.Sprintf("invalid username: %q", username)}
+	}
+
+	call := newListingCall(nil, opts)
+	if call.cache.enabled() {
+		return nil, &pkgerrs.ConfigError{Field: "Cache", Message: "caching is only supported for subreddit listings"}
+	}
+	sort := SortNew
+	if call.sortSet {
+		if call.sort.Order == "rising" {
+			return nil, &pkgerrs.ConfigError{Field: "Sort", Message: "user listings do not support rising"}
+		}
+		sort = call.sort
+	}
+	if err := sort.validate(); err != nil {
+		return nil, err
+	}
+	if err := r.validator.ValidatePagination(&call.request.Pagination); err != nil {
+		return nil, err
+	}
+	ctx, cancel := call.context(ctx)
+	defer cancel()
+
+	params := buildPaginationParams(&call.request.Pagination)
+	params.Set("sort", sort.Order)
+	if sort.Time != "" {
+		params.Set("t", sort.Time)
+	}
+	if err := addExtraParams(params, call.request.Params); err != nil {
+		return nil, err
+	}
+
+	path := UserPrefixURL + username + "/" + where
+	return fetchListing[T](ctx, r, path, params, "get user "+where, "parse user "+where)
+}
+
+// GetUsersByIDs looks up many accounts by fullname (e.g. "t2_abc123") in one request and
+// returns their usernames, karma, and avatars. Use it to turn stored author fullnames back
+// into display names. Accounts that do not exist, or are suspended or deleted, are missing
+// from the response.
+//
+// Returns an error if:
+//   - No fullnames are provided, or more than MaxUserDataFullnames
+//   - Any fullname is not an account fullname
+//   - The API request fails
+func (r *Reddit) GetUsersByIDs(ctx context.Context, fullnames []string) (*types.UsersResponse, error) {
+	if len(fullnames) == 0 {
+		return nil, &pkgerrs.ConfigError{Field: "fullnames", Message: "at least one fullname is required"}
+	}
+	if len(fullnames) > MaxUserDataFullnames {
+		return nil, &pkgerrs.ConfigError{
+			Field:   "fullnames",
+			Message: fmt.Sprintf("too many fullnames: %d (max %d)", len(fullnames), MaxUserDataFullnames),
+		}
+	}
+	for _, name := range fullnames {
+		if !strings.HasPrefix(name, string(types.KIND_ACCOUNT)) || !validation.IsValidFullname(name) {
+			return nil, &pkgerrs.ConfigError{Field: "fullnames", Message: fmt.Sprintf("not an account fullname: %q", name)}
+		}
+	}
+
+	ctx, rateLimit := r.recordResponse(ctx)
+	params := url.Values{}
+	params.Set("ids", strings.Join(fullnames, ","))
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, UserDataURL, nil, params)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: UserDataURL, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	users := make(map[string]*types.UserSummary)
+	if err := r.httpClient.DoJSON(req, &users); err != nil {
+		return nil, wrapDoError(err, "get users by ids", UserDataURL)
+	}
+	for fullname, user := range users {
+		if user == nil {
+			delete(users, fullname)
+			continue
+		}
+		user.Fullname = fullname
+	}
+	return &types.UsersResponse{Users: users, RateLimit: rateLimit.Info(), Meta: rateLimit.Meta()}, nil
+}
@@ -0,0 +1,210 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// userListingMock answers every listing request with children and records the request.
+func userListingMock(t *testing.T, got **http.Request, children ...*types.Thing) *mockHTTPClient {
+	return &mockHTTPClient{
+		doFunc: func(req *http.Request, v *types.Thing) error {
+			*got = req
+			listing := listingThing(t, children...)
+			*v = *listing
+			return nil
+		},
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	var req *http.Request
+	mock := &mockHTTPClient{
+		doFunc: func(r *http.Request, v *types.Thing) error {
+			req = r
+			if r.URL.Path == "/user/ghost/about" {
+				return &pkgerrs.APIError{StatusCode: http.StatusNotFound, Message: "Not Found"}
+			}
+			return json.Unmarshal([]byte(`{"kind": "t2", "data": {"id": "abc12", "name": "gopher", "created": 1700000000, "created_utc": 1700000000, "link_karma": 10, "comment_karma": 20}}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	account, err := client.GetUser(ctx, "u/gopher")
+	if err != nil {
+		t.Fatalf("GetUser returned error: %v", err)
+	}
+	if req.URL.Path != "/user/gopher/about" {
+		t.Errorf("path = %q, want /user/gopher/about", req.URL.Path)
+	}
+	if account.Name != "gopher" || account.LinkKarma != 10 || account.CommentKarma != 20 || account.Status() != types.AccountActive {
+		t.Errorf("account = %+v", account)
+	}
+
+	var notFound *pkgerrs.NotFoundError
+	if _, err := client.GetUser(ctx, "ghost"); !errors.As(err, &notFound) {
+		t.Errorf("missing user error = %v, want NotFoundError", err)
+	}
+	var configErr *pkgerrs.ConfigError
+	if _, err := client.GetUser(ctx, "bad name!"); !errors.As(err, &configErr) {
+		t.Errorf("invalid username error = %v, want ConfigError", err)
+	}
+}
+
+func TestGetUserOverview(t *testing.T) {
+	var req *http.Request
+	post := submitPostThing(t, "p1", "title", "gopher", time.Unix(1700000100, 0))
+	comment := commentThing(t, "c1", "hello", false)
+	client := newTestClient(userListingMock(t, &req, comment, post), nil)
+
+	overview, err := client.GetUserOverview(context.Background(), "u/gopher", WithLimit(2), WithAfter("t1_prev"))
+	if err != nil {
+		t.Fatalf("GetUserOverview returned error: %v", err)
+	}
+	if req.URL.Path != "/user/gopher/overview" {
+		t.Errorf("path = %q, want /user/gopher/overview", req.URL.Path)
+	}
+	q := req.URL.Query()
+	if q.Get("sort") != "new" || q.Get("limit") != "2" || q.Get("after") != "t1_prev" {
+		t.Errorf("query = %v", q)
+	}
+
+	if overview.Len() != 2 {
+		t.Fatalf("got %d items, want 2", overview.Len())
+	}
+	first, second := overview.Items[0], overview.Items[1]
+	if first.Kind != types.OverviewComment || first.Comment == nil || first.Post != nil || first.Fullname() != "t1_c1" {
+		t.Errorf("first item = %+v, want comment t1_c1", first)
+	}
+	if second.Kind != types.OverviewPost || second.Post == nil || second.Fullname() != "t3_p1" {
+		t.Errorf("second item = %+v, want post t3_p1", second)
+	}
+	if !second.CreatedAt().Equal(time.Unix(1700000100, 0)) {
+		t.Errorf("CreatedAt() = %v", second.CreatedAt())
+	}
+}
+
+func TestGetSaved(t *testing.T) {
+	var req *http.Request
+	post := submitPostThing(t, "p1", "title", "gopher", time.Unix(1700000100, 0))
+	comment := commentThing(t, "c1", "hello", false)
+	client := newTestClient(userListingMock(t, &req, post, comment), nil)
+
+	saved, err := client.GetSaved(context.Background(), "gopher", WithLimit(100), WithAfter("t3_prev"))
+	if err != nil {
+		t.Fatalf("GetSaved returned error: %v", err)
+	}
+	if req.URL.Path != "/user/gopher/saved" || req.URL.Query().Get("limit") != "100" || req.URL.Query().Get("after") != "t3_prev" {
+		t.Errorf("request = %s", req.URL)
+	}
+	if saved.Len() != 2 || saved.Items[0].Kind != types.OverviewPost || saved.Items[1].Kind != types.OverviewComment {
+		t.Errorf("items = %+v, want post then comment", saved.Items)
+	}
+}
+
+func TestGetUserSubmittedAndComments(t *testing.T) {
+	var req *http.Request
+	post := submitPostThing(t, "p1", "title", "gopher", time.Unix(1700000100, 0))
+	comment := commentThing(t, "c1", "hello", false)
+	client := newTestClient(userListingMock(t, &req, comment, post), nil)
+	ctx := context.Background()
+
+	posts, err := client.GetUserPosts(ctx, "gopher", WithSort(TopWeek))
+	if err != nil {
+		t.Fatalf("GetUserPosts returned error: %v", err)
+	}
+	if req.URL.Path != "/user/gopher/submitted" || req.URL.Query().Get("sort") != "top" || req.URL.Query().Get("t") != "week" {
+		t.Errorf("request = %s", req.URL)
+	}
+	if posts.Len() != 1 || posts.Items[0].ID != "p1" {
+		t.Errorf("posts = %+v, want only p1", posts.Items)
+	}
+
+	comments, err := client.GetUserComments(ctx, "/user/gopher/")
+	if err != nil {
+		t.Fatalf("GetUserComments returned error: %v", err)
+	}
+	if req.URL.Path != "/user/gopher/comments" {
+		t.Errorf("path = %q", req.URL.Path)
+	}
+	if comments.Len() != 1 || comments.Items[0].ID != "c1" {
+		t.Errorf("comments = %+v, want only c1", comments.Items)
+	}
+}
+
+func TestGetUserListing_InvalidInput(t *testing.T) {
+	client := newTestClient(&mockHTTPClient{}, nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		username string
+		opts     []ListingOption
+	}{
+		{"empty username", "", nil},
+		{"invalid username", "bad name!", nil},
+		{"rising", "gopher", []ListingOption{WithSort(SortRising)}},
+		{"cache", "gopher", []ListingOption{WithCacheTTL(time.Minute)}},
+		{"reserved param", "gopher", []ListingOption{WithParam("sort", "old")}},
+		{"both cursors", "gopher", []ListingOption{WithParam("x", "y"), func(c *listingCall) {
+			c.request.After, c.request.Before = "t1_a", "t1_b"
+		}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetUserSubmitted(ctx, tt.username, tt.opts...)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if _, ok := err.(*pkgerrs.ConfigError); !ok {
+				t.Errorf("error = %T %v, want *errors.ConfigError", err, err)
+			}
+		})
+	}
+}
+
+func TestGetUsersByIDs(t *testing.T) {
+	var req *http.Request
+	mock := &mockHTTPClient{
+		doJSONFunc: func(r *http.Request, v any) error {
+			req = r
+			return json.Unmarshal([]byte(`{
+				"t2_abc12": {"name": "gopher", "created_utc": 1700000000, "link_karma": 10, "comment_karma": 20, "profile_img": "https://styles.redditmedia.com/avatar.png", "profile_over_18": false},
+				"t2_def34": null
+			}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	resp, err := client.GetUsersByIDs(context.Background(), []string{"t2_abc12", "t2_def34", "t2_zzz99"})
+	if err != nil {
+		t.Fatalf("GetUsersByIDs returned error: %v", err)
+	}
+	if req.URL.Path != "/"+UserDataURL || req.URL.Query().Get("ids") != "t2_abc12,t2_def34,t2_zzz99" {
+		t.Errorf("request = %s", req.URL)
+	}
+	want := types.UserSummary{Fullname: "t2_abc12", Name: "gopher", CreatedUTC: 1700000000, LinkKarma: 10, CommentKarma: 20, ProfileImage: "https://styles.redditmedia.com/avatar.png"}
+	if len(resp.Users) != 1 || resp.Users["t2_abc12"] == nil || *resp.Users["t2_abc12"] != want {
+		t.Errorf("Users = %v, want only %+v", resp.Users, want)
+	}
+
+	tooMany := make([]string, MaxUserDataFullnames+1)
+	for i := range tooMany {
+		tooMany[i] = "t2_abc12"
+	}
+	for _, fullnames := range [][]string{nil, tooMany, {"t3_abc12"}, {"t2_"}} {
+		_, err := client.GetUsersByIDs(context.Background(), fullnames)
+		var configErr *pkgerrs.ConfigError
+		if !errors.As(err, &configErr) || configErr.Field != "fullnames" {
+			t.Errorf("GetUsersByIDs(%d fullnames) error = %v, want fullnames ConfigError", len(fullnames), err)
+		}
+	}
+}
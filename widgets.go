@@ -0,0 +1,78 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// widgetsResponse is the raw shape of the widgets endpoint.
+type widgetsResponse struct {
+	Items  map[string]*types.Widget `json:"items"`
+	Layout struct {
+		IDCardWidget    string `json:"idCardWidget"`
+		ModeratorWidget string `json:"moderatorWidget"`
+		Sidebar         struct {
+			Order []string `json:"order"`
+		} `json:"sidebar"`
+		Topbar struct {
+			Order []string `json:"order"`
+		} `json:"topbar"`
+	} `json:"layout"`
+}
+
+// GetSubredditWidgets retrieves a subreddit's sidebar widgets, such as rules, text areas,
+// community lists, and calendars, in the order Reddit displays them.
+//
+// Returns an error if:
+//   - The subreddit name is invalid
+//   - The subreddit doesn't exist or is private
+//   - The API request fails or a widget cannot be decoded
+func (r *Reddit) GetSubredditWidgets(ctx context.Context, subreddit string) (*types.SubredditWidgets, error) {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(WidgetsURLFormat, subreddit)
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var raw widgetsResponse
+	if err := r.httpClient.DoJSON(req, &raw); err != nil {
+		return nil, wrapDoError(err, "get subreddit widgets", path)
+	}
+
+	widgets := &types.SubredditWidgets{
+		Sidebar:    orderedWidgets(raw.Items, raw.Layout.Sidebar.Order),
+		Topbar:     orderedWidgets(raw.Items, raw.Layout.Topbar.Order),
+		IDCard:     raw.Items[raw.Layout.IDCardWidget],
+		Moderators: raw.Items[raw.Layout.ModeratorWidget],
+		Items:      raw.Items,
+	}
+	if widgets.Items == nil {
+		widgets.Items = map[string]*types.Widget{}
+	}
+	return widgets, nil
+}
+
+// orderedWidgets returns the widgets named by order, skipping IDs missing from items.
+func orderedWidgets(items map[string]*types.Widget, order []string) []*types.Widget {
+	widgets := make([]*types.Widget, 0, len(order))
+	for _, id := range order {
+		if w, ok := items[id]; ok && w != nil {
+			widgets = append(widgets, w)
+		}
+	}
+	return widgets
+}
@@ -0,0 +1,84 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const widgetsFixture = `{
+  "items": {
+    "widget_rules": {"id": "widget_rules", "kind": "subreddit-rules", "shortName": "Rules", "display": "full",
+      "data": [{"shortName": "Be kind", "description": "No insults", "violationReason": "Unkind", "priority": 0}]},
+    "widget_text": {"id": "widget_text", "kind": "textarea", "shortName": "About", "text": "Welcome", "textHtml": "<p>Welcome</p>"},
+    "widget_related": {"id": "widget_related", "kind": "community-list", "shortName": "Related",
+      "data": [{"name": "golang", "prefixedName": "r/golang", "subscribers": 250000, "isNSFW": false}]},
+    "widget_events": {"id": "widget_events", "kind": "calendar", "shortName": "Meetups",
+      "data": [{"title": "GopherCon", "startTime": 1700000000, "endTime": 1700086400, "allDay": true}]},
+    "widget_id_card": {"id": "widget_id_card", "kind": "id-card", "shortName": "Community Details"},
+    "widget_mods": {"id": "widget_mods", "kind": "moderators"},
+    "widget_menu": {"id": "widget_menu", "kind": "menu", "data": [{"text": "Wiki", "url": "https://example.com"}]}
+  },
+  "layout": {
+    "idCardWidget": "widget_id_card",
+    "moderatorWidget": "widget_mods",
+    "topbar": {"order": ["widget_menu"]},
+    "sidebar": {"order": ["widget_text", "widget_rules", "widget_missing", "widget_related", "widget_events"]}
+  }
+}`
+
+func TestClient_GetSubredditWidgets(t *testing.T) {
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.URL.Path != "/r/golang/api/widgets" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			return json.Unmarshal([]byte(widgetsFixture), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+
+	widgets, err := client.GetSubredditWidgets(context.Background(), "golang")
+	if err != nil {
+		t.Fatalf("GetSubredditWidgets returned error: %v", err)
+	}
+
+	wantOrder := []string{"widget_text", "widget_rules", "widget_related", "widget_events"}
+	if len(widgets.Sidebar) != len(wantOrder) {
+		t.Fatalf("Sidebar has %d widgets, want %d", len(widgets.Sidebar), len(wantOrder))
+	}
+	for i, id := range wantOrder {
+		if widgets.Sidebar[i].ID != id {
+			t.Errorf("Sidebar[%d] = %s, want %s", i, widgets.Sidebar[i].ID, id)
+		}
+	}
+
+	if text := widgets.Sidebar[0]; text.Kind != types.WidgetKindTextArea || text.Text != "Welcome" {
+		t.Errorf("textarea widget = %+v", text)
+	}
+	if rules := widgets.Sidebar[1].Rules; len(rules) != 1 || rules[0].ShortName != "Be kind" || rules[0].ViolationReason != "Unkind" {
+		t.Errorf("rules = %+v", rules)
+	}
+	if communities := widgets.Sidebar[2].Communities; len(communities) != 1 || communities[0].Subscribers != 250000 {
+		t.Errorf("communities = %+v", communities)
+	}
+	if events := widgets.Sidebar[3].Events; len(events) != 1 || events[0].Title != "GopherCon" || !events[0].AllDay {
+		t.Errorf("events = %+v", events)
+	}
+	if widgets.IDCard == nil || widgets.IDCard.ID != "widget_id_card" {
+		t.Errorf("IDCard = %+v", widgets.IDCard)
+	}
+	if widgets.Moderators == nil || widgets.Moderators.Kind != types.WidgetKindModerators {
+		t.Errorf("Moderators = %+v", widgets.Moderators)
+	}
+	if len(widgets.Topbar) != 1 || len(widgets.Topbar[0].Data) == 0 {
+		t.Errorf("Topbar = %+v, want menu widget with raw data", widgets.Topbar)
+	}
+
+	if _, err := client.GetSubredditWidgets(context.Background(), "a"); err == nil {
+		t.Error("expected error for invalid subreddit name")
+	}
+}
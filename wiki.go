@@ -0,0 +1,220 @@
+package graw
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+// maxWikiReasonLength is Reddit's limit on WikiEditRequest.Reason.
+const maxWikiReasonLength = 256
+
+var (
+	// wikiPageName matches a wiki page name: slash-separated segments such as "config/bot".
+	wikiPageName = regexp.MustCompile(`^[a-z0-9_-]+(?:/[a-z0-9_-]+)*$`)
+	// wikiRevisionID matches a wiki revision ID, a UUID.
+	wikiRevisionID = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	// wikiRevisionCursor matches the cursor that pages a wiki revision history.
+	wikiRevisionCursor = regexp.MustCompile(`^WikiRevision_[0-9a-f-]+$`)
+)
+
+// wikiAuthor is the account thing Reddit embeds as the author of a wiki revision.
+type wikiAuthor struct {
+	Data struct {
+		Name string `json:"name"`
+	} `json:"data"`
+}
+
+// wikiPageResponse is the raw shape of the wiki page endpoint.
+type wikiPageResponse struct {
+	Data struct {
+		types.WikiPage
+		RevisionBy wikiAuthor `json:"revision_by"`
+	} `json:"data"`
+}
+
+// wikiRevisionEntry is the raw shape of an entry of a wiki revision history.
+type wikiRevisionEntry struct {
+	types.WikiRevision
+	Author wikiAuthor `json:"author"`
+}
+
+// GetWikiPage retrieves the current revision of a page of a subreddit's wiki, e.g. "index"
+// or "config/bot". Bots that keep their settings in a wiki page can read them with it.
+//
+// The subreddit may be given as "golang", "r/golang", or "/r/golang". Page names are
+// case-insensitive.
+//
+// Returns an error if:
+//   - The subreddit or page name is invalid
+//   - The page does not exist, or the wiki is disabled or hidden from the client
+//   - The API request fails
+func (r *Reddit) GetWikiPage(ctx context.Context, subreddit, page string) (*types.WikiPage, error) {
+	return r.getWikiPage(ctx, subreddit, page, "")
+}
+
+// GetWikiPageRevision retrieves a page of a subreddit's wiki as it was at a past revision,
+// identified by a WikiRevision.ID from GetWikiRevisions. See GetWikiPage for the accepted
+// names.
+func (r *Reddit) GetWikiPageRevision(ctx context.Context, subreddit, page, revisionID string) (*types.WikiPage, error) {
+	if !wikiRevisionID.MatchString(revisionID) {
+		return nil, &pkgerrs.ConfigError{Field: "revisionID", Message: fmt.Sprintf("invalid wiki revision ID: %q", revisionID)}
+	}
+	return r.getWikiPage(ctx, subreddit, page, revisionID)
+}
+
+// getWikiPage fetches a wiki page at revisionID, or at its current revision if revisionID
+// is empty.
+func (r *Reddit) getWikiPage(ctx context.Context, subreddit, page, revisionID string) (*types.WikiPage, error) {
+	subreddit, page, err := r.normalizeWikiPage(subreddit, page)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	if revisionID != "" {
+		params.Set("v", revisionID)
+	}
+	path := fmt.Sprintf(WikiPageURLFormat, subreddit, page)
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil, params)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var resp wikiPageResponse
+	if err := r.httpClient.DoJSON(req, &resp); err != nil {
+		return nil, wrapDoError(err, "get wiki page", path)
+	}
+	wikiPage := resp.Data.WikiPage
+	wikiPage.Subreddit = subreddit
+	wikiPage.Name = page
+	wikiPage.RevisionBy = resp.Data.RevisionBy.Data.Name
+	return &wikiPage, nil
+}
+
+// GetWikiPages lists the names of the pages of a subreddit's wiki that the client can see.
+//
+// Returns an error if the subreddit name is invalid, the wiki is disabled, or the API
+// request fails.
+func (r *Reddit) GetWikiPages(ctx context.Context, subreddit string) ([]string, error) {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(WikiPagesURLFormat, subreddit)
+	req, err := r.httpClient.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, &pkgerrs.RequestError{Operation: "create request", URL: path, Err: err}
+	}
+
+	// Add authentication headers
+	if err := r.addAuthHeaders(ctx, req); err != nil {
+		return nil, &pkgerrs.AuthError{Message: "failed to add auth headers", Err: err}
+	}
+
+	var resp struct {
+		Data []string `json:"data"`
+	}
+	if err := r.httpClient.DoJSON(req, &resp); err != nil {
+		return nil, wrapDoError(err, "get wiki pages", path)
+	}
+	return resp.Data, nil
+}
+
+// GetWikiRevisions retrieves a page of a wiki page's revision history, newest first.
+//
+// The history is paged by cursors of the form "WikiRevision_...", not by fullname: pass
+// Listing.After to WithAfter for the next page. WithLimit and WithTimeout apply as usual;
+// sorting and caching are not supported. See GetWikiPage for the accepted names.
+func (r *Reddit) GetWikiRevisions(ctx context.Context, subreddit, page string, opts ...ListingOption) (*types.Listing[types.WikiRevision], error) {
+	subreddit, page, err := r.normalizeWikiPage(subreddit, page)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf(WikiRevisionsURLFormat, subreddit, page)
+	entries, err := fetchRawListing[wikiRevisionEntry](ctx, r, path, wikiRevisionCursor, "wiki revision", "get wiki revisions", opts)
+	if err != nil {
+		return nil, err
+	}
+	revisions := &types.Listing[types.WikiRevision]{
+		Items:     make([]types.WikiRevision, len(entries.Items)),
+		After:     entries.After,
+		Before:    entries.Before,
+		RateLimit: entries.RateLimit,
+		Meta:      entries.Meta,
+	}
+	for i, entry := range entries.Items {
+		revisions.Items[i] = entry.WikiRevision
+		revisions.Items[i].Author = entry.Author.Data.Name
+	}
+	return revisions, nil
+}
+
+// EditWikiPage replaces the content of a wiki page, creating the page if it does not
+// exist. The client must be allowed to edit the wiki, usually as a moderator with the wiki
+// permission.
+//
+// Set PreviousRevision to the RevisionID of the page as read, so that an edit made by
+// someone else in the meantime is not overwritten: Reddit then rejects the edit with a
+// *errors.APIError with status 409.
+//
+// Returns an error if:
+//   - request is nil, or its subreddit, page name, or PreviousRevision is invalid
+//   - Reason is longer than 256 characters
+//   - The API request fails, e.g. with a *errors.ForbiddenError without edit permission
+func (r *Reddit) EditWikiPage(ctx context.Context, request *types.WikiEditRequest) error {
+	if request == nil {
+		return &pkgerrs.ConfigError{Message: "wiki edit request cannot be nil"}
+	}
+	subreddit, page, err := r.normalizeWikiPage(request.Subreddit, request.Page)
+	if err != nil {
+		return err
+	}
+	if len(request.Reason) > maxWikiReasonLength {
+		return &pkgerrs.ConfigError{Field: "Reason", Message: fmt.Sprintf("edit reason cannot exceed %d characters", maxWikiReasonLength)}
+	}
+	if request.PreviousRevision != "" && !wikiRevisionID.MatchString(request.PreviousRevision) {
+		return &pkgerrs.ConfigError{
+			Field:   "PreviousRevision",
+			Message: fmt.Sprintf("invalid wiki revision ID: %q", request.PreviousRevision),
+		}
+	}
+
+	form := url.Values{}
+	form.Set("page", page)
+	form.Set("content", request.Content)
+	if request.Reason != "" {
+		form.Set("reason", request.Reason)
+	}
+	if request.PreviousRevision != "" {
+		form.Set("previous", request.PreviousRevision)
+	}
+	path := fmt.Sprintf(WikiEditURLFormat, subreddit)
+	return r.postForm(ctx, "edit wiki page", path, subreddit+"/"+page, form, nil)
+}
+
+// normalizeWikiPage validates a subreddit and wiki page name, returning them normalized.
+func (r *Reddit) normalizeWikiPage(subreddit, page string) (string, string, error) {
+	subreddit, err := r.validator.NormalizeSubredditName(subreddit)
+	if err != nil {
+		return "", "", err
+	}
+	page = strings.ToLower(strings.Trim(page, "/"))
+	if !wikiPageName.MatchString(page) {
+		return "", "", &pkgerrs.ConfigError{Field: "page", Message: fmt.Sprintf("invalid wiki page name: %q", page)}
+	}
+	return subreddit, page, nil
+}
@@ -0,0 +1,179 @@
+package graw
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	pkgerrs "github.com/jamesprial/go-reddit-api-wrapper/pkg/errors"
+	"github.com/jamesprial/go-reddit-api-wrapper/pkg/types"
+)
+
+const testRevisionID = "3f2b9c1e-7a4d-11ee-9c2b-0242ac120002"
+
+func TestClient_GetWikiPage(t *testing.T) {
+	var query url.Values
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			query = req.URL.Query()
+			switch req.URL.Path {
+			case "/r/golang/wiki/config/bot":
+				return json.Unmarshal([]byte(`{"kind": "wikipage", "data": {
+					"content_md": "threshold: 5",
+					"content_html": "&lt;p&gt;threshold: 5&lt;/p&gt;",
+					"may_revise": true,
+					"reason": "raise threshold",
+					"revision_date": 1700000000.0,
+					"revision_id": "`+testRevisionID+`",
+					"revision_by": {"kind": "t2", "data": {"name": "gophermod"}}
+				}}`), v)
+			case "/r/golang/wiki/pages":
+				return json.Unmarshal([]byte(`{"kind": "wikipagelisting", "data": ["config/bot", "index"]}`), v)
+			}
+			t.Errorf("unexpected path %q", req.URL.Path)
+			return nil
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	page, err := client.GetWikiPage(ctx, "r/golang", "/Config/Bot")
+	if err != nil {
+		t.Fatalf("GetWikiPage returned error: %v", err)
+	}
+	if page.Subreddit != "golang" || page.Name != "config/bot" || page.Content != "threshold: 5" {
+		t.Errorf("GetWikiPage() = %+v, want config/bot of golang", page)
+	}
+	if page.RevisionID != testRevisionID || page.RevisionBy != "gophermod" || !page.MayRevise {
+		t.Errorf("GetWikiPage() revision = %q by %q, may revise %v", page.RevisionID, page.RevisionBy, page.MayRevise)
+	}
+	if page.RevisionReason == nil || *page.RevisionReason != "raise threshold" {
+		t.Errorf("GetWikiPage() reason = %v, want raise threshold", page.RevisionReason)
+	}
+	if query.Has("v") {
+		t.Errorf("GetWikiPage sent revision %q", query.Get("v"))
+	}
+
+	if _, err := client.GetWikiPageRevision(ctx, "golang", "config/bot", testRevisionID); err != nil {
+		t.Fatalf("GetWikiPageRevision returned error: %v", err)
+	}
+	if query.Get("v") != testRevisionID {
+		t.Errorf("GetWikiPageRevision sent v=%q, want %q", query.Get("v"), testRevisionID)
+	}
+
+	pages, err := client.GetWikiPages(ctx, "golang")
+	if err != nil {
+		t.Fatalf("GetWikiPages returned error: %v", err)
+	}
+	if strings.Join(pages, ",") != "config/bot,index" {
+		t.Errorf("GetWikiPages() = %v, want [config/bot index]", pages)
+	}
+
+	var configErr *pkgerrs.ConfigError
+	if _, err := client.GetWikiPage(ctx, "golang", "../about"); !errors.As(err, &configErr) {
+		t.Errorf("GetWikiPage(../about) error = %v, want ConfigError", err)
+	}
+	if _, err := client.GetWikiPageRevision(ctx, "golang", "index", "latest"); !errors.As(err, &configErr) {
+		t.Errorf("GetWikiPageRevision(latest) error = %v, want ConfigError", err)
+	}
+}
+
+func TestClient_GetWikiRevisions(t *testing.T) {
+	var query url.Values
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			if req.URL.Path != "/r/golang/wiki/revisions/index" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			query = req.URL.Query()
+			return json.Unmarshal([]byte(`{"kind": "Listing", "data": {"children": [
+				{"timestamp": 1700000000.0, "reason": "typo", "page": "index", "id": "`+testRevisionID+`",
+				 "author": {"kind": "t2", "data": {"name": "gophermod"}}, "revision_hidden": false},
+				{"timestamp": 1690000000.0, "reason": null, "page": "index", "id": "11111111-2222-3333-4444-555555555555",
+				 "author": {"kind": "t2", "data": {"name": "other"}}, "revision_hidden": true}
+			], "after": "WikiRevision_11111111-2222-3333-4444-555555555555", "before": null}}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	revisions, err := client.GetWikiRevisions(ctx, "golang", "index", WithLimit(2), WithAfter("WikiRevision_"+testRevisionID))
+	if err != nil {
+		t.Fatalf("GetWikiRevisions returned error: %v", err)
+	}
+	if query.Get("limit") != "2" || query.Get("after") != "WikiRevision_"+testRevisionID {
+		t.Errorf("query = %v, want limit and after", query)
+	}
+	if revisions.Len() != 2 || revisions.After != "WikiRevision_11111111-2222-3333-4444-555555555555" {
+		t.Fatalf("GetWikiRevisions() = %+v, want two revisions and a next page", revisions)
+	}
+	first, second := revisions.Items[0], revisions.Items[1]
+	if first.ID != testRevisionID || first.Author != "gophermod" || first.Reason == nil || *first.Reason != "typo" {
+		t.Errorf("first revision = %+v, want typo fix by gophermod", first)
+	}
+	if second.Author != "other" || second.Reason != nil || !second.Hidden {
+		t.Errorf("second revision = %+v, want hidden revision by other", second)
+	}
+
+	var configErr *pkgerrs.ConfigError
+	if _, err := client.GetWikiRevisions(ctx, "golang", "index", WithAfter("t3_abc")); !errors.As(err, &configErr) {
+		t.Errorf("fullname cursor error = %v, want ConfigError", err)
+	}
+}
+
+func TestClient_EditWikiPage(t *testing.T) {
+	var path string
+	var form url.Values
+	mock := &mockHTTPClient{
+		doJSONFunc: func(req *http.Request, v any) error {
+			body, _ := io.ReadAll(req.Body)
+			path = req.URL.Path
+			form, _ = url.ParseQuery(string(body))
+			return json.Unmarshal([]byte(`{}`), v)
+		},
+	}
+	client := newTestClient(mock, nil)
+	ctx := context.Background()
+
+	err := client.EditWikiPage(ctx, &types.WikiEditRequest{
+		Subreddit:        "golang",
+		Page:             "config/bot",
+		Content:          "threshold: 10",
+		Reason:           "raise threshold",
+		PreviousRevision: testRevisionID,
+	})
+	if err != nil {
+		t.Fatalf("EditWikiPage returned error: %v", err)
+	}
+	if path != "/r/golang/api/wiki/edit" {
+		t.Errorf("EditWikiPage path = %q, want /r/golang/api/wiki/edit", path)
+	}
+	want := map[string]string{"page": "config/bot", "content": "threshold: 10", "reason": "raise threshold", "previous": testRevisionID}
+	for key, value := range want {
+		if got := form.Get(key); got != value {
+			t.Errorf("EditWikiPage form %s = %q, want %q", key, got, value)
+		}
+	}
+
+	path = ""
+	invalid := map[string]*types.WikiEditRequest{
+		"nil":               nil,
+		"page":              {Subreddit: "golang", Page: "bad page"},
+		"reason":            {Subreddit: "golang", Page: "index", Reason: strings.Repeat("x", 257)},
+		"previous revision": {Subreddit: "golang", Page: "index", PreviousRevision: "abc"},
+	}
+	for name, request := range invalid {
+		var configErr *pkgerrs.ConfigError
+		if err := client.EditWikiPage(ctx, request); !errors.As(err, &configErr) {
+			t.Errorf("%s: error = %v, want ConfigError", name, err)
+		}
+	}
+	if path != "" {
+		t.Error("invalid edit sent a request")
+	}
+}
@@ -632,75 +632,92 @@ func TestUserActivityWorkflow(t *testing.T) {
 		w.Header().Set("X-Ratelimit-Reset", "60")
 		w.Header().Set("Content-Type", "application/json")
 
-		switch {
-		case strings.Contains(r.URL.Path, "/api/v1/me"):
-			// Current user info
-			userData := map[string]interface{}{
+		account := map[string]interface{}{
+			"kind": "t2",
+			"data": map[string]interface{}{
 				"id":                 "user123",
 				"name":               "testuser",
 				"link_karma":         5000,
 				"comment_karma":      3000,
+				"created":            1609459200.0,
 				"created_utc":        1609459200.0,
-				"verified":           true,
 				"has_verified_email": true,
-			}
-			json.NewEncoder(w).Encode(userData)
+			},
+		}
 
-		case strings.Contains(r.URL.Path, "/user/testuser/submitted.json"):
+		switch r.URL.Path {
+		case "/api/v1/me", "/user/testuser/about":
+			// Current user info and the same account's public profile
+			json.NewEncoder(w).Encode(account)
+
+		case "/user/testuser/submitted":
 			// User's posts
-			posts := []map[string]interface{}{
-				{
-					"kind": "t3",
-					"data": map[string]interface{}{
-						"id":        "userpost1",
-						"title":     "My Go Project",
-						"author":    "testuser",
-						"subreddit": "golang",
-						"score":     50,
-					},
-				},
-				{
+			if r.URL.Query().Get("sort") != "top" || r.URL.Query().Get("t") != "all" {
+				t.Errorf("submitted query = %v, want sort=top&t=all", r.URL.Query())
+			}
+			posts := []map[string]interface{}{}
+			for _, p := range []struct {
+				id, title, subreddit string
+				score                int
+			}{
+				{"userpost1", "My Go Project", "golang", 50},
+				{"userpost2", "Rust vs Go", "rust", 25},
+			} {
+				posts = append(posts, map[string]interface{}{
 					"kind": "t3",
 					"data": map[string]interface{}{
-						"id":        "userpost2",
-						"title":     "Rust vs Go",
-						"author":    "testuser",
-						"subreddit": "rust",
-						"score":     25,
+						"id":          p.id,
+						"name":        "t3_" + p.id,
+						"title":       p.title,
+						"author":      "testuser",
+						"subreddit":   p.subreddit,
+						"score":       p.score,
+						"ups":         p.score,
+						"permalink":   "/r/" + p.subreddit + "/comments/" + p.id + "/slug/",
+						"url":         "https://www.reddit.com/r/" + p.subreddit + "/comments/" + p.id + "/slug/",
+						"created":     1609459300.0,
+						"created_utc": 1609459300.0,
 					},
-				},
+				})
 			}
 			listingData := map[string]interface{}{
 				"kind": "Listing",
 				"data": map[string]interface{}{
 					"children": posts,
+					"after":    "t3_userpost2",
 				},
 			}
 			json.NewEncoder(w).Encode(listingData)
 
-		case strings.Contains(r.URL.Path, "/user/testuser/comments.json"):
+		case "/user/testuser/comments":
 			// User's comments
-			comments := []map[string]interface{}{
-				{
-					"kind": "t1",
-					"data": map[string]interface{}{
-						"id":        "usercomment1",
-						"body":      "Great explanation!",
-						"author":    "testuser",
-						"subreddit": "golang",
-						"score":     10,
-					},
-				},
-				{
+			if r.URL.Query().Get("limit") != "10" {
+				t.Errorf("comments query = %v, want limit=10", r.URL.Query())
+			}
+			comments := []map[string]interface{}{}
+			for _, c := range []struct {
+				id, body, subreddit string
+				score               int
+			}{
+				{"usercomment1", "Great explanation!", "golang", 10},
+				{"usercomment2", "I disagree with this approach", "programming", 5},
+			} {
+				comments = append(comments, map[string]interface{}{
 					"kind": "t1",
 					"data": map[string]interface{}{
-						"id":        "usercomment2",
-						"body":      "I disagree with this approach",
-						"author":    "testuser",
-						"subreddit": "programming",
-						"score":     5,
+						"id":          c.id,
+						"name":        "t1_" + c.id,
+						"body":        c.body,
+						"author":      "testuser",
+						"subreddit":   c.subreddit,
+						"score":       c.score,
+						"ups":         c.score,
+						"link_id":     "t3_userpost1",
+						"parent_id":   "t3_userpost1",
+						"created":     1609459400.0,
+						"created_utc": 1609459400.0,
 					},
-				},
+				})
 			}
 			listingData := map[string]interface{}{
 				"kind": "Listing",
@@ -719,16 +736,16 @@ func TestUserActivityWorkflow(t *testing.T) {
 
 	// Create client
 	httpClient := &http.Client{Timeout: 30 * time.Second}
-	internalClient, err := internal.NewClient(httpClient, server.URL, "test/1.0", nil)
+	internalClient, err := internal.NewClient(httpClient, server.URL+"/", "test/1.0", nil)
 	if err != nil {
 		t.Fatalf("Failed to create internal httpClient: %v", err)
 	}
 
 	client := &Reddit{
-		httpClient:    internalClient,
-		parser:    internal.NewParser(),
-		validator: internal.NewValidator(),
-		auth:      &mockTokenProvider{token: "test_token"},
+		httpClient: internalClient,
+		parser:     internal.NewParser(),
+		validator:  internal.NewValidator(),
+		auth:       &mockTokenProvider{token: "test_token"},
 	}
 
 	ctx := context.Background()
@@ -752,57 +769,67 @@ func TestUserActivityWorkflow(t *testing.T) {
 			account.Name, account.LinkKarma, account.CommentKarma)
 	})
 
-	// Step 2: Get user's posts
-	t.Run("GetUserPosts", func(t *testing.T) {
-		resp, err := client.GetHot(ctx, &types.PostsRequest{
-			Subreddit: "testuser",
-			Pagination: types.Pagination{
-				Limit: 10,
-			},
-		})
+	// Step 2: Look up the same account's public profile
+	t.Run("GetUserProfile", func(t *testing.T) {
+		account, err := client.GetUser(ctx, "u/testuser")
+		if err != nil {
+			t.Fatalf("Failed to get user profile: %v", err)
+		}
 
+		if account.Name != "testuser" || account.CommentKarma != 3000 {
+			t.Errorf("Expected testuser with 3000 comment karma, got %s with %d", account.Name, account.CommentKarma)
+		}
+	})
+
+	// Step 3: Get user's posts
+	t.Run("GetUserPosts", func(t *testing.T) {
+		resp, err := client.GetUserPosts(ctx, "testuser", WithSort(TopAll))
 		if err != nil {
 			t.Fatalf("Failed to get user posts: %v", err)
 		}
 
-		if len(resp.Posts) != 2 {
-			t.Errorf("Expected 2 user posts, got %d", len(resp.Posts))
+		if resp.Len() != 2 {
+			t.Errorf("Expected 2 user posts, got %d", resp.Len())
+		}
+
+		if resp.After != "t3_userpost2" {
+			t.Errorf("Expected after cursor 't3_userpost2', got '%s'", resp.After)
 		}
 
 		// Verify all posts belong to the user
-		for _, post := range resp.Posts {
+		for _, post := range resp.Items {
 			if post.Author != "testuser" {
 				t.Errorf("Expected post author 'testuser', got '%s'", post.Author)
 			}
 		}
 
-		t.Logf("Retrieved %d user posts", len(resp.Posts))
+		t.Logf("Retrieved %d user posts", resp.Len())
 	})
 
-	// Step 3: Get user's comments (simulated by getting comments from user's subreddit)
+	// Step 4: Get user's comments
 	t.Run("GetUserComments", func(t *testing.T) {
-		// Note: In a real implementation, you might have a specific method for user comments
-		// For this test, we simulate it by getting comments from the user's "subreddit"
-		_, err := client.GetComments(ctx, &types.CommentsRequest{
-			Subreddit: "testuser",
-			PostID:    "userpost1",
-			Pagination: types.Pagination{
-				Limit: 10,
-			},
-		})
-
+		resp, err := client.GetUserComments(ctx, "testuser", WithLimit(10))
 		if err != nil {
 			t.Fatalf("Failed to get user comments: %v", err)
 		}
 
-		// In a real scenario, you'd verify the comments belong to the user
-		t.Logf("Retrieved user comments (simulated)")
+		if resp.Len() != 2 {
+			t.Errorf("Expected 2 user comments, got %d", resp.Len())
+		}
+
+		for _, comment := range resp.Items {
+			if comment.Author != "testuser" {
+				t.Errorf("Expected comment author 'testuser', got '%s'", comment.Author)
+			}
+		}
+
+		t.Logf("Retrieved %d user comments", resp.Len())
 	})
 
-	// Step 4: Verify workflow completion
+	// Step 5: Verify workflow completion
 	t.Run("WorkflowCompletion", func(t *testing.T) {
-		if requestCount < 3 {
-			t.Errorf("Expected at least 3 requests (user info + posts + comments), got %d", requestCount)
+		if requestCount < 4 {
+			t.Errorf("Expected at least 4 requests (user info + profile + posts + comments), got %d", requestCount)
 		}
 
 		t.Logf("User activity workflow completed successfully with %d requests", requestCount)